@@ -3,49 +3,196 @@ package main
 import (
 	"context"
 	"errors"
-	"log"
+	"log/slog"
 	stdhttp "net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
 	cfg "github.com/VladislavDraga398/kanban-backend/internal/config"
+	"github.com/VladislavDraga398/kanban-backend/internal/health"
 	myhttp "github.com/VladislavDraga398/kanban-backend/internal/http"
+	"github.com/VladislavDraga398/kanban-backend/internal/logging"
+	"github.com/VladislavDraga398/kanban-backend/internal/mail"
+	"github.com/VladislavDraga398/kanban-backend/internal/metrics"
+	"github.com/VladislavDraga398/kanban-backend/internal/oidc"
+	"github.com/VladislavDraga398/kanban-backend/internal/ratelimit"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
 	pg "github.com/VladislavDraga398/kanban-backend/internal/storage/postgres"
+	"github.com/VladislavDraga398/kanban-backend/internal/tracing"
 )
 
 func main() {
 	// 1. Загружаем конфиг (порт + DSN БД)
 	config, err := cfg.Load()
 	if err != nil {
-		log.Fatalf("failed to load config: %v", err)
+		slog.Error("failed to load config", "error", err)
+		os.Exit(1)
 	}
 
+	// Структурированный логгер по настройкам LOG_FORMAT/LOG_LEVEL
+	logger := logging.New(os.Stdout, config.LogFormat, config.LogLevel)
+	slog.SetDefault(logger)
+
+	// Трассировка OpenTelemetry (no-op, если OTEL_EXPORTER_OTLP_ENDPOINT не задан)
+	tracerProvider, shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		Endpoint:    config.OTLPEndpoint,
+		ServiceName: config.ServiceName,
+	})
+	if err != nil {
+		logger.Error("failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Error("failed to flush traces", "error", err)
+		}
+	}()
+
 	// 2. Подключаемся к Postgres
 	db, err := pg.New(config.DBDSN)
 	if err != nil {
-		log.Fatalf("failed to connect to database: %v", err)
+		logger.Error("failed to connect to database", "error", err)
+		os.Exit(1)
 	}
 	defer db.Close()
 
 	// 3. Создаём репозитории поверх БД
 	userRepo := pg.NewUserRepository(db)
 	boardRepo, columnRepo, taskRepo := pg.NewBoardRepository(db), pg.NewColumnRepository(db), pg.NewTaskRepository(db)
+	trashRepo := pg.NewTrashRepository(db)
+	go purgeTrash(service.NewTrashService(trashRepo, config.TrashRetention), logger)
+	archiveRepo := pg.NewArchiveRepository(db)
+	if config.AutoArchiveAfter > 0 {
+		go autoArchive(service.NewArchiveService(archiveRepo, boardRepo).WithAutoArchiveAfter(config.AutoArchiveAfter), logger)
+	}
+
+	// Метрики Prometheus, включая статистику пула соединений
+	m := metrics.New()
+	if err := m.RegisterDB(db.DB, "kanban"); err != nil {
+		logger.Error("failed to register db metrics", "error", err)
+		os.Exit(1)
+	}
+
+	// Проверки готовности: доступность БД и версия схемы
+	checker := health.NewChecker(config.HealthCheckTimeout)
+	checker.Add("postgres", db.PingContext)
+	checker.Add("migrations", db.CheckMigrations)
+
+	// Хранилище лимитов аутентификации: в памяти процесса или общее в Postgres
+	var limitStore ratelimit.Store = ratelimit.NewMemoryStore(time.Hour)
+	if config.RateLimitStore == "postgres" {
+		pgStore := pg.NewRateLimitStore(db)
+		limitStore = pgStore
+		go pruneRateLimits(pgStore, logger)
+	}
+
+	// Политика паролей: встроенный список утечек дополняется файлом из конфига
+	breached := auth.DefaultBreachedList()
+	if config.PasswordBreachedList != "" {
+		if err := breached.LoadFile(config.PasswordBreachedList); err != nil {
+			logger.Error("failed to load breached password list", "path", config.PasswordBreachedList, "error", err)
+			os.Exit(1)
+		}
+	}
+	logger.Info("password policy loaded", "min_length", config.PasswordMinLength, "breached_passwords", breached.Len())
+
+	// Почта для подтверждения email и сброса пароля
+	var mailer mail.Mailer
+	switch config.Mailer {
+	case "smtp":
+		mailer = mail.NewSMTPMailer(config.SMTP)
+	case "memory":
+		mailer = mail.NewOutbox()
+		logger.Warn("emails are kept in memory and never delivered; use MAILER=memory only for development")
+	default:
+		mailer = mail.NewFileMailer(config.MailOutboxDir, config.SMTP.From)
+		logger.Warn("emails with account links are written to files in plain text; use MAILER=file only for development", "dir", config.MailOutboxDir)
+	}
+
+	// Ключи подписи токенов доступа: без JWT_SIGNING_KEY_FILE остаётся HS256 на JWT_SECRET
+	var jwtKeys *auth.KeySet
+	if config.JWTSigningKeyFile != "" {
+		jwtKeys, err = auth.LoadKeySet(config.JWTSigningKeyFile, config.JWTVerifyKeys)
+		if err != nil {
+			logger.Error("failed to load jwt keys", "error", err)
+			os.Exit(1)
+		}
+		logger.Info("jwt signing keys loaded", "kid", jwtKeys.SigningKeyID(), "published", len(jwtKeys.JWKS().Keys))
+	}
+
+	// Вход через внешних OpenID Connect провайдеров; метаданные загружаются при первом входе
+	var oidcProviders []*oidc.Provider
+	for _, cfg := range config.OIDCProviders {
+		oidcProviders = append(oidcProviders, oidc.NewProvider(cfg, nil))
+		logger.Info("oidc provider configured", "provider", cfg.Name, "issuer", cfg.Issuer)
+	}
 
 	// 4. Собираем HTTP-роутер, передавая зависимости
 	router := myhttp.NewRouter(myhttp.Deps{
-		UserRepo:   userRepo,
-		BoardRepo:  boardRepo,
-		ColumnRepo: columnRepo,
-		TaskRepo:   taskRepo,
-		JWTSecret:  config.JWTSecret,
-		JWTTTL:     config.JWTTTL,
+		UserRepo:         userRepo,
+		BoardRepo:        boardRepo,
+		ColumnRepo:       columnRepo,
+		TaskRepo:         taskRepo,
+		JWTSecret:        config.JWTSecret,
+		JWTTTL:           config.JWTTTL,
+		JWTKeys:          jwtKeys,
+		Logger:           logger,
+		Metrics:          m,
+		TracerProvider:   tracerProvider,
+		Health:           checker,
+		AuthIPLimiter:    ratelimit.NewLimiter(limitStore, config.AuthIPRateLimit),
+		AuthEmailLimiter: ratelimit.NewLimiter(limitStore, config.AuthEmailRateLimit),
+		PublicLimiter:    ratelimit.NewLimiter(limitStore, config.PublicRateLimit),
+		LoginLockout:     ratelimit.NewLockout(limitStore, config.LoginLockout),
+		TrustProxy:       config.TrustProxyHeaders,
+		TokenRepo:        pg.NewTokenRepository(db),
+		AccessTokenRepo:  pg.NewAccessTokenRepository(db),
+		TwoFactorRepo:    pg.NewTwoFactorRepository(db),
+		SessionRepo:      pg.NewSessionRepository(db),
+		WorkspaceRepo:    pg.NewWorkspaceRepository(db),
+		InvitationRepo:   pg.NewInvitationRepository(db),
+		ShareLinkRepo:    pg.NewShareLinkRepository(db),
+		ActivityRepo:     pg.NewActivityRepository(db),
+		TaskVersionRepo:  taskRepo,
+		TrashRepo:        trashRepo,
+		TrashRetention:   config.TrashRetention,
+		ArchiveRepo:      archiveRepo,
+		Mailer:           mailer,
+		OIDCProviders:    oidcProviders,
+		IdentityRepo:     pg.NewIdentityRepository(db),
+		Emails: service.EmailSettings{
+			BaseURL:         config.AppBaseURL,
+			VerifyTTL:       config.EmailVerifyTTL,
+			ResetTTL:        config.PasswordResetTTL,
+			InviteTTL:       config.InvitationTTL,
+			RequireVerified: config.RequireVerifiedEmail,
+		},
+		PasswordPolicy: &auth.PasswordPolicy{
+			MinLength:      config.PasswordMinLength,
+			MinEntropyBits: config.PasswordMinEntropy,
+			Breached:       breached,
+		},
+		SeparateAdmin: config.AdminAddr != "",
 	})
 
-	// 5. Поднимаем HTTP-сервер
+	// 5. Поднимаем HTTP-сервер (и admin-сервер с /metrics и /health, если он настроен)
 	server := myhttp.NewServer(config.HTTPAddr, router)
 
+	var adminServer *myhttp.Server
+	if config.AdminAddr != "" {
+		adminServer = myhttp.NewServer(config.AdminAddr, myhttp.NewAdminRouter(m, checker))
+		go func() {
+			if err := adminServer.Start(); err != nil && !errors.Is(err, stdhttp.ErrServerClosed) {
+				logger.Error("admin http server stopped with error", "error", err)
+			}
+		}()
+	}
+
 	// 6. Ловим сигналы и корректно гасим сервер
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
@@ -58,22 +205,81 @@ func main() {
 
 	select {
 	case sig := <-stop:
-		log.Printf("received signal: %v", sig)
+		logger.Info("received signal", "signal", sig.String())
 	case err := <-serverErr:
 		if err != nil && !errors.Is(err, stdhttp.ErrServerClosed) {
-			log.Fatalf("failed to start http server: %v", err)
+			logger.Error("failed to start http server", "error", err)
+			os.Exit(1)
 		}
 		return
 	}
 
+	// /readyz отвечает 503, пока балансировщик снимает инстанс с трафика
+	checker.SetDraining()
+	if config.ShutdownDrainDelay > 0 {
+		logger.Info("draining before shutdown", "delay", config.ShutdownDrainDelay.String())
+		time.Sleep(config.ShutdownDrainDelay)
+	}
+
 	// Плавное завершение с тайм-аутом
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	if err := server.Shutdown(ctx); err != nil {
-		log.Printf("failed to gracefully shutdown http server: %v", err)
+		logger.Error("failed to gracefully shutdown http server", "error", err)
+	}
+	if adminServer != nil {
+		if err := adminServer.Shutdown(ctx); err != nil {
+			logger.Error("failed to gracefully shutdown admin http server", "error", err)
+		}
 	}
 
 	if err := <-serverErr; err != nil && !errors.Is(err, stdhttp.ErrServerClosed) {
-		log.Printf("http server stopped with error: %v", err)
+		logger.Error("http server stopped with error", "error", err)
+	}
+}
+
+// pruneRateLimits периодически удаляет из Postgres давно не использованные ключи лимитов.
+func pruneRateLimits(store *pg.RateLimitStore, logger *slog.Logger) {
+	ticker := time.NewTicker(10 * time.Minute)
+	defer ticker.Stop()
+	for range ticker.C {
+		n, err := store.Prune(context.Background(), time.Now().Add(-24*time.Hour))
+		if err != nil {
+			logger.Warn("failed to prune rate limits", "error", err)
+			continue
+		}
+		logger.Debug("pruned rate limits", "deleted", n)
+	}
+}
+
+// purgeTrash периодически окончательно удаляет то, что пролежало в корзине дольше срока хранения.
+func purgeTrash(trash *service.TrashService, logger *slog.Logger) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for range ticker.C {
+		n, err := trash.Purge(context.Background(), time.Now())
+		if err != nil {
+			logger.Warn("failed to purge trash", "error", err)
+			continue
+		}
+		if n > 0 {
+			logger.Info("purged trash", "deleted", n)
+		}
+	}
+}
+
+// autoArchive периодически убирает в архив задачи, которые пролежали в колонках «готово» дольше срока.
+func autoArchive(archives *service.ArchiveService, logger *slog.Logger) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for range ticker.C {
+		n, err := archives.AutoArchive(context.Background(), time.Now())
+		if err != nil {
+			logger.Warn("failed to auto-archive tasks", "error", err)
+			continue
+		}
+		if n > 0 {
+			logger.Info("auto-archived tasks", "archived", n)
+		}
 	}
 }
@@ -4,6 +4,7 @@ import { AuthOnlyRoute, ProtectedRoute } from './auth/route-guards'
 import { AuthPage } from './pages/AuthPage'
 import { BoardPage } from './pages/BoardPage'
 import { BoardsPage } from './pages/BoardsPage'
+import { SSOCallbackPage } from './pages/SSOCallbackPage'
 
 function RootRedirect() {
   const { isAuthenticated } = useAuth()
@@ -21,6 +22,7 @@ export default function App() {
           </AuthOnlyRoute>
         }
       />
+      <Route path="/sso/callback" element={<SSOCallbackPage />} />
       <Route
         path="/boards"
         element={
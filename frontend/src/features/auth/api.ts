@@ -1,5 +1,5 @@
 import { apiClient } from '../../shared/api/client'
-import type { AuthResponse } from '../../shared/api/types'
+import type { AuthResponse, SSOProvider } from '../../shared/api/types'
 
 type AuthPayload = {
   email: string
@@ -15,3 +15,25 @@ export async function login(payload: AuthPayload): Promise<AuthResponse> {
   const { data } = await apiClient.post<AuthResponse>('/auth/login', payload)
   return data
 }
+
+type TwoFactorPayload = {
+  challenge_token: string
+  code?: string
+  recovery_code?: string
+}
+
+export async function loginTwoFactor(payload: TwoFactorPayload): Promise<AuthResponse> {
+  const { data } = await apiClient.post<AuthResponse>('/auth/login/2fa', payload)
+  return data
+}
+
+export async function listSSOProviders(): Promise<SSOProvider[]> {
+  const { data } = await apiClient.get<SSOProvider[]>('/auth/oidc')
+  return data
+}
+
+// ssoLoginURL превращает login_url провайдера в абсолютный адрес API: вход идёт навигацией браузера, а не через axios.
+export function ssoLoginURL(provider: SSOProvider): string {
+  const apiBase = new URL(apiClient.defaults.baseURL ?? '/api/v1', window.location.origin)
+  return new URL(provider.login_url, apiBase).toString()
+}
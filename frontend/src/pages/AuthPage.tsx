@@ -1,9 +1,10 @@
-import { useMutation } from '@tanstack/react-query'
+import { useMutation, useQuery } from '@tanstack/react-query'
 import { useMemo, useState, type FormEvent } from 'react'
-import { useNavigate } from 'react-router-dom'
+import { useLocation, useNavigate } from 'react-router-dom'
 import { useAuth } from '../auth/use-auth'
-import { login, register } from '../features/auth/api'
+import { listSSOProviders, login, loginTwoFactor, register, ssoLoginURL } from '../features/auth/api'
 import { getErrorMessage } from '../shared/api/errors'
+import type { AuthResponse } from '../shared/api/types'
 
 type AuthMode = 'login' | 'register'
 
@@ -11,23 +12,59 @@ export function AuthPage() {
   const [mode, setMode] = useState<AuthMode>('login')
   const [email, setEmail] = useState('')
   const [password, setPassword] = useState('')
+  const location = useLocation()
+  // challenge — промежуточный токен после пароля или входа через SSO, если у пользователя включена 2FA.
+  const [challenge, setChallenge] = useState(
+    () => (location.state as { challenge?: string } | null)?.challenge ?? '',
+  )
+  const [code, setCode] = useState('')
   const { login: storeLogin } = useAuth()
   const navigate = useNavigate()
+  // Без настроенных провайдеров эндпоинт отвечает 404 — тогда кнопок SSO просто нет.
+  const ssoProviders = useQuery({
+    queryKey: ['sso-providers'],
+    queryFn: listSSOProviders,
+    retry: false,
+  })
 
   const authMutation = useMutation({
     mutationFn: async () => {
       const payload = {
         email: email.trim(),
-        password: password.trim(),
+        password,
       }
       return mode === 'login' ? login(payload) : register(payload)
     },
     onSuccess: (data) => {
-      storeLogin(data.token)
-      navigate('/boards', { replace: true })
+      if (data.two_factor_required && data.challenge_token) {
+        setChallenge(data.challenge_token)
+        return
+      }
+      finish(data)
+    },
+  })
+
+  // Код из приложения — 6 цифр, всё остальное считаем кодом восстановления.
+  const twoFactorMutation = useMutation({
+    mutationFn: () => {
+      const value = code.trim()
+      return loginTwoFactor(
+        /^\d{6}$/.test(value)
+          ? { challenge_token: challenge, code: value }
+          : { challenge_token: challenge, recovery_code: value },
+      )
     },
+    onSuccess: finish,
   })
 
+  function finish(data: AuthResponse) {
+    if (!data.token) {
+      return
+    }
+    storeLogin(data.token)
+    navigate('/boards', { replace: true })
+  }
+
   const pageTitle = useMemo(
     () => (mode === 'login' ? 'Войти в Kanban' : 'Создать аккаунт'),
     [mode],
@@ -41,9 +78,63 @@ export function AuthPage() {
     [mode],
   )
 
+  function onSubmitCode(event: FormEvent<HTMLFormElement>) {
+    event.preventDefault()
+    if (!code.trim()) {
+      return
+    }
+    twoFactorMutation.mutate()
+  }
+
+  if (challenge) {
+    return (
+      <main className="auth-page">
+        <section className="auth-card">
+          <div className="auth-card__heading">
+            <p className="badge">KANBAN CONTROL</p>
+            <h1>Подтвердите вход</h1>
+            <p>Введите код из приложения-аутентификатора или код восстановления.</p>
+          </div>
+
+          <form className="auth-form" onSubmit={onSubmitCode}>
+            <label>
+              <span>Код</span>
+              <input
+                value={code}
+                autoComplete="one-time-code"
+                inputMode="text"
+                onChange={(event) => setCode(event.target.value)}
+                placeholder="123456"
+                autoFocus
+                required
+              />
+            </label>
+
+            {twoFactorMutation.isError && (
+              <p className="error-text">{getErrorMessage(twoFactorMutation.error)}</p>
+            )}
+
+            <button type="submit" className="primary-button" disabled={twoFactorMutation.isPending}>
+              {twoFactorMutation.isPending ? 'Проверяем...' : 'Подтвердить'}
+            </button>
+            <button
+              type="button"
+              onClick={() => {
+                setChallenge('')
+                setCode('')
+              }}
+            >
+              Назад
+            </button>
+          </form>
+        </section>
+      </main>
+    )
+  }
+
   function onSubmit(event: FormEvent<HTMLFormElement>) {
     event.preventDefault()
-    if (!email.trim() || !password.trim()) {
+    if (!email.trim() || !password) {
       return
     }
     authMutation.mutate()
@@ -112,6 +203,16 @@ export function AuthPage() {
                 : 'Создать аккаунт'}
           </button>
         </form>
+
+        {ssoProviders.data && ssoProviders.data.length > 0 && (
+          <div className="auth-form">
+            {ssoProviders.data.map((provider) => (
+              <a key={provider.name} className="primary-button" href={ssoLoginURL(provider)}>
+                Войти через {provider.display_name}
+              </a>
+            ))}
+          </div>
+        )}
       </section>
     </main>
   )
@@ -0,0 +1,52 @@
+import { render, screen } from '@testing-library/react'
+import { describe, expect, it, vi } from 'vitest'
+import { MemoryRouter, Route, Routes } from 'react-router-dom'
+import { AuthContext, type AuthContextValue } from '../auth/auth-context-store'
+import { SSOCallbackPage } from './SSOCallbackPage'
+
+function renderCallback(entry: string, login: AuthContextValue['login']) {
+  const value: AuthContextValue = {
+    token: null,
+    isAuthenticated: false,
+    login,
+    logout: () => undefined,
+  }
+
+  return render(
+    <AuthContext.Provider value={value}>
+      <MemoryRouter initialEntries={[entry]}>
+        <Routes>
+          <Route path="/sso/callback" element={<SSOCallbackPage />} />
+          <Route path="/boards" element={<div>Boards Screen</div>} />
+          <Route path="/auth" element={<div>Auth Screen</div>} />
+        </Routes>
+      </MemoryRouter>
+    </AuthContext.Provider>,
+  )
+}
+
+describe('SSOCallbackPage', () => {
+  it('stores token from fragment and opens boards', () => {
+    const login = vi.fn()
+    renderCallback('/sso/callback#token=jwt-token', login)
+
+    expect(login).toHaveBeenCalledWith('jwt-token')
+    expect(screen.getByText('Boards Screen')).toBeInTheDocument()
+  })
+
+  it('sends two-factor challenge to the login page', () => {
+    const login = vi.fn()
+    renderCallback('/sso/callback#two_factor_required=true&challenge_token=challenge', login)
+
+    expect(login).not.toHaveBeenCalled()
+    expect(screen.getByText('Auth Screen')).toBeInTheDocument()
+  })
+
+  it('shows provider error', () => {
+    const login = vi.fn()
+    renderCallback('/sso/callback#error=email_not_verified', login)
+
+    expect(login).not.toHaveBeenCalled()
+    expect(screen.getByText('Провайдер не подтвердил email учётной записи.')).toBeInTheDocument()
+  })
+})
@@ -0,0 +1,53 @@
+import { useEffect, useMemo } from 'react'
+import { Link, useLocation, useNavigate } from 'react-router-dom'
+import { useAuth } from '../auth/use-auth'
+
+const ssoErrorMessages: Record<string, string> = {
+  access_denied: 'Вход отменён у провайдера.',
+  invalid_state: 'Сессия входа устарела. Попробуйте ещё раз.',
+  email_not_verified: 'Провайдер не подтвердил email учётной записи.',
+  email_already_used: 'Этот email уже занят.',
+  idp_error: 'Провайдер входа вернул ошибку. Попробуйте позже.',
+  invalid_id_token: 'Не удалось проверить ответ провайдера входа.',
+}
+
+// SSOCallbackPage принимает результат входа через OIDC: сервер кладёт token, challenge_token (2FA) или error во фрагмент адреса.
+export function SSOCallbackPage() {
+  const { hash } = useLocation()
+  const { login } = useAuth()
+  const navigate = useNavigate()
+
+  const result = useMemo(() => new URLSearchParams(hash.replace(/^#/, '')), [hash])
+  const token = result.get('token')
+  const challenge = result.get('challenge_token')
+  const error = result.get('error') ?? (token || challenge ? null : 'invalid_state')
+
+  useEffect(() => {
+    if (token) {
+      login(token)
+      navigate('/boards', { replace: true })
+    } else if (challenge) {
+      // Второй фактор вводится на странице входа, как после пароля.
+      navigate('/auth', { replace: true, state: { challenge } })
+    }
+  }, [token, challenge, login, navigate])
+
+  if (!error) {
+    return null
+  }
+
+  return (
+    <main className="auth-page">
+      <section className="auth-card">
+        <div className="auth-card__heading">
+          <p className="badge">KANBAN CONTROL</p>
+          <h1>Не удалось войти</h1>
+          <p className="error-text">{ssoErrorMessages[error] ?? 'Внутренняя ошибка. Попробуйте позже.'}</p>
+        </div>
+        <Link to="/auth" className="primary-button">
+          Вернуться ко входу
+        </Link>
+      </section>
+    </main>
+  )
+}
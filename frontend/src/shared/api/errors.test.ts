@@ -1,5 +1,5 @@
 import { describe, expect, it } from 'vitest'
-import { getErrorMessage } from './errors'
+import { getErrorCode, getErrorMessage, getFieldErrors } from './errors'
 
 describe('getErrorMessage', () => {
   it('returns api error message from axios response body', () => {
@@ -8,7 +8,11 @@ describe('getErrorMessage', () => {
       message: 'Request failed',
       response: {
         data: {
-          error: 'invalid credentials',
+          type: 'urn:kanban:problem:invalid_credentials',
+          title: 'Unauthorized',
+          status: 401,
+          detail: 'invalid credentials',
+          code: 'invalid_credentials',
         },
       },
     }
@@ -28,6 +32,26 @@ describe('getErrorMessage', () => {
     expect(getErrorMessage(error)).toBe('Network Error')
   })
 
+  it('exposes problem code and field errors', () => {
+    const error = {
+      isAxiosError: true,
+      message: 'Request failed',
+      response: {
+        data: {
+          type: 'urn:kanban:problem:validation_failed',
+          title: 'Bad Request',
+          status: 400,
+          detail: 'title is required',
+          code: 'validation_failed',
+          errors: [{ field: 'title', message: 'title is required' }],
+        },
+      },
+    }
+
+    expect(getErrorCode(error)).toBe('validation_failed')
+    expect(getFieldErrors(error)).toEqual({ title: 'title is required' })
+  })
+
   it('returns native error message', () => {
     const error = new Error('native error')
     expect(getErrorMessage(error)).toBe('native error')
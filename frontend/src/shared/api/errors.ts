@@ -3,10 +3,29 @@ import type { ApiErrorBody } from './types'
 
 export function getErrorMessage(error: unknown): string {
   if (axios.isAxiosError<ApiErrorBody>(error)) {
-    return error.response?.data?.error || error.message || 'Request failed'
+    const body = error.response?.data
+    return body?.detail || body?.title || error.message || 'Request failed'
   }
   if (error instanceof Error) {
     return error.message
   }
   return 'Unknown error'
 }
+
+export function getErrorCode(error: unknown): string | undefined {
+  if (axios.isAxiosError<ApiErrorBody>(error)) {
+    return error.response?.data?.code
+  }
+  return undefined
+}
+
+export function getFieldErrors(error: unknown): Record<string, string> {
+  if (!axios.isAxiosError<ApiErrorBody>(error)) {
+    return {}
+  }
+  const result: Record<string, string> = {}
+  for (const item of error.response?.data?.errors ?? []) {
+    result[item.field] = item.message
+  }
+  return result
+}
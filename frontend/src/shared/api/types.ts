@@ -1,11 +1,27 @@
+export type FieldError = {
+  field: string
+  message: string
+}
+
+// RFC 7807 problem details (application/problem+json).
 export type ApiErrorBody = {
-  error: string
+  type: string
+  title: string
+  status: number
+  detail?: string
+  instance?: string
+  code: string
+  request_id?: string
+  errors?: FieldError[]
 }
 
 export type AuthResponse = {
   id: string
   email: string
-  token: string
+  // token отсутствует, если для входа нужен второй фактор: тогда приходит challenge_token.
+  token?: string
+  two_factor_required?: boolean
+  challenge_token?: string
 }
 
 export type Board = {
@@ -35,3 +51,9 @@ export type Task = {
   created_at: string
   updated_at: string
 }
+
+export type SSOProvider = {
+  name: string
+  display_name: string
+  login_url: string
+}
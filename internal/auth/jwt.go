@@ -9,35 +9,69 @@ import (
 
 var ErrInvalidToken = errors.New("invalid token")
 
+// ErrSessionRevoked — сессия токена отозвана, истекла или не найдена.
+var ErrSessionRevoked = errors.New("session revoked")
+
+// Claims — полезная нагрузка токена доступа.
+type Claims struct {
+	UserID string
+	// SessionID — сессия входа, которую можно отозвать; пусто у токенов без сессии.
+	SessionID string
+}
+
+// tokenClaims — claims JWT: стандартные поля и идентификатор сессии (sid).
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	SessionID string `json:"sid,omitempty"`
+}
+
+func newTokenClaims(c Claims, now time.Time, ttl time.Duration) tokenClaims {
+	return tokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   c.UserID,
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+		SessionID: c.SessionID,
+	}
+}
+
+func (c *tokenClaims) claims() (Claims, error) {
+	if c.Subject == "" {
+		return Claims{}, ErrInvalidToken
+	}
+	return Claims{UserID: c.Subject, SessionID: c.SessionID}, nil
+}
+
 // GenerateJWT выпускает токен с subject=userID и заданным TTL.
 func GenerateJWT(userID string, secret []byte, ttl time.Duration) (string, error) {
-	claims := jwt.RegisteredClaims{
-		Subject:   userID,
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
-		IssuedAt:  jwt.NewNumericDate(time.Now()),
-	}
+	return GenerateClaimsJWT(Claims{UserID: userID}, secret, ttl)
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+// GenerateClaimsJWT выпускает токен HS256 с заданными claims.
+func GenerateClaimsJWT(c Claims, secret []byte, ttl time.Duration) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, newTokenClaims(c, time.Now(), ttl))
 	return token.SignedString(secret)
 }
 
 // ParseJWT возвращает userID из токена, валидируя подпись и срок действия.
 func ParseJWT(tokenStr string, secret []byte) (string, error) {
-	token, err := jwt.ParseWithClaims(tokenStr, &jwt.RegisteredClaims{}, func(token *jwt.Token) (any, error) {
+	c, err := ParseClaimsJWT(tokenStr, secret)
+	return c.UserID, err
+}
+
+// ParseClaimsJWT возвращает claims токена HS256, валидируя подпись и срок действия.
+func ParseClaimsJWT(tokenStr string, secret []byte) (Claims, error) {
+	var claims tokenClaims
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, func(token *jwt.Token) (any, error) {
 		// Защита от подмены алгоритма подписи
 		if token.Method == nil || token.Method.Alg() != jwt.SigningMethodHS256.Alg() {
 			return nil, ErrInvalidToken
 		}
 		return secret, nil
 	})
-	if err != nil {
-		return "", ErrInvalidToken
-	}
-
-	claims, ok := token.Claims.(*jwt.RegisteredClaims)
-	if !ok || !token.Valid || claims.Subject == "" {
-		return "", ErrInvalidToken
+	if err != nil || !token.Valid {
+		return Claims{}, ErrInvalidToken
 	}
-
-	return claims.Subject, nil
+	return claims.claims()
 }
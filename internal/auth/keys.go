@@ -0,0 +1,279 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minRSABits — минимальный размер RSA-ключа для подписи токенов.
+const minRSABits = 2048
+
+// KeyFile — PEM-файл ключа для проверки токенов. NotAfter — с этого момента ключ больше
+// не принимается и не публикуется в JWKS; нулевое значение — без ограничения.
+type KeyFile struct {
+	Path     string
+	NotAfter time.Time
+}
+
+// KeySet подписывает токены доступа текущим асимметричным ключом (RS256 или EdDSA) и проверяет
+// их по всем действующим ключам, различая ключи по kid. Прежние ключи остаются в наборе на время
+// перекрытия, пока не истекут выданные ими токены, а следующий можно опубликовать заранее,
+// чтобы его успели получить кэши JWKS у других сервисов.
+type KeySet struct {
+	signer  crypto.Signer
+	signKID string
+	keys    map[string]*publicKey
+	order   []string
+	now     func() time.Time
+}
+
+type publicKey struct {
+	method   jwt.SigningMethod
+	key      crypto.PublicKey
+	notAfter time.Time
+}
+
+// NewKeySet создаёт набор с ключом подписи signer (*rsa.PrivateKey или ed25519.PrivateKey).
+func NewKeySet(signer crypto.Signer) (*KeySet, error) {
+	s := &KeySet{keys: map[string]*publicKey{}, now: time.Now}
+	kid, err := s.add(signer.Public(), time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	s.signer, s.signKID = signer, kid
+	return s, nil
+}
+
+// LoadKeySet читает ключ подписи и дополнительные ключи проверки из PEM-файлов.
+// Файл ключа проверки может содержать как открытый, так и закрытый ключ.
+func LoadKeySet(signingFile string, verify []KeyFile) (*KeySet, error) {
+	key, err := readPEMKey(signingFile)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%s: signing key must be a private key", signingFile)
+	}
+	s, err := NewKeySet(signer)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", signingFile, err)
+	}
+
+	for _, f := range verify {
+		key, err := readPEMKey(f.Path)
+		if err != nil {
+			return nil, err
+		}
+		if signer, ok := key.(crypto.Signer); ok {
+			key = signer.Public()
+		}
+		if err := s.AddVerificationKey(key, f.NotAfter); err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Path, err)
+		}
+	}
+	return s, nil
+}
+
+// AddVerificationKey добавляет открытый ключ, которым принимаются токены до notAfter
+// (нулевое значение — без ограничения). Ключ подписи добавлять не нужно.
+func (s *KeySet) AddVerificationKey(key crypto.PublicKey, notAfter time.Time) error {
+	_, err := s.add(key, notAfter)
+	return err
+}
+
+// WithClock подменяет источник времени (для тестов).
+func (s *KeySet) WithClock(now func() time.Time) *KeySet {
+	s.now = now
+	return s
+}
+
+func (s *KeySet) add(key crypto.PublicKey, notAfter time.Time) (string, error) {
+	var method jwt.SigningMethod
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if k.N.BitLen() < minRSABits {
+			return "", fmt.Errorf("rsa key must be at least %d bits", minRSABits)
+		}
+		method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		method = jwt.SigningMethodEdDSA
+	default:
+		return "", fmt.Errorf("unsupported key type %T: use RSA or Ed25519", key)
+	}
+
+	jwk, err := publicJWK(key)
+	if err != nil {
+		return "", err
+	}
+	kid := jwk.thumbprint()
+	if existing, ok := s.keys[kid]; ok {
+		// Тот же ключ дважды (например, и подписи, и проверки): оставляем более широкое окно.
+		if existing.notAfter.IsZero() || notAfter.IsZero() {
+			existing.notAfter = time.Time{}
+		} else if notAfter.After(existing.notAfter) {
+			existing.notAfter = notAfter
+		}
+		return kid, nil
+	}
+	s.keys[kid] = &publicKey{method: method, key: key, notAfter: notAfter}
+	s.order = append(s.order, kid)
+	return kid, nil
+}
+
+// SigningKeyID возвращает kid текущего ключа подписи.
+func (s *KeySet) SigningKeyID() string {
+	return s.signKID
+}
+
+// Sign выпускает токен доступа с subject=userID и заданным TTL, подписанный текущим ключом.
+func (s *KeySet) Sign(userID string, ttl time.Duration) (string, error) {
+	return s.SignClaims(Claims{UserID: userID}, ttl)
+}
+
+// SignClaims выпускает токен доступа с заданными claims, подписанный текущим ключом.
+func (s *KeySet) SignClaims(c Claims, ttl time.Duration) (string, error) {
+	token := jwt.NewWithClaims(s.keys[s.signKID].method, newTokenClaims(c, s.now(), ttl))
+	token.Header["kid"] = s.signKID
+	return token.SignedString(s.signer)
+}
+
+// Parse возвращает userID из токена, проверяя подпись ключом из заголовка kid и срок действия.
+func (s *KeySet) Parse(tokenStr string) (string, error) {
+	c, err := s.ParseClaims(tokenStr)
+	return c.UserID, err
+}
+
+// ParseClaims возвращает claims токена, проверяя подпись ключом из заголовка kid и срок действия.
+func (s *KeySet) ParseClaims(tokenStr string) (Claims, error) {
+	now := s.now()
+	var claims tokenClaims
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		k, ok := s.keys[kid]
+		if !ok || (!k.notAfter.IsZero() && !now.Before(k.notAfter)) {
+			return nil, ErrInvalidToken
+		}
+		// Алгоритм определяется ключом, а не заголовком токена.
+		if token.Method == nil || token.Method.Alg() != k.method.Alg() {
+			return nil, ErrInvalidToken
+		}
+		return k.key, nil
+	}, jwt.WithTimeFunc(s.now))
+	if err != nil || !token.Valid {
+		return Claims{}, ErrInvalidToken
+	}
+	return claims.claims()
+}
+
+// JWKS возвращает открытые ключи для публикации в /.well-known/jwks.json: ключ подписи
+// и все ещё действующие ключи проверки.
+func (s *KeySet) JWKS() JWKS {
+	now := s.now()
+	set := JWKS{Keys: make([]JWK, 0, len(s.order))}
+	for _, kid := range s.order {
+		k := s.keys[kid]
+		if !k.notAfter.IsZero() && !now.Before(k.notAfter) {
+			continue
+		}
+		jwk, err := publicJWK(k.key)
+		if err != nil {
+			continue
+		}
+		jwk.KeyID, jwk.Alg, jwk.Use = kid, k.method.Alg(), "sig"
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+// JWKS — набор открытых ключей (RFC 7517).
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK — открытый ключ RSA или Ed25519 (RFC 7517, RFC 8037).
+type JWK struct {
+	Kty   string `json:"kty"`
+	KeyID string `json:"kid,omitempty"`
+	Alg   string `json:"alg,omitempty"`
+	Use   string `json:"use,omitempty"`
+	N     string `json:"n,omitempty"`
+	E     string `json:"e,omitempty"`
+	Crv   string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+func publicJWK(key crypto.PublicKey) (JWK, error) {
+	enc := base64.RawURLEncoding
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return JWK{Kty: "RSA", N: enc.EncodeToString(k.N.Bytes()), E: enc.EncodeToString(big.NewInt(int64(k.E)).Bytes())}, nil
+	case ed25519.PublicKey:
+		return JWK{Kty: "OKP", Crv: "Ed25519", X: enc.EncodeToString(k)}, nil
+	default:
+		return JWK{}, fmt.Errorf("unsupported key type %T", key)
+	}
+}
+
+// thumbprint — отпечаток ключа по RFC 7638; служит kid, поэтому kid не нужно настраивать.
+func (j JWK) thumbprint() string {
+	// Обязательные члены в лексикографическом порядке, без пробелов.
+	var members any
+	if j.Kty == "RSA" {
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{j.E, j.Kty, j.N}
+	} else {
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{j.Crv, j.Kty, j.X}
+	}
+	raw, _ := json.Marshal(members)
+	sum := sha256.Sum256(raw)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// readPEMKey читает первый PEM-блок файла: PKCS#8 или PKCS#1 закрытый ключ либо PKIX открытый ключ.
+func readPEMKey(path string) (any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM block found", path)
+	}
+
+	var key any
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		err = errors.New("unsupported PEM block " + block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return key, nil
+}
@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// NewOpaqueToken выпускает случайный одноразовый токен (256 бит) для ссылок в письмах.
+// Клиенту отдаётся raw, в хранилище — только hash.
+func NewOpaqueToken() (raw, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	raw = base64.RawURLEncoding.EncodeToString(buf)
+	return raw, HashOpaqueToken(raw), nil
+}
+
+// HashOpaqueToken возвращает SHA-256 токена в hex. Токены случайные и длинные,
+// поэтому медленный хэш (как для паролей) не нужен.
+func HashOpaqueToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
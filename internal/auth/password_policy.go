@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxPasswordBytes — bcrypt учитывает только первые 72 байта, более длинные пароли отклоняем явно.
+const MaxPasswordBytes = 72
+
+//go:embed breached_passwords.txt
+var bundledBreached string
+
+// PasswordPolicy — требования к новому паролю.
+type PasswordPolicy struct {
+	// MinLength — минимальная длина в символах (рунах).
+	MinLength int
+	// MinEntropyBits — минимальная оценка энтропии (см. EstimateEntropy); 0 — без проверки.
+	MinEntropyBits float64
+	// Breached — список известных утёкших паролей; nil — без проверки.
+	Breached *BreachedList
+}
+
+// Check возвращает нарушения политики в виде сообщений для клиента; пустой срез — пароль подходит.
+// email нужен, чтобы запретить пароли, содержащие адрес или его локальную часть.
+func (p PasswordPolicy) Check(password, email string) []string {
+	var violations []string
+	if n := utf8.RuneCountInString(password); n < p.MinLength {
+		violations = append(violations, fmt.Sprintf("password must be at least %d characters long", p.MinLength))
+	}
+	if len(password) > MaxPasswordBytes {
+		violations = append(violations, fmt.Sprintf("password must be at most %d bytes long", MaxPasswordBytes))
+	}
+	if local, _, _ := strings.Cut(strings.ToLower(email), "@"); len(local) >= 3 && strings.Contains(strings.ToLower(password), local) {
+		violations = append(violations, "password must not contain the email address")
+	}
+	if p.Breached.Contains(password) {
+		violations = append(violations, "password appears in a list of breached passwords")
+	} else if p.MinEntropyBits > 0 && EstimateEntropy(password) < p.MinEntropyBits {
+		violations = append(violations, "password is too easy to guess; use a longer passphrase or mix character types")
+	}
+	return violations
+}
+
+// EstimateEntropy грубо оценивает энтропию пароля в битах: размер алфавита по встреченным классам
+// символов, умноженный на «эффективную» длину, где повторы и последовательности (aaaa, abcd, 4321)
+// почти не добавляют энтропии.
+func EstimateEntropy(password string) float64 {
+	var lower, upper, digit, symbol, other bool
+	runes := []rune(password)
+	for _, r := range runes {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < unicode.MaxASCII && unicode.IsPrint(r):
+			symbol = true
+		default:
+			other = true
+		}
+	}
+
+	pool := 0
+	for _, c := range []struct {
+		present bool
+		size    int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {symbol, 33}, {other, 100}} {
+		if c.present {
+			pool += c.size
+		}
+	}
+	if pool == 0 {
+		return 0
+	}
+
+	effective := 0.0
+	for i, r := range runes {
+		if i > 0 {
+			delta := r - runes[i-1]
+			if delta == 0 || delta == 1 || delta == -1 {
+				effective += 0.25
+				continue
+			}
+		}
+		effective++
+	}
+	return effective * math.Log2(float64(pool))
+}
+
+// BreachedList — множество утёкших паролей для офлайн-проверки (без учёта регистра).
+type BreachedList struct {
+	set map[string]struct{}
+}
+
+// DefaultBreachedList возвращает встроенный список самых частых паролей.
+func DefaultBreachedList() *BreachedList {
+	l := &BreachedList{set: map[string]struct{}{}}
+	_ = l.load(strings.NewReader(bundledBreached))
+	return l
+}
+
+// LoadFile добавляет пароли из файла (по одному в строке, # — комментарий).
+func (l *BreachedList) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return l.load(f)
+}
+
+// Contains проверяет пароль по списку; nil-список ничего не содержит.
+func (l *BreachedList) Contains(password string) bool {
+	if l == nil {
+		return false
+	}
+	_, ok := l.set[strings.ToLower(password)]
+	return ok
+}
+
+// Len возвращает размер списка.
+func (l *BreachedList) Len() int {
+	if l == nil {
+		return 0
+	}
+	return len(l.set)
+}
+
+func (l *BreachedList) load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		l.set[strings.ToLower(line)] = struct{}{}
+	}
+	return scanner.Err()
+}
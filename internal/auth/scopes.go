@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// AccessTokenPrefix отличает личные токены доступа от JWT в заголовке Authorization.
+const AccessTokenPrefix = "kbn_pat_"
+
+// ErrInvalidAccessToken — токен доступа не найден, отозван или истёк.
+var ErrInvalidAccessToken = errors.New("invalid access token")
+
+// Scope — разрешение личного токена доступа. Разрешения вложены: admin ⊃ write ⊃ read.
+type Scope string
+
+const (
+	// ScopeRead — чтение досок, колонок, задач и профиля.
+	ScopeRead Scope = "read"
+	// ScopeWrite — изменение досок, колонок и задач.
+	ScopeWrite Scope = "write"
+	// ScopeAdmin — управление учётной записью и токенами; JWT сессии входа имеют его неявно.
+	ScopeAdmin Scope = "admin"
+)
+
+var scopeRank = map[Scope]int{ScopeRead: 1, ScopeWrite: 2, ScopeAdmin: 3}
+
+// ParseScopes проверяет и нормализует список разрешений (без дубликатов, в исходном порядке).
+func ParseScopes(raw []string) ([]Scope, error) {
+	seen := map[Scope]bool{}
+	out := make([]Scope, 0, len(raw))
+	for _, r := range raw {
+		s := Scope(strings.ToLower(strings.TrimSpace(r)))
+		if _, ok := scopeRank[s]; !ok {
+			return nil, fmt.Errorf("unknown scope %q (want read, write or admin)", r)
+		}
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+// Allows сообщает, покрывает ли набор granted разрешение required.
+func Allows(granted []Scope, required Scope) bool {
+	for _, s := range granted {
+		if scopeRank[s] >= scopeRank[required] {
+			return true
+		}
+	}
+	return false
+}
+
+// NewAccessToken выпускает личный токен доступа: клиенту отдаётся raw, в хранилище — hash.
+func NewAccessToken() (raw, hash string, err error) {
+	opaque, _, err := NewOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+	raw = AccessTokenPrefix + opaque
+	return raw, HashOpaqueToken(raw), nil
+}
@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Параметры TOTP (RFC 6238) совпадают со значениями по умолчанию приложений-аутентификаторов:
+// HMAC-SHA1, 6 цифр, шаг 30 секунд. Другие значения многие приложения молча игнорируют.
+const (
+	TOTPDigits = 6
+	TOTPPeriod = 30 * time.Second
+	// totpSkew — сколько соседних шагов принимается из-за расхождения часов телефона и сервера.
+	totpSkew       = 1
+	totpSecretSize = 20
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewTOTPSecret возвращает случайный секрет (160 бит) в base32 без выравнивания — в таком виде
+// его вводят вручную и передают в otpauth URI.
+func NewTOTPSecret() (string, error) {
+	buf := make([]byte, totpSecretSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(buf), nil
+}
+
+// TOTPURI собирает otpauth:// URI для QR-кода. issuer и account показываются в приложении.
+func TOTPURI(issuer, account, secret string) string {
+	q := url.Values{}
+	q.Set("secret", secret)
+	q.Set("issuer", issuer)
+	q.Set("algorithm", "SHA1")
+	q.Set("digits", fmt.Sprint(TOTPDigits))
+	q.Set("period", fmt.Sprint(int(TOTPPeriod/time.Second)))
+	label := url.PathEscape(issuer + ":" + account)
+	return "otpauth://totp/" + label + "?" + q.Encode()
+}
+
+// TOTPStep возвращает номер шага для момента t.
+func TOTPStep(t time.Time) int64 {
+	return t.Unix() / int64(TOTPPeriod/time.Second)
+}
+
+// TOTPCode вычисляет код для шага step.
+func TOTPCode(secret string, step int64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", fmt.Errorf("decode totp secret: %w", err)
+	}
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// Динамическое усечение (RFC 4226, раздел 5.3).
+	off := sum[len(sum)-1] & 0x0f
+	bin := binary.BigEndian.Uint32(sum[off:off+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", TOTPDigits, bin%1_000_000), nil
+}
+
+// ValidateTOTP проверяет код на момент now с допуском в один шаг в каждую сторону и возвращает
+// шаг, которому код соответствует. Шаг нужен, чтобы не принять один и тот же код повторно.
+func ValidateTOTP(secret, code string, now time.Time) (int64, bool) {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(code) != TOTPDigits {
+		return 0, false
+	}
+
+	current := TOTPStep(now)
+	for d := int64(-totpSkew); d <= totpSkew; d++ {
+		want, err := TOTPCode(secret, current+d)
+		if err != nil {
+			return 0, false
+		}
+		if hmac.Equal([]byte(want), []byte(code)) {
+			return current + d, true
+		}
+	}
+	return 0, false
+}
+
+const (
+	// RecoveryCodeCount — сколько кодов восстановления выдаётся за раз.
+	RecoveryCodeCount = 10
+	recoveryCodeBytes = 10
+)
+
+// NewRecoveryCodes выпускает одноразовые коды восстановления (80 бит каждый) в виде
+// xxxx-xxxx-xxxx-xxxx. Пользователю отдаются raw, в хранилище — только hashes.
+func NewRecoveryCodes(n int) (raw, hashes []string, err error) {
+	raw = make([]string, n)
+	hashes = make([]string, n)
+	buf := make([]byte, recoveryCodeBytes)
+	for i := range n {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		s := strings.ToLower(totpEncoding.EncodeToString(buf))
+		raw[i] = s[0:4] + "-" + s[4:8] + "-" + s[8:12] + "-" + s[12:16]
+		hashes[i] = HashRecoveryCode(raw[i])
+	}
+	return raw, hashes, nil
+}
+
+// HashRecoveryCode хэширует код восстановления без учёта регистра, пробелов и дефисов.
+func HashRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.NewReplacer("-", "", " ", "").Replace(code)
+	return HashOpaqueToken(code)
+}
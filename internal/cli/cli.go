@@ -0,0 +1,258 @@
+// Package cli реализует kanbanctl — консольный клиент Kanban API поверх pkg/client.
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/VladislavDraga398/kanban-backend/pkg/client"
+)
+
+const defaultServer = "http://localhost:8083"
+
+// Env — окружение запуска; в тестах подменяется целиком.
+type Env struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+	Getenv func(string) string
+}
+
+// DefaultEnv возвращает окружение текущего процесса.
+func DefaultEnv() Env {
+	return Env{Stdin: os.Stdin, Stdout: os.Stdout, Stderr: os.Stderr, Getenv: os.Getenv}
+}
+
+// errUsage — неверные аргументы; справка уже напечатана.
+var errUsage = errors.New("usage error")
+
+// app — состояние одного запуска команды.
+type app struct {
+	env        Env
+	configPath string
+	config     *fileConfig
+	server     string
+	token      string
+	output     string
+}
+
+const usage = `kanbanctl — command-line client for the Kanban API.
+
+Usage:
+  kanbanctl [global flags] <command> [args]
+
+Commands:
+  login                              log in and store the token in the config file
+  logout                             forget the stored token
+  boards list|create|rename|delete   manage boards
+  columns list|add                   manage columns of a board
+  tasks list|add|edit|move           manage tasks
+  completion bash|zsh|fish           print a shell completion script
+
+Boards and columns may be referenced by ID or by (case-insensitive) name.
+
+Global flags:
+  -o, --output table|json|yaml       output format (default table, env KANBAN_OUTPUT)
+      --server URL                   API base URL (env KANBAN_SERVER)
+      --token TOKEN                  access token (env KANBAN_TOKEN)
+      --config PATH                  config file (env KANBANCTL_CONFIG)
+`
+
+// Run выполняет команду и возвращает код выхода процесса.
+func Run(ctx context.Context, args []string, env Env) int {
+	if env.Getenv == nil {
+		env.Getenv = func(string) string { return "" }
+	}
+	a := &app{env: env}
+	if err := a.run(ctx, args); err != nil {
+		if errors.Is(err, errUsage) || errors.Is(err, flag.ErrHelp) {
+			return 2
+		}
+		fmt.Fprintln(env.Stderr, "error:", describeError(err))
+		return 1
+	}
+	return 0
+}
+
+func (a *app) run(ctx context.Context, args []string) error {
+	fs := a.flagSet("kanbanctl")
+	fs.StringVar(&a.output, "output", "", "output format")
+	fs.StringVar(&a.output, "o", "", "output format (shorthand)")
+	fs.StringVar(&a.server, "server", "", "API base URL")
+	fs.StringVar(&a.token, "token", "", "access token")
+	fs.StringVar(&a.configPath, "config", "", "config file")
+	fs.Usage = func() { fmt.Fprint(a.env.Stderr, usage) }
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	if err := a.resolveSettings(); err != nil {
+		return err
+	}
+
+	cmd, rest := fs.Arg(0), fs.Args()[1:]
+	switch cmd {
+	case "login":
+		return a.login(ctx, rest)
+	case "logout":
+		return a.logout()
+	case "boards", "board":
+		return a.boards(ctx, rest)
+	case "columns", "column":
+		return a.columns(ctx, rest)
+	case "tasks", "task":
+		return a.tasks(ctx, rest)
+	case "completion":
+		return a.completion(rest)
+	case "help":
+		fs.Usage()
+		return nil
+	default:
+		fmt.Fprintf(a.env.Stderr, "unknown command %q\n\n", cmd)
+		fs.Usage()
+		return errUsage
+	}
+}
+
+// resolveSettings применяет приоритет: флаг > переменная окружения > конфиг > значение по умолчанию.
+func (a *app) resolveSettings() error {
+	if a.configPath == "" {
+		a.configPath = defaultConfigPath(a.env.Getenv)
+	}
+	cfg, err := loadConfig(a.configPath)
+	if err != nil {
+		return err
+	}
+	a.config = cfg
+
+	a.server = firstNonEmpty(a.server, a.env.Getenv("KANBAN_SERVER"), cfg.Server, defaultServer)
+	a.token = firstNonEmpty(a.token, a.env.Getenv("KANBAN_TOKEN"), cfg.Token)
+	a.output = firstNonEmpty(a.output, a.env.Getenv("KANBAN_OUTPUT"), formatTable)
+	if !validFormat(a.output) {
+		return fmt.Errorf("unknown output format %q (want table, json or yaml)", a.output)
+	}
+	return nil
+}
+
+func (a *app) client() (*client.Client, error) {
+	return client.New(a.server, client.WithToken(a.token), client.WithUserAgent("kanbanctl"))
+}
+
+func (a *app) authedClient() (*client.Client, error) {
+	if a.token == "" {
+		return nil, errors.New("not logged in: run `kanbanctl login` or set KANBAN_TOKEN")
+	}
+	return a.client()
+}
+
+func (a *app) flagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.env.Stderr)
+	return fs
+}
+
+func (a *app) render(v any, tbl table) error {
+	return render(a.env.Stdout, a.output, v, tbl)
+}
+
+func (a *app) login(ctx context.Context, args []string) error {
+	fs := a.flagSet("login")
+	email := fs.String("email", "", "account email")
+	password := fs.String("password", "", "account password (prefer --password-stdin or KANBAN_PASSWORD)")
+	passwordStdin := fs.Bool("password-stdin", false, "read the password from stdin")
+	if _, err := parseInterspersed(fs, args); err != nil {
+		return err
+	}
+
+	*email = firstNonEmpty(*email, a.env.Getenv("KANBAN_EMAIL"), a.config.Email)
+	if *email == "" {
+		return errors.New("--email is required")
+	}
+	if *passwordStdin {
+		line, err := bufio.NewReader(a.env.Stdin).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("read password: %w", err)
+		}
+		*password = strings.TrimRight(line, "\r\n")
+	}
+	*password = firstNonEmpty(*password, a.env.Getenv("KANBAN_PASSWORD"))
+	if *password == "" {
+		return errors.New("password is required: use --password-stdin or KANBAN_PASSWORD")
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	res, err := c.Login(ctx, *email, *password)
+	if err != nil {
+		return err
+	}
+
+	a.config.Server = a.server
+	a.config.Email = res.Email
+	a.config.Token = res.Token
+	if err := saveConfig(a.configPath, a.config); err != nil {
+		return err
+	}
+	return a.render(map[string]string{"id": res.ID, "email": res.Email, "server": a.server},
+		messageTable("Logged in as %s (%s)", res.Email, a.server))
+}
+
+func (a *app) logout() error {
+	a.config.Token = ""
+	if err := saveConfig(a.configPath, a.config); err != nil {
+		return err
+	}
+	return a.render(map[string]bool{"logged_out": true}, messageTable("Logged out"))
+}
+
+// parseInterspersed разбирает флаги, стоящие и до, и после позиционных аргументов.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func describeError(err error) string {
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) {
+		return err.Error()
+	}
+	msg := apiErr.Error()
+	for _, f := range apiErr.Errors {
+		msg += "\n  " + f.Field + ": " + f.Message
+	}
+	if apiErr.RequestID != "" {
+		msg += "\n  request id: " + apiErr.RequestID
+	}
+	return msg
+}
@@ -0,0 +1,297 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/VladislavDraga398/kanban-backend/pkg/client"
+)
+
+// expectArgs проверяет число позиционных аргументов подкоманды.
+func (a *app) expectArgs(args []string, n int, synopsis string) error {
+	if len(args) != n {
+		fmt.Fprintf(a.env.Stderr, "usage: kanbanctl %s\n", synopsis)
+		return errUsage
+	}
+	return nil
+}
+
+func (a *app) subcommand(args []string, group string, names ...string) (string, []string, error) {
+	if len(args) == 0 {
+		fmt.Fprintf(a.env.Stderr, "usage: kanbanctl %s %s\n", group, strings.Join(names, "|"))
+		return "", nil, errUsage
+	}
+	for _, n := range names {
+		if args[0] == n {
+			return n, args[1:], nil
+		}
+	}
+	fmt.Fprintf(a.env.Stderr, "unknown %s subcommand %q (want %s)\n", group, args[0], strings.Join(names, ", "))
+	return "", nil, errUsage
+}
+
+func (a *app) boards(ctx context.Context, args []string) error {
+	sub, args, err := a.subcommand(args, "boards", "list", "create", "rename", "delete")
+	if err != nil {
+		return err
+	}
+	args, err = parseInterspersed(a.flagSet("boards "+sub), args)
+	if err != nil {
+		return err
+	}
+	c, err := a.authedClient()
+	if err != nil {
+		return err
+	}
+
+	switch sub {
+	case "list":
+		if err := a.expectArgs(args, 0, "boards list"); err != nil {
+			return err
+		}
+		boards, err := c.ListBoards(ctx)
+		if err != nil {
+			return err
+		}
+		return a.render(boards, boardsTable(boards...))
+	case "create":
+		if err := a.expectArgs(args, 1, "boards create NAME"); err != nil {
+			return err
+		}
+		b, err := c.CreateBoard(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return a.render(b, boardsTable(*b))
+	case "rename":
+		if err := a.expectArgs(args, 2, "boards rename BOARD NAME"); err != nil {
+			return err
+		}
+		boardID, err := resolveBoard(ctx, c, args[0])
+		if err != nil {
+			return err
+		}
+		b, err := c.RenameBoard(ctx, boardID, args[1])
+		if err != nil {
+			return err
+		}
+		return a.render(b, boardsTable(*b))
+	default:
+		if err := a.expectArgs(args, 1, "boards delete BOARD"); err != nil {
+			return err
+		}
+		boardID, err := resolveBoard(ctx, c, args[0])
+		if err != nil {
+			return err
+		}
+		if err := c.DeleteBoard(ctx, boardID); err != nil {
+			return err
+		}
+		return a.render(map[string]string{"deleted": boardID}, messageTable("Deleted board %s", boardID))
+	}
+}
+
+func (a *app) columns(ctx context.Context, args []string) error {
+	sub, args, err := a.subcommand(args, "columns", "list", "add")
+	if err != nil {
+		return err
+	}
+	args, err = parseInterspersed(a.flagSet("columns "+sub), args)
+	if err != nil {
+		return err
+	}
+	c, err := a.authedClient()
+	if err != nil {
+		return err
+	}
+
+	switch sub {
+	case "list":
+		if err := a.expectArgs(args, 1, "columns list BOARD"); err != nil {
+			return err
+		}
+		boardID, err := resolveBoard(ctx, c, args[0])
+		if err != nil {
+			return err
+		}
+		cols, err := c.ListColumns(ctx, boardID)
+		if err != nil {
+			return err
+		}
+		return a.render(cols, columnsTable(cols...))
+	default:
+		if err := a.expectArgs(args, 2, "columns add BOARD NAME"); err != nil {
+			return err
+		}
+		boardID, err := resolveBoard(ctx, c, args[0])
+		if err != nil {
+			return err
+		}
+		col, err := c.CreateColumn(ctx, boardID, args[1])
+		if err != nil {
+			return err
+		}
+		return a.render(col, columnsTable(*col))
+	}
+}
+
+func (a *app) tasks(ctx context.Context, args []string) error {
+	sub, args, err := a.subcommand(args, "tasks", "list", "add", "edit", "move")
+	if err != nil {
+		return err
+	}
+
+	fs := a.flagSet("tasks " + sub)
+	var title, description string
+	var descriptionSet bool
+	if sub == "add" || sub == "edit" {
+		fs.Func("description", "task description", func(v string) error {
+			description, descriptionSet = v, true
+			return nil
+		})
+	}
+	if sub == "edit" {
+		fs.StringVar(&title, "title", "", "new task title")
+	}
+	args, err = parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	c, err := a.authedClient()
+	if err != nil {
+		return err
+	}
+
+	switch sub {
+	case "list":
+		if err := a.expectArgs(args, 2, "tasks list BOARD COLUMN"); err != nil {
+			return err
+		}
+		boardID, columnID, err := resolveBoardColumn(ctx, c, args[0], args[1])
+		if err != nil {
+			return err
+		}
+		tasks, err := c.ListTasks(ctx, boardID, columnID)
+		if err != nil {
+			return err
+		}
+		return a.render(tasks, tasksTable(tasks...))
+	case "add":
+		if err := a.expectArgs(args, 3, "tasks add BOARD COLUMN TITLE [--description TEXT]"); err != nil {
+			return err
+		}
+		boardID, columnID, err := resolveBoardColumn(ctx, c, args[0], args[1])
+		if err != nil {
+			return err
+		}
+		tk, err := c.CreateTask(ctx, boardID, columnID, client.TaskInput{Title: args[2], Description: description})
+		if err != nil {
+			return err
+		}
+		return a.render(tk, tasksTable(*tk))
+	case "edit":
+		if err := a.expectArgs(args, 3, "tasks edit BOARD COLUMN TASK [--title TEXT] [--description TEXT]"); err != nil {
+			return err
+		}
+		boardID, columnID, err := resolveBoardColumn(ctx, c, args[0], args[1])
+		if err != nil {
+			return err
+		}
+		// API заменяет задачу целиком, поэтому незаданные поля берём из текущего состояния.
+		current, err := findTask(ctx, c, boardID, columnID, args[2])
+		if err != nil {
+			return err
+		}
+		in := client.TaskInput{Title: current.Title, Description: current.Description}
+		if title != "" {
+			in.Title = title
+		}
+		if descriptionSet {
+			in.Description = description
+		}
+		tk, err := c.UpdateTask(ctx, boardID, columnID, current.ID, in)
+		if err != nil {
+			return err
+		}
+		return a.render(tk, tasksTable(*tk))
+	default:
+		if err := a.expectArgs(args, 3, "tasks move BOARD TASK COLUMN"); err != nil {
+			return err
+		}
+		boardID, columnID, err := resolveBoardColumn(ctx, c, args[0], args[2])
+		if err != nil {
+			return err
+		}
+		tk, err := c.MoveTask(ctx, boardID, args[1], columnID)
+		if err != nil {
+			return err
+		}
+		return a.render(tk, tasksTable(*tk))
+	}
+}
+
+// resolveBoard принимает ID или название доски.
+func resolveBoard(ctx context.Context, c *client.Client, ref string) (string, error) {
+	boards, err := c.ListBoards(ctx)
+	if err != nil {
+		return "", err
+	}
+	var matches []string
+	for _, b := range boards {
+		if b.ID == ref {
+			return b.ID, nil
+		}
+		if strings.EqualFold(b.Name, ref) {
+			matches = append(matches, b.ID)
+		}
+	}
+	return pickMatch("board", ref, matches)
+}
+
+// resolveBoardColumn принимает ID или названия доски и колонки.
+func resolveBoardColumn(ctx context.Context, c *client.Client, boardRef, columnRef string) (string, string, error) {
+	boardID, err := resolveBoard(ctx, c, boardRef)
+	if err != nil {
+		return "", "", err
+	}
+	cols, err := c.ListColumns(ctx, boardID)
+	if err != nil {
+		return "", "", err
+	}
+	var matches []string
+	for _, col := range cols {
+		if col.ID == columnRef {
+			return boardID, col.ID, nil
+		}
+		if strings.EqualFold(col.Name, columnRef) {
+			matches = append(matches, col.ID)
+		}
+	}
+	columnID, err := pickMatch("column", columnRef, matches)
+	return boardID, columnID, err
+}
+
+func findTask(ctx context.Context, c *client.Client, boardID, columnID, taskID string) (*client.Task, error) {
+	tasks, err := c.ListTasks(ctx, boardID, columnID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range tasks {
+		if tasks[i].ID == taskID {
+			return &tasks[i], nil
+		}
+	}
+	return nil, fmt.Errorf("task %q not found in column", taskID)
+}
+
+func pickMatch(kind, ref string, matches []string) (string, error) {
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("%s %q not found", kind, ref)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("%s name %q is ambiguous, use an ID (%s)", kind, ref, strings.Join(matches, ", "))
+	}
+}
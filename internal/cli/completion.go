@@ -0,0 +1,64 @@
+package cli
+
+import "fmt"
+
+const bashCompletion = `# bash completion for kanbanctl
+_kanbanctl() {
+    local cur prev words cword
+    COMPREPLY=()
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    local cmd="" sub="" i
+    for ((i = 1; i < COMP_CWORD; i++)); do
+        case "${COMP_WORDS[i]}" in
+            -*) ;;
+            *) if [[ -z "$cmd" ]]; then cmd="${COMP_WORDS[i]}"; elif [[ -z "$sub" ]]; then sub="${COMP_WORDS[i]}"; fi ;;
+        esac
+    done
+    if [[ "${COMP_WORDS[COMP_CWORD-1]}" == "-o" || "${COMP_WORDS[COMP_CWORD-1]}" == "--output" ]]; then
+        COMPREPLY=($(compgen -W "table json yaml" -- "$cur")); return
+    fi
+    if [[ "$cur" == -* ]]; then
+        COMPREPLY=($(compgen -W "--output --server --token --config --email --password-stdin --title --description" -- "$cur")); return
+    fi
+    case "$cmd" in
+        "") COMPREPLY=($(compgen -W "login logout boards columns tasks completion help" -- "$cur")) ;;
+        boards) [[ -z "$sub" ]] && COMPREPLY=($(compgen -W "list create rename delete" -- "$cur")) ;;
+        columns) [[ -z "$sub" ]] && COMPREPLY=($(compgen -W "list add" -- "$cur")) ;;
+        tasks) [[ -z "$sub" ]] && COMPREPLY=($(compgen -W "list add edit move" -- "$cur")) ;;
+        completion) [[ -z "$sub" ]] && COMPREPLY=($(compgen -W "bash zsh fish" -- "$cur")) ;;
+    esac
+}
+complete -F _kanbanctl kanbanctl
+`
+
+const zshCompletion = `#compdef kanbanctl
+# zsh completion for kanbanctl
+autoload -U +X bashcompinit && bashcompinit
+` + bashCompletion
+
+const fishCompletion = `# fish completion for kanbanctl
+complete -c kanbanctl -f
+complete -c kanbanctl -s o -l output -xa "table json yaml" -d "output format"
+complete -c kanbanctl -l server -r -d "API base URL"
+complete -c kanbanctl -l token -r -d "access token"
+complete -c kanbanctl -l config -r -d "config file"
+complete -c kanbanctl -n "__fish_use_subcommand" -a "login logout boards columns tasks completion help"
+complete -c kanbanctl -n "__fish_seen_subcommand_from boards" -a "list create rename delete"
+complete -c kanbanctl -n "__fish_seen_subcommand_from columns" -a "list add"
+complete -c kanbanctl -n "__fish_seen_subcommand_from tasks" -a "list add edit move"
+complete -c kanbanctl -n "__fish_seen_subcommand_from completion" -a "bash zsh fish"
+`
+
+func (a *app) completion(args []string) error {
+	if len(args) != 1 {
+		fmt.Fprintln(a.env.Stderr, "usage: kanbanctl completion bash|zsh|fish")
+		return errUsage
+	}
+	scripts := map[string]string{"bash": bashCompletion, "zsh": zshCompletion, "fish": fishCompletion}
+	script, ok := scripts[args[0]]
+	if !ok {
+		return fmt.Errorf("unsupported shell %q (want bash, zsh or fish)", args[0])
+	}
+	_, err := fmt.Fprint(a.env.Stdout, script)
+	return err
+}
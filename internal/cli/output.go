@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/VladislavDraga398/kanban-backend/pkg/client"
+)
+
+// Форматы вывода.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+)
+
+func validFormat(f string) bool {
+	return f == formatTable || f == formatJSON || f == formatYAML
+}
+
+// table — данные для табличного вывода.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+// render печатает v в выбранном формате; для table используется tbl.
+func render(w io.Writer, format string, v any, tbl table) error {
+	switch format {
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case formatYAML:
+		// Через JSON, чтобы ключи YAML совпадали с JSON-тегами API.
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		var generic any
+		if err := json.Unmarshal(data, &generic); err != nil {
+			return err
+		}
+		out, err := yaml.Marshal(generic)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(out)
+		return err
+	default:
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		writeRow(tw, tbl.header)
+		for _, row := range tbl.rows {
+			writeRow(tw, row)
+		}
+		return tw.Flush()
+	}
+}
+
+func writeRow(w io.Writer, cells []string) {
+	for i, c := range cells {
+		if i > 0 {
+			_, _ = io.WriteString(w, "\t")
+		}
+		_, _ = io.WriteString(w, c)
+	}
+	_, _ = io.WriteString(w, "\n")
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func boardsTable(boards ...client.Board) table {
+	t := table{header: []string{"ID", "NAME", "UPDATED"}}
+	for _, b := range boards {
+		t.rows = append(t.rows, []string{b.ID, b.Name, formatTime(b.UpdatedAt)})
+	}
+	return t
+}
+
+func columnsTable(cols ...client.Column) table {
+	t := table{header: []string{"ID", "POS", "NAME"}}
+	for _, c := range cols {
+		t.rows = append(t.rows, []string{c.ID, strconv.Itoa(c.Position), c.Name})
+	}
+	return t
+}
+
+func tasksTable(tasks ...client.Task) table {
+	t := table{header: []string{"ID", "POS", "TITLE", "COLUMN"}}
+	for _, tk := range tasks {
+		t.rows = append(t.rows, []string{tk.ID, strconv.Itoa(tk.Position), tk.Title, tk.ColumnID})
+	}
+	return t
+}
+
+func messageTable(format string, args ...any) table {
+	return table{header: []string{fmt.Sprintf(format, args...)}}
+}
@@ -0,0 +1,27 @@
+package accesstoken
+
+import (
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+)
+
+// Token — личный токен доступа для скриптов и интеграций; хранится только хэш.
+type Token struct {
+	ID     string
+	UserID string
+	Name   string
+	Scopes []auth.Scope
+	Hash   string
+	// Hint — начало токена, по которому пользователь узнаёт его в списке.
+	Hint string
+	// ExpiresAt — nil для бессрочного токена.
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+}
+
+// Expired сообщает, истёк ли токен к моменту now.
+func (t *Token) Expired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
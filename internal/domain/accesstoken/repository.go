@@ -0,0 +1,22 @@
+package accesstoken
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrNotFound = errors.New("access token not found")
+
+type Repository interface {
+	// Create - сохранение нового токена
+	Create(ctx context.Context, t *Token) error
+	// ListByUser - токены пользователя, новые первыми
+	ListByUser(ctx context.Context, userID string) ([]*Token, error)
+	// GetByHash - поиск токена по хэшу (для аутентификации)
+	GetByHash(ctx context.Context, hash string) (*Token, error)
+	// Delete - отзыв токена пользователя
+	Delete(ctx context.Context, id, userID string) error
+	// TouchLastUsed - отметка времени последнего использования
+	TouchLastUsed(ctx context.Context, id string, at time.Time) error
+}
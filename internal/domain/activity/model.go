@@ -0,0 +1,121 @@
+package activity
+
+import (
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/column"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
+)
+
+// Action — что произошло с сущностью доски. Действие *.restored означает возврат из корзины,
+// а для задачи — ещё и возврат к одной из прежних версий. Задачи, убранные в архив автоматически,
+// записываются как task.archived без автора.
+type Action string
+
+const (
+	BoardCreated     Action = "board.created"
+	BoardUpdated     Action = "board.updated"
+	BoardTransferred Action = "board.transferred"
+	BoardDeleted     Action = "board.deleted"
+	BoardRestored    Action = "board.restored"
+	BoardArchived    Action = "board.archived"
+	BoardUnarchived  Action = "board.unarchived"
+	ColumnCreated    Action = "column.created"
+	ColumnUpdated    Action = "column.updated"
+	ColumnDeleted    Action = "column.deleted"
+	ColumnRestored   Action = "column.restored"
+	TaskCreated      Action = "task.created"
+	TaskUpdated      Action = "task.updated"
+	TaskMoved        Action = "task.moved"
+	TaskDeleted      Action = "task.deleted"
+	TaskRestored     Action = "task.restored"
+	TaskArchived     Action = "task.archived"
+	TaskUnarchived   Action = "task.unarchived"
+)
+
+// Actions — все действия, которые попадают в журнал.
+var Actions = []Action{
+	BoardCreated, BoardUpdated, BoardTransferred, BoardDeleted, BoardRestored, BoardArchived, BoardUnarchived,
+	ColumnCreated, ColumnUpdated, ColumnDeleted, ColumnRestored,
+	TaskCreated, TaskUpdated, TaskMoved, TaskDeleted, TaskRestored, TaskArchived, TaskUnarchived,
+}
+
+// Valid сообщает, известно ли действие.
+func (a Action) Valid() bool {
+	for _, known := range Actions {
+		if a == known {
+			return true
+		}
+	}
+	return false
+}
+
+// EntityType — тип сущности, к которой относится запись.
+type EntityType string
+
+const (
+	EntityBoard  EntityType = "board"
+	EntityColumn EntityType = "column"
+	EntityTask   EntityType = "task"
+)
+
+// Fields — значения полей сущности, которые попадают в журнал.
+type Fields map[string]any
+
+// Entry — запись журнала действий доски. Журнал только дополняется.
+type Entry struct {
+	ID      int64
+	BoardID string
+	// ActorID — пустой, если автор удалил аккаунт.
+	ActorID    string
+	Action     Action
+	EntityType EntityType
+	EntityID   string
+	// Before и After — изменённые поля до и после действия; у созданной сущности нет Before,
+	// у удалённой — After.
+	Before    Fields
+	After     Fields
+	CreatedAt time.Time
+}
+
+// Filter задаёт выборку журнала: новые записи первыми, с ID меньше Before.
+type Filter struct {
+	BoardID string
+	// TaskID — только записи о задаче.
+	TaskID  string
+	Actions []Action
+	// Before — курсор: ID последней записи предыдущей страницы; 0 — с начала.
+	Before int64
+	Limit  int
+}
+
+// BoardFields возвращает поля доски для журнала.
+func BoardFields(b *board.Board) Fields {
+	f := Fields{"name": b.Name, "owner_id": b.OwnerID, "workspace_id": nil}
+	if b.WorkspaceID != nil {
+		f["workspace_id"] = *b.WorkspaceID
+	}
+	return f
+}
+
+// ColumnFields возвращает поля колонки для журнала.
+func ColumnFields(c *column.Column) Fields {
+	return Fields{"name": c.Name, "position": c.Position, "done": c.Done}
+}
+
+// TaskFields возвращает поля задачи для журнала.
+func TaskFields(t *task.Task) Fields {
+	return Fields{"title": t.Title, "description": t.Description, "column_id": t.ColumnID, "position": t.Position}
+}
+
+// Diff оставляет только поля, значения которых различаются. Пустой результат — изменений нет.
+func Diff(before, after Fields) (Fields, Fields) {
+	b, a := Fields{}, Fields{}
+	for k, v := range after {
+		if before[k] != v {
+			b[k], a[k] = before[k], v
+		}
+	}
+	return b, a
+}
@@ -0,0 +1,10 @@
+package activity
+
+import "context"
+
+// Repository читает журнал действий. Записи добавляют репозитории досок, колонок и задач
+// в той же транзакции, что и само изменение.
+type Repository interface {
+	// List - записи журнала доски по фильтру, новые первыми
+	List(ctx context.Context, f Filter) ([]*Entry, error)
+}
@@ -0,0 +1,36 @@
+package archive
+
+import (
+	"context"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
+)
+
+// Repository работает с архивом досок и задач. Архив — не корзина: архивная доска или задача доступна
+// по ID и редактируется как обычно, но не попадает в обычные списки.
+// Недоступные доски и задачи — board.ErrNotFound, task.ErrNotFound и column.ErrNotFound.
+type Repository interface {
+	// ListBoards - архивные личные доски пользователя, недавно архивированные первыми
+	ListBoards(ctx context.Context, userID string) ([]*board.Board, error)
+	// ListWorkspaceBoards - архивные доски пространства, недавно архивированные первыми
+	ListWorkspaceBoards(ctx context.Context, workspaceID string) ([]*board.Board, error)
+	// ListSharedBoards - архивные доски, куда пользователя пригласили, недавно архивированные первыми
+	ListSharedBoards(ctx context.Context, userID string) ([]*board.Board, error)
+	// ListTasks - архивные задачи колонки по позиции
+	ListTasks(ctx context.Context, boardID, columnID, userID string) ([]*task.Task, error)
+	// ArchiveBoard - архивирование доски b.ID, которой может управлять userID; уже архивная доска не меняется
+	ArchiveBoard(ctx context.Context, b *board.Board, userID string) error
+	// UnarchiveBoard - возврат доски b.ID из архива
+	UnarchiveBoard(ctx context.Context, b *board.Board, userID string) error
+	// ArchiveTask - архивирование задачи t.ID доски t.BoardID; уже архивная задача не меняется
+	ArchiveTask(ctx context.Context, t *task.Task, userID string) error
+	// UnarchiveTask - возврат задачи t.ID доски t.BoardID из архива на прежнюю позицию
+	UnarchiveTask(ctx context.Context, t *task.Task, userID string) error
+	// ArchiveColumn - архивирование всех задач колонки, возвращает их число
+	ArchiveColumn(ctx context.Context, boardID, columnID, userID string) (int, error)
+	// AutoArchive - архивирование задач, которые лежат в колонках «готово» с момента раньше before;
+	// одновременно его выполняет только один экземпляр сервиса, остальные получают 0
+	AutoArchive(ctx context.Context, before time.Time) (int64, error)
+}
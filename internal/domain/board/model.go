@@ -6,9 +6,39 @@ import (
 
 // Board описывает канбан-доску.
 type Board struct {
-	ID        string
-	OwnerID   string
-	Name      string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID string
+	// OwnerID — владелец личной доски; у доски пространства — пользователь, который её создал или перенёс.
+	OwnerID string
+	// WorkspaceID — рабочее пространство доски; nil у личной доски.
+	WorkspaceID *string
+	Name        string
+	// ArchivedAt — когда доску убрали в архив; nil у активной доски.
+	ArchivedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Role — роль участника доски, получившего доступ по приглашению.
+type Role string
+
+const (
+	// RoleMember работает с колонками и задачами доски.
+	RoleMember Role = "member"
+	// RoleAdmin дополнительно переименовывает и удаляет доску, приглашает и исключает участников.
+	RoleAdmin Role = "admin"
+)
+
+// Valid сообщает, известна ли роль.
+func (r Role) Valid() bool {
+	return r == RoleMember || r == RoleAdmin
+}
+
+// Member — участник доски.
+type Member struct {
+	BoardID     string
+	UserID      string
+	Email       string
+	DisplayName string
+	Role        Role
+	JoinedAt    time.Time
 }
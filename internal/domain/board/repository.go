@@ -5,22 +5,47 @@ import (
 	"errors"
 )
 
-var ErrNotFound = errors.New("board not found")
+var (
+	ErrNotFound = errors.New("board not found")
+	// ErrMemberNotFound — пользователь не состоит в доске.
+	ErrMemberNotFound = errors.New("board member not found")
+)
 
 // Repository - описываем, что домен ждет от хранилища досок.
+// Доступ к доске есть у владельца личной доски, у всех участников пространства доски и у приглашённых
+// участников доски; управлять доской (переименовывать, удалять, приглашать) может владелец личной доски,
+// администратор пространства или доски либо создатель доски, пока он состоит в пространстве.
 type Repository interface {
 	// Create - создание новой доски
 	Create(ctx context.Context, b *Board) error
 
-	// Update - Обновляем название доски.
-	Update(ctx context.Context, b *Board) error
+	// Update - Обновляем название доски, которой может управлять userID.
+	Update(ctx context.Context, b *Board, userID string) error
 
-	// GetByID - Возвращает доску по ID и владельцу.
-	GetByID(ctx context.Context, id, ownerID string) (*Board, error)
+	// GetByID - Возвращает доску по ID, если у userID есть к ней доступ.
+	GetByID(ctx context.Context, id, userID string) (*Board, error)
 
-	// ListByOwnerID - Возвращаем доски конкретного пользователя.
+	// ListByOwnerID - Возвращаем личные доски конкретного пользователя, кроме архивных.
 	ListByOwnerID(ctx context.Context, ownerID string) ([]*Board, error)
 
-	//Delete - Удаляем доску по ID и владельцу.
-	Delete(ctx context.Context, id, ownerID string) error
+	// ListShared - Возвращаем доски, куда пользователя пригласили, кроме архивных.
+	ListShared(ctx context.Context, userID string) ([]*Board, error)
+
+	// CanManage - Может ли userID управлять доской; ErrNotFound, если доска ему недоступна.
+	CanManage(ctx context.Context, id, userID string) (bool, error)
+
+	// ListMembers - Приглашённые участники доски в порядке вступления.
+	ListMembers(ctx context.Context, boardID string) ([]*Member, error)
+
+	// RemoveMember - Исключаем приглашённого участника.
+	RemoveMember(ctx context.Context, boardID, userID string) error
+
+	// ListByWorkspace - Возвращаем доски рабочего пространства, кроме архивных.
+	ListByWorkspace(ctx context.Context, workspaceID string) ([]*Board, error)
+
+	// Transfer - Переносим доску в пространство b.WorkspaceID (nil - в личные доски b.OwnerID).
+	Transfer(ctx context.Context, b *Board) error
+
+	//Delete - Переносим в корзину доску, которой может управлять userID.
+	Delete(ctx context.Context, id, userID string) error
 }
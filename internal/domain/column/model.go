@@ -4,10 +4,12 @@ import "time"
 
 // Column описывает колонку доски.
 type Column struct {
-	ID        string
-	BoardID   string
-	Name      string
-	Position  int
+	ID       string
+	BoardID  string
+	Name     string
+	Position int
+	// Done — колонка завершённых задач: задачи, пролежавшие в ней дольше срока автоархивации, уходят в архив.
+	Done      bool
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -15,6 +15,6 @@ type Repository interface {
 	CreateInBoard(ctx context.Context, column *Column, boardID, ownerID string) error
 	// Update обновляет колонку и проверяет владение доской.
 	Update(ctx context.Context, c *Column, ownerID string) error
-	// Delete удаляет колонку и проверяет владение доской.
+	// Delete переносит колонку с её задачами в корзину и проверяет владение доской.
 	Delete(ctx context.Context, id, boardID, ownerID string) error
 }
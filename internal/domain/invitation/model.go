@@ -0,0 +1,31 @@
+package invitation
+
+import (
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+)
+
+// Status — состояние приглашения.
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusAccepted Status = "accepted"
+	StatusDeclined Status = "declined"
+)
+
+// Invitation — приглашение на доску по email. Токен из письма хранится только в виде хэша.
+type Invitation struct {
+	ID      string
+	BoardID string
+	// BoardName — название доски для письма и ответа приглашённому.
+	BoardName string
+	Email     string
+	Role      board.Role
+	InvitedBy string
+	Hash      string
+	Status    Status
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,29 @@
+package invitation
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrNotFound = errors.New("invitation not found")
+	// ErrInvalid — токен не найден, приглашение истекло или уже принято или отклонено.
+	ErrInvalid = errors.New("invitation is invalid or expired")
+)
+
+type Repository interface {
+	// Create - сохранение приглашения; прежнее действующее приглашение того же email на доску отзывается
+	Create(ctx context.Context, inv *Invitation) error
+	// ListPending - действующие приглашения доски
+	ListPending(ctx context.Context, boardID string, now time.Time) ([]*Invitation, error)
+	// Revoke - отзыв действующего приглашения доски
+	Revoke(ctx context.Context, id, boardID string) error
+	// FindPending - действующее приглашение по хэшу токена; ErrInvalid, если его нет
+	FindPending(ctx context.Context, hash string, now time.Time) (*Invitation, error)
+	// Accept - атомарно принимает приглашение и добавляет userID в участники доски
+	// (если у него уже есть участие, роль не понижается)
+	Accept(ctx context.Context, hash, userID string, now time.Time) (*Invitation, error)
+	// Decline - атомарно отклоняет приглашение
+	Decline(ctx context.Context, hash string, now time.Time) (*Invitation, error)
+}
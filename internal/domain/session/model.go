@@ -0,0 +1,23 @@
+package session
+
+import "time"
+
+// Session — вход пользователя на устройстве. Идентификатор сессии записывается в JWT (claim sid),
+// поэтому отзыв сессии отклоняет её токен, не дожидаясь истечения срока.
+type Session struct {
+	ID        string
+	UserID    string
+	UserAgent string
+	IP        string
+	CreatedAt time.Time
+	// LastSeenAt обновляется не на каждый запрос, а с точностью до минуты.
+	LastSeenAt time.Time
+	// ExpiresAt совпадает со сроком действия выданного токена.
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+}
+
+// Active сообщает, принимается ли токен сессии в момент now.
+func (s *Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
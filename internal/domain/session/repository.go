@@ -0,0 +1,24 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrNotFound = errors.New("session not found")
+
+type Repository interface {
+	// Create - сохранение новой сессии
+	Create(ctx context.Context, s *Session) error
+	// Get - сессия по идентификатору, в том числе отозванная
+	Get(ctx context.Context, id string) (*Session, error)
+	// ListActive - неотозванные и неистёкшие сессии пользователя, недавно активные первыми
+	ListActive(ctx context.Context, userID string, now time.Time) ([]*Session, error)
+	// Revoke - отзыв активной сессии пользователя
+	Revoke(ctx context.Context, id, userID string) error
+	// RevokeAll - отзыв всех сессий пользователя, кроме exceptID (пусто - без исключений); возвращает отозванные
+	RevokeAll(ctx context.Context, userID, exceptID string) ([]string, error)
+	// TouchLastSeen - отметка времени последней активности
+	TouchLastSeen(ctx context.Context, id string, at time.Time) error
+}
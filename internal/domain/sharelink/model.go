@@ -0,0 +1,34 @@
+package sharelink
+
+import (
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/column"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
+)
+
+// Link — публичная ссылка только для чтения на доску; хранится только хэш токена.
+type Link struct {
+	ID        string
+	BoardID   string
+	CreatedBy string
+	Hash      string
+	// Hint — начало токена, по которому ссылку узнают в списке.
+	Hint string
+	// ExpiresAt — nil для бессрочной ссылки.
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+}
+
+// Expired сообщает, истекла ли ссылка к моменту now.
+func (l *Link) Expired(now time.Time) bool {
+	return l.ExpiresAt != nil && !now.Before(*l.ExpiresAt)
+}
+
+// Snapshot — содержимое доски на момент чтения по публичной ссылке.
+type Snapshot struct {
+	Board   *board.Board
+	Columns []column.Column
+	Tasks   []task.Task
+}
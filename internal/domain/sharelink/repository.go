@@ -0,0 +1,21 @@
+package sharelink
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFound — ссылка не найдена, отозвана или истекла.
+var ErrNotFound = errors.New("share link not found")
+
+type Repository interface {
+	// Create - сохранение новой ссылки
+	Create(ctx context.Context, l *Link) error
+	// ListByBoard - ссылки доски, новые первыми
+	ListByBoard(ctx context.Context, boardID string) ([]*Link, error)
+	// Delete - отзыв ссылки доски
+	Delete(ctx context.Context, id, boardID string) error
+	// Snapshot - доска с колонками и задачами по хэшу действующей ссылки
+	Snapshot(ctx context.Context, hash string, now time.Time) (*Snapshot, error)
+}
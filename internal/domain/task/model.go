@@ -12,6 +12,21 @@ type Task struct {
 	Title       string
 	Description string
 	Position    int
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	// ArchivedAt — когда задачу убрали в архив; nil у активной задачи.
+	ArchivedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Version — сохранённое состояние задачи. Новая версия появляется при создании задачи,
+// изменении заголовка, описания или колонки и при восстановлении старой версии.
+type Version struct {
+	TaskID      string
+	Version     int
+	ColumnID    string
+	Title       string
+	Description string
+	// ActorID — пустой, если автор неизвестен или удалил аккаунт.
+	ActorID   string
+	CreatedAt time.Time
 }
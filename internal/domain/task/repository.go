@@ -5,7 +5,11 @@ import (
 	"errors"
 )
 
-var ErrNotFound = errors.New("task not found")
+var (
+	ErrNotFound = errors.New("task not found")
+	// ErrVersionNotFound — у задачи нет такой версии.
+	ErrVersionNotFound = errors.New("task version not found")
+)
 
 // Repository описывает операции хранилища, необходимые домену задач.
 type Repository interface {
@@ -15,8 +19,19 @@ type Repository interface {
 	CreateInColumn(ctx context.Context, task *Task, boardID, columnID, ownerID string) error
 	// Update обновляет задачу и проверяет владение доской.
 	Update(ctx context.Context, task *Task, ownerID string) error
-	// Delete удаляет задачу и проверяет владение доской.
+	// Delete переносит задачу в корзину и проверяет владение доской.
 	Delete(ctx context.Context, id, boardID, columnID, ownerID string) error
 	// MoveToColumn переносит задачу в другую колонку и проверяет владение доской.
 	MoveToColumn(ctx context.Context, task *Task, columnID, ownerID string) error
 }
+
+// VersionRepository хранит историю версий задач. Версии добавляет Repository в транзакции изменения задачи.
+type VersionRepository interface {
+	// ListVersions возвращает версии задачи доски, доступной ownerID, новые первыми.
+	ListVersions(ctx context.Context, boardID, taskID, ownerID string) ([]*Version, error)
+	// GetVersion возвращает одну версию задачи.
+	GetVersion(ctx context.Context, boardID, taskID string, version int, ownerID string) (*Version, error)
+	// Restore возвращает задаче заголовок, описание и, если она ещё есть на доске, колонку версии
+	// и сохраняет результат новой версией.
+	Restore(ctx context.Context, task *Task, version int, ownerID string) error
+}
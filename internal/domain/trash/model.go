@@ -0,0 +1,27 @@
+package trash
+
+import "time"
+
+// Kind — что лежит в корзине.
+type Kind string
+
+const (
+	KindBoard  Kind = "board"
+	KindColumn Kind = "column"
+	KindTask   Kind = "task"
+)
+
+// Item — удалённая доска, колонка или задача. Колонка уходит в корзину вместе со своими задачами,
+// поэтому такие задачи отдельно не показываются и возвращаются вместе с колонкой.
+type Item struct {
+	Kind    Kind
+	ID      string
+	BoardID string
+	// ColumnID — колонка задачи; пусто для досок и колонок.
+	ColumnID string
+	// Name — название доски или колонки, заголовок задачи.
+	Name      string
+	DeletedAt time.Time
+	// DeletedBy — пустой, если удаливший пользователь удалил аккаунт.
+	DeletedBy string
+}
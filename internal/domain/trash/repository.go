@@ -0,0 +1,34 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/column"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
+)
+
+var (
+	// ErrNotFound — в корзине нет такого элемента или он недоступен пользователю.
+	ErrNotFound = errors.New("trash item not found")
+	// ErrParentDeleted — задачу нельзя восстановить, пока её колонка в корзине.
+	ErrParentDeleted = errors.New("parent is deleted")
+)
+
+// Repository работает с корзиной. В корзину элементы кладут Delete репозиториев досок, колонок и задач.
+type Repository interface {
+	// List - удалённые доски, которыми пользователь может управлять, и удалённые колонки и задачи
+	// доступных ему досок, недавно удалённые первыми
+	List(ctx context.Context, userID string) ([]*Item, error)
+	// RestoreBoard - восстановление доски b.ID
+	RestoreBoard(ctx context.Context, b *board.Board, userID string) error
+	// RestoreColumn - восстановление колонки c.ID вместе с задачами, удалёнными вместе с ней
+	RestoreColumn(ctx context.Context, c *column.Column, userID string) error
+	// RestoreTask - восстановление задачи t.ID
+	RestoreTask(ctx context.Context, t *task.Task, userID string) error
+	// Purge - окончательное удаление всего, что лежит в корзине с момента раньше before;
+	// одновременно очистку выполняет только один экземпляр сервиса, остальные получают 0
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
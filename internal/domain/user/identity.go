@@ -0,0 +1,27 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrIdentityNotFound = errors.New("identity not found")
+
+// Identity связывает пользователя с учётной записью у внешнего провайдера входа (OIDC).
+type Identity struct {
+	Provider string
+	// Subject — неизменный идентификатор пользователя у провайдера (claim sub).
+	Subject string
+	UserID  string
+	// Email — адрес из провайдера на момент привязки, для аудита.
+	Email     string
+	CreatedAt time.Time
+}
+
+type IdentityRepository interface {
+	// Find - привязка по провайдеру и subject
+	Find(ctx context.Context, provider, subject string) (*Identity, error)
+	// Link - привязка внешней учётной записи к пользователю
+	Link(ctx context.Context, id *Identity) error
+}
@@ -6,5 +6,18 @@ type User struct {
 	ID           string
 	Email        string
 	PasswordHash string
-	CreatedAt    time.Time
+	// EmailVerifiedAt — когда пользователь подтвердил email; nil — не подтверждён.
+	EmailVerifiedAt *time.Time
+	// Профиль: отображаемое имя, аватар, часовой пояс (IANA) и локаль (BCP 47); пустая строка — не задано.
+	DisplayName string
+	AvatarURL   string
+	Timezone    string
+	Locale      string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// EmailVerified сообщает, подтверждён ли email.
+func (u *User) EmailVerified() bool {
+	return u.EmailVerifiedAt != nil
 }
@@ -17,4 +17,15 @@ type Repository interface {
 	GetByID(ctx context.Context, id string) (*User, error)
 	// GetByEmail - получение пользователя по email
 	GetByEmail(ctx context.Context, email string) (*User, error)
+	// MarkEmailVerified - отметка о подтверждении email (повторный вызов не меняет дату)
+	MarkEmailVerified(ctx context.Context, id string) error
+	// UpdatePassword - замена хэша пароля
+	UpdatePassword(ctx context.Context, id, passwordHash string) error
+	// UpdateProfile - сохранение полей профиля (имя, аватар, часовой пояс, локаль)
+	UpdateProfile(ctx context.Context, u *User) error
+	// UpdateEmail - замена email на подтверждённый новый адрес
+	UpdateEmail(ctx context.Context, id, email string) error
+	// Delete - удаление пользователя вместе с его личными досками; пространства и созданные
+	// в них доски передаются оставшимся участникам
+	Delete(ctx context.Context, id string) error
 }
@@ -0,0 +1,41 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// TokenPurpose — назначение одноразового токена из письма.
+type TokenPurpose string
+
+const (
+	PurposeVerifyEmail   TokenPurpose = "verify_email"
+	PurposeResetPassword TokenPurpose = "reset_password"
+	PurposeChangeEmail   TokenPurpose = "change_email"
+)
+
+// ErrTokenInvalid — токен не найден, истёк или уже использован.
+var ErrTokenInvalid = errors.New("token is invalid or expired")
+
+// Token — одноразовый токен; хранится только хэш.
+type Token struct {
+	ID      string
+	UserID  string
+	Purpose TokenPurpose
+	Hash    string
+	// Email — новый адрес для PurposeChangeEmail.
+	Email     string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+type TokenRepository interface {
+	// Issue - сохранение нового токена; прежние неиспользованные токены того же назначения гасятся
+	Issue(ctx context.Context, t *Token) error
+	// Find - действующий (не истёкший и не использованный) токен по хэшу
+	Find(ctx context.Context, purpose TokenPurpose, hash string) (*Token, error)
+	// Consume - атомарно помечает действующий токен использованным
+	Consume(ctx context.Context, purpose TokenPurpose, hash string) (*Token, error)
+}
@@ -0,0 +1,50 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrTwoFactorNotFound — пользователь не начинал подключение двухфакторной аутентификации.
+	ErrTwoFactorNotFound = errors.New("two-factor authentication not found")
+	// ErrRecoveryCodeInvalid — код восстановления не найден или уже использован.
+	ErrRecoveryCodeInvalid = errors.New("recovery code is invalid or used")
+)
+
+// TwoFactor — настройки TOTP пользователя. Пока ConfirmedAt nil, подключение не завершено
+// и вход по-прежнему выполняется только по паролю.
+type TwoFactor struct {
+	UserID string
+	// Secret — общий с приложением-аутентификатором секрет в base32.
+	Secret      string
+	ConfirmedAt *time.Time
+	// LastUsedStep — шаг TOTP последнего принятого кода; коды этого и более ранних шагов отклоняются.
+	LastUsedStep int64
+	// RecoveryCodesLeft — сколько неиспользованных кодов восстановления осталось.
+	RecoveryCodesLeft int
+	CreatedAt         time.Time
+}
+
+// Enabled сообщает, включена ли двухфакторная аутентификация.
+func (t *TwoFactor) Enabled() bool {
+	return t.ConfirmedAt != nil
+}
+
+type TwoFactorRepository interface {
+	// Get - настройки пользователя вместе с числом оставшихся кодов восстановления
+	Get(ctx context.Context, userID string) (*TwoFactor, error)
+	// Begin - новый неподтверждённый секрет; заменяет прежнее неподтверждённое подключение
+	Begin(ctx context.Context, userID, secret string) error
+	// Confirm - включение 2FA с сохранением хэшей кодов восстановления
+	Confirm(ctx context.Context, userID string, step int64, recoveryHashes []string) error
+	// UseStep - атомарно запоминает шаг принятого кода; false, если шаг не новее последнего
+	UseStep(ctx context.Context, userID string, step int64) (bool, error)
+	// UseRecoveryCode - атомарно гасит неиспользованный код восстановления
+	UseRecoveryCode(ctx context.Context, userID, hash string) error
+	// ReplaceRecoveryCodes - замена всех кодов восстановления новыми
+	ReplaceRecoveryCodes(ctx context.Context, userID string, hashes []string) error
+	// Delete - отключение 2FA вместе с кодами восстановления
+	Delete(ctx context.Context, userID string) error
+}
@@ -0,0 +1,48 @@
+package workspace
+
+import "time"
+
+// Role — роль участника рабочего пространства. Роли вложены: owner ⊃ admin ⊃ member.
+type Role string
+
+const (
+	// RoleMember создаёт доски и работает с содержимым всех досок пространства.
+	RoleMember Role = "member"
+	// RoleAdmin дополнительно управляет всеми досками и участниками с ролью member.
+	RoleAdmin Role = "admin"
+	// RoleOwner дополнительно назначает администраторов и удаляет пространство.
+	RoleOwner Role = "owner"
+)
+
+var roleRank = map[Role]int{RoleMember: 1, RoleAdmin: 2, RoleOwner: 3}
+
+// Valid сообщает, известна ли роль.
+func (r Role) Valid() bool {
+	_, ok := roleRank[r]
+	return ok
+}
+
+// AtLeast сообщает, покрывает ли роль r роль other.
+func (r Role) AtLeast(other Role) bool {
+	return roleRank[r] >= roleRank[other]
+}
+
+// Workspace — рабочее пространство (организация), которому могут принадлежать доски.
+type Workspace struct {
+	ID   string
+	Name string
+	// Role — роль пользователя, от имени которого получено пространство.
+	Role      Role
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Member — участник рабочего пространства.
+type Member struct {
+	WorkspaceID string
+	UserID      string
+	Email       string
+	DisplayName string
+	Role        Role
+	JoinedAt    time.Time
+}
@@ -0,0 +1,41 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrNotFound = errors.New("workspace not found")
+	// ErrNotEmpty — в пространстве остались доски.
+	ErrNotEmpty = errors.New("workspace has boards")
+	// ErrMemberNotFound — пользователь не состоит в пространстве.
+	ErrMemberNotFound = errors.New("workspace member not found")
+	// ErrMemberExists — пользователь уже состоит в пространстве.
+	ErrMemberExists = errors.New("workspace member already exists")
+	// ErrLastOwner — действие оставило бы пространство без владельца.
+	ErrLastOwner = errors.New("workspace must keep an owner")
+)
+
+type Repository interface {
+	// Create - создание пространства; ownerID становится его владельцем
+	Create(ctx context.Context, w *Workspace, ownerID string) error
+	// ListByMember - пространства, в которых состоит пользователь, с его ролью
+	ListByMember(ctx context.Context, userID string) ([]*Workspace, error)
+	// GetForMember - пространство с ролью пользователя; ErrNotFound, если он в нём не состоит
+	GetForMember(ctx context.Context, id, userID string) (*Workspace, error)
+	// Rename - смена названия
+	Rename(ctx context.Context, w *Workspace) error
+	// Delete - удаление пространства без досок; доски из корзины становятся личными досками удалившего их
+	Delete(ctx context.Context, id string) error
+	// ListMembers - участники пространства в порядке вступления
+	ListMembers(ctx context.Context, workspaceID string) ([]*Member, error)
+	// GetMember - участник пространства; ErrMemberNotFound, если пользователь в нём не состоит
+	GetMember(ctx context.Context, workspaceID, userID string) (*Member, error)
+	// AddMember - добавление участника
+	AddMember(ctx context.Context, m *Member) error
+	// SetMemberRole - смена роли участника; ErrLastOwner, если это единственный владелец
+	SetMemberRole(ctx context.Context, workspaceID, userID string, role Role) error
+	// RemoveMember - исключение участника; ErrLastOwner, если это единственный владелец
+	RemoveMember(ctx context.Context, workspaceID, userID string) error
+}
@@ -0,0 +1,117 @@
+// Package health выполняет проверки зависимостей для /readyz и подробного отчёта о здоровье.
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Статусы проверок и отчёта.
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// CheckFunc проверяет одну зависимость; nil означает, что она доступна.
+type CheckFunc func(ctx context.Context) error
+
+type check struct {
+	name string
+	fn   CheckFunc
+}
+
+// CheckResult — результат одной проверки.
+type CheckResult struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report — подробный отчёт о здоровье сервиса.
+type Report struct {
+	Status   string        `json:"status"`
+	Draining bool          `json:"draining"`
+	Checks   []CheckResult `json:"checks"`
+}
+
+// Ready сообщает, готов ли сервис принимать трафик.
+func (r Report) Ready() bool {
+	return r.Status == StatusUp
+}
+
+// Checker хранит набор проверок и признак завершения работы.
+type Checker struct {
+	timeout  time.Duration
+	checks   []check
+	draining atomic.Bool
+}
+
+// NewChecker создаёт Checker; timeout ограничивает каждую проверку.
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// Add регистрирует проверку. Вызывается при сборке приложения, до обработки запросов.
+func (c *Checker) Add(name string, fn CheckFunc) {
+	c.checks = append(c.checks, check{name: name, fn: fn})
+}
+
+// SetDraining переводит сервис в состояние «не готов» на время плавного завершения.
+func (c *Checker) SetDraining() {
+	c.draining.Store(true)
+}
+
+// Draining сообщает, идёт ли плавное завершение.
+func (c *Checker) Draining() bool {
+	return c.draining.Load()
+}
+
+// Run выполняет все проверки параллельно и собирает отчёт.
+func (c *Checker) Run(ctx context.Context) Report {
+	results := make([]CheckResult, len(c.checks))
+
+	var wg sync.WaitGroup
+	for i, ch := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.run(ctx, ch)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusUp, Draining: c.Draining(), Checks: results}
+	if report.Draining {
+		report.Status = StatusDown
+	}
+	for _, res := range results {
+		if res.Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+func (c *Checker) run(ctx context.Context, ch check) CheckResult {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	err := ch.fn(ctx)
+	res := CheckResult{
+		Name:      ch.name,
+		Status:    StatusUp,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		res.Status = StatusDown
+		res.Error = err.Error()
+	}
+	return res
+}
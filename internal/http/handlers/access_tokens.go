@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/accesstoken"
+	"github.com/VladislavDraga398/kanban-backend/internal/http/httputil"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+// AccessTokenHandler обрабатывает личные токены доступа текущего пользователя (/me/tokens).
+type AccessTokenHandler struct {
+	tokens accessTokenService
+}
+
+// NewAccessTokenHandler создаёт хендлер /me/tokens.
+func NewAccessTokenHandler(tokens accessTokenService) *AccessTokenHandler {
+	return &AccessTokenHandler{tokens: tokens}
+}
+
+type accessTokenService interface {
+	Create(ctx context.Context, userID string, in service.CreateAccessTokenInput) (*accesstoken.Token, string, error)
+	List(ctx context.Context, userID string) ([]*accesstoken.Token, error)
+	Revoke(ctx context.Context, userID, id string) error
+}
+
+type createAccessTokenRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type accessTokenResponse struct {
+	ID         string       `json:"id"`
+	Name       string       `json:"name"`
+	Scopes     []auth.Scope `json:"scopes"`
+	Hint       string       `json:"token_hint"`
+	ExpiresAt  *time.Time   `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time   `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+type createdAccessTokenResponse struct {
+	accessTokenResponse
+	// Token показывается один раз: сервер хранит только хэш.
+	Token string `json:"token"`
+}
+
+func writeAccessToken(t *accesstoken.Token) accessTokenResponse {
+	scopes := t.Scopes
+	if scopes == nil {
+		scopes = []auth.Scope{}
+	}
+	return accessTokenResponse{
+		ID:         t.ID,
+		Name:       t.Name,
+		Scopes:     scopes,
+		Hint:       t.Hint,
+		ExpiresAt:  t.ExpiresAt,
+		LastUsedAt: t.LastUsedAt,
+		CreatedAt:  t.CreatedAt,
+	}
+}
+
+// List обрабатывает GET /api/v1/me/tokens.
+func (h *AccessTokenHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.tokens.List(r.Context(), userID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	resp := make([]accessTokenResponse, 0, len(tokens))
+	for _, t := range tokens {
+		resp = append(resp, writeAccessToken(t))
+	}
+	httputil.JSON(w, http.StatusOK, resp)
+}
+
+// Create обрабатывает POST /api/v1/me/tokens.
+func (h *AccessTokenHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req createAccessTokenRequest
+	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
+		return
+	}
+
+	t, raw, err := h.tokens.Create(r.Context(), userID, service.CreateAccessTokenInput{
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusCreated, createdAccessTokenResponse{accessTokenResponse: writeAccessToken(t), Token: raw})
+}
+
+// Revoke обрабатывает DELETE /api/v1/me/tokens/{token_id}.
+func (h *AccessTokenHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.tokens.Revoke(r.Context(), userID, chi.URLParam(r, "token_id")); err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
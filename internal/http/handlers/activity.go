@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/activity"
+	"github.com/VladislavDraga398/kanban-backend/internal/http/httputil"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
+)
+
+// ActivityHandler обрабатывает журнал действий доски.
+type ActivityHandler struct {
+	activity activityService
+}
+
+// NewActivityHandler создаёт хендлер журнала действий.
+func NewActivityHandler(activity activityService) *ActivityHandler {
+	return &ActivityHandler{activity: activity}
+}
+
+type activityService interface {
+	ListBoard(ctx context.Context, userID, boardID string, q service.ActivityQuery) (*service.ActivityPage, error)
+	ListTask(ctx context.Context, userID, boardID, taskID string, q service.ActivityQuery) (*service.ActivityPage, error)
+}
+
+type activityEntryResponse struct {
+	ID         int64               `json:"id"`
+	BoardID    string              `json:"board_id"`
+	ActorID    *string             `json:"actor_id"`
+	Action     activity.Action     `json:"action"`
+	EntityType activity.EntityType `json:"entity_type"`
+	EntityID   string              `json:"entity_id"`
+	Before     activity.Fields     `json:"before,omitempty"`
+	After      activity.Fields     `json:"after,omitempty"`
+	CreatedAt  time.Time           `json:"created_at"`
+}
+
+type activityPageResponse struct {
+	Items      []activityEntryResponse `json:"items"`
+	NextCursor string                  `json:"next_cursor,omitempty"`
+}
+
+func writeActivityPage(page *service.ActivityPage) activityPageResponse {
+	resp := activityPageResponse{
+		Items:      make([]activityEntryResponse, 0, len(page.Entries)),
+		NextCursor: page.NextCursor,
+	}
+	for _, e := range page.Entries {
+		item := activityEntryResponse{
+			ID:         e.ID,
+			BoardID:    e.BoardID,
+			Action:     e.Action,
+			EntityType: e.EntityType,
+			EntityID:   e.EntityID,
+			Before:     e.Before,
+			After:      e.After,
+			CreatedAt:  e.CreatedAt,
+		}
+		if e.ActorID != "" {
+			item.ActorID = &e.ActorID
+		}
+		resp.Items = append(resp.Items, item)
+	}
+	return resp
+}
+
+// activityQuery разбирает ?action=…&cursor=…&limit=…; action можно повторять или перечислять через запятую.
+func activityQuery(w http.ResponseWriter, r *http.Request) (service.ActivityQuery, bool) {
+	query := r.URL.Query()
+	q := service.ActivityQuery{Cursor: query.Get("cursor")}
+	for _, raw := range query["action"] {
+		for _, a := range strings.Split(raw, ",") {
+			if a = strings.TrimSpace(a); a != "" {
+				q.Actions = append(q.Actions, a)
+			}
+		}
+	}
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil {
+			httputil.Problem(w, r, httputil.ErrorResponse{
+				Status: http.StatusBadRequest,
+				Code:   service.CodeValidation,
+				Detail: "must be an integer",
+				Errors: []httputil.FieldError{{Field: "limit", Message: "must be an integer"}},
+			})
+			return q, false
+		}
+		q.Limit = limit
+	}
+	return q, true
+}
+
+// ListBoard обрабатывает GET /api/v1/boards/{id}/activity.
+func (h *ActivityHandler) ListBoard(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+	q, ok := activityQuery(w, r)
+	if !ok {
+		return
+	}
+
+	page, err := h.activity.ListBoard(r.Context(), userID, chi.URLParam(r, "id"), q)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, writeActivityPage(page))
+}
+
+// ListTask обрабатывает GET /api/v1/boards/{board_id}/tasks/{task_id}/activity.
+func (h *ActivityHandler) ListTask(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+	q, ok := activityQuery(w, r)
+	if !ok {
+		return
+	}
+
+	page, err := h.activity.ListTask(r.Context(), userID, chi.URLParam(r, "board_id"), chi.URLParam(r, "task_id"), q)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, writeActivityPage(page))
+}
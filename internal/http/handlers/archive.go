@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
+	"github.com/VladislavDraga398/kanban-backend/internal/http/httputil"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
+)
+
+// ArchiveHandler обрабатывает архив досок и задач.
+type ArchiveHandler struct {
+	archive archiveService
+}
+
+// NewArchiveHandler создаёт хендлер архива.
+func NewArchiveHandler(archive archiveService) *ArchiveHandler {
+	return &ArchiveHandler{archive: archive}
+}
+
+type archiveService interface {
+	ListBoards(ctx context.Context, userID string) ([]*board.Board, error)
+	ListWorkspaceBoards(ctx context.Context, userID, workspaceID string) ([]*board.Board, error)
+	ListSharedBoards(ctx context.Context, userID string) ([]*board.Board, error)
+	ListTasks(ctx context.Context, userID, boardID, columnID string) ([]*task.Task, error)
+	ArchiveBoard(ctx context.Context, userID, boardID string) (*board.Board, error)
+	UnarchiveBoard(ctx context.Context, userID, boardID string) (*board.Board, error)
+	ArchiveTask(ctx context.Context, userID, boardID, taskID string) (*task.Task, error)
+	UnarchiveTask(ctx context.Context, userID, boardID, taskID string) (*task.Task, error)
+	ArchiveColumn(ctx context.Context, userID, boardID, columnID string) (int, error)
+}
+
+type archiveColumnResponse struct {
+	// Archived — сколько задач ушло в архив.
+	Archived int `json:"archived"`
+}
+
+// WithArchived отдаёт запрос с ?archived=true хендлеру archived, остальные — active.
+func WithArchived(active, archived http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		raw := r.URL.Query().Get("archived")
+		if raw == "" {
+			active(w, r)
+			return
+		}
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			httputil.Problem(w, r, httputil.ErrorResponse{
+				Status: http.StatusBadRequest,
+				Code:   service.CodeValidation,
+				Detail: "must be true or false",
+				Errors: []httputil.FieldError{{Field: "archived", Message: "must be true or false"}},
+			})
+			return
+		}
+		if v {
+			archived(w, r)
+			return
+		}
+		active(w, r)
+	}
+}
+
+// ListBoards обрабатывает GET /api/v1/boards?archived=true.
+func (h *ArchiveHandler) ListBoards(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	boards, err := h.archive.ListBoards(r.Context(), userID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, writeBoards(boards))
+}
+
+// ListWorkspaceBoards обрабатывает GET /api/v1/workspaces/{workspace_id}/boards?archived=true.
+func (h *ArchiveHandler) ListWorkspaceBoards(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	boards, err := h.archive.ListWorkspaceBoards(r.Context(), userID, chi.URLParam(r, "workspace_id"))
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, writeBoards(boards))
+}
+
+// ListSharedBoards обрабатывает GET /api/v1/boards/shared?archived=true.
+func (h *ArchiveHandler) ListSharedBoards(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	boards, err := h.archive.ListSharedBoards(r.Context(), userID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, writeBoards(boards))
+}
+
+// ListTasks обрабатывает GET /api/v1/boards/{board_id}/columns/{column_id}/tasks?archived=true.
+func (h *ArchiveHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	tasks, err := h.archive.ListTasks(r.Context(), userID, chi.URLParam(r, "board_id"), chi.URLParam(r, "column_id"))
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	resp := make([]taskResponse, 0, len(tasks))
+	for _, t := range tasks {
+		resp = append(resp, writeTask(t))
+	}
+	httputil.JSON(w, http.StatusOK, resp)
+}
+
+// ArchiveBoard обрабатывает POST /api/v1/boards/{id}/archive.
+func (h *ArchiveHandler) ArchiveBoard(w http.ResponseWriter, r *http.Request) {
+	h.setBoardArchived(w, r, h.archive.ArchiveBoard)
+}
+
+// UnarchiveBoard обрабатывает POST /api/v1/boards/{id}/unarchive.
+func (h *ArchiveHandler) UnarchiveBoard(w http.ResponseWriter, r *http.Request) {
+	h.setBoardArchived(w, r, h.archive.UnarchiveBoard)
+}
+
+func (h *ArchiveHandler) setBoardArchived(w http.ResponseWriter, r *http.Request, set func(ctx context.Context, userID, boardID string) (*board.Board, error)) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	b, err := set(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, writeBoard(b))
+}
+
+// ArchiveTask обрабатывает POST /api/v1/boards/{board_id}/tasks/{task_id}/archive.
+func (h *ArchiveHandler) ArchiveTask(w http.ResponseWriter, r *http.Request) {
+	h.setTaskArchived(w, r, h.archive.ArchiveTask)
+}
+
+// UnarchiveTask обрабатывает POST /api/v1/boards/{board_id}/tasks/{task_id}/unarchive.
+func (h *ArchiveHandler) UnarchiveTask(w http.ResponseWriter, r *http.Request) {
+	h.setTaskArchived(w, r, h.archive.UnarchiveTask)
+}
+
+func (h *ArchiveHandler) setTaskArchived(w http.ResponseWriter, r *http.Request, set func(ctx context.Context, userID, boardID, taskID string) (*task.Task, error)) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	t, err := set(r.Context(), userID, chi.URLParam(r, "board_id"), chi.URLParam(r, "task_id"))
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, writeTask(t))
+}
+
+// ArchiveColumn обрабатывает POST /api/v1/boards/{board_id}/columns/{column_id}/archive-tasks.
+func (h *ArchiveHandler) ArchiveColumn(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	n, err := h.archive.ArchiveColumn(r.Context(), userID, chi.URLParam(r, "board_id"), chi.URLParam(r, "column_id"))
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, archiveColumnResponse{Archived: n})
+}
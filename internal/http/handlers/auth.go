@@ -2,30 +2,29 @@ package handlers
 
 import (
 	"context"
-	"errors"
-	"log"
 	"net/http"
-	"strings"
 	"time"
 
-	"github.com/VladislavDraga398/kanban-backend/internal/auth"
-	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
 	"github.com/VladislavDraga398/kanban-backend/internal/http/httputil"
+	"github.com/VladislavDraga398/kanban-backend/internal/http/middleware"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
 )
 
 type AuthHandler struct {
-	users     authUserStore
-	jwtSecret []byte
-	jwtTTL    time.Duration
+	auth authService
 }
 
-type authUserStore interface {
-	Create(ctx context.Context, u *user.User) error
-	GetByEmail(ctx context.Context, email string) (*user.User, error)
+type authService interface {
+	RegisterWithInvitation(ctx context.Context, email, password, invitationToken string) (*service.Session, error)
+	Login(ctx context.Context, email, password string) (*service.Session, error)
+	LoginTwoFactor(ctx context.Context, challenge, code, recoveryCode string) (*service.Session, error)
+	VerifyEmail(ctx context.Context, token string) error
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, password string) error
 }
 
-func NewAuthHandler(users authUserStore, jwtSecret string, jwtTTL time.Duration) *AuthHandler {
-	return &AuthHandler{users: users, jwtSecret: []byte(jwtSecret), jwtTTL: jwtTTL}
+func NewAuthHandler(auth authService) *AuthHandler {
+	return &AuthHandler{auth: auth}
 }
 
 type loginRequest struct {
@@ -36,19 +35,45 @@ type loginRequest struct {
 type loginResponse struct {
 	ID    string `json:"id"`
 	Email string `json:"email"`
-	Token string `json:"token"`
+	Token string `json:"token,omitempty"`
+	// При включённой 2FA вместо токена выдаётся промежуточный challenge_token для /auth/login/2fa.
+	TwoFactorRequired bool   `json:"two_factor_required,omitempty"`
+	ChallengeToken    string `json:"challenge_token,omitempty"`
+}
+
+type loginTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+	RecoveryCode   string `json:"recovery_code"`
 }
 
 type registerRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	// InvitationToken — токен из приглашения на доску: после регистрации приглашение принимается.
+	InvitationToken string `json:"invitation_token"`
 }
 
 type registerResponse struct {
-	ID        string    `json:"id"`
-	Email     string    `json:"email"`
-	CreatedAt time.Time `json:"created_at"`
-	Token     string    `json:"token"`
+	ID            string    `json:"id"`
+	Email         string    `json:"email"`
+	EmailVerified bool      `json:"email_verified"`
+	CreatedAt     time.Time `json:"created_at"`
+	// Token не выдаётся, пока вход требует подтверждённого email.
+	Token string `json:"token,omitempty"`
+}
+
+type verifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+type forgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type resetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
 }
 
 // Register обрабатывает POST /api/v1/auth/register
@@ -58,98 +83,112 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	req.Email = strings.TrimSpace(req.Email)
-	req.Password = strings.TrimSpace(req.Password)
-
-	if req.Email == "" || req.Password == "" {
-		httputil.Error(w, http.StatusBadRequest, "email and password are required")
+	s, err := h.auth.RegisterWithInvitation(clientContext(r), req.Email, req.Password, req.InvitationToken)
+	if err != nil {
+		writeServiceError(w, r, err)
 		return
 	}
 
-	hash, err := auth.HashPassword(req.Password)
-	if err != nil {
-		log.Printf("failed to hash password: %v", err)
-		httputil.Error(w, http.StatusInternalServerError, "internal server error")
+	resp := registerResponse{
+		ID:            s.User.ID,
+		Email:         s.User.Email,
+		EmailVerified: s.User.EmailVerified(),
+		CreatedAt:     s.User.CreatedAt,
+		Token:         s.Token,
+	}
+
+	httputil.JSON(w, http.StatusCreated, resp)
+}
+
+// Login обрабатывает POST /api/v1/auth/login
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req loginRequest
+	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
 		return
 	}
 
-	u := &user.User{
-		Email:        req.Email,
-		PasswordHash: hash,
+	s, err := h.auth.Login(clientContext(r), req.Email, req.Password)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
 	}
 
-	if err := h.users.Create(r.Context(), u); err != nil {
-		if errors.Is(err, user.ErrEmailAlreadyUsed) {
-			httputil.Error(w, http.StatusConflict, "email already in use")
-			return
-		}
+	httputil.JSON(w, http.StatusOK, writeLogin(s))
+}
 
-		log.Printf("failed to create user: %v", err)
-		httputil.Error(w, http.StatusInternalServerError, "internal server error")
+// LoginTwoFactor обрабатывает POST /api/v1/auth/login/2fa
+func (h *AuthHandler) LoginTwoFactor(w http.ResponseWriter, r *http.Request) {
+	var req loginTwoFactorRequest
+	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
 		return
 	}
 
-	token, err := auth.GenerateJWT(u.ID, h.jwtSecret, h.jwtTTL)
+	s, err := h.auth.LoginTwoFactor(clientContext(r), req.ChallengeToken, req.Code, req.RecoveryCode)
 	if err != nil {
-		log.Printf("failed to sign token: %v", err)
-		httputil.Error(w, http.StatusInternalServerError, "internal server error")
+		writeServiceError(w, r, err)
 		return
 	}
 
-	resp := registerResponse{
-		ID:        u.ID,
-		Email:     u.Email,
-		CreatedAt: u.CreatedAt,
-		Token:     token,
-	}
+	httputil.JSON(w, http.StatusOK, writeLogin(s))
+}
 
-	httputil.JSON(w, http.StatusCreated, resp)
+// clientContext добавляет к контексту запроса устройство клиента для сессии, создаваемой при входе.
+func clientContext(r *http.Request) context.Context {
+	return service.WithClientInfo(r.Context(), service.ClientInfo{UserAgent: r.UserAgent(), IP: middleware.ClientIP(r)})
 }
 
-// Login обрабатывает POST /api/v1/auth/login
-func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
-	var req loginRequest
+func writeLogin(s *service.Session) loginResponse {
+	return loginResponse{
+		ID:                s.User.ID,
+		Email:             s.User.Email,
+		Token:             s.Token,
+		TwoFactorRequired: s.Challenge != "",
+		ChallengeToken:    s.Challenge,
+	}
+}
+
+// VerifyEmail обрабатывает POST /api/v1/auth/verify-email
+func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req verifyEmailRequest
 	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
 		return
 	}
 
-	req.Email = strings.TrimSpace(req.Email)
-	req.Password = strings.TrimSpace(req.Password)
-
-	if req.Email == "" || req.Password == "" {
-		httputil.Error(w, http.StatusBadRequest, "email and password are required")
+	if err := h.auth.VerifyEmail(r.Context(), req.Token); err != nil {
+		writeServiceError(w, r, err)
 		return
 	}
 
-	u, err := h.users.GetByEmail(r.Context(), req.Email)
-	if err != nil {
-		if errors.Is(err, user.ErrNotFound) {
-			httputil.Error(w, http.StatusUnauthorized, "invalid credentials")
-			return
-		}
+	w.WriteHeader(http.StatusNoContent)
+}
 
-		log.Printf("failed to get user: %v", err)
-		httputil.Error(w, http.StatusInternalServerError, "internal server error")
+// ForgotPassword обрабатывает POST /api/v1/auth/forgot-password
+func (h *AuthHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req forgotPasswordRequest
+	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
 		return
 	}
 
-	if err := auth.ComparePasswords(u.PasswordHash, req.Password); err != nil {
-		httputil.Error(w, http.StatusUnauthorized, "invalid credentials")
+	if err := h.auth.ForgotPassword(r.Context(), req.Email); err != nil {
+		writeServiceError(w, r, err)
 		return
 	}
 
-	token, err := auth.GenerateJWT(u.ID, h.jwtSecret, h.jwtTTL)
-	if err != nil {
-		log.Printf("failed to sign token: %v", err)
-		httputil.Error(w, http.StatusInternalServerError, "internal server error")
+	// 202 независимо от того, зарегистрирован ли адрес.
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// ResetPassword обрабатывает POST /api/v1/auth/reset-password
+func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req resetPasswordRequest
+	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
 		return
 	}
 
-	resp := loginResponse{
-		ID:    u.ID,
-		Email: u.Email,
-		Token: token,
+	if err := h.auth.ResetPassword(r.Context(), req.Token, req.Password); err != nil {
+		writeServiceError(w, r, err)
+		return
 	}
 
-	httputil.JSON(w, http.StatusOK, resp)
+	w.WriteHeader(http.StatusNoContent)
 }
@@ -12,7 +12,7 @@ import (
 func requireUserID(w http.ResponseWriter, r *http.Request) (string, bool) {
 	userID, ok := middleware.UserIDFromContext(r.Context())
 	if !ok {
-		httputil.Error(w, http.StatusUnauthorized, "unauthorized")
+		httputil.Error(w, r, http.StatusUnauthorized, httputil.CodeUnauthorized, "unauthorized")
 		return "", false
 	}
 	return userID, true
@@ -2,10 +2,7 @@ package handlers
 
 import (
 	"context"
-	"errors"
-	"log"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -16,42 +13,63 @@ import (
 
 // BoardHandler обрабатывает эндпоинты досок.
 type BoardHandler struct {
-	boards boardStore
+	boards boardService
 }
 
 // NewBoardHandler создаёт хендлер досок.
-func NewBoardHandler(boards boardStore) *BoardHandler {
+func NewBoardHandler(boards boardService) *BoardHandler {
 	return &BoardHandler{boards: boards}
 }
 
-type boardStore interface {
-	ListByOwnerID(ctx context.Context, ownerID string) ([]*board.Board, error)
-	GetByID(ctx context.Context, id, ownerID string) (*board.Board, error)
-	Create(ctx context.Context, b *board.Board) error
-	Update(ctx context.Context, b *board.Board) error
-	Delete(ctx context.Context, id, ownerID string) error
+type boardService interface {
+	List(ctx context.Context, userID string) ([]*board.Board, error)
+	Get(ctx context.Context, userID, boardID string) (*board.Board, error)
+	Create(ctx context.Context, userID, name string) (*board.Board, error)
+	Rename(ctx context.Context, userID, boardID, name string) (*board.Board, error)
+	Delete(ctx context.Context, userID, boardID string) error
+	ListByWorkspace(ctx context.Context, userID, workspaceID string) ([]*board.Board, error)
+	CreateInWorkspace(ctx context.Context, userID, workspaceID, name string) (*board.Board, error)
+	Transfer(ctx context.Context, userID, boardID string, workspaceID *string) (*board.Board, error)
 }
 
 type createBoardRequest struct {
 	Name string `json:"name"`
 }
 
+type transferBoardRequest struct {
+	// WorkspaceID — целевое пространство; null — в личные доски.
+	WorkspaceID *string `json:"workspace_id"`
+}
+
 type boardResponse struct {
-	ID        string    `json:"id"`
-	OwnerID   string    `json:"owner_id"`
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID          string  `json:"id"`
+	OwnerID     string  `json:"owner_id"`
+	WorkspaceID *string `json:"workspace_id,omitempty"`
+	Name        string  `json:"name"`
+	// ArchivedAt — есть только у архивной доски.
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
 }
 
 func writeBoard(b *board.Board) boardResponse {
 	return boardResponse{
-		ID:        b.ID,
-		OwnerID:   b.OwnerID,
-		Name:      b.Name,
-		CreatedAt: b.CreatedAt,
-		UpdatedAt: b.UpdatedAt,
+		ID:          b.ID,
+		OwnerID:     b.OwnerID,
+		WorkspaceID: b.WorkspaceID,
+		Name:        b.Name,
+		ArchivedAt:  b.ArchivedAt,
+		CreatedAt:   b.CreatedAt,
+		UpdatedAt:   b.UpdatedAt,
+	}
+}
+
+func writeBoards(boards []*board.Board) []boardResponse {
+	resp := make([]boardResponse, 0, len(boards))
+	for _, b := range boards {
+		resp = append(resp, writeBoard(b))
 	}
+	return resp
 }
 
 // List обрабатывает GET /api/v1/boards.
@@ -61,19 +79,13 @@ func (h *BoardHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	boardsList, err := h.boards.ListByOwnerID(r.Context(), userID)
+	boardsList, err := h.boards.List(r.Context(), userID)
 	if err != nil {
-		log.Printf("failed to list boards: %v", err)
-		httputil.Error(w, http.StatusInternalServerError, "internal server error")
+		writeServiceError(w, r, err)
 		return
 	}
 
-	resp := make([]boardResponse, 0, len(boardsList))
-	for _, b := range boardsList {
-		resp = append(resp, writeBoard(b))
-	}
-
-	httputil.JSON(w, http.StatusOK, resp)
+	httputil.JSON(w, http.StatusOK, writeBoards(boardsList))
 }
 
 // Get обрабатывает GET /api/v1/boards/{id}.
@@ -83,20 +95,9 @@ func (h *BoardHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	boardID := chi.URLParam(r, "id")
-	if boardID == "" {
-		httputil.Error(w, http.StatusBadRequest, "board id is required")
-		return
-	}
-
-	b, err := h.boards.GetByID(r.Context(), boardID, userID)
+	b, err := h.boards.Get(r.Context(), userID, chi.URLParam(r, "id"))
 	if err != nil {
-		if errors.Is(err, board.ErrNotFound) {
-			httputil.Error(w, http.StatusNotFound, "board not found")
-			return
-		}
-		log.Printf("failed to get board: %v", err)
-		httputil.Error(w, http.StatusInternalServerError, "internal server error")
+		writeServiceError(w, r, err)
 		return
 	}
 
@@ -116,93 +117,107 @@ func (h *BoardHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	req.Name = strings.TrimSpace(req.Name)
-	if req.Name == "" {
-		httputil.Error(w, http.StatusBadRequest, "name is required")
+	b, err := h.boards.Create(r.Context(), userID, req.Name)
+	if err != nil {
+		writeServiceError(w, r, err)
 		return
 	}
 
-	b := &board.Board{
-		OwnerID: userID,
-		Name:    req.Name,
+	resp := writeBoard(b)
+	httputil.JSON(w, http.StatusCreated, resp)
+}
+
+// Update обрабатывает PUT /api/v1/boards/{id}.
+func (h *BoardHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
 	}
 
-	if err := h.boards.Create(r.Context(), b); err != nil {
-		log.Printf("failed to create board: %v", err)
-		httputil.Error(w, http.StatusInternalServerError, "internal server error")
+	var req createBoardRequest
+	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
+		return
+	}
+
+	b, err := h.boards.Rename(r.Context(), userID, chi.URLParam(r, "id"), req.Name)
+	if err != nil {
+		writeServiceError(w, r, err)
 		return
 	}
 
 	resp := writeBoard(b)
-	httputil.JSON(w, http.StatusCreated, resp)
+	httputil.JSON(w, http.StatusOK, resp)
 }
 
-// Update обрабатывает PUT /api/v1/boards/{id}.
-func (h *BoardHandler) Update(w http.ResponseWriter, r *http.Request) {
+// Delete обрабатывает DELETE /api/v1/boards/{id}.
+func (h *BoardHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	userID, ok := requireUserID(w, r)
 	if !ok {
 		return
 	}
 
-	boardID := chi.URLParam(r, "id")
-	if boardID == "" {
-		httputil.Error(w, http.StatusBadRequest, "board id is required")
+	if err := h.boards.Delete(r.Context(), userID, chi.URLParam(r, "id")); err != nil {
+		writeServiceError(w, r, err)
 		return
 	}
 
-	var req createBoardRequest
-	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListInWorkspace обрабатывает GET /api/v1/workspaces/{workspace_id}/boards.
+func (h *BoardHandler) ListInWorkspace(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	boardsList, err := h.boards.ListByWorkspace(r.Context(), userID, chi.URLParam(r, "workspace_id"))
+	if err != nil {
+		writeServiceError(w, r, err)
 		return
 	}
 
-	req.Name = strings.TrimSpace(req.Name)
-	if req.Name == "" {
-		httputil.Error(w, http.StatusBadRequest, "name is required")
+	httputil.JSON(w, http.StatusOK, writeBoards(boardsList))
+}
+
+// CreateInWorkspace обрабатывает POST /api/v1/workspaces/{workspace_id}/boards.
+func (h *BoardHandler) CreateInWorkspace(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
 		return
 	}
 
-	b := &board.Board{
-		ID:      boardID,
-		OwnerID: userID,
-		Name:    req.Name,
+	var req createBoardRequest
+	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
+		return
 	}
 
-	if err := h.boards.Update(r.Context(), b); err != nil {
-		if errors.Is(err, board.ErrNotFound) {
-			httputil.Error(w, http.StatusNotFound, "board not found")
-			return
-		}
-		log.Printf("failed to update board: %v", err)
-		httputil.Error(w, http.StatusInternalServerError, "internal server error")
+	b, err := h.boards.CreateInWorkspace(r.Context(), userID, chi.URLParam(r, "workspace_id"), req.Name)
+	if err != nil {
+		writeServiceError(w, r, err)
 		return
 	}
 
-	resp := writeBoard(b)
-	httputil.JSON(w, http.StatusOK, resp)
+	httputil.JSON(w, http.StatusCreated, writeBoard(b))
 }
 
-// Delete обрабатывает DELETE /api/v1/boards/{id}.
-func (h *BoardHandler) Delete(w http.ResponseWriter, r *http.Request) {
+// Transfer обрабатывает POST /api/v1/boards/{id}/transfer.
+func (h *BoardHandler) Transfer(w http.ResponseWriter, r *http.Request) {
 	userID, ok := requireUserID(w, r)
 	if !ok {
 		return
 	}
 
-	boardID := chi.URLParam(r, "id")
-	if boardID == "" {
-		httputil.Error(w, http.StatusBadRequest, "board id is required")
+	var req transferBoardRequest
+	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
 		return
 	}
 
-	if err := h.boards.Delete(r.Context(), boardID, userID); err != nil {
-		if errors.Is(err, board.ErrNotFound) {
-			httputil.Error(w, http.StatusNotFound, "board not found")
-			return
-		}
-		log.Printf("failed to delete board: %v", err)
-		httputil.Error(w, http.StatusInternalServerError, "internal server error")
+	b, err := h.boards.Transfer(r.Context(), userID, chi.URLParam(r, "id"), req.WorkspaceID)
+	if err != nil {
+		writeServiceError(w, r, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	httputil.JSON(w, http.StatusOK, writeBoard(b))
 }
@@ -2,10 +2,7 @@ package handlers
 
 import (
 	"context"
-	"errors"
-	"log"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -16,23 +13,30 @@ import (
 
 // ColumnHandler обрабатывает эндпоинты колонок.
 type ColumnHandler struct {
-	columns columnStore
+	columns columnService
 }
 
 // NewColumnHandler создаёт хендлер колонок.
-func NewColumnHandler(columns columnStore) *ColumnHandler {
+func NewColumnHandler(columns columnService) *ColumnHandler {
 	return &ColumnHandler{columns: columns}
 }
 
-type columnStore interface {
-	ListByBoardOwner(ctx context.Context, boardID, ownerID string) ([]*column.Column, error)
-	CreateInBoard(ctx context.Context, column *column.Column, boardID, ownerID string) error
-	Update(ctx context.Context, c *column.Column, ownerID string) error
-	Delete(ctx context.Context, id, boardID, ownerID string) error
+type columnService interface {
+	List(ctx context.Context, userID, boardID string) ([]*column.Column, error)
+	Create(ctx context.Context, userID, boardID, name string, done bool) (*column.Column, error)
+	Update(ctx context.Context, userID, boardID, columnID, name string, done *bool) (*column.Column, error)
+	Delete(ctx context.Context, userID, boardID, columnID string) error
 }
 
 type createColumnRequest struct {
 	Name string `json:"name"`
+	Done bool   `json:"done"`
+}
+
+type updateColumnRequest struct {
+	Name string `json:"name"`
+	// Done — nil оставляет признак колонки завершённых задач как есть.
+	Done *bool `json:"done"`
 }
 
 type columnResponse struct {
@@ -40,6 +44,7 @@ type columnResponse struct {
 	BoardID   string    `json:"board_id"`
 	Name      string    `json:"name"`
 	Position  int       `json:"position"`
+	Done      bool      `json:"done"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -50,6 +55,7 @@ func writeColumn(c *column.Column) columnResponse {
 		BoardID:   c.BoardID,
 		Name:      c.Name,
 		Position:  c.Position,
+		Done:      c.Done,
 		CreatedAt: c.CreatedAt,
 		UpdatedAt: c.UpdatedAt,
 	}
@@ -62,16 +68,9 @@ func (h *ColumnHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	boardID := chi.URLParam(r, "board_id")
-	if boardID == "" {
-		httputil.Error(w, http.StatusBadRequest, "board id is required")
-		return
-	}
-
-	cols, err := h.columns.ListByBoardOwner(r.Context(), boardID, userID)
+	cols, err := h.columns.List(r.Context(), userID, chi.URLParam(r, "board_id"))
 	if err != nil {
-		log.Printf("failed to list columns: %v", err)
-		httputil.Error(w, http.StatusInternalServerError, "internal server error")
+		writeServiceError(w, r, err)
 		return
 	}
 
@@ -90,35 +89,14 @@ func (h *ColumnHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	boardID := chi.URLParam(r, "board_id")
-	if boardID == "" {
-		httputil.Error(w, http.StatusBadRequest, "board id is required")
-		return
-	}
-
 	var req createColumnRequest
 	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
 		return
 	}
 
-	req.Name = strings.TrimSpace(req.Name)
-	if req.Name == "" {
-		httputil.Error(w, http.StatusBadRequest, "name is required")
-		return
-	}
-
-	c := &column.Column{
-		Name: req.Name,
-	}
-
-	if err := h.columns.CreateInBoard(r.Context(), c, boardID, userID); err != nil {
-		if errors.Is(err, column.ErrNotFound) {
-			httputil.Error(w, http.StatusNotFound, "board not found")
-			return
-		}
-
-		log.Printf("failed to create column: %v", err)
-		httputil.Error(w, http.StatusInternalServerError, "internal server error")
+	c, err := h.columns.Create(r.Context(), userID, chi.URLParam(r, "board_id"), req.Name, req.Done)
+	if err != nil {
+		writeServiceError(w, r, err)
 		return
 	}
 
@@ -133,42 +111,14 @@ func (h *ColumnHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	boardID := chi.URLParam(r, "board_id")
-	if boardID == "" {
-		httputil.Error(w, http.StatusBadRequest, "board id is required")
-		return
-	}
-
-	columnID := chi.URLParam(r, "column_id")
-	if columnID == "" {
-		httputil.Error(w, http.StatusBadRequest, "column id is required")
-		return
-	}
-
-	var req createColumnRequest
+	var req updateColumnRequest
 	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
 		return
 	}
 
-	req.Name = strings.TrimSpace(req.Name)
-	if req.Name == "" {
-		httputil.Error(w, http.StatusBadRequest, "name is required")
-		return
-	}
-
-	c := &column.Column{
-		ID:      columnID,
-		BoardID: boardID,
-		Name:    req.Name,
-	}
-
-	if err := h.columns.Update(r.Context(), c, userID); err != nil {
-		if errors.Is(err, column.ErrNotFound) {
-			httputil.Error(w, http.StatusNotFound, "column not found")
-			return
-		}
-		log.Printf("failed to update column: %v", err)
-		httputil.Error(w, http.StatusInternalServerError, "internal server error")
+	c, err := h.columns.Update(r.Context(), userID, chi.URLParam(r, "board_id"), chi.URLParam(r, "column_id"), req.Name, req.Done)
+	if err != nil {
+		writeServiceError(w, r, err)
 		return
 	}
 
@@ -183,25 +133,8 @@ func (h *ColumnHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	boardID := chi.URLParam(r, "board_id")
-	if boardID == "" {
-		httputil.Error(w, http.StatusBadRequest, "board id is required")
-		return
-	}
-
-	columnID := chi.URLParam(r, "column_id")
-	if columnID == "" {
-		httputil.Error(w, http.StatusBadRequest, "column id is required")
-		return
-	}
-
-	if err := h.columns.Delete(r.Context(), columnID, boardID, userID); err != nil {
-		if errors.Is(err, column.ErrNotFound) {
-			httputil.Error(w, http.StatusNotFound, "column not found")
-			return
-		}
-		log.Printf("failed to delete column: %v", err)
-		httputil.Error(w, http.StatusInternalServerError, "internal server error")
+	if err := h.columns.Delete(r.Context(), userID, chi.URLParam(r, "board_id"), chi.URLParam(r, "column_id")); err != nil {
+		writeServiceError(w, r, err)
 		return
 	}
 
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/http/httputil"
+	"github.com/VladislavDraga398/kanban-backend/internal/logging"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
+)
+
+// statusByKind сопоставляет вид ошибки сценария HTTP-статусу.
+var statusByKind = map[service.Kind]int{
+	service.KindValidation:      http.StatusBadRequest,
+	service.KindUnauthorized:    http.StatusUnauthorized,
+	service.KindForbidden:       http.StatusForbidden,
+	service.KindNotFound:        http.StatusNotFound,
+	service.KindConflict:        http.StatusConflict,
+	service.KindTooManyRequests: http.StatusTooManyRequests,
+}
+
+// writeServiceError пишет problem+json для ошибки сценария; внутренние ошибки логируются логгером запроса.
+func writeServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	status, ok := statusByKind[service.KindOf(err)]
+	if !ok {
+		logging.FromContext(r.Context()).ErrorContext(r.Context(), "request failed", "error", err)
+		status = http.StatusInternalServerError
+	}
+
+	if retryAfter := service.RetryAfterOf(err); retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	}
+
+	var fields []httputil.FieldError
+	for _, f := range service.FieldsOf(err) {
+		fields = append(fields, httputil.FieldError{Field: f.Field, Message: f.Message})
+	}
+
+	httputil.Problem(w, r, httputil.ErrorResponse{
+		Status: status,
+		Code:   service.CodeOf(err),
+		Detail: service.MessageOf(err),
+		Errors: fields,
+	})
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/health"
+	"github.com/VladislavDraga398/kanban-backend/internal/http/httputil"
+	"github.com/VladislavDraga398/kanban-backend/internal/logging"
+)
+
+// HealthHandler обрабатывает пробы живости/готовности и подробный отчёт о здоровье.
+type HealthHandler struct {
+	checker *health.Checker
+}
+
+// NewHealthHandler создаёт хендлер проверок здоровья.
+func NewHealthHandler(checker *health.Checker) *HealthHandler {
+	return &HealthHandler{checker: checker}
+}
+
+// Live обрабатывает GET /livez: процесс жив, зависимости не проверяются.
+func (h *HealthHandler) Live(w http.ResponseWriter, r *http.Request) {
+	writePlain(w, http.StatusOK, "ok")
+}
+
+// Ready обрабатывает GET /readyz: 503, если зависимость недоступна или идёт плавное завершение.
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	report := h.checker.Run(r.Context())
+	if !report.Ready() {
+		logging.FromContext(r.Context()).WarnContext(r.Context(), "service is not ready", "checks", report.Checks, "draining", report.Draining)
+		writePlain(w, http.StatusServiceUnavailable, "not ready")
+		return
+	}
+	writePlain(w, http.StatusOK, "ok")
+}
+
+// Report обрабатывает GET /health: статус и задержка каждой проверки в JSON.
+func (h *HealthHandler) Report(w http.ResponseWriter, r *http.Request) {
+	report := h.checker.Run(r.Context())
+	status := http.StatusOK
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+	httputil.JSON(w, status, report)
+}
+
+func writePlain(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(body))
+}
@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/invitation"
+	"github.com/VladislavDraga398/kanban-backend/internal/http/httputil"
+)
+
+// InvitationHandler обрабатывает приглашения на доски и участников досок.
+type InvitationHandler struct {
+	invitations invitationService
+	boards      boardMemberService
+}
+
+// NewInvitationHandler создаёт хендлер приглашений.
+func NewInvitationHandler(invitations invitationService, boards boardMemberService) *InvitationHandler {
+	return &InvitationHandler{invitations: invitations, boards: boards}
+}
+
+type invitationService interface {
+	Create(ctx context.Context, userID, boardID, email string, role board.Role) (*invitation.Invitation, error)
+	List(ctx context.Context, userID, boardID string) ([]*invitation.Invitation, error)
+	Revoke(ctx context.Context, userID, boardID, id string) error
+	Accept(ctx context.Context, userID, token string) (*board.Board, error)
+	Decline(ctx context.Context, token string) error
+}
+
+type boardMemberService interface {
+	ListShared(ctx context.Context, userID string) ([]*board.Board, error)
+	ListMembers(ctx context.Context, userID, boardID string) ([]*board.Member, error)
+	RemoveMember(ctx context.Context, userID, boardID, memberID string) error
+}
+
+type createInvitationRequest struct {
+	Email string     `json:"email"`
+	Role  board.Role `json:"role"`
+}
+
+type invitationTokenRequest struct {
+	Token string `json:"token"`
+}
+
+type invitationResponse struct {
+	ID        string            `json:"id"`
+	BoardID   string            `json:"board_id"`
+	Email     string            `json:"email"`
+	Role      board.Role        `json:"role"`
+	InvitedBy string            `json:"invited_by"`
+	Status    invitation.Status `json:"status"`
+	ExpiresAt time.Time         `json:"expires_at"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+type boardMemberResponse struct {
+	UserID      string     `json:"user_id"`
+	Email       string     `json:"email"`
+	DisplayName string     `json:"display_name"`
+	Role        board.Role `json:"role"`
+	JoinedAt    time.Time  `json:"joined_at"`
+}
+
+func writeInvitation(inv *invitation.Invitation) invitationResponse {
+	return invitationResponse{
+		ID:        inv.ID,
+		BoardID:   inv.BoardID,
+		Email:     inv.Email,
+		Role:      inv.Role,
+		InvitedBy: inv.InvitedBy,
+		Status:    inv.Status,
+		ExpiresAt: inv.ExpiresAt,
+		CreatedAt: inv.CreatedAt,
+	}
+}
+
+// Create обрабатывает POST /api/v1/boards/{id}/invitations.
+func (h *InvitationHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req createInvitationRequest
+	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
+		return
+	}
+
+	inv, err := h.invitations.Create(r.Context(), userID, chi.URLParam(r, "id"), req.Email, req.Role)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusCreated, writeInvitation(inv))
+}
+
+// List обрабатывает GET /api/v1/boards/{id}/invitations.
+func (h *InvitationHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	list, err := h.invitations.List(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	resp := make([]invitationResponse, 0, len(list))
+	for _, inv := range list {
+		resp = append(resp, writeInvitation(inv))
+	}
+	httputil.JSON(w, http.StatusOK, resp)
+}
+
+// Revoke обрабатывает DELETE /api/v1/boards/{id}/invitations/{invitation_id}.
+func (h *InvitationHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.invitations.Revoke(r.Context(), userID, chi.URLParam(r, "id"), chi.URLParam(r, "invitation_id")); err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Accept обрабатывает POST /api/v1/invitations/accept.
+func (h *InvitationHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req invitationTokenRequest
+	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
+		return
+	}
+
+	b, err := h.invitations.Accept(r.Context(), userID, req.Token)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, writeBoard(b))
+}
+
+// Decline обрабатывает POST /api/v1/invitations/decline.
+func (h *InvitationHandler) Decline(w http.ResponseWriter, r *http.Request) {
+	var req invitationTokenRequest
+	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
+		return
+	}
+
+	if err := h.invitations.Decline(r.Context(), req.Token); err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListShared обрабатывает GET /api/v1/boards/shared.
+func (h *InvitationHandler) ListShared(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	list, err := h.boards.ListShared(r.Context(), userID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, writeBoards(list))
+}
+
+// ListMembers обрабатывает GET /api/v1/boards/{id}/members.
+func (h *InvitationHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	members, err := h.boards.ListMembers(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	resp := make([]boardMemberResponse, 0, len(members))
+	for _, m := range members {
+		resp = append(resp, boardMemberResponse{
+			UserID:      m.UserID,
+			Email:       m.Email,
+			DisplayName: m.DisplayName,
+			Role:        m.Role,
+			JoinedAt:    m.JoinedAt,
+		})
+	}
+	httputil.JSON(w, http.StatusOK, resp)
+}
+
+// RemoveMember обрабатывает DELETE /api/v1/boards/{id}/members/{user_id}.
+func (h *InvitationHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.boards.RemoveMember(r.Context(), userID, chi.URLParam(r, "id"), chi.URLParam(r, "user_id")); err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
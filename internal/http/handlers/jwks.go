@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+	"github.com/VladislavDraga398/kanban-backend/internal/http/httputil"
+)
+
+// jwksMaxAge — сколько секунд другие сервисы могут кэшировать JWKS. Новый ключ нужно публиковать
+// как ключ проверки хотя бы на это время раньше, чем им начнут подписывать.
+const jwksMaxAge = "300"
+
+// JWKSHandler публикует открытые ключи проверки токенов доступа.
+type JWKSHandler struct {
+	keys *auth.KeySet
+}
+
+// NewJWKSHandler создаёт хендлер JWKS; nil keys — токены подписываются HS256 и публиковать нечего.
+func NewJWKSHandler(keys *auth.KeySet) *JWKSHandler {
+	return &JWKSHandler{keys: keys}
+}
+
+// JWKS обрабатывает GET /.well-known/jwks.json.
+func (h *JWKSHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	set := auth.JWKS{Keys: []auth.JWK{}}
+	if h.keys != nil {
+		set = h.keys.JWKS()
+	}
+	w.Header().Set("Cache-Control", "public, max-age="+jwksMaxAge)
+	httputil.JSON(w, http.StatusOK, set)
+}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	"github.com/VladislavDraga398/kanban-backend/internal/http/httputil"
+	"github.com/VladislavDraga398/kanban-backend/internal/http/middleware"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
+)
+
+// MeHandler обрабатывает эндпоинты учётной записи текущего пользователя.
+type MeHandler struct {
+	account accountService
+}
+
+// NewMeHandler создаёт хендлер /me.
+func NewMeHandler(account accountService) *MeHandler {
+	return &MeHandler{account: account}
+}
+
+type accountService interface {
+	Get(ctx context.Context, userID string) (*user.User, error)
+	UpdateProfile(ctx context.Context, userID string, patch service.ProfilePatch) (*user.User, error)
+	ChangePassword(ctx context.Context, userID, currentSessionID, currentPassword, newPassword string) error
+	ChangeEmail(ctx context.Context, userID, newEmail, password string) error
+	Delete(ctx context.Context, userID, password, confirmEmail string) error
+}
+
+type updateProfileRequest struct {
+	DisplayName *string `json:"display_name"`
+	AvatarURL   *string `json:"avatar_url"`
+	Timezone    *string `json:"timezone"`
+	Locale      *string `json:"locale"`
+}
+
+type changePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+type changeEmailRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type deleteAccountRequest struct {
+	Password     string `json:"password"`
+	ConfirmEmail string `json:"confirm_email"`
+}
+
+type userResponse struct {
+	ID            string    `json:"id"`
+	Email         string    `json:"email"`
+	EmailVerified bool      `json:"email_verified"`
+	DisplayName   string    `json:"display_name"`
+	AvatarURL     string    `json:"avatar_url"`
+	Timezone      string    `json:"timezone"`
+	Locale        string    `json:"locale"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func writeUser(u *user.User) userResponse {
+	return userResponse{
+		ID:            u.ID,
+		Email:         u.Email,
+		EmailVerified: u.EmailVerified(),
+		DisplayName:   u.DisplayName,
+		AvatarURL:     u.AvatarURL,
+		Timezone:      u.Timezone,
+		Locale:        u.Locale,
+		CreatedAt:     u.CreatedAt,
+		UpdatedAt:     u.UpdatedAt,
+	}
+}
+
+// Get обрабатывает GET /api/v1/me.
+func (h *MeHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	u, err := h.account.Get(r.Context(), userID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, writeUser(u))
+}
+
+// Update обрабатывает PATCH /api/v1/me.
+func (h *MeHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req updateProfileRequest
+	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
+		return
+	}
+
+	u, err := h.account.UpdateProfile(r.Context(), userID, service.ProfilePatch{
+		DisplayName: req.DisplayName,
+		AvatarURL:   req.AvatarURL,
+		Timezone:    req.Timezone,
+		Locale:      req.Locale,
+	})
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, writeUser(u))
+}
+
+// ChangePassword обрабатывает POST /api/v1/me/password.
+func (h *MeHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req changePasswordRequest
+	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
+		return
+	}
+
+	current, _ := middleware.SessionIDFromContext(r.Context())
+	if err := h.account.ChangePassword(r.Context(), userID, current, req.CurrentPassword, req.NewPassword); err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ChangeEmail обрабатывает POST /api/v1/me/email.
+func (h *MeHandler) ChangeEmail(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req changeEmailRequest
+	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
+		return
+	}
+
+	if err := h.account.ChangeEmail(r.Context(), userID, req.Email, req.Password); err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	// Адрес сменится после подтверждения по ссылке из письма.
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// Delete обрабатывает DELETE /api/v1/me.
+func (h *MeHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req deleteAccountRequest
+	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
+		return
+	}
+
+	if err := h.account.Delete(r.Context(), userID, req.Password, req.ConfirmEmail); err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/http/httputil"
+	"github.com/VladislavDraga398/kanban-backend/internal/logging"
+	"github.com/VladislavDraga398/kanban-backend/internal/oidc"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	oidcFlowCookie = "kanban_oidc_flow"
+	oidcCookiePath = "/api/v1/auth/oidc/"
+	// oidcFlowTTL — сколько пользователь может провести на странице входа провайдера.
+	oidcFlowTTL = 10 * time.Minute
+	// oidcCallbackPath — страница фронтенда, которая забирает токен или код ошибки из фрагмента URL.
+	oidcCallbackPath = "/sso/callback"
+)
+
+// Коды ошибок входа через провайдера, которые получает страница фронтенда.
+const (
+	codeOIDCProviderNotFound = "oidc_provider_not_found"
+	codeIdPUnavailable       = "idp_unavailable"
+	codeSSOIdPError          = "idp_error"
+	codeSSOAccessDenied      = "access_denied"
+	codeSSOInvalidState      = "invalid_state"
+	codeSSOInvalidIDToken    = "invalid_id_token"
+)
+
+// OIDCHandler обрабатывает вход через внешних OpenID Connect провайдеров.
+type OIDCHandler struct {
+	providers  []*oidc.Provider
+	auth       externalAuthService
+	flowKey    []byte
+	appBaseURL string
+}
+
+type externalAuthService interface {
+	LoginExternal(ctx context.Context, in service.ExternalLogin) (*service.Session, error)
+}
+
+// NewOIDCHandler создаёт хендлер /auth/oidc. После входа пользователь возвращается
+// на appBaseURL/sso/callback с токеном или кодом ошибки во фрагменте URL.
+func NewOIDCHandler(providers []*oidc.Provider, auth externalAuthService, secret []byte, appBaseURL string) *OIDCHandler {
+	return &OIDCHandler{
+		providers:  providers,
+		auth:       auth,
+		flowKey:    oidc.FlowKey(secret),
+		appBaseURL: strings.TrimSuffix(appBaseURL, "/"),
+	}
+}
+
+type oidcProviderResponse struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	LoginURL    string `json:"login_url"`
+}
+
+// Providers обрабатывает GET /api/v1/auth/oidc: список провайдеров для кнопок входа.
+func (h *OIDCHandler) Providers(w http.ResponseWriter, r *http.Request) {
+	resp := make([]oidcProviderResponse, 0, len(h.providers))
+	for _, p := range h.providers {
+		resp = append(resp, oidcProviderResponse{
+			Name:        p.Name(),
+			DisplayName: p.DisplayName(),
+			LoginURL:    oidcCookiePath + url.PathEscape(p.Name()) + "/login",
+		})
+	}
+	httputil.JSON(w, http.StatusOK, resp)
+}
+
+// Login обрабатывает GET /api/v1/auth/oidc/{provider}/login: перенаправляет на страницу входа провайдера.
+func (h *OIDCHandler) Login(w http.ResponseWriter, r *http.Request) {
+	p, ok := h.provider(w, r)
+	if !ok {
+		return
+	}
+
+	flow, err := oidc.NewFlowState(p.Name())
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	target, err := p.AuthCodeURL(r.Context(), flow.State, flow.Nonce, flow.Challenge())
+	if err != nil {
+		logging.FromContext(r.Context()).ErrorContext(r.Context(), "oidc discovery failed", "provider", p.Name(), "error", err)
+		httputil.Error(w, r, http.StatusBadGateway, codeIdPUnavailable, "identity provider is unavailable")
+		return
+	}
+	sealed, err := flow.Seal(h.flowKey, oidcFlowTTL)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	h.setFlowCookie(w, p, sealed, int(oidcFlowTTL.Seconds()))
+	http.Redirect(w, r, target, http.StatusFound)
+}
+
+// Callback обрабатывает GET /api/v1/auth/oidc/{provider}/callback, куда провайдер возвращает пользователя.
+// Ответ всегда — перенаправление на страницу фронтенда: браузер пришёл сюда навигацией, а не через fetch.
+func (h *OIDCHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	p, ok := h.provider(w, r)
+	if !ok {
+		return
+	}
+	ctx := r.Context()
+	log := logging.FromContext(ctx)
+	q := r.URL.Query()
+
+	// Cookie одноразовая: повтор callback с тем же кодом должен начинаться заново.
+	h.setFlowCookie(w, p, "", -1)
+
+	if e := q.Get("error"); e != "" {
+		log.WarnContext(ctx, "oidc provider returned error", "provider", p.Name(), "error", e, "description", q.Get("error_description"))
+		h.finish(w, r, url.Values{"error": {codeSSOAccessDenied}})
+		return
+	}
+
+	cookie, err := r.Cookie(oidcFlowCookie)
+	if err != nil {
+		h.finish(w, r, url.Values{"error": {codeSSOInvalidState}})
+		return
+	}
+	flow, err := oidc.OpenFlowState(h.flowKey, cookie.Value)
+	if err != nil || flow.Provider != p.Name() || q.Get("code") == "" ||
+		subtle.ConstantTimeCompare([]byte(flow.State), []byte(q.Get("state"))) != 1 {
+		h.finish(w, r, url.Values{"error": {codeSSOInvalidState}})
+		return
+	}
+
+	idToken, err := p.Exchange(ctx, q.Get("code"), flow.Verifier)
+	if err != nil {
+		log.ErrorContext(ctx, "oidc code exchange failed", "provider", p.Name(), "error", err)
+		h.finish(w, r, url.Values{"error": {codeSSOIdPError}})
+		return
+	}
+	claims, err := p.VerifyIDToken(ctx, idToken, flow.Nonce)
+	if err != nil {
+		code := codeSSOInvalidIDToken
+		if errors.Is(err, oidc.ErrProvider) {
+			code = codeSSOIdPError
+		}
+		log.WarnContext(ctx, "oidc id token rejected", "provider", p.Name(), "error", err)
+		h.finish(w, r, url.Values{"error": {code}})
+		return
+	}
+
+	sess, err := h.auth.LoginExternal(clientContext(r), service.ExternalLogin{
+		Provider:      p.Name(),
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+	})
+	if err != nil {
+		if service.KindOf(err) == service.KindInternal {
+			log.ErrorContext(ctx, "oidc login failed", "provider", p.Name(), "error", err)
+		}
+		h.finish(w, r, url.Values{"error": {service.CodeOf(err)}})
+		return
+	}
+	if sess.Challenge != "" {
+		h.finish(w, r, url.Values{"two_factor_required": {"true"}, "challenge_token": {sess.Challenge}})
+		return
+	}
+
+	h.finish(w, r, url.Values{"token": {sess.Token}})
+}
+
+func (h *OIDCHandler) provider(w http.ResponseWriter, r *http.Request) (*oidc.Provider, bool) {
+	name := chi.URLParam(r, "provider")
+	for _, p := range h.providers {
+		if p.Name() == name {
+			return p, true
+		}
+	}
+	httputil.Error(w, r, http.StatusNotFound, codeOIDCProviderNotFound, "identity provider not found")
+	return nil, false
+}
+
+func (h *OIDCHandler) setFlowCookie(w http.ResponseWriter, p *oidc.Provider, value string, maxAge int) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcFlowCookie,
+		Value:    value,
+		Path:     oidcCookiePath,
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(p.RedirectURL(), "https://"),
+		// Lax нужен, чтобы cookie пришла с навигацией от провайдера обратно на callback.
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// finish возвращает пользователя на фронтенд; результат во фрагменте не попадает в логи серверов и Referer.
+func (h *OIDCHandler) finish(w http.ResponseWriter, r *http.Request, result url.Values) {
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, h.appBaseURL+oidcCallbackPath+"#"+result.Encode(), http.StatusFound)
+}
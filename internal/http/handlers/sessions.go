@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/session"
+	"github.com/VladislavDraga398/kanban-backend/internal/http/httputil"
+	"github.com/VladislavDraga398/kanban-backend/internal/http/middleware"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+// SessionHandler обрабатывает сессии входа текущего пользователя (/me/sessions).
+type SessionHandler struct {
+	sessions sessionService
+}
+
+// NewSessionHandler создаёт хендлер /me/sessions.
+func NewSessionHandler(sessions sessionService) *SessionHandler {
+	return &SessionHandler{sessions: sessions}
+}
+
+type sessionService interface {
+	List(ctx context.Context, userID string) ([]*session.Session, error)
+	Revoke(ctx context.Context, userID, id string) error
+	RevokeAll(ctx context.Context, userID, exceptID string) (int, error)
+}
+
+type sessionResponse struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	// Current отмечает сессию, токеном которой выполнен запрос.
+	Current bool `json:"current"`
+}
+
+type revokeSessionsResponse struct {
+	Revoked int `json:"revoked"`
+}
+
+// List обрабатывает GET /api/v1/me/sessions.
+func (h *SessionHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.sessions.List(r.Context(), userID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	current, _ := middleware.SessionIDFromContext(r.Context())
+	resp := make([]sessionResponse, 0, len(sessions))
+	for _, s := range sessions {
+		resp = append(resp, sessionResponse{
+			ID:         s.ID,
+			UserAgent:  s.UserAgent,
+			IP:         s.IP,
+			CreatedAt:  s.CreatedAt,
+			LastSeenAt: s.LastSeenAt,
+			ExpiresAt:  s.ExpiresAt,
+			Current:    s.ID == current,
+		})
+	}
+	httputil.JSON(w, http.StatusOK, resp)
+}
+
+// Revoke обрабатывает DELETE /api/v1/me/sessions/{session_id}.
+func (h *SessionHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.sessions.Revoke(r.Context(), userID, chi.URLParam(r, "session_id")); err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeAll обрабатывает DELETE /api/v1/me/sessions: выход на всех устройствах.
+// С keep_current=true текущая сессия остаётся активной.
+func (h *SessionHandler) RevokeAll(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var except string
+	if raw := r.URL.Query().Get("keep_current"); raw != "" {
+		keep, err := strconv.ParseBool(raw)
+		if err != nil {
+			httputil.Problem(w, r, httputil.ErrorResponse{
+				Status: http.StatusBadRequest,
+				Code:   service.CodeValidation,
+				Detail: "must be a boolean",
+				Errors: []httputil.FieldError{{Field: "keep_current", Message: "must be a boolean"}},
+			})
+			return
+		}
+		if keep {
+			except, _ = middleware.SessionIDFromContext(r.Context())
+		}
+	}
+
+	n, err := h.sessions.RevokeAll(r.Context(), userID, except)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, revokeSessionsResponse{Revoked: n})
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/sharelink"
+	"github.com/VladislavDraga398/kanban-backend/internal/http/httputil"
+)
+
+// ShareLinkHandler обрабатывает публичные ссылки на доски и просмотр доски по ссылке.
+type ShareLinkHandler struct {
+	links shareLinkService
+}
+
+// NewShareLinkHandler создаёт хендлер публичных ссылок.
+func NewShareLinkHandler(links shareLinkService) *ShareLinkHandler {
+	return &ShareLinkHandler{links: links}
+}
+
+type shareLinkService interface {
+	Create(ctx context.Context, userID, boardID string, expiresAt *time.Time) (*sharelink.Link, string, error)
+	List(ctx context.Context, userID, boardID string) ([]*sharelink.Link, error)
+	Revoke(ctx context.Context, userID, boardID, id string) error
+	Snapshot(ctx context.Context, token string) (*sharelink.Snapshot, error)
+}
+
+type createShareLinkRequest struct {
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type shareLinkResponse struct {
+	ID        string     `json:"id"`
+	CreatedBy string     `json:"created_by"`
+	Hint      string     `json:"token_hint"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type createdShareLinkResponse struct {
+	shareLinkResponse
+	// Token показывается один раз: сервер хранит только хэш.
+	Token string `json:"token"`
+}
+
+// Публичный снимок доски: без ID, владельца и пространства — только то, что видно на доске.
+type publicBoardResponse struct {
+	Name      string                 `json:"name"`
+	UpdatedAt time.Time              `json:"updated_at"`
+	Columns   []publicColumnResponse `json:"columns"`
+}
+
+type publicColumnResponse struct {
+	Name     string               `json:"name"`
+	Position int                  `json:"position"`
+	Tasks    []publicTaskResponse `json:"tasks"`
+}
+
+type publicTaskResponse struct {
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Position    int       `json:"position"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func writeShareLink(l *sharelink.Link) shareLinkResponse {
+	return shareLinkResponse{
+		ID:        l.ID,
+		CreatedBy: l.CreatedBy,
+		Hint:      l.Hint,
+		ExpiresAt: l.ExpiresAt,
+		CreatedAt: l.CreatedAt,
+	}
+}
+
+func writePublicBoard(snap *sharelink.Snapshot) publicBoardResponse {
+	tasks := make(map[string][]publicTaskResponse, len(snap.Columns))
+	for _, t := range snap.Tasks {
+		tasks[t.ColumnID] = append(tasks[t.ColumnID], publicTaskResponse{
+			Title:       t.Title,
+			Description: t.Description,
+			Position:    t.Position,
+			UpdatedAt:   t.UpdatedAt,
+		})
+	}
+
+	resp := publicBoardResponse{
+		Name:      snap.Board.Name,
+		UpdatedAt: snap.Board.UpdatedAt,
+		Columns:   make([]publicColumnResponse, 0, len(snap.Columns)),
+	}
+	for _, c := range snap.Columns {
+		col := publicColumnResponse{Name: c.Name, Position: c.Position, Tasks: tasks[c.ID]}
+		if col.Tasks == nil {
+			col.Tasks = []publicTaskResponse{}
+		}
+		resp.Columns = append(resp.Columns, col)
+	}
+	return resp
+}
+
+// List обрабатывает GET /api/v1/boards/{id}/share-links.
+func (h *ShareLinkHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	links, err := h.links.List(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	resp := make([]shareLinkResponse, 0, len(links))
+	for _, l := range links {
+		resp = append(resp, writeShareLink(l))
+	}
+	httputil.JSON(w, http.StatusOK, resp)
+}
+
+// Create обрабатывает POST /api/v1/boards/{id}/share-links.
+func (h *ShareLinkHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req createShareLinkRequest
+	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
+		return
+	}
+
+	l, raw, err := h.links.Create(r.Context(), userID, chi.URLParam(r, "id"), req.ExpiresAt)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusCreated, createdShareLinkResponse{shareLinkResponse: writeShareLink(l), Token: raw})
+}
+
+// Revoke обрабатывает DELETE /api/v1/boards/{id}/share-links/{link_id}.
+func (h *ShareLinkHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.links.Revoke(r.Context(), userID, chi.URLParam(r, "id"), chi.URLParam(r, "link_id")); err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Public обрабатывает GET /api/v1/public/boards/{token}.
+func (h *ShareLinkHandler) Public(w http.ResponseWriter, r *http.Request) {
+	snap, err := h.links.Snapshot(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	// Отозванная ссылка должна перестать работать сразу, поэтому ответ не кэшируется.
+	w.Header().Set("Cache-Control", "no-store")
+	httputil.JSON(w, http.StatusOK, writePublicBoard(snap))
+}
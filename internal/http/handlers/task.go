@@ -2,34 +2,32 @@ package handlers
 
 import (
 	"context"
-	"errors"
-	"log"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 
 	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
 	"github.com/VladislavDraga398/kanban-backend/internal/http/httputil"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
 )
 
 // TaskHandler обрабатывает эндпоинты задач.
 type TaskHandler struct {
-	tasks taskStore
+	tasks taskService
 }
 
 // NewTaskHandler создаёт хендлер задач.
-func NewTaskHandler(tasks taskStore) *TaskHandler {
+func NewTaskHandler(tasks taskService) *TaskHandler {
 	return &TaskHandler{tasks: tasks}
 }
 
-type taskStore interface {
-	ListByColumnOwner(ctx context.Context, boardID, columnID, ownerID string) ([]*task.Task, error)
-	CreateInColumn(ctx context.Context, task *task.Task, boardID, columnID, ownerID string) error
-	Update(ctx context.Context, task *task.Task, ownerID string) error
-	Delete(ctx context.Context, id, boardID, columnID, ownerID string) error
-	MoveToColumn(ctx context.Context, task *task.Task, columnID, ownerID string) error
+type taskService interface {
+	List(ctx context.Context, userID, boardID, columnID string) ([]*task.Task, error)
+	Create(ctx context.Context, userID, boardID, columnID string, in service.TaskInput) (*task.Task, error)
+	Update(ctx context.Context, userID, boardID, columnID, taskID string, in service.TaskInput) (*task.Task, error)
+	Delete(ctx context.Context, userID, boardID, columnID, taskID string) error
+	Move(ctx context.Context, userID, boardID, taskID, columnID string) (*task.Task, error)
 }
 
 type createTaskRequest struct {
@@ -37,19 +35,25 @@ type createTaskRequest struct {
 	Description string `json:"description"`
 }
 
+func (req createTaskRequest) input() service.TaskInput {
+	return service.TaskInput{Title: req.Title, Description: req.Description}
+}
+
 type moveTaskRequest struct {
 	ColumnID string `json:"column_id"`
 }
 
 type taskResponse struct {
-	ID          string    `json:"id"`
-	BoardID     string    `json:"board_id"`
-	ColumnID    string    `json:"column_id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Position    int       `json:"position"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          string `json:"id"`
+	BoardID     string `json:"board_id"`
+	ColumnID    string `json:"column_id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Position    int    `json:"position"`
+	// ArchivedAt — есть только у архивной задачи.
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
 }
 
 func writeTask(t *task.Task) taskResponse {
@@ -60,6 +64,7 @@ func writeTask(t *task.Task) taskResponse {
 		Title:       t.Title,
 		Description: t.Description,
 		Position:    t.Position,
+		ArchivedAt:  t.ArchivedAt,
 		CreatedAt:   t.CreatedAt,
 		UpdatedAt:   t.UpdatedAt,
 	}
@@ -72,17 +77,9 @@ func (h *TaskHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	boardID := chi.URLParam(r, "board_id")
-	columnID := chi.URLParam(r, "column_id")
-	if boardID == "" || columnID == "" {
-		httputil.Error(w, http.StatusBadRequest, "board id and column id are required")
-		return
-	}
-
-	tasksList, err := h.tasks.ListByColumnOwner(r.Context(), boardID, columnID, userID)
+	tasksList, err := h.tasks.List(r.Context(), userID, chi.URLParam(r, "board_id"), chi.URLParam(r, "column_id"))
 	if err != nil {
-		log.Printf("failed to list tasks: %v", err)
-		httputil.Error(w, http.StatusInternalServerError, "internal server error")
+		writeServiceError(w, r, err)
 		return
 	}
 
@@ -101,39 +98,14 @@ func (h *TaskHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	boardID := chi.URLParam(r, "board_id")
-	columnID := chi.URLParam(r, "column_id")
-	if boardID == "" || columnID == "" {
-		httputil.Error(w, http.StatusBadRequest, "board id and column id are required")
-		return
-	}
-
 	var req createTaskRequest
 	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
 		return
 	}
 
-	req.Title = strings.TrimSpace(req.Title)
-	req.Description = strings.TrimSpace(req.Description)
-
-	if req.Title == "" {
-		httputil.Error(w, http.StatusBadRequest, "title is required")
-		return
-	}
-
-	t := &task.Task{
-		Title:       req.Title,
-		Description: req.Description,
-	}
-
-	if err := h.tasks.CreateInColumn(r.Context(), t, boardID, columnID, userID); err != nil {
-		if errors.Is(err, task.ErrNotFound) {
-			httputil.Error(w, http.StatusNotFound, "board or column not found")
-			return
-		}
-
-		log.Printf("failed to create task: %v", err)
-		httputil.Error(w, http.StatusInternalServerError, "internal server error")
+	t, err := h.tasks.Create(r.Context(), userID, chi.URLParam(r, "board_id"), chi.URLParam(r, "column_id"), req.input())
+	if err != nil {
+		writeServiceError(w, r, err)
 		return
 	}
 
@@ -148,41 +120,21 @@ func (h *TaskHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	boardID := chi.URLParam(r, "board_id")
-	columnID := chi.URLParam(r, "column_id")
-	taskID := chi.URLParam(r, "task_id")
-	if boardID == "" || columnID == "" || taskID == "" {
-		httputil.Error(w, http.StatusBadRequest, "board id, column id and task id are required")
-		return
-	}
-
 	var req createTaskRequest
 	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
 		return
 	}
 
-	req.Title = strings.TrimSpace(req.Title)
-	req.Description = strings.TrimSpace(req.Description)
-	if req.Title == "" {
-		httputil.Error(w, http.StatusBadRequest, "title is required")
-		return
-	}
-
-	t := &task.Task{
-		ID:          taskID,
-		BoardID:     boardID,
-		ColumnID:    columnID,
-		Title:       req.Title,
-		Description: req.Description,
-	}
-
-	if err := h.tasks.Update(r.Context(), t, userID); err != nil {
-		if errors.Is(err, task.ErrNotFound) {
-			httputil.Error(w, http.StatusNotFound, "task not found")
-			return
-		}
-		log.Printf("failed to update task: %v", err)
-		httputil.Error(w, http.StatusInternalServerError, "internal server error")
+	t, err := h.tasks.Update(
+		r.Context(),
+		userID,
+		chi.URLParam(r, "board_id"),
+		chi.URLParam(r, "column_id"),
+		chi.URLParam(r, "task_id"),
+		req.input(),
+	)
+	if err != nil {
+		writeServiceError(w, r, err)
 		return
 	}
 
@@ -197,21 +149,15 @@ func (h *TaskHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	boardID := chi.URLParam(r, "board_id")
-	columnID := chi.URLParam(r, "column_id")
-	taskID := chi.URLParam(r, "task_id")
-	if boardID == "" || columnID == "" || taskID == "" {
-		httputil.Error(w, http.StatusBadRequest, "board id, column id and task id are required")
-		return
-	}
-
-	if err := h.tasks.Delete(r.Context(), taskID, boardID, columnID, userID); err != nil {
-		if errors.Is(err, task.ErrNotFound) {
-			httputil.Error(w, http.StatusNotFound, "task not found")
-			return
-		}
-		log.Printf("failed to delete task: %v", err)
-		httputil.Error(w, http.StatusInternalServerError, "internal server error")
+	err := h.tasks.Delete(
+		r.Context(),
+		userID,
+		chi.URLParam(r, "board_id"),
+		chi.URLParam(r, "column_id"),
+		chi.URLParam(r, "task_id"),
+	)
+	if err != nil {
+		writeServiceError(w, r, err)
 		return
 	}
 
@@ -225,35 +171,14 @@ func (h *TaskHandler) Move(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	boardID := chi.URLParam(r, "board_id")
-	taskID := chi.URLParam(r, "task_id")
-	if boardID == "" || taskID == "" {
-		httputil.Error(w, http.StatusBadRequest, "board id and task id are required")
-		return
-	}
-
 	var req moveTaskRequest
 	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
 		return
 	}
-	req.ColumnID = strings.TrimSpace(req.ColumnID)
-	if req.ColumnID == "" {
-		httputil.Error(w, http.StatusBadRequest, "column_id is required")
-		return
-	}
-
-	t := &task.Task{
-		ID:      taskID,
-		BoardID: boardID,
-	}
 
-	if err := h.tasks.MoveToColumn(r.Context(), t, req.ColumnID, userID); err != nil {
-		if errors.Is(err, task.ErrNotFound) {
-			httputil.Error(w, http.StatusNotFound, "task or column not found")
-			return
-		}
-		log.Printf("failed to move task: %v", err)
-		httputil.Error(w, http.StatusInternalServerError, "internal server error")
+	t, err := h.tasks.Move(r.Context(), userID, chi.URLParam(r, "board_id"), chi.URLParam(r, "task_id"), req.ColumnID)
+	if err != nil {
+		writeServiceError(w, r, err)
 		return
 	}
 
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
+	"github.com/VladislavDraga398/kanban-backend/internal/http/httputil"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
+)
+
+// TaskVersionHandler обрабатывает историю версий задачи.
+type TaskVersionHandler struct {
+	versions taskVersionService
+}
+
+// NewTaskVersionHandler создаёт хендлер версий задач.
+func NewTaskVersionHandler(versions taskVersionService) *TaskVersionHandler {
+	return &TaskVersionHandler{versions: versions}
+}
+
+type taskVersionService interface {
+	List(ctx context.Context, userID, boardID, taskID string) ([]*task.Version, error)
+	Diff(ctx context.Context, userID, boardID, taskID string, version int, against *int) (*service.VersionDiff, error)
+	Restore(ctx context.Context, userID, boardID, taskID string, version int) (*task.Task, error)
+}
+
+type taskVersionResponse struct {
+	Version     int       `json:"version"`
+	ColumnID    string    `json:"column_id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	ActorID     *string   `json:"actor_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type fieldChangeResponse struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type versionDiffResponse struct {
+	From    int                            `json:"from"`
+	To      int                            `json:"to"`
+	Changes map[string]fieldChangeResponse `json:"changes"`
+}
+
+// intParam разбирает целочисленный параметр пути или запроса; при ошибке отвечает 400.
+func intParam(w http.ResponseWriter, r *http.Request, name, raw string) (int, bool) {
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		httputil.Problem(w, r, httputil.ErrorResponse{
+			Status: http.StatusBadRequest,
+			Code:   service.CodeValidation,
+			Detail: "must be an integer",
+			Errors: []httputil.FieldError{{Field: name, Message: "must be an integer"}},
+		})
+		return 0, false
+	}
+	return n, true
+}
+
+// List обрабатывает GET /api/v1/boards/{board_id}/tasks/{task_id}/versions.
+func (h *TaskVersionHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	versions, err := h.versions.List(r.Context(), userID, chi.URLParam(r, "board_id"), chi.URLParam(r, "task_id"))
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	resp := make([]taskVersionResponse, 0, len(versions))
+	for _, v := range versions {
+		item := taskVersionResponse{
+			Version:     v.Version,
+			ColumnID:    v.ColumnID,
+			Title:       v.Title,
+			Description: v.Description,
+			CreatedAt:   v.CreatedAt,
+		}
+		if v.ActorID != "" {
+			item.ActorID = &v.ActorID
+		}
+		resp = append(resp, item)
+	}
+	httputil.JSON(w, http.StatusOK, resp)
+}
+
+// Diff обрабатывает GET /api/v1/boards/{board_id}/tasks/{task_id}/versions/{version}/diff?against=N.
+func (h *TaskVersionHandler) Diff(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+	version, ok := intParam(w, r, "version", chi.URLParam(r, "version"))
+	if !ok {
+		return
+	}
+	var against *int
+	if raw := r.URL.Query().Get("against"); raw != "" {
+		n, ok := intParam(w, r, "against", raw)
+		if !ok {
+			return
+		}
+		against = &n
+	}
+
+	diff, err := h.versions.Diff(r.Context(), userID, chi.URLParam(r, "board_id"), chi.URLParam(r, "task_id"), version, against)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	resp := versionDiffResponse{From: diff.From, To: diff.To, Changes: make(map[string]fieldChangeResponse, len(diff.Changes))}
+	for field, c := range diff.Changes {
+		resp.Changes[field] = fieldChangeResponse{From: c.From, To: c.To}
+	}
+	httputil.JSON(w, http.StatusOK, resp)
+}
+
+// Restore обрабатывает POST /api/v1/boards/{board_id}/tasks/{task_id}/restore/{version}.
+func (h *TaskVersionHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+	version, ok := intParam(w, r, "version", chi.URLParam(r, "version"))
+	if !ok {
+		return
+	}
+
+	t, err := h.versions.Restore(r.Context(), userID, chi.URLParam(r, "board_id"), chi.URLParam(r, "task_id"), version)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, writeTask(t))
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/column"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/trash"
+	"github.com/VladislavDraga398/kanban-backend/internal/http/httputil"
+)
+
+// TrashHandler обрабатывает корзину текущего пользователя (/me/trash).
+type TrashHandler struct {
+	trash trashService
+}
+
+// NewTrashHandler создаёт хендлер /me/trash.
+func NewTrashHandler(trash trashService) *TrashHandler {
+	return &TrashHandler{trash: trash}
+}
+
+type trashService interface {
+	List(ctx context.Context, userID string) ([]*trash.Item, error)
+	PurgeAt(deletedAt time.Time) time.Time
+	RestoreBoard(ctx context.Context, userID, id string) (*board.Board, error)
+	RestoreColumn(ctx context.Context, userID, id string) (*column.Column, error)
+	RestoreTask(ctx context.Context, userID, id string) (*task.Task, error)
+}
+
+type trashItemResponse struct {
+	Kind     trash.Kind `json:"kind"`
+	ID       string     `json:"id"`
+	BoardID  string     `json:"board_id"`
+	ColumnID string     `json:"column_id,omitempty"`
+	Name     string     `json:"name"`
+	// DeletedBy — null, если удаливший пользователь удалил аккаунт.
+	DeletedBy *string   `json:"deleted_by"`
+	DeletedAt time.Time `json:"deleted_at"`
+	// PurgeAt — когда элемент будет удалён окончательно.
+	PurgeAt time.Time `json:"purge_at"`
+}
+
+// List обрабатывает GET /api/v1/me/trash.
+func (h *TrashHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	items, err := h.trash.List(r.Context(), userID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	resp := make([]trashItemResponse, 0, len(items))
+	for _, it := range items {
+		item := trashItemResponse{
+			Kind:      it.Kind,
+			ID:        it.ID,
+			BoardID:   it.BoardID,
+			ColumnID:  it.ColumnID,
+			Name:      it.Name,
+			DeletedAt: it.DeletedAt,
+			PurgeAt:   h.trash.PurgeAt(it.DeletedAt),
+		}
+		if it.DeletedBy != "" {
+			item.DeletedBy = &it.DeletedBy
+		}
+		resp = append(resp, item)
+	}
+	httputil.JSON(w, http.StatusOK, resp)
+}
+
+// RestoreBoard обрабатывает POST /api/v1/me/trash/boards/{id}/restore.
+func (h *TrashHandler) RestoreBoard(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	b, err := h.trash.RestoreBoard(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, writeBoard(b))
+}
+
+// RestoreColumn обрабатывает POST /api/v1/me/trash/columns/{id}/restore.
+func (h *TrashHandler) RestoreColumn(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	c, err := h.trash.RestoreColumn(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, writeColumn(c))
+}
+
+// RestoreTask обрабатывает POST /api/v1/me/trash/tasks/{id}/restore.
+func (h *TrashHandler) RestoreTask(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	t, err := h.trash.RestoreTask(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, writeTask(t))
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	"github.com/VladislavDraga398/kanban-backend/internal/http/httputil"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
+)
+
+// TwoFactorHandler обрабатывает подключение двухфакторной аутентификации текущего пользователя (/me/2fa).
+type TwoFactorHandler struct {
+	twoFactor twoFactorService
+}
+
+// NewTwoFactorHandler создаёт хендлер /me/2fa.
+func NewTwoFactorHandler(twoFactor twoFactorService) *TwoFactorHandler {
+	return &TwoFactorHandler{twoFactor: twoFactor}
+}
+
+type twoFactorService interface {
+	Status(ctx context.Context, userID string) (*user.TwoFactor, error)
+	Enroll(ctx context.Context, userID string) (*service.TwoFactorEnrollment, error)
+	Confirm(ctx context.Context, userID, code string) ([]string, error)
+	Disable(ctx context.Context, userID string, proof service.TwoFactorProof) error
+	RegenerateRecoveryCodes(ctx context.Context, userID string, proof service.TwoFactorProof) ([]string, error)
+}
+
+type twoFactorStatusResponse struct {
+	Enabled           bool       `json:"enabled"`
+	EnabledAt         *time.Time `json:"enabled_at,omitempty"`
+	RecoveryCodesLeft int        `json:"recovery_codes_left"`
+}
+
+type twoFactorEnrollResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+type twoFactorConfirmRequest struct {
+	Code string `json:"code"`
+}
+
+// twoFactorProofRequest — пароль, а у учётной записи без пароля — код из приложения или код восстановления.
+type twoFactorProofRequest struct {
+	Password     string `json:"password"`
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
+func (r twoFactorProofRequest) proof() service.TwoFactorProof {
+	return service.TwoFactorProof{Password: r.Password, Code: r.Code, RecoveryCode: r.RecoveryCode}
+}
+
+type recoveryCodesResponse struct {
+	// RecoveryCodes показываются один раз: сервер хранит только хэши.
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// Status обрабатывает GET /api/v1/me/2fa.
+func (h *TwoFactorHandler) Status(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	tf, err := h.twoFactor.Status(r.Context(), userID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, twoFactorStatusResponse{
+		Enabled:           tf.Enabled(),
+		EnabledAt:         tf.ConfirmedAt,
+		RecoveryCodesLeft: tf.RecoveryCodesLeft,
+	})
+}
+
+// Enroll обрабатывает POST /api/v1/me/2fa/enroll.
+func (h *TwoFactorHandler) Enroll(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	e, err := h.twoFactor.Enroll(r.Context(), userID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, twoFactorEnrollResponse{Secret: e.Secret, URI: e.URI})
+}
+
+// Confirm обрабатывает POST /api/v1/me/2fa/confirm.
+func (h *TwoFactorHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req twoFactorConfirmRequest
+	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
+		return
+	}
+
+	codes, err := h.twoFactor.Confirm(r.Context(), userID, req.Code)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, recoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// Disable обрабатывает POST /api/v1/me/2fa/disable.
+func (h *TwoFactorHandler) Disable(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req twoFactorProofRequest
+	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
+		return
+	}
+
+	if err := h.twoFactor.Disable(r.Context(), userID, req.proof()); err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RegenerateRecoveryCodes обрабатывает POST /api/v1/me/2fa/recovery-codes.
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req twoFactorProofRequest
+	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
+		return
+	}
+
+	codes, err := h.twoFactor.RegenerateRecoveryCodes(r.Context(), userID, req.proof())
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, recoveryCodesResponse{RecoveryCodes: codes})
+}
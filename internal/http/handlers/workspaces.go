@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/workspace"
+	"github.com/VladislavDraga398/kanban-backend/internal/http/httputil"
+)
+
+// WorkspaceHandler обрабатывает рабочие пространства и их участников (/workspaces).
+type WorkspaceHandler struct {
+	workspaces workspaceService
+}
+
+// NewWorkspaceHandler создаёт хендлер /workspaces.
+func NewWorkspaceHandler(workspaces workspaceService) *WorkspaceHandler {
+	return &WorkspaceHandler{workspaces: workspaces}
+}
+
+type workspaceService interface {
+	List(ctx context.Context, userID string) ([]*workspace.Workspace, error)
+	Create(ctx context.Context, userID, name string) (*workspace.Workspace, error)
+	Get(ctx context.Context, userID, workspaceID string) (*workspace.Workspace, error)
+	Rename(ctx context.Context, userID, workspaceID, name string) (*workspace.Workspace, error)
+	Delete(ctx context.Context, userID, workspaceID string) error
+	ListMembers(ctx context.Context, userID, workspaceID string) ([]*workspace.Member, error)
+	AddMember(ctx context.Context, userID, workspaceID, email string, role workspace.Role) (*workspace.Member, error)
+	SetMemberRole(ctx context.Context, userID, workspaceID, memberID string, role workspace.Role) (*workspace.Member, error)
+	RemoveMember(ctx context.Context, userID, workspaceID, memberID string) error
+}
+
+type workspaceRequest struct {
+	Name string `json:"name"`
+}
+
+type addMemberRequest struct {
+	Email string         `json:"email"`
+	Role  workspace.Role `json:"role"`
+}
+
+type memberRoleRequest struct {
+	Role workspace.Role `json:"role"`
+}
+
+type workspaceResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Role — роль текущего пользователя в пространстве.
+	Role      workspace.Role `json:"role"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+type memberResponse struct {
+	UserID      string         `json:"user_id"`
+	Email       string         `json:"email"`
+	DisplayName string         `json:"display_name"`
+	Role        workspace.Role `json:"role"`
+	JoinedAt    time.Time      `json:"joined_at"`
+}
+
+func writeWorkspace(ws *workspace.Workspace) workspaceResponse {
+	return workspaceResponse{
+		ID:        ws.ID,
+		Name:      ws.Name,
+		Role:      ws.Role,
+		CreatedAt: ws.CreatedAt,
+		UpdatedAt: ws.UpdatedAt,
+	}
+}
+
+func writeMember(m *workspace.Member) memberResponse {
+	return memberResponse{
+		UserID:      m.UserID,
+		Email:       m.Email,
+		DisplayName: m.DisplayName,
+		Role:        m.Role,
+		JoinedAt:    m.JoinedAt,
+	}
+}
+
+// List обрабатывает GET /api/v1/workspaces.
+func (h *WorkspaceHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	list, err := h.workspaces.List(r.Context(), userID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	resp := make([]workspaceResponse, 0, len(list))
+	for _, ws := range list {
+		resp = append(resp, writeWorkspace(ws))
+	}
+	httputil.JSON(w, http.StatusOK, resp)
+}
+
+// Create обрабатывает POST /api/v1/workspaces.
+func (h *WorkspaceHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req workspaceRequest
+	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
+		return
+	}
+
+	ws, err := h.workspaces.Create(r.Context(), userID, req.Name)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusCreated, writeWorkspace(ws))
+}
+
+// Get обрабатывает GET /api/v1/workspaces/{workspace_id}.
+func (h *WorkspaceHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	ws, err := h.workspaces.Get(r.Context(), userID, chi.URLParam(r, "workspace_id"))
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, writeWorkspace(ws))
+}
+
+// Update обрабатывает PATCH /api/v1/workspaces/{workspace_id}.
+func (h *WorkspaceHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req workspaceRequest
+	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
+		return
+	}
+
+	ws, err := h.workspaces.Rename(r.Context(), userID, chi.URLParam(r, "workspace_id"), req.Name)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, writeWorkspace(ws))
+}
+
+// Delete обрабатывает DELETE /api/v1/workspaces/{workspace_id}.
+func (h *WorkspaceHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.workspaces.Delete(r.Context(), userID, chi.URLParam(r, "workspace_id")); err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListMembers обрабатывает GET /api/v1/workspaces/{workspace_id}/members.
+func (h *WorkspaceHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	members, err := h.workspaces.ListMembers(r.Context(), userID, chi.URLParam(r, "workspace_id"))
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	resp := make([]memberResponse, 0, len(members))
+	for _, m := range members {
+		resp = append(resp, writeMember(m))
+	}
+	httputil.JSON(w, http.StatusOK, resp)
+}
+
+// AddMember обрабатывает POST /api/v1/workspaces/{workspace_id}/members.
+func (h *WorkspaceHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req addMemberRequest
+	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
+		return
+	}
+
+	m, err := h.workspaces.AddMember(r.Context(), userID, chi.URLParam(r, "workspace_id"), req.Email, req.Role)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusCreated, writeMember(m))
+}
+
+// UpdateMember обрабатывает PATCH /api/v1/workspaces/{workspace_id}/members/{user_id}.
+func (h *WorkspaceHandler) UpdateMember(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req memberRoleRequest
+	if !httputil.DecodeJSONOrError(w, r, &req, httputil.DefaultMaxJSONBodyBytes) {
+		return
+	}
+
+	m, err := h.workspaces.SetMemberRole(r.Context(), userID, chi.URLParam(r, "workspace_id"), chi.URLParam(r, "user_id"), req.Role)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	httputil.JSON(w, http.StatusOK, writeMember(m))
+}
+
+// RemoveMember обрабатывает DELETE /api/v1/workspaces/{workspace_id}/members/{user_id}.
+func (h *WorkspaceHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.workspaces.RemoveMember(r.Context(), userID, chi.URLParam(r, "workspace_id"), chi.URLParam(r, "user_id")); err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
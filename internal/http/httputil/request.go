@@ -46,10 +46,10 @@ func DecodeJSON(w http.ResponseWriter, r *http.Request, dst any, maxBytes int64)
 func DecodeJSONOrError(w http.ResponseWriter, r *http.Request, dst any, maxBytes int64) bool {
 	if err := DecodeJSON(w, r, dst, maxBytes); err != nil {
 		if errors.Is(err, ErrBodyTooLarge) {
-			Error(w, http.StatusBadRequest, "request body too large")
+			Error(w, r, http.StatusBadRequest, CodeBodyTooLarge, "request body too large")
 			return false
 		}
-		Error(w, http.StatusBadRequest, "invalid json")
+		Error(w, r, http.StatusBadRequest, CodeInvalidJSON, "invalid json")
 		return false
 	}
 	return true
@@ -2,28 +2,83 @@ package httputil
 
 import (
 	"encoding/json"
-	"log"
+	"log/slog"
 	"net/http"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// ProblemContentType — медиатип ошибок по RFC 7807.
+const ProblemContentType = "application/problem+json"
+
+// ProblemTypePrefix — префикс URI поля type; к нему добавляется машиночитаемый код.
+const ProblemTypePrefix = "urn:kanban:problem:"
+
+// Коды ошибок транспортного уровня.
+const (
+	CodeInvalidJSON       = "invalid_json"
+	CodeBodyTooLarge      = "body_too_large"
+	CodeUnauthorized      = "unauthorized"
+	CodeRateLimited       = "rate_limited"
+	CodeInsufficientScope = "insufficient_scope"
+	CodeInternal          = "internal_error"
 )
 
-// ErrorResponse - единый формат ошибок во всём API
+// FieldError — нарушение валидации конкретного поля.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ErrorResponse - единый формат ошибок во всём API (RFC 7807 problem details).
 type ErrorResponse struct {
-	Error string `json:"error"`
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
 }
 
 // JSON - отдать любой объект как JSON с нужным статусом HTTP
 func JSON(w http.ResponseWriter, status int, v any) {
-	w.Header().Set("Content-Type", "application/json")
+	writeJSON(w, "application/json", status, v)
+}
+
+// Problem - отдаём ошибку в формате application/problem+json.
+// Незаполненные type/title/instance/request_id выводятся из кода, статуса и запроса.
+func Problem(w http.ResponseWriter, r *http.Request, p ErrorResponse) {
+	if p.Type == "" {
+		p.Type = ProblemTypePrefix + p.Code
+	}
+	if p.Title == "" {
+		p.Title = http.StatusText(p.Status)
+	}
+	if r != nil {
+		if p.Instance == "" {
+			p.Instance = r.URL.Path
+		}
+		if p.RequestID == "" {
+			p.RequestID = chimiddleware.GetReqID(r.Context())
+		}
+	}
+	writeJSON(w, ProblemContentType, p.Status, p)
+}
+
+// Error - отдаём ошибку без деталей по полям с заданным статусом HTTP и кодом.
+func Error(w http.ResponseWriter, r *http.Request, status int, code, detail string) {
+	Problem(w, r, ErrorResponse{Status: status, Code: code, Detail: detail})
+}
+
+func writeJSON(w http.ResponseWriter, contentType string, status int, v any) {
+	w.Header().Set("Content-Type", contentType)
 	w.WriteHeader(status)
 	if v == nil {
 		return
 	}
 	if err := json.NewEncoder(w).Encode(v); err != nil {
-		log.Printf("failed to write response: %v", err)
+		slog.Warn("failed to write response", "error", err)
 	}
 }
-
-// Error - отдаём ошибку в виде {"error": "some error"} с заданным статусом HTTP
-func Error(w http.ResponseWriter, status int, msg string) {
-	JSON(w, status, ErrorResponse{Error: msg})
-}
@@ -2,38 +2,124 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/VladislavDraga398/kanban-backend/internal/auth"
 	"github.com/VladislavDraga398/kanban-backend/internal/http/httputil"
+	"github.com/VladislavDraga398/kanban-backend/internal/logging"
 )
 
 type ctxKey string
 
-const userIDKey ctxKey = "userID"
+const (
+	userIDKey    ctxKey = "userID"
+	scopesKey    ctxKey = "scopes"
+	sessionIDKey ctxKey = "sessionID"
+)
+
+// sessionScopes — разрешения JWT сессии входа: пользователь действует от своего имени без ограничений.
+var sessionScopes = []auth.Scope{auth.ScopeAdmin}
+
+// AccessTokenVerifier проверяет личный токен доступа (например, *service.AccessTokenService).
+type AccessTokenVerifier interface {
+	// VerifyAccessToken возвращает владельца и разрешения токена или auth.ErrInvalidAccessToken.
+	VerifyAccessToken(ctx context.Context, raw string) (userID string, scopes []auth.Scope, err error)
+}
+
+// SessionVerifier проверяет, что сессия входа JWT не отозвана (например, *service.SessionService).
+type SessionVerifier interface {
+	// VerifySession возвращает auth.ErrSessionRevoked, если сессия отозвана, истекла или чужая.
+	VerifySession(ctx context.Context, userID, sessionID string) error
+}
+
+// AuthOption настраивает Auth.
+type AuthOption func(*authConfig)
+
+type authConfig struct {
+	accessTokens AccessTokenVerifier
+	keys         *auth.KeySet
+	sessions     SessionVerifier
+}
+
+// WithAccessTokens принимает наряду с JWT личные токены доступа (с префиксом auth.AccessTokenPrefix).
+func WithAccessTokens(v AccessTokenVerifier) AuthOption {
+	return func(c *authConfig) { c.accessTokens = v }
+}
+
+// WithKeySet проверяет JWT асимметричными ключами набора вместо общего секрета HS256.
+func WithKeySet(keys *auth.KeySet) AuthOption {
+	return func(c *authConfig) { c.keys = keys }
+}
+
+// WithSessions отклоняет JWT, сессия которых отозвана. Токены без сессии (выпущенные до её
+// включения) принимаются до истечения срока.
+func WithSessions(v SessionVerifier) AuthOption {
+	return func(c *authConfig) { c.sessions = v }
+}
+
+// Auth валидирует Bearer токен из Authorization (JWT или личный токен доступа)
+// и кладёт userID и разрешения в контекст.
+func Auth(secret []byte, opts ...AuthOption) func(http.Handler) http.Handler {
+	var cfg authConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
 
-// Auth валидирует JWT из Authorization: Bearer <token> и кладёт userID в контекст.
-func Auth(secret []byte) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
 			if !strings.HasPrefix(authHeader, "Bearer ") {
 				// Подсказка клиенту, что требуется Bearer токен
 				w.Header().Set("WWW-Authenticate", "Bearer")
-				httputil.Error(w, http.StatusUnauthorized, "unauthorized")
+				httputil.Error(w, r, http.StatusUnauthorized, httputil.CodeUnauthorized, "unauthorized")
 				return
 			}
 
 			token := strings.TrimPrefix(authHeader, "Bearer ")
-			userID, err := auth.ParseJWT(token, secret)
+			var (
+				userID    string
+				sessionID string
+				scopes    []auth.Scope
+				err       error
+			)
+			if strings.HasPrefix(token, auth.AccessTokenPrefix) && cfg.accessTokens != nil {
+				userID, scopes, err = cfg.accessTokens.VerifyAccessToken(r.Context(), token)
+				if err != nil && !errors.Is(err, auth.ErrInvalidAccessToken) {
+					logging.FromContext(r.Context()).ErrorContext(r.Context(), "verify access token failed", "error", err)
+					httputil.Error(w, r, http.StatusInternalServerError, httputil.CodeInternal, "internal error")
+					return
+				}
+			} else {
+				var claims auth.Claims
+				if cfg.keys != nil {
+					claims, err = cfg.keys.ParseClaims(token)
+				} else {
+					claims, err = auth.ParseClaimsJWT(token, secret)
+				}
+				userID, sessionID, scopes = claims.UserID, claims.SessionID, sessionScopes
+				if err == nil && sessionID != "" && cfg.sessions != nil {
+					err = cfg.sessions.VerifySession(r.Context(), userID, sessionID)
+					if err != nil && !errors.Is(err, auth.ErrSessionRevoked) {
+						logging.FromContext(r.Context()).ErrorContext(r.Context(), "verify session failed", "error", err)
+						httputil.Error(w, r, http.StatusInternalServerError, httputil.CodeInternal, "internal error")
+						return
+					}
+				}
+			}
 			if err != nil {
 				w.Header().Set("WWW-Authenticate", "Bearer error=\"invalid_token\"")
-				httputil.Error(w, http.StatusUnauthorized, "unauthorized")
+				httputil.Error(w, r, http.StatusUnauthorized, httputil.CodeUnauthorized, "unauthorized")
 				return
 			}
 
 			ctx := context.WithValue(r.Context(), userIDKey, userID)
+			ctx = context.WithValue(ctx, scopesKey, scopes)
+			if sessionID != "" {
+				ctx = context.WithValue(ctx, sessionIDKey, sessionID)
+			}
+			ctx = setRequestUser(ctx, userID)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -45,3 +131,39 @@ func UserIDFromContext(ctx context.Context) (string, bool) {
 	id, ok := v.(string)
 	return id, ok && id != ""
 }
+
+// SessionIDFromContext достает сессию входа JWT, которую положил Auth; у личных токенов доступа её нет.
+func SessionIDFromContext(ctx context.Context) (string, bool) {
+	id, _ := ctx.Value(sessionIDKey).(string)
+	return id, id != ""
+}
+
+// ScopesFromContext достает разрешения запроса, которые положил Auth.
+func ScopesFromContext(ctx context.Context) []auth.Scope {
+	scopes, _ := ctx.Value(scopesKey).([]auth.Scope)
+	return scopes
+}
+
+// RequireScope пропускает запрос, только если его разрешения покрывают scope.
+func RequireScope(scope auth.Scope) func(http.Handler) http.Handler {
+	return ScopeByMethod(scope, scope)
+}
+
+// ScopeByMethod требует read для безопасных методов (GET, HEAD, OPTIONS) и write для остальных.
+func ScopeByMethod(read, write auth.Scope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			required := write
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				required = read
+			}
+			if !auth.Allows(ScopesFromContext(r.Context()), required) {
+				w.Header().Set("WWW-Authenticate", `Bearer error="insufficient_scope", scope="`+string(required)+`"`)
+				httputil.Error(w, r, http.StatusForbidden, httputil.CodeInsufficientScope, "token lacks the "+string(required)+" scope")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
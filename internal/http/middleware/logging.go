@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/logging"
+)
+
+const requestMetaKey ctxKey = "requestMeta"
+
+// requestMeta собирает данные, которые становятся известны глубже по цепочке middleware
+// (например, userID после Auth), чтобы их увидел access-лог.
+type requestMeta struct {
+	userID string
+}
+
+// AccessLog кладёт в контекст логгер запроса (request_id, method, path, trace_id)
+// и по завершении пишет строку access-лога.
+func AccessLog(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			meta := &requestMeta{}
+
+			reqLogger := logger.With(
+				"request_id", chimiddleware.GetReqID(r.Context()),
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+			if sc := trace.SpanContextFromContext(r.Context()); sc.IsValid() {
+				reqLogger = reqLogger.With("trace_id", sc.TraceID().String())
+			}
+			ctx := context.WithValue(r.Context(), requestMetaKey, meta)
+			ctx = logging.WithLogger(ctx, reqLogger)
+
+			ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			level := slog.LevelInfo
+			switch {
+			case status >= 500:
+				level = slog.LevelError
+			case status >= 400:
+				level = slog.LevelWarn
+			}
+
+			route := ""
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				route = rctx.RoutePattern()
+			}
+
+			reqLogger.LogAttrs(r.Context(), level, "http request",
+				slog.String("route", route),
+				slog.Int("status", status),
+				slog.Duration("latency", time.Since(start)),
+				slog.Int("bytes", ww.BytesWritten()),
+				slog.String("user_id", meta.userID),
+				slog.String("remote_addr", r.RemoteAddr),
+			)
+		})
+	}
+}
+
+// setRequestUser сохраняет userID для access-лога, логгера и span запроса.
+func setRequestUser(ctx context.Context, userID string) context.Context {
+	if meta, ok := ctx.Value(requestMetaKey).(*requestMeta); ok {
+		meta.userID = userID
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("enduser.id", userID))
+	return logging.With(ctx, "user_id", userID)
+}
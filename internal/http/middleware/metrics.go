@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/metrics"
+)
+
+// unmatchedRoute — метка для запросов, не попавших ни в один маршрут,
+// чтобы произвольные пути не раздували кардинальность метрик.
+const unmatchedRoute = "unmatched"
+
+// Metrics считает запросы, их длительность и число запросов в обработке
+// с разбивкой по шаблону маршрута chi и статусу.
+func Metrics(m *metrics.Metrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			m.RequestStarted()
+
+			ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			defer func() {
+				status := ww.Status()
+				if status == 0 {
+					status = http.StatusOK
+				}
+				route := unmatchedRoute
+				if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+					route = rctx.RoutePattern()
+				}
+				m.RequestFinished(r.Method, route, strconv.Itoa(status), time.Since(start).Seconds())
+			}()
+
+			next.ServeHTTP(ww, r)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/http/httputil"
+	"github.com/VladislavDraga398/kanban-backend/internal/logging"
+	"github.com/VladislavDraga398/kanban-backend/internal/ratelimit"
+)
+
+type limitKey struct {
+	limiter *ratelimit.Limiter
+	key     string
+}
+
+// AuthRateLimit ограничивает частоту запросов к эндпоинтам аутентификации:
+// отдельные корзины по IP клиента и по email из JSON-тела. nil-ограничитель выключает проверку.
+// При превышении отвечает 429 с Retry-After; сбой хранилища пропускает запрос.
+func AuthRateLimit(perIP, perEmail *ratelimit.Limiter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			keys := []limitKey{{perIP, "auth:ip:" + ClientIP(r)}}
+			if perEmail != nil {
+				if email := peekEmail(r); email != "" {
+					keys = append(keys, limitKey{perEmail, "auth:email:" + email})
+				}
+			}
+
+			if allow(w, r, keys) {
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+// IPRateLimit ограничивает частоту запросов с одного IP клиента; scope разделяет корзины
+// разных групп эндпоинтов. nil-ограничитель выключает проверку.
+func IPRateLimit(limiter *ratelimit.Limiter, scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if allow(w, r, []limitKey{{limiter, scope + ":ip:" + ClientIP(r)}}) {
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+// allow списывает токен по каждому ключу и при превышении отвечает 429; сбой хранилища пропускает запрос.
+func allow(w http.ResponseWriter, r *http.Request, keys []limitKey) bool {
+	for _, k := range keys {
+		res, err := k.limiter.Allow(r.Context(), k.key)
+		if err != nil {
+			logging.FromContext(r.Context()).WarnContext(r.Context(), "rate limiter unavailable", "error", err)
+			continue
+		}
+		if !res.Allowed {
+			WriteTooManyRequests(w, r, httputil.CodeRateLimited, "too many requests, try again later", res.RetryAfter)
+			return false
+		}
+	}
+	return true
+}
+
+// WriteTooManyRequests пишет 429 problem+json с заголовком Retry-After в целых секундах.
+func WriteTooManyRequests(w http.ResponseWriter, r *http.Request, code, detail string, retryAfter time.Duration) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	httputil.Error(w, r, http.StatusTooManyRequests, code, detail)
+}
+
+// ClientIP берёт адрес из RemoteAddr (при TrustProxy его заранее подменяет chi RealIP).
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// peekEmail читает email из JSON-тела и возвращает тело на место для обработчика.
+func peekEmail(r *http.Request) string {
+	if r.Body == nil {
+		return ""
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, httputil.DefaultMaxJSONBodyBytes+1))
+	_ = r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+
+	var req struct {
+		Email string `json:"email"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(req.Email))
+}
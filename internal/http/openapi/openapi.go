@@ -0,0 +1,32 @@
+// Package openapi хранит OpenAPI-контракт API и отдаёт его по HTTP.
+package openapi
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+//go:embed openapi.json
+var spec []byte
+
+//go:embed docs.html
+var docsPage []byte
+
+// Spec возвращает OpenAPI 3.1 документ в JSON.
+func Spec() []byte {
+	return spec
+}
+
+// SpecHandler обрабатывает GET /api/v1/openapi.json.
+func SpecHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(spec)
+}
+
+// DocsHandler обрабатывает GET /api/v1/docs — HTML-страницу, рендерящую спецификацию.
+func DocsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(docsPage)
+}
@@ -1,17 +1,37 @@
 package http
 
 import (
+	"log/slog"
 	"net/http"
 	"time"
 
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/accesstoken"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/activity"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/archive"
 	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
 	"github.com/VladislavDraga398/kanban-backend/internal/domain/column"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/invitation"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/session"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/sharelink"
 	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/trash"
 	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/workspace"
+	"github.com/VladislavDraga398/kanban-backend/internal/health"
 	"github.com/VladislavDraga398/kanban-backend/internal/http/handlers"
 	"github.com/VladislavDraga398/kanban-backend/internal/http/middleware"
+	"github.com/VladislavDraga398/kanban-backend/internal/http/openapi"
+	"github.com/VladislavDraga398/kanban-backend/internal/mail"
+	"github.com/VladislavDraga398/kanban-backend/internal/metrics"
+	"github.com/VladislavDraga398/kanban-backend/internal/oidc"
+	"github.com/VladislavDraga398/kanban-backend/internal/ratelimit"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
+	"github.com/VladislavDraga398/kanban-backend/internal/tracing"
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Deps содержит зависимости HTTP-слоя.
@@ -22,11 +42,97 @@ type Deps struct {
 	TaskRepo   task.Repository
 	JWTSecret  string
 	JWTTTL     time.Duration
+	// JWTKeys подписывает токены доступа RS256/EdDSA и публикует открытые ключи в /.well-known/jwks.json;
+	// nil — HS256 на JWTSecret (JWKS пуст).
+	JWTKeys *auth.KeySet
+	// Logger — базовый логгер для access-лога и логгеров запросов; nil означает slog.Default().
+	Logger *slog.Logger
+	// Metrics — метрики Prometheus; nil означает отдельный набор метрик только для этого роутера.
+	Metrics *metrics.Metrics
+	// TracerProvider — провайдер OpenTelemetry; nil означает глобальный (по умолчанию no-op).
+	TracerProvider trace.TracerProvider
+	// Health — проверки зависимостей для /readyz; nil означает набор без проверок.
+	Health *health.Checker
+	// AuthIPLimiter и AuthEmailLimiter ограничивают частоту запросов к /auth по IP и по email; nil — без лимита.
+	AuthIPLimiter    *ratelimit.Limiter
+	AuthEmailLimiter *ratelimit.Limiter
+	// PublicLimiter ограничивает частоту запросов к /public по IP; nil — без лимита.
+	PublicLimiter *ratelimit.Limiter
+	// LoginLockout — прогрессивная блокировка входа после неудачных попыток; nil — выключена.
+	LoginLockout *ratelimit.Lockout
+	// PasswordPolicy — требования к паролю при регистрации; nil — только непустой пароль до 72 байт.
+	PasswordPolicy *auth.PasswordPolicy
+	// TokenRepo включает подтверждение и смену email и сброс пароля; nil — эти ручки не регистрируются.
+	TokenRepo user.TokenRepository
+	// Mailer отправляет письма; nil — письма складываются в память (mail.Outbox).
+	Mailer mail.Mailer
+	Emails service.EmailSettings
+	// OIDCProviders и IdentityRepo включают вход через внешних OpenID Connect провайдеров;
+	// после входа пользователь возвращается на Emails.BaseURL (адрес фронтенда).
+	OIDCProviders []*oidc.Provider
+	IdentityRepo  user.IdentityRepository
+	// TwoFactorRepo включает двухфакторную аутентификацию по TOTP (/me/2fa и /auth/login/2fa); nil — выключена.
+	TwoFactorRepo user.TwoFactorRepository
+	// SessionRepo включает сессии входа: токены получают идентификатор сессии, который можно отозвать
+	// (/me/sessions); nil — токены действуют до истечения срока.
+	SessionRepo session.Repository
+	// WorkspaceRepo включает рабочие пространства (/workspaces) и перенос досок между ними; nil — только личные доски.
+	WorkspaceRepo workspace.Repository
+	// InvitationRepo включает приглашения на доски по email и участников досок; письма уходят через Mailer,
+	// срок действия приглашения — Emails.InviteTTL. nil — доски доступны только владельцу и пространству.
+	InvitationRepo invitation.Repository
+	// ShareLinkRepo включает публичные ссылки только для чтения на доски (/boards/{id}/share-links
+	// и /public/boards/{token} без аутентификации); nil — выключены.
+	ShareLinkRepo sharelink.Repository
+	// ActivityRepo открывает журнал действий доски (/boards/{id}/activity и журнал задачи); nil — журнал недоступен через API.
+	ActivityRepo activity.Repository
+	// TaskVersionRepo включает историю версий задач и восстановление старой версии
+	// (/boards/{board_id}/tasks/{task_id}/versions); nil — выключена.
+	TaskVersionRepo task.VersionRepository
+	// TrashRepo включает корзину (/me/trash) и восстановление удалённых досок, колонок и задач; удаление
+	// мягкое и без него, а окончательно элементы удаляет фоновая очистка через TrashRetention.
+	TrashRepo      trash.Repository
+	TrashRetention time.Duration
+	// ArchiveRepo включает архив досок и задач (/archive, /unarchive и ?archived=true в списках);
+	// nil — архивные элементы просто не видны в списках.
+	ArchiveRepo archive.Repository
+	// AccessTokenRepo включает личные токены доступа (/me/tokens и их приём в Authorization); nil — только JWT.
+	AccessTokenRepo accesstoken.Repository
+	// TrustProxy — брать IP клиента из X-Forwarded-For/X-Real-IP (только за доверенным прокси).
+	TrustProxy bool
+	// SeparateAdmin — /metrics обслуживается отдельным admin-листенером (NewAdminRouter), а не этим роутером.
+	SeparateAdmin bool
 }
 
 func NewRouter(deps Deps) http.Handler {
+	logger := deps.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	m := deps.Metrics
+	if m == nil {
+		m = metrics.New()
+	}
+
+	tp := deps.TracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+
+	checker := deps.Health
+	if checker == nil {
+		checker = health.NewChecker(0)
+	}
+
 	r := chi.NewRouter()
+	if deps.TrustProxy {
+		r.Use(chimiddleware.RealIP)
+	}
 	r.Use(chimiddleware.RequestID)
+	r.Use(middleware.Tracing(tp, tracing.Propagator()))
+	r.Use(middleware.AccessLog(logger))
+	r.Use(middleware.Metrics(m))
 	r.Use(chimiddleware.Recoverer)
 	r.Use(chimiddleware.Timeout(30 * time.Second))
 
@@ -35,26 +141,253 @@ func NewRouter(deps Deps) http.Handler {
 		_, _ = w.Write([]byte("ok"))
 	})
 
-	authHandler := handlers.NewAuthHandler(deps.UserRepo, deps.JWTSecret, deps.JWTTTL)
-	boardHandler := handlers.NewBoardHandler(deps.BoardRepo)
-	columnHandler := handlers.NewColumnHandler(deps.ColumnRepo)
-	taskHandler := handlers.NewTaskHandler(deps.TaskRepo)
+	r.Get("/.well-known/jwks.json", handlers.NewJWKSHandler(deps.JWTKeys).JWKS)
+
+	healthHandler := handlers.NewHealthHandler(checker)
+	r.Get("/livez", healthHandler.Live)
+	r.Get("/readyz", healthHandler.Ready)
+
+	// Подробный /health с ошибками и задержками проверок отдаёт только admin-листенер.
+	if !deps.SeparateAdmin {
+		r.Method(http.MethodGet, "/metrics", m.Handler())
+	}
+
+	authService := service.NewAuthService(deps.UserRepo, deps.JWTSecret, deps.JWTTTL).
+		WithRecorder(m).
+		WithLoginGuard(deps.LoginLockout).
+		WithPasswordPolicy(deps.PasswordPolicy).
+		WithKeySet(deps.JWTKeys)
+	accountService := service.NewAccountService(deps.UserRepo).
+		WithPasswordPolicy(deps.PasswordPolicy)
+	mailer := deps.Mailer
+	if mailer == nil {
+		mailer = mail.NewOutbox()
+	}
+	if deps.TokenRepo != nil {
+		authService.WithEmails(deps.TokenRepo, mailer, deps.Emails)
+		accountService.WithEmails(deps.TokenRepo, mailer, deps.Emails)
+	}
+	var authOpts []middleware.AuthOption
+	if deps.JWTKeys != nil {
+		authOpts = append(authOpts, middleware.WithKeySet(deps.JWTKeys))
+	}
+	var sessionHandler *handlers.SessionHandler
+	if deps.SessionRepo != nil {
+		sessions := service.NewSessionService(deps.SessionRepo)
+		authService.WithSessions(sessions)
+		accountService.WithSessions(sessions)
+		authOpts = append(authOpts, middleware.WithSessions(sessions))
+		sessionHandler = handlers.NewSessionHandler(sessions)
+	}
+	var accessTokenHandler *handlers.AccessTokenHandler
+	if deps.AccessTokenRepo != nil {
+		accessTokens := service.NewAccessTokenService(deps.AccessTokenRepo)
+		authOpts = append(authOpts, middleware.WithAccessTokens(accessTokens))
+		accessTokenHandler = handlers.NewAccessTokenHandler(accessTokens)
+	}
+	var oidcHandler *handlers.OIDCHandler
+	if len(deps.OIDCProviders) > 0 && deps.IdentityRepo != nil {
+		authService.WithIdentities(deps.IdentityRepo)
+		oidcHandler = handlers.NewOIDCHandler(deps.OIDCProviders, authService, []byte(deps.JWTSecret), deps.Emails.BaseURL)
+	}
+	var twoFactorHandler *handlers.TwoFactorHandler
+	if deps.TwoFactorRepo != nil {
+		authService.WithTwoFactor(deps.TwoFactorRepo)
+		twoFactorHandler = handlers.NewTwoFactorHandler(service.NewTwoFactorService(deps.UserRepo, deps.TwoFactorRepo))
+	}
+	authHandler := handlers.NewAuthHandler(authService)
+	meHandler := handlers.NewMeHandler(accountService)
+	boardService := service.NewBoardService(deps.BoardRepo)
+	var workspaceHandler *handlers.WorkspaceHandler
+	if deps.WorkspaceRepo != nil {
+		boardService.WithWorkspaces(deps.WorkspaceRepo)
+		workspaceHandler = handlers.NewWorkspaceHandler(service.NewWorkspaceService(deps.WorkspaceRepo, deps.UserRepo))
+	}
+	boardHandler := handlers.NewBoardHandler(boardService)
+	var invitationHandler *handlers.InvitationHandler
+	if deps.InvitationRepo != nil {
+		invitations := service.NewInvitationService(deps.InvitationRepo, deps.BoardRepo, deps.UserRepo, mailer, deps.Emails)
+		authService.WithInvitations(invitations)
+		invitationHandler = handlers.NewInvitationHandler(invitations, boardService)
+	}
+	var shareLinkHandler *handlers.ShareLinkHandler
+	if deps.ShareLinkRepo != nil {
+		shareLinkHandler = handlers.NewShareLinkHandler(service.NewShareLinkService(deps.ShareLinkRepo, deps.BoardRepo))
+	}
+	var activityHandler *handlers.ActivityHandler
+	if deps.ActivityRepo != nil {
+		activityHandler = handlers.NewActivityHandler(service.NewActivityService(deps.ActivityRepo, deps.BoardRepo))
+	}
+	var taskVersionHandler *handlers.TaskVersionHandler
+	if deps.TaskVersionRepo != nil {
+		taskVersionHandler = handlers.NewTaskVersionHandler(service.NewTaskVersionService(deps.TaskVersionRepo))
+	}
+	var trashHandler *handlers.TrashHandler
+	if deps.TrashRepo != nil {
+		trashHandler = handlers.NewTrashHandler(service.NewTrashService(deps.TrashRepo, deps.TrashRetention))
+	}
+	columnHandler := handlers.NewColumnHandler(service.NewColumnService(deps.ColumnRepo, deps.BoardRepo))
+	taskHandler := handlers.NewTaskHandler(service.NewTaskService(deps.TaskRepo, deps.BoardRepo).WithRecorder(m))
+	listBoards, listTasks, listWorkspaceBoards := boardHandler.List, taskHandler.List, boardHandler.ListInWorkspace
+	var listSharedBoards http.HandlerFunc
+	if invitationHandler != nil {
+		listSharedBoards = invitationHandler.ListShared
+	}
+	var archiveHandler *handlers.ArchiveHandler
+	if deps.ArchiveRepo != nil {
+		archives := service.NewArchiveService(deps.ArchiveRepo, deps.BoardRepo)
+		if deps.WorkspaceRepo != nil {
+			archives.WithWorkspaces(deps.WorkspaceRepo)
+		}
+		archiveHandler = handlers.NewArchiveHandler(archives)
+		listBoards = handlers.WithArchived(boardHandler.List, archiveHandler.ListBoards)
+		listTasks = handlers.WithArchived(taskHandler.List, archiveHandler.ListTasks)
+		listWorkspaceBoards = handlers.WithArchived(boardHandler.ListInWorkspace, archiveHandler.ListWorkspaceBoards)
+		if listSharedBoards != nil {
+			listSharedBoards = handlers.WithArchived(listSharedBoards, archiveHandler.ListSharedBoards)
+		}
+	}
 
 	r.Route("/api/v1", func(r chi.Router) {
+		r.Get("/openapi.json", openapi.SpecHandler)
+		r.Get("/docs", openapi.DocsHandler)
+
 		r.Route("/auth", func(r chi.Router) {
+			r.Use(middleware.AuthRateLimit(deps.AuthIPLimiter, deps.AuthEmailLimiter))
 			r.Post("/register", authHandler.Register)
 			r.Post("/login", authHandler.Login)
+			if twoFactorHandler != nil {
+				r.Post("/login/2fa", authHandler.LoginTwoFactor)
+			}
+			if deps.TokenRepo != nil {
+				r.Post("/verify-email", authHandler.VerifyEmail)
+				r.Post("/forgot-password", authHandler.ForgotPassword)
+				r.Post("/reset-password", authHandler.ResetPassword)
+			}
+			if oidcHandler != nil {
+				r.Get("/oidc", oidcHandler.Providers)
+				r.Get("/oidc/{provider}/login", oidcHandler.Login)
+				r.Get("/oidc/{provider}/callback", oidcHandler.Callback)
+			}
 		})
 
+		if shareLinkHandler != nil {
+			// Публичные ссылки открываются без входа, поэтому middleware.Auth сюда не подключается.
+			r.Route("/public", func(r chi.Router) {
+				r.Use(middleware.IPRateLimit(deps.PublicLimiter, "public"))
+				r.Get("/boards/{token}", shareLinkHandler.Public)
+			})
+		}
+		if invitationHandler != nil {
+			// Отклонить приглашение можно и без аккаунта — достаточно токена из письма.
+			r.Post("/invitations/decline", invitationHandler.Decline)
+		}
+
 		r.Group(func(r chi.Router) {
-			r.Use(middleware.Auth([]byte(deps.JWTSecret)))
+			r.Use(middleware.Auth([]byte(deps.JWTSecret), authOpts...))
+
+			if invitationHandler != nil {
+				r.With(middleware.RequireScope(auth.ScopeWrite)).Post("/invitations/accept", invitationHandler.Accept)
+			}
+
+			r.Route("/me", func(r chi.Router) {
+				// Профиль читается с read, а менять учётную запись может только admin.
+				r.Use(middleware.ScopeByMethod(auth.ScopeRead, auth.ScopeAdmin))
+				r.Get("/", meHandler.Get)
+				r.Patch("/", meHandler.Update)
+				r.Delete("/", meHandler.Delete)
+				r.Post("/password", meHandler.ChangePassword)
+				if deps.TokenRepo != nil {
+					r.Post("/email", meHandler.ChangeEmail)
+				}
+				if twoFactorHandler != nil {
+					r.Route("/2fa", func(r chi.Router) {
+						r.Use(middleware.RequireScope(auth.ScopeAdmin))
+						r.Get("/", twoFactorHandler.Status)
+						r.Post("/enroll", twoFactorHandler.Enroll)
+						r.Post("/confirm", twoFactorHandler.Confirm)
+						r.Post("/disable", twoFactorHandler.Disable)
+						r.Post("/recovery-codes", twoFactorHandler.RegenerateRecoveryCodes)
+					})
+				}
+				if sessionHandler != nil {
+					r.Route("/sessions", func(r chi.Router) {
+						r.Use(middleware.RequireScope(auth.ScopeAdmin))
+						r.Get("/", sessionHandler.List)
+						r.Delete("/", sessionHandler.RevokeAll)
+						r.Delete("/{session_id}", sessionHandler.Revoke)
+					})
+				}
+				if accessTokenHandler != nil {
+					r.Route("/tokens", func(r chi.Router) {
+						r.Use(middleware.RequireScope(auth.ScopeAdmin))
+						r.Get("/", accessTokenHandler.List)
+						r.Post("/", accessTokenHandler.Create)
+						r.Delete("/{token_id}", accessTokenHandler.Revoke)
+					})
+				}
+			})
+
+			if trashHandler != nil {
+				// Корзина — часть /me, но восстановление меняет доски, поэтому ему хватает write, а не admin.
+				r.Route("/me/trash", func(r chi.Router) {
+					r.Use(middleware.ScopeByMethod(auth.ScopeRead, auth.ScopeWrite))
+					r.Get("/", trashHandler.List)
+					r.Post("/boards/{id}/restore", trashHandler.RestoreBoard)
+					r.Post("/columns/{id}/restore", trashHandler.RestoreColumn)
+					r.Post("/tasks/{id}/restore", trashHandler.RestoreTask)
+				})
+			}
+
+			if workspaceHandler != nil {
+				r.Route("/workspaces", func(r chi.Router) {
+					r.Use(middleware.ScopeByMethod(auth.ScopeRead, auth.ScopeWrite))
+					r.Get("/", workspaceHandler.List)
+					r.Post("/", workspaceHandler.Create)
+					r.Get("/{workspace_id}", workspaceHandler.Get)
+					r.Patch("/{workspace_id}", workspaceHandler.Update)
+					r.Delete("/{workspace_id}", workspaceHandler.Delete)
+					r.Get("/{workspace_id}/members", workspaceHandler.ListMembers)
+					r.Post("/{workspace_id}/members", workspaceHandler.AddMember)
+					r.Patch("/{workspace_id}/members/{user_id}", workspaceHandler.UpdateMember)
+					r.Delete("/{workspace_id}/members/{user_id}", workspaceHandler.RemoveMember)
+					r.Get("/{workspace_id}/boards", listWorkspaceBoards)
+					r.Post("/{workspace_id}/boards", boardHandler.CreateInWorkspace)
+				})
+			}
 
 			r.Route("/boards", func(r chi.Router) {
-				r.Get("/", boardHandler.List)
+				r.Use(middleware.ScopeByMethod(auth.ScopeRead, auth.ScopeWrite))
+				r.Get("/", listBoards)
 				r.Post("/", boardHandler.Create)
+				if listSharedBoards != nil {
+					r.Get("/shared", listSharedBoards)
+				}
 				r.Get("/{id}", boardHandler.Get)
 				r.Put("/{id}", boardHandler.Update)
 				r.Delete("/{id}", boardHandler.Delete)
+				if workspaceHandler != nil {
+					r.Post("/{id}/transfer", boardHandler.Transfer)
+				}
+				if invitationHandler != nil {
+					r.Get("/{id}/invitations", invitationHandler.List)
+					r.Post("/{id}/invitations", invitationHandler.Create)
+					r.Delete("/{id}/invitations/{invitation_id}", invitationHandler.Revoke)
+					r.Get("/{id}/members", invitationHandler.ListMembers)
+					r.Delete("/{id}/members/{user_id}", invitationHandler.RemoveMember)
+				}
+				if shareLinkHandler != nil {
+					r.Get("/{id}/share-links", shareLinkHandler.List)
+					r.Post("/{id}/share-links", shareLinkHandler.Create)
+					r.Delete("/{id}/share-links/{link_id}", shareLinkHandler.Revoke)
+				}
+				if activityHandler != nil {
+					r.Get("/{id}/activity", activityHandler.ListBoard)
+				}
+				if archiveHandler != nil {
+					r.Post("/{id}/archive", archiveHandler.ArchiveBoard)
+					r.Post("/{id}/unarchive", archiveHandler.UnarchiveBoard)
+				}
 
 				r.Route("/{board_id}/columns", func(r chi.Router) {
 					r.Get("/", columnHandler.List)
@@ -62,9 +395,12 @@ func NewRouter(deps Deps) http.Handler {
 
 					r.Put("/{column_id}", columnHandler.Update)
 					r.Delete("/{column_id}", columnHandler.Delete)
+					if archiveHandler != nil {
+						r.Post("/{column_id}/archive-tasks", archiveHandler.ArchiveColumn)
+					}
 
 					r.Route("/{column_id}/tasks", func(r chi.Router) {
-						r.Get("/", taskHandler.List)
+						r.Get("/", listTasks)
 						r.Post("/", taskHandler.Create)
 
 						r.Put("/{task_id}", taskHandler.Update)
@@ -74,9 +410,30 @@ func NewRouter(deps Deps) http.Handler {
 
 				r.Route("/{board_id}/tasks", func(r chi.Router) {
 					r.Patch("/{task_id}/move", taskHandler.Move)
+					if activityHandler != nil {
+						r.Get("/{task_id}/activity", activityHandler.ListTask)
+					}
+					if taskVersionHandler != nil {
+						r.Get("/{task_id}/versions", taskVersionHandler.List)
+						r.Get("/{task_id}/versions/{version}/diff", taskVersionHandler.Diff)
+						r.Post("/{task_id}/restore/{version}", taskVersionHandler.Restore)
+					}
+					if archiveHandler != nil {
+						r.Post("/{task_id}/archive", archiveHandler.ArchiveTask)
+						r.Post("/{task_id}/unarchive", archiveHandler.UnarchiveTask)
+					}
 				})
 			})
 		})
 	})
 	return r
 }
+
+// NewAdminRouter собирает служебный роутер для отдельного admin-листенера.
+func NewAdminRouter(m *metrics.Metrics, checker *health.Checker) http.Handler {
+	r := chi.NewRouter()
+	r.Use(chimiddleware.Recoverer)
+	r.Method(http.MethodGet, "/metrics", m.Handler())
+	r.Get("/health", handlers.NewHealthHandler(checker).Report)
+	return r
+}
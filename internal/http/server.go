@@ -2,7 +2,7 @@ package http
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"net/http"
 	"time"
 )
@@ -26,12 +26,12 @@ func NewServer(addr string, handler http.Handler) *Server {
 
 // Start запускает HTTP-сервер.
 func (s *Server) Start() error {
-	log.Printf("starting HTTP server on %s\n", s.httpServer.Addr)
+	slog.Info("starting HTTP server", "addr", s.httpServer.Addr)
 	return s.httpServer.ListenAndServe()
 }
 
 // Shutdown выполняет корректное завершение HTTP-сервера.
 func (s *Server) Shutdown(ctx context.Context) error {
-	log.Println("shutting down HTTP server...")
+	slog.Info("shutting down HTTP server")
 	return s.httpServer.Shutdown(ctx)
 }
@@ -0,0 +1,49 @@
+package mail
+
+import (
+	"context"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// SMTPConfig — параметры подключения к SMTP-серверу.
+type SMTPConfig struct {
+	Host string
+	Port int
+	// Username и Password — для AUTH PLAIN; пустой Username — без аутентификации.
+	// net/smtp разрешает AUTH PLAIN только поверх TLS (STARTTLS) или к localhost.
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPMailer отправляет письма через SMTP-сервер (STARTTLS включается, если сервер его поддерживает).
+type SMTPMailer struct {
+	cfg SMTPConfig
+}
+
+// NewSMTPMailer создаёт SMTP-отправителя.
+func NewSMTPMailer(cfg SMTPConfig) *SMTPMailer {
+	return &SMTPMailer{cfg: cfg}
+}
+
+// Send отправляет письмо. net/smtp не принимает контекст, поэтому отменённый контекст
+// проверяется только до подключения.
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	data, err := Format(m.cfg.From, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.cfg.Username != "" {
+		auth = smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+	}
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+	return smtp.SendMail(addr, auth, envelopeAddress(m.cfg.From), []string{msg.To}, data)
+}
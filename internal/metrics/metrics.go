@@ -0,0 +1,114 @@
+// Package metrics собирает метрики Prometheus: HTTP-трафик, пул соединений БД и доменные счётчики.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "kanban"
+
+// Metrics — набор метрик сервиса в собственном реестре (без глобального состояния).
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	inFlight prometheus.Gauge
+
+	tasksCreated prometheus.Counter
+	tasksMoved   prometheus.Counter
+	logins       *prometheus.CounterVec
+}
+
+// New создаёт метрики и регистрирует стандартные коллекторы Go-рантайма и процесса.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by method, chi route pattern and status.",
+		}, []string{"method", "route", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method, chi route pattern and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		inFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "http_requests_in_flight",
+			Help:      "HTTP requests currently being served.",
+		}),
+		tasksCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tasks_created_total",
+			Help:      "Tasks created.",
+		}),
+		tasksMoved: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tasks_moved_total",
+			Help:      "Tasks moved between columns.",
+		}),
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "logins_total",
+			Help:      "Login attempts by result (success, failure).",
+		}, []string{"result"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests, m.duration, m.inFlight,
+		m.tasksCreated, m.tasksMoved, m.logins,
+	)
+	// Обе серии видны в /metrics сразу, а не после первой попытки входа.
+	m.logins.WithLabelValues("success")
+	m.logins.WithLabelValues("failure")
+	return m
+}
+
+// RegisterDB добавляет метрики пула соединений из sql.DB.Stats().
+func (m *Metrics) RegisterDB(db *sql.DB, name string) error {
+	return m.registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// Handler отдаёт метрики в текстовом формате Prometheus.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// Registry возвращает реестр, например для регистрации дополнительных коллекторов.
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// RequestStarted увеличивает число запросов в обработке.
+func (m *Metrics) RequestStarted() {
+	m.inFlight.Inc()
+}
+
+// RequestFinished учитывает завершённый запрос.
+func (m *Metrics) RequestFinished(method, route, status string, seconds float64) {
+	m.inFlight.Dec()
+	m.requests.WithLabelValues(method, route, status).Inc()
+	m.duration.WithLabelValues(method, route, status).Observe(seconds)
+}
+
+// TaskCreated учитывает созданную задачу.
+func (m *Metrics) TaskCreated() { m.tasksCreated.Inc() }
+
+// TaskMoved учитывает перемещение задачи.
+func (m *Metrics) TaskMoved() { m.tasksMoved.Inc() }
+
+// LoginSucceeded учитывает успешный вход.
+func (m *Metrics) LoginSucceeded() { m.logins.WithLabelValues("success").Inc() }
+
+// LoginFailed учитывает неудачный вход (неверные учётные данные).
+func (m *Metrics) LoginFailed() { m.logins.WithLabelValues("failure").Inc() }
@@ -0,0 +1,101 @@
+package oidc
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalidState — cookie входа отсутствует, подделана, истекла или не соответствует callback.
+var ErrInvalidState = errors.New("invalid oidc state")
+
+// FlowState — данные незавершённого входа. Между /login и /callback их несёт браузер
+// в подписанной HttpOnly cookie, поэтому серверу не нужно хранилище.
+type FlowState struct {
+	Provider string
+	State    string
+	Nonce    string
+	Verifier string
+}
+
+// NewFlowState выпускает случайные state, nonce и PKCE verifier для провайдера.
+func NewFlowState(provider string) (FlowState, error) {
+	values := make([]string, 3)
+	for i := range values {
+		v, err := randomString()
+		if err != nil {
+			return FlowState{}, err
+		}
+		values[i] = v
+	}
+	return FlowState{Provider: provider, State: values[0], Nonce: values[1], Verifier: values[2]}, nil
+}
+
+// Challenge — PKCE code_challenge для метода S256.
+func (s FlowState) Challenge() string {
+	sum := sha256.Sum256([]byte(s.Verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+type flowClaims struct {
+	jwt.RegisteredClaims
+	Provider string `json:"prv"`
+	State    string `json:"st"`
+	Nonce    string `json:"nn"`
+	Verifier string `json:"cv"`
+}
+
+// flowAudience отделяет cookie входа от других токенов, подписанных тем же ключом.
+const flowAudience = "oidc-flow"
+
+// Seal подписывает состояние (HS256) для cookie со сроком жизни ttl.
+func (s FlowState) Seal(key []byte, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := flowClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{flowAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+		Provider: s.Provider,
+		State:    s.State,
+		Nonce:    s.Nonce,
+		Verifier: s.Verifier,
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(key)
+}
+
+// OpenFlowState проверяет cookie и возвращает состояние входа.
+func OpenFlowState(key []byte, raw string) (FlowState, error) {
+	var claims flowClaims
+	_, err := jwt.ParseWithClaims(raw, &claims, func(*jwt.Token) (any, error) { return key, nil },
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithAudience(flowAudience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return FlowState{}, fmt.Errorf("%w: %v", ErrInvalidState, err)
+	}
+	return FlowState{Provider: claims.Provider, State: claims.State, Nonce: claims.Nonce, Verifier: claims.Verifier}, nil
+}
+
+func randomString() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// FlowKey выводит из секрета сервиса отдельный ключ для cookie входа.
+func FlowKey(secret []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(flowAudience))
+	return mac.Sum(nil)
+}
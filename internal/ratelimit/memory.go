@@ -0,0 +1,60 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore хранит состояния в памяти процесса — подходит для одной реплики и тестов.
+type MemoryStore struct {
+	mu      sync.Mutex
+	states  map[string]State
+	idleTTL time.Duration
+	ops     int
+}
+
+// NewMemoryStore создаёт хранилище; ключи без обращений дольше idleTTL периодически удаляются.
+func NewMemoryStore(idleTTL time.Duration) *MemoryStore {
+	return &MemoryStore{states: map[string]State{}, idleTTL: idleTTL}
+}
+
+// Update применяет fn к состоянию key под мьютексом.
+func (m *MemoryStore) Update(ctx context.Context, key string, fn func(s *State)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := m.states[key]
+	fn(&s)
+	m.states[key] = s
+
+	m.ops++
+	if m.idleTTL > 0 && m.ops%1024 == 0 {
+		m.prune(time.Now())
+	}
+	return nil
+}
+
+// Get возвращает копию состояния key.
+func (m *MemoryStore) Get(ctx context.Context, key string) (State, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.states[key]
+	return s, ok, nil
+}
+
+// Delete удаляет состояние key.
+func (m *MemoryStore) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.states, key)
+	return nil
+}
+
+func (m *MemoryStore) prune(now time.Time) {
+	for key, s := range m.states {
+		if now.Sub(s.UpdatedAt) > m.idleTTL && !s.LockedUntil.After(now) {
+			delete(m.states, key)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// Package ratelimit реализует token bucket и прогрессивную блокировку входа поверх
+// подключаемого хранилища состояния (в памяти процесса или в Postgres для нескольких реплик).
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// State — состояние одного ключа. Алгоритмы меняют его только внутри Store.Update,
+// поэтому хранилищу достаточно обеспечить атомарность чтения-изменения-записи.
+type State struct {
+	Tokens      float64
+	Failures    int
+	LockedUntil time.Time
+	// UpdatedAt нулевой у нового ключа.
+	UpdatedAt time.Time
+}
+
+// Store хранит состояния по ключам.
+type Store interface {
+	// Update атомарно применяет fn к состоянию key (нулевому, если ключа ещё нет) и сохраняет результат.
+	Update(ctx context.Context, key string, fn func(s *State)) error
+	// Get возвращает состояние key; ok=false, если ключа нет.
+	Get(ctx context.Context, key string) (s State, ok bool, err error)
+	// Delete удаляет состояние key.
+	Delete(ctx context.Context, key string) error
+}
+
+// Limit — параметры token bucket: Burst токенов, пополняемых равномерно за Per.
+type Limit struct {
+	Burst int
+	Per   time.Duration
+}
+
+// Enabled сообщает, задан ли лимит.
+func (l Limit) Enabled() bool {
+	return l.Burst > 0 && l.Per > 0
+}
+
+func (l Limit) String() string {
+	if !l.Enabled() {
+		return "off"
+	}
+	return strconv.Itoa(l.Burst) + "/" + l.Per.String()
+}
+
+// ParseLimit разбирает лимит вида "20/1m" (20 запросов в минуту); "", "0" и "off" выключают лимит.
+func ParseLimit(s string) (Limit, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "0" || strings.EqualFold(s, "off") {
+		return Limit{}, nil
+	}
+	countStr, perStr, ok := strings.Cut(s, "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("limit %q must look like 20/1m", s)
+	}
+	burst, err := strconv.Atoi(strings.TrimSpace(countStr))
+	if err != nil || burst <= 0 {
+		return Limit{}, fmt.Errorf("limit %q: count must be a positive integer", s)
+	}
+	per, err := time.ParseDuration(strings.TrimSpace(perStr))
+	if err != nil || per <= 0 {
+		return Limit{}, fmt.Errorf("limit %q: period must be a positive duration", s)
+	}
+	return Limit{Burst: burst, Per: per}, nil
+}
+
+// Result — итог попытки взять токен.
+type Result struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration
+}
+
+// take пополняет корзину с момента прошлого обращения и списывает токен, если он есть.
+func (l Limit) take(s *State, now time.Time) Result {
+	rate := float64(l.Burst) / l.Per.Seconds()
+	if s.UpdatedAt.IsZero() {
+		s.Tokens = float64(l.Burst)
+	} else if elapsed := now.Sub(s.UpdatedAt).Seconds(); elapsed > 0 {
+		s.Tokens = math.Min(float64(l.Burst), s.Tokens+elapsed*rate)
+	}
+	s.UpdatedAt = now
+
+	if s.Tokens >= 1 {
+		s.Tokens--
+		return Result{Allowed: true, Remaining: int(s.Tokens)}
+	}
+	wait := time.Duration((1 - s.Tokens) / rate * float64(time.Second))
+	return Result{RetryAfter: wait}
+}
+
+// Limiter ограничивает частоту обращений по ключу.
+type Limiter struct {
+	store Store
+	limit Limit
+	now   func() time.Time
+}
+
+// NewLimiter создаёт ограничитель с заданным лимитом.
+func NewLimiter(store Store, limit Limit) *Limiter {
+	return &Limiter{store: store, limit: limit, now: time.Now}
+}
+
+// WithClock подменяет источник времени (для тестов).
+func (l *Limiter) WithClock(now func() time.Time) *Limiter {
+	l.now = now
+	return l
+}
+
+// Allow списывает токен для key. При выключенном лимите всегда разрешает.
+func (l *Limiter) Allow(ctx context.Context, key string) (Result, error) {
+	if l == nil || !l.limit.Enabled() {
+		return Result{Allowed: true}, nil
+	}
+	var res Result
+	err := l.store.Update(ctx, key, func(s *State) {
+		res = l.limit.take(s, l.now())
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	return res, nil
+}
+
+// LockoutPolicy — параметры прогрессивной блокировки: после Threshold неудач подряд ключ
+// блокируется на Base, и каждая следующая неудача удваивает срок вплоть до Max.
+// Счётчик сбрасывается после успешного входа или если неудач не было дольше Max.
+type LockoutPolicy struct {
+	Threshold int
+	Base      time.Duration
+	Max       time.Duration
+}
+
+// Enabled сообщает, включена ли блокировка.
+func (p LockoutPolicy) Enabled() bool {
+	return p.Threshold > 0 && p.Base > 0
+}
+
+func (p LockoutPolicy) duration(failures int) time.Duration {
+	d := p.Base
+	for i := p.Threshold; i < failures && d < p.Max; i++ {
+		d *= 2
+	}
+	if p.Max > 0 && d > p.Max {
+		d = p.Max
+	}
+	return d
+}
+
+// Lockout считает неудачные попытки входа и блокирует ключ по LockoutPolicy.
+type Lockout struct {
+	store  Store
+	policy LockoutPolicy
+	now    func() time.Time
+}
+
+// NewLockout создаёт счётчик блокировок.
+func NewLockout(store Store, policy LockoutPolicy) *Lockout {
+	return &Lockout{store: store, policy: policy, now: time.Now}
+}
+
+// WithClock подменяет источник времени (для тестов).
+func (l *Lockout) WithClock(now func() time.Time) *Lockout {
+	l.now = now
+	return l
+}
+
+// Check возвращает оставшееся время блокировки key (0 — не заблокирован).
+func (l *Lockout) Check(ctx context.Context, key string) (time.Duration, error) {
+	if l == nil || !l.policy.Enabled() {
+		return 0, nil
+	}
+	s, ok, err := l.store.Get(ctx, key)
+	if err != nil || !ok {
+		return 0, err
+	}
+	if left := s.LockedUntil.Sub(l.now()); left > 0 {
+		return left, nil
+	}
+	return 0, nil
+}
+
+// Failed учитывает неудачную попытку и возвращает срок блокировки, если она наступила.
+func (l *Lockout) Failed(ctx context.Context, key string) (time.Duration, error) {
+	if l == nil || !l.policy.Enabled() {
+		return 0, nil
+	}
+	now := l.now()
+	var locked time.Duration
+	err := l.store.Update(ctx, key, func(s *State) {
+		if !s.UpdatedAt.IsZero() && l.policy.Max > 0 && now.Sub(s.UpdatedAt) > l.policy.Max {
+			s.Failures = 0
+		}
+		s.Failures++
+		s.UpdatedAt = now
+		if s.Failures >= l.policy.Threshold {
+			locked = l.policy.duration(s.Failures)
+			s.LockedUntil = now.Add(locked)
+		}
+	})
+	return locked, err
+}
+
+// Succeeded сбрасывает счётчик неудач.
+func (l *Lockout) Succeeded(ctx context.Context, key string) error {
+	if l == nil || !l.policy.Enabled() {
+		return nil
+	}
+	return l.store.Delete(ctx, key)
+}
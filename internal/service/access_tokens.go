@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/accesstoken"
+	"github.com/VladislavDraga398/kanban-backend/internal/logging"
+)
+
+const (
+	maxAccessTokenNameLength = 100
+	// accessTokenHintLength — сколько символов токена (вместе с префиксом) показывать в списке.
+	accessTokenHintLength = len(auth.AccessTokenPrefix) + 4
+	// lastUsedResolution — не чаще этого интервала last_used_at пишется в базу, чтобы не делать запись на каждый запрос.
+	lastUsedResolution = time.Minute
+)
+
+// AccessTokenService выпускает, перечисляет, отзывает и проверяет личные токены доступа.
+type AccessTokenService struct {
+	tokens accesstoken.Repository
+	now    func() time.Time
+}
+
+// NewAccessTokenService создаёт сервис личных токенов доступа.
+func NewAccessTokenService(tokens accesstoken.Repository) *AccessTokenService {
+	return &AccessTokenService{tokens: tokens, now: time.Now}
+}
+
+// CreateAccessTokenInput — параметры нового токена; ExpiresAt nil — бессрочный токен.
+type CreateAccessTokenInput struct {
+	Name      string
+	Scopes    []string
+	ExpiresAt *time.Time
+}
+
+// Create выпускает токен. Открытое значение возвращается только здесь: храним лишь хэш.
+func (s *AccessTokenService) Create(ctx context.Context, userID string, in CreateAccessTokenInput) (*accesstoken.Token, string, error) {
+	name := strings.TrimSpace(in.Name)
+
+	var v validator
+	v.required("name", name)
+	if utf8.RuneCountInString(name) > maxAccessTokenNameLength {
+		v.add("name", fmt.Sprintf("must be at most %d characters long", maxAccessTokenNameLength))
+	}
+	scopes, err := auth.ParseScopes(in.Scopes)
+	if err != nil {
+		v.add("scopes", err.Error())
+	} else if len(scopes) == 0 {
+		v.add("scopes", "at least one scope is required")
+	}
+	if in.ExpiresAt != nil && !in.ExpiresAt.After(s.now()) {
+		v.add("expires_at", "must be in the future")
+	}
+	if err := v.err(); err != nil {
+		return nil, "", err
+	}
+
+	raw, hash, err := auth.NewAccessToken()
+	if err != nil {
+		return nil, "", internalError("generate access token", err)
+	}
+
+	t := &accesstoken.Token{
+		UserID:    userID,
+		Name:      name,
+		Scopes:    scopes,
+		Hash:      hash,
+		Hint:      raw[:accessTokenHintLength],
+		ExpiresAt: in.ExpiresAt,
+	}
+	if err := s.tokens.Create(ctx, t); err != nil {
+		return nil, "", internalError("create access token", err)
+	}
+	return t, raw, nil
+}
+
+// List возвращает токены пользователя, включая истёкшие.
+func (s *AccessTokenService) List(ctx context.Context, userID string) ([]*accesstoken.Token, error) {
+	tokens, err := s.tokens.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, internalError("list access tokens", err)
+	}
+	return tokens, nil
+}
+
+// Revoke отзывает токен пользователя.
+func (s *AccessTokenService) Revoke(ctx context.Context, userID, id string) error {
+	if err := s.tokens.Delete(ctx, id, userID); err != nil {
+		if errors.Is(err, accesstoken.ErrNotFound) {
+			return notFoundError(CodeAccessTokenNotFound, "access token not found", err)
+		}
+		return internalError("delete access token", err)
+	}
+	return nil
+}
+
+// VerifyAccessToken находит действующий токен по открытому значению и отмечает его использование.
+// Для неизвестного или истёкшего токена возвращает auth.ErrInvalidAccessToken.
+func (s *AccessTokenService) VerifyAccessToken(ctx context.Context, raw string) (string, []auth.Scope, error) {
+	t, err := s.tokens.GetByHash(ctx, auth.HashOpaqueToken(raw))
+	if err != nil {
+		if errors.Is(err, accesstoken.ErrNotFound) {
+			return "", nil, auth.ErrInvalidAccessToken
+		}
+		return "", nil, fmt.Errorf("get access token: %w", err)
+	}
+
+	now := s.now()
+	if t.Expired(now) {
+		return "", nil, auth.ErrInvalidAccessToken
+	}
+	if t.LastUsedAt == nil || now.Sub(*t.LastUsedAt) >= lastUsedResolution {
+		// Отметка использования вспомогательная: её сбой не должен отклонять запрос.
+		if err := s.tokens.TouchLastUsed(ctx, t.ID, now); err != nil {
+			logging.FromContext(ctx).WarnContext(ctx, "access token last-used update failed", "error", err)
+		}
+	}
+	return t.UserID, t.Scopes, nil
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	_ "time/tzdata" // часовые пояса проверяются и в образах без системной базы zoneinfo
+	"unicode/utf8"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	"github.com/VladislavDraga398/kanban-backend/internal/mail"
+)
+
+// AccountStore — операции хранилища, необходимые сценариям профиля текущего пользователя.
+type AccountStore interface {
+	GetByID(ctx context.Context, id string) (*user.User, error)
+	GetByEmail(ctx context.Context, email string) (*user.User, error)
+	UpdateProfile(ctx context.Context, u *user.User) error
+	UpdatePassword(ctx context.Context, id, passwordHash string) error
+	Delete(ctx context.Context, id string) error
+}
+
+// AccountService реализует управление собственной учётной записью (/me).
+type AccountService struct {
+	users    AccountStore
+	policy   *auth.PasswordPolicy
+	mail     *accountMailer
+	sessions *SessionService
+}
+
+// NewAccountService создаёт сервис учётной записи.
+func NewAccountService(users AccountStore) *AccountService {
+	return &AccountService{users: users}
+}
+
+// WithPasswordPolicy задаёт требования к новому паролю (те же, что при регистрации).
+func (s *AccountService) WithPasswordPolicy(p *auth.PasswordPolicy) *AccountService {
+	s.policy = p
+	return s
+}
+
+// WithSessions завершает остальные сессии пользователя при смене пароля.
+func (s *AccountService) WithSessions(sessions *SessionService) *AccountService {
+	s.sessions = sessions
+	return s
+}
+
+// WithEmails включает смену email с подтверждением нового адреса.
+func (s *AccountService) WithEmails(tokens user.TokenRepository, mailer mail.Mailer, settings EmailSettings) *AccountService {
+	s.mail = &accountMailer{tokens: tokens, mailer: mailer, settings: settings}
+	return s
+}
+
+// ProfilePatch — частичное обновление профиля; nil-поля не меняются, пустая строка очищает поле.
+type ProfilePatch struct {
+	DisplayName *string
+	AvatarURL   *string
+	Timezone    *string
+	Locale      *string
+}
+
+const (
+	maxDisplayNameLength = 100
+	maxAvatarURLLength   = 2048
+)
+
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+// Get возвращает текущего пользователя.
+func (s *AccountService) Get(ctx context.Context, userID string) (*user.User, error) {
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, mapUserError("get user", err)
+	}
+	return u, nil
+}
+
+// UpdateProfile применяет patch к профилю.
+func (s *AccountService) UpdateProfile(ctx context.Context, userID string, patch ProfilePatch) (*user.User, error) {
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, mapUserError("get user", err)
+	}
+
+	var v validator
+	if patch.DisplayName != nil {
+		name := strings.TrimSpace(*patch.DisplayName)
+		if utf8.RuneCountInString(name) > maxDisplayNameLength {
+			v.add("display_name", fmt.Sprintf("display_name must be at most %d characters long", maxDisplayNameLength))
+		}
+		u.DisplayName = name
+	}
+	if patch.AvatarURL != nil {
+		avatar := strings.TrimSpace(*patch.AvatarURL)
+		if avatar != "" && !validAvatarURL(avatar) {
+			v.add("avatar_url", "avatar_url must be an absolute http(s) URL")
+		}
+		u.AvatarURL = avatar
+	}
+	if patch.Timezone != nil {
+		tz := strings.TrimSpace(*patch.Timezone)
+		// LoadLocation принимает и "Local", но часовой пояс сервера пользователю ни о чём не скажет.
+		if _, err := time.LoadLocation(tz); tz != "" && (err != nil || tz == "Local") {
+			v.add("timezone", "timezone must be an IANA time zone such as Europe/Moscow")
+		}
+		u.Timezone = tz
+	}
+	if patch.Locale != nil {
+		locale := strings.TrimSpace(*patch.Locale)
+		if locale != "" && !localePattern.MatchString(locale) {
+			v.add("locale", "locale must be a BCP 47 language tag such as ru-RU")
+		}
+		u.Locale = locale
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.users.UpdateProfile(ctx, u); err != nil {
+		return nil, mapUserError("update profile", err)
+	}
+	return u, nil
+}
+
+// ChangePassword меняет пароль после проверки текущего и завершает все сессии пользователя,
+// кроме currentSessionID.
+func (s *AccountService) ChangePassword(ctx context.Context, userID, currentSessionID, currentPassword, newPassword string) error {
+	var v validator
+	v.required("current_password", currentPassword)
+	v.required("new_password", newPassword)
+
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return mapUserError("get user", err)
+	}
+	if err := requirePassword(u); err != nil {
+		return err
+	}
+	if err := v.err(); err != nil {
+		return err
+	}
+	if err := auth.ComparePasswords(u.PasswordHash, currentPassword); err != nil {
+		return validationError("current_password", "current password is incorrect")
+	}
+	if err := checkPassword(s.policy, "new_password", newPassword, u.Email); err != nil {
+		return err
+	}
+
+	hash, err := auth.HashPassword(newPassword)
+	if err != nil {
+		return internalError("hash password", err)
+	}
+	if err := s.users.UpdatePassword(ctx, u.ID, hash); err != nil {
+		return mapUserError("update password", err)
+	}
+	// Старый пароль мог утечь вместе с сессией: оставляем только ту, из которой его сменили.
+	if s.sessions != nil {
+		if _, err := s.sessions.RevokeAll(ctx, u.ID, currentSessionID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ChangeEmail отправляет на новый адрес ссылку подтверждения; email меняется только после перехода
+// по ней (POST /auth/verify-email). На старый адрес уходит уведомление. Учётной записи без пароля
+// сначала нужно задать его по ссылке сброса: иначе одна сессия позволила бы увести адрес, а с ним
+// и сброс пароля.
+func (s *AccountService) ChangeEmail(ctx context.Context, userID, newEmail, password string) error {
+	if s.mail == nil {
+		return internalError("change email", errEmailsDisabled)
+	}
+	newEmail = strings.TrimSpace(newEmail)
+	var v validator
+	v.required("email", newEmail)
+	v.required("password", password)
+
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return mapUserError("get user", err)
+	}
+	if err := requirePassword(u); err != nil {
+		return err
+	}
+	if err := v.err(); err != nil {
+		return err
+	}
+	if err := auth.ComparePasswords(u.PasswordHash, password); err != nil {
+		return validationError("password", "password is incorrect")
+	}
+	if strings.EqualFold(newEmail, u.Email) {
+		return validationError("email", "email must differ from the current one")
+	}
+	if _, err := s.users.GetByEmail(ctx, newEmail); err == nil {
+		return conflictError(CodeEmailAlreadyUsed, "email already in use", nil)
+	} else if !errors.Is(err, user.ErrNotFound) {
+		return internalError("get user by email", err)
+	}
+
+	raw, err := s.mail.issueToken(ctx, &user.Token{UserID: u.ID, Purpose: user.PurposeChangeEmail, Email: newEmail}, s.mail.settings.VerifyTTL)
+	if err != nil {
+		return err
+	}
+	s.mail.send(ctx, mail.Message{
+		To:      newEmail,
+		Subject: "Подтвердите новый email в Kanban",
+		Body: fmt.Sprintf("Чтобы сделать этот адрес основным, перейдите по ссылке:\n\n%s\n\nСсылка действует %s.\n",
+			s.mail.link("/verify-email", raw), s.mail.settings.VerifyTTL),
+	})
+	s.mail.send(ctx, mail.Message{
+		To:      u.Email,
+		Subject: "Запрошена смена email в Kanban",
+		Body: fmt.Sprintf("Для вашей учётной записи запрошена смена адреса на %s. "+
+			"Адрес изменится после подтверждения по ссылке из письма на новый email.\n"+
+			"Если это были не вы, смените пароль.\n", newEmail),
+	})
+	return nil
+}
+
+// Delete удаляет учётную запись вместе с досками пользователя. Для подтверждения нужны
+// текущий пароль и email учётной записи; у пользователя без пароля (только вход через
+// внешнего провайдера) — один email.
+func (s *AccountService) Delete(ctx context.Context, userID, password, confirmEmail string) error {
+	var v validator
+	v.required("confirm_email", strings.TrimSpace(confirmEmail))
+	if err := v.err(); err != nil {
+		return err
+	}
+
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return mapUserError("get user", err)
+	}
+	if u.PasswordHash != "" {
+		if password == "" {
+			v.add("password", "password is required")
+		} else if err := auth.ComparePasswords(u.PasswordHash, password); err != nil {
+			v.add("password", "password is incorrect")
+		}
+	}
+	if !strings.EqualFold(strings.TrimSpace(confirmEmail), u.Email) {
+		v.add("confirm_email", "confirm_email must match the account email")
+	}
+	if err := v.err(); err != nil {
+		return err
+	}
+
+	if err := s.users.Delete(ctx, u.ID); err != nil {
+		return mapUserError("delete user", err)
+	}
+	return nil
+}
+
+// requirePassword отказывает пользователю, входящему только через внешнего провайдера: одной сессии
+// мало, чтобы задать первый пароль, — он задаётся по ссылке из письма (POST /auth/forgot-password).
+func requirePassword(u *user.User) error {
+	if u.PasswordHash == "" {
+		return forbiddenError(CodePasswordNotSet, "account has no password, set one through password reset", nil)
+	}
+	return nil
+}
+
+func validAvatarURL(raw string) bool {
+	if len(raw) > maxAvatarURLLength {
+		return false
+	}
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// mapUserError превращает user.ErrNotFound в 404 (учётная запись удалена, а токен ещё действует).
+func mapUserError(op string, err error) error {
+	if errors.Is(err, user.ErrNotFound) {
+		return notFoundError(CodeUserNotFound, "user not found", err)
+	}
+	return internalError(op, err)
+}
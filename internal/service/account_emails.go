@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	"github.com/VladislavDraga398/kanban-backend/internal/logging"
+	"github.com/VladislavDraga398/kanban-backend/internal/mail"
+)
+
+// EmailSettings — параметры писем подтверждения email, сброса пароля и приглашений.
+type EmailSettings struct {
+	// BaseURL — адрес фронтенда, на страницы которого ведут ссылки из писем.
+	BaseURL   string
+	VerifyTTL time.Duration
+	ResetTTL  time.Duration
+	// InviteTTL — срок действия приглашения на доску.
+	InviteTTL time.Duration
+	// RequireVerified запрещает вход, пока email не подтверждён.
+	RequireVerified bool
+}
+
+var errEmailsDisabled = errors.New("email flows are not configured")
+
+// accountMailer выпускает одноразовые токены и отправляет письма со ссылками на них.
+type accountMailer struct {
+	tokens   user.TokenRepository
+	mailer   mail.Mailer
+	settings EmailSettings
+}
+
+// WithEmails включает подтверждение email и сброс пароля по ссылке из письма.
+func (s *AuthService) WithEmails(tokens user.TokenRepository, mailer mail.Mailer, settings EmailSettings) *AuthService {
+	s.mail = &accountMailer{tokens: tokens, mailer: mailer, settings: settings}
+	s.requireVerified = settings.RequireVerified
+	return s
+}
+
+// VerifyEmail подтверждает email по токену из письма: адрес после регистрации
+// или новый адрес, запрошенный при смене email в профиле.
+func (s *AuthService) VerifyEmail(ctx context.Context, token string) error {
+	if s.mail == nil {
+		return internalError("verify email", errEmailsDisabled)
+	}
+	if err := requiredToken(token); err != nil {
+		return err
+	}
+
+	hash := auth.HashOpaqueToken(token)
+	t, err := s.mail.tokens.Consume(ctx, user.PurposeVerifyEmail, hash)
+	if errors.Is(err, user.ErrTokenInvalid) {
+		t, err = s.mail.tokens.Consume(ctx, user.PurposeChangeEmail, hash)
+	}
+	if err != nil {
+		return tokenError("consume verification token", err)
+	}
+
+	if t.Purpose == user.PurposeChangeEmail {
+		if err := s.users.UpdateEmail(ctx, t.UserID, t.Email); err != nil {
+			if errors.Is(err, user.ErrEmailAlreadyUsed) {
+				return conflictError(CodeEmailAlreadyUsed, "email already in use", err)
+			}
+			return internalError("update email", err)
+		}
+		return nil
+	}
+	if err := s.users.MarkEmailVerified(ctx, t.UserID); err != nil {
+		return internalError("mark email verified", err)
+	}
+	return nil
+}
+
+// ForgotPassword отправляет ссылку для сброса пароля. Для неизвестного адреса ничего не делает
+// и тоже возвращает nil, чтобы ответ не раскрывал, какие email зарегистрированы.
+func (s *AuthService) ForgotPassword(ctx context.Context, email string) error {
+	if s.mail == nil {
+		return internalError("forgot password", errEmailsDisabled)
+	}
+	email = strings.TrimSpace(email)
+	var v validator
+	v.required("email", email)
+	if err := v.err(); err != nil {
+		return err
+	}
+
+	u, err := s.users.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, user.ErrNotFound) {
+			return nil
+		}
+		return internalError("get user", err)
+	}
+
+	raw, err := s.mail.issueToken(ctx, &user.Token{UserID: u.ID, Purpose: user.PurposeResetPassword}, s.mail.settings.ResetTTL)
+	if err != nil {
+		return err
+	}
+	s.mail.send(ctx, mail.Message{
+		To:      u.Email,
+		Subject: "Сброс пароля в Kanban",
+		Body: fmt.Sprintf("Чтобы задать новый пароль, перейдите по ссылке:\n\n%s\n\n"+
+			"Ссылка действует %s и сработает один раз. Если вы не запрашивали сброс, просто проигнорируйте письмо.\n",
+			s.mail.link("/reset-password", raw), s.mail.settings.ResetTTL),
+	})
+	return nil
+}
+
+// ResetPassword задаёт новый пароль по токену из письма. Токен проверяется до политики паролей
+// и гасится только после неё, поэтому слабый пароль не сжигает ссылку.
+func (s *AuthService) ResetPassword(ctx context.Context, token, password string) error {
+	if s.mail == nil {
+		return internalError("reset password", errEmailsDisabled)
+	}
+	var v validator
+	v.required("token", token)
+	v.required("password", password)
+	if err := v.err(); err != nil {
+		return err
+	}
+
+	hash := auth.HashOpaqueToken(token)
+	t, err := s.mail.tokens.Find(ctx, user.PurposeResetPassword, hash)
+	if err != nil {
+		return tokenError("find reset token", err)
+	}
+	u, err := s.users.GetByID(ctx, t.UserID)
+	if err != nil {
+		return internalError("get user", err)
+	}
+	if err := checkPassword(s.policy, "password", password, u.Email); err != nil {
+		return err
+	}
+	passwordHash, err := auth.HashPassword(password)
+	if err != nil {
+		return internalError("hash password", err)
+	}
+
+	if _, err := s.mail.tokens.Consume(ctx, user.PurposeResetPassword, hash); err != nil {
+		return tokenError("consume reset token", err)
+	}
+	if err := s.users.UpdatePassword(ctx, u.ID, passwordHash); err != nil {
+		return internalError("update password", err)
+	}
+	// Ссылка пришла на этот адрес, значит, он подтверждён.
+	if err := s.users.MarkEmailVerified(ctx, u.ID); err != nil {
+		return internalError("mark email verified", err)
+	}
+	if err := s.guard.Succeeded(ctx, lockoutKey(u.Email)); err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "login guard reset failed", "error", err)
+	}
+	// Пароль сбрасывают, когда учётную запись могли захватить: завершаем все её сессии.
+	if s.sessions != nil {
+		if _, err := s.sessions.RevokeAll(ctx, u.ID, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendVerification отправляет письмо со ссылкой подтверждения. Сбой отправки не отменяет регистрацию:
+// пользователь сможет подтвердить адрес через сброс пароля.
+func (m *accountMailer) sendVerification(ctx context.Context, u *user.User) {
+	raw, err := m.issueToken(ctx, &user.Token{UserID: u.ID, Purpose: user.PurposeVerifyEmail}, m.settings.VerifyTTL)
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to issue verification token", "error", err)
+		return
+	}
+	m.send(ctx, mail.Message{
+		To:      u.Email,
+		Subject: "Подтвердите email в Kanban",
+		Body: fmt.Sprintf("Чтобы подтвердить адрес, перейдите по ссылке:\n\n%s\n\nСсылка действует %s.\n",
+			m.link("/verify-email", raw), m.settings.VerifyTTL),
+	})
+}
+
+// issueToken сохраняет хэш нового токена и возвращает сам токен для ссылки.
+func (m *accountMailer) issueToken(ctx context.Context, t *user.Token, ttl time.Duration) (string, error) {
+	raw, hash, err := auth.NewOpaqueToken()
+	if err != nil {
+		return "", internalError("generate token", err)
+	}
+	t.Hash, t.ExpiresAt = hash, time.Now().Add(ttl)
+	if err := m.tokens.Issue(ctx, t); err != nil {
+		return "", internalError("issue token", err)
+	}
+	return raw, nil
+}
+
+func (m *accountMailer) send(ctx context.Context, msg mail.Message) {
+	deliver(ctx, m.mailer, msg)
+}
+
+func (m *accountMailer) link(path, token string) string {
+	return tokenLink(m.settings.BaseURL, path, token)
+}
+
+// deliver отправляет письмо, только логируя ошибку: ответ клиенту не должен зависеть от почтового сервера.
+func deliver(ctx context.Context, mailer mail.Mailer, msg mail.Message) {
+	if err := mailer.Send(ctx, msg); err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to send email", "subject", msg.Subject, "error", err)
+	}
+}
+
+// tokenLink строит ссылку на страницу фронтенда с токеном из письма.
+func tokenLink(baseURL, path, token string) string {
+	return strings.TrimRight(baseURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
+
+func requiredToken(token string) error {
+	var v validator
+	v.required("token", token)
+	return v.err()
+}
+
+// tokenError превращает недействительный токен в 400 invalid_token, остальное — во внутреннюю ошибку.
+func tokenError(op string, err error) error {
+	if errors.Is(err, user.ErrTokenInvalid) {
+		return &Error{Kind: KindValidation, Code: CodeInvalidToken, Message: "token is invalid or expired", Err: err}
+	}
+	return internalError(op, err)
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/activity"
+)
+
+const (
+	defaultActivityLimit = 50
+	maxActivityLimit     = 100
+)
+
+// ActivityQuery — страница журнала действий.
+type ActivityQuery struct {
+	// Actions — только эти действия; пусто — все.
+	Actions []string
+	// Cursor — next_cursor предыдущей страницы; пусто — с самых новых записей.
+	Cursor string
+	// Limit — размер страницы; 0 — по умолчанию.
+	Limit int
+}
+
+// ActivityPage — записи журнала и курсор следующей страницы (пустой, если записей больше нет).
+type ActivityPage struct {
+	Entries    []*activity.Entry
+	NextCursor string
+}
+
+// ActivityService отдаёт журнал действий доски всем, у кого есть к ней доступ.
+type ActivityService struct {
+	activity activity.Repository
+	boards   BoardStore
+}
+
+// NewActivityService создаёт сервис журнала действий.
+func NewActivityService(entries activity.Repository, boards BoardStore) *ActivityService {
+	return &ActivityService{activity: entries, boards: boards}
+}
+
+// ListBoard возвращает журнал доски, новые записи первыми.
+func (s *ActivityService) ListBoard(ctx context.Context, userID, boardID string, q ActivityQuery) (*ActivityPage, error) {
+	return s.list(ctx, userID, activity.Filter{BoardID: boardID}, q)
+}
+
+// ListTask возвращает записи журнала об одной задаче доски, в том числе уже удалённой.
+func (s *ActivityService) ListTask(ctx context.Context, userID, boardID, taskID string, q ActivityQuery) (*ActivityPage, error) {
+	if taskID == "" {
+		return nil, validationError("task_id", "task_id is required")
+	}
+	return s.list(ctx, userID, activity.Filter{BoardID: boardID, TaskID: taskID}, q)
+}
+
+func (s *ActivityService) list(ctx context.Context, userID string, f activity.Filter, q ActivityQuery) (*ActivityPage, error) {
+	var v validator
+	v.required("board_id", f.BoardID)
+	for _, raw := range q.Actions {
+		a := activity.Action(raw)
+		if !a.Valid() {
+			v.add("action", "unknown action "+strconv.Quote(raw))
+			continue
+		}
+		f.Actions = append(f.Actions, a)
+	}
+	if q.Cursor != "" {
+		before, ok := decodeActivityCursor(q.Cursor)
+		if !ok {
+			v.add("cursor", "must be a next_cursor value from a previous page")
+		}
+		f.Before = before
+	}
+	switch {
+	case q.Limit == 0:
+		f.Limit = defaultActivityLimit
+	case q.Limit < 0 || q.Limit > maxActivityLimit:
+		v.add("limit", "must be between 1 and "+strconv.Itoa(maxActivityLimit))
+	default:
+		f.Limit = q.Limit
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.boards.GetByID(ctx, f.BoardID, userID); err != nil {
+		return nil, mapBoardError("get board", err)
+	}
+
+	// Лишняя запись показывает, есть ли следующая страница.
+	limit := f.Limit
+	f.Limit++
+	entries, err := s.activity.List(ctx, f)
+	if err != nil {
+		return nil, internalError("list activity", err)
+	}
+	page := &ActivityPage{Entries: entries}
+	if len(entries) > limit {
+		page.Entries = entries[:limit]
+		page.NextCursor = encodeActivityCursor(page.Entries[limit-1].ID)
+	}
+	return page, nil
+}
+
+// Курсор непрозрачен для клиента: это ID последней записи страницы.
+func encodeActivityCursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+func decodeActivityCursor(cursor string) (int64, bool) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/archive"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/column"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/workspace"
+)
+
+// ArchiveService убирает доски и задачи в архив и возвращает их оттуда.
+type ArchiveService struct {
+	archive    archive.Repository
+	boards     BoardStore
+	workspaces workspace.Repository
+	// autoArchiveAfter — через сколько задачи из колонок «готово» уходят в архив; 0 выключает автоархивацию.
+	autoArchiveAfter time.Duration
+}
+
+// NewArchiveService создаёт сервис архива; boards нужен, чтобы отличать 403 от 404 для досок.
+func NewArchiveService(items archive.Repository, boards BoardStore) *ArchiveService {
+	return &ArchiveService{archive: items, boards: boards}
+}
+
+// WithWorkspaces включает архивные списки досок пространств.
+func (s *ArchiveService) WithWorkspaces(workspaces workspace.Repository) *ArchiveService {
+	s.workspaces = workspaces
+	return s
+}
+
+// WithAutoArchiveAfter включает автоархивацию задач, пролежавших в колонках «готово» дольше after.
+func (s *ArchiveService) WithAutoArchiveAfter(after time.Duration) *ArchiveService {
+	s.autoArchiveAfter = after
+	return s
+}
+
+// AutoArchive убирает в архив задачи, которые на момент now пролежали в колонках «готово» дольше срока.
+func (s *ArchiveService) AutoArchive(ctx context.Context, now time.Time) (int64, error) {
+	if s.autoArchiveAfter <= 0 {
+		return 0, nil
+	}
+	n, err := s.archive.AutoArchive(ctx, now.Add(-s.autoArchiveAfter))
+	if err != nil {
+		return 0, internalError("auto-archive tasks", err)
+	}
+	return n, nil
+}
+
+// ListBoards возвращает архивные личные доски пользователя — тот же круг, что GET /boards без архивных.
+func (s *ArchiveService) ListBoards(ctx context.Context, userID string) ([]*board.Board, error) {
+	boards, err := s.archive.ListBoards(ctx, userID)
+	if err != nil {
+		return nil, internalError("list archived boards", err)
+	}
+	return boards, nil
+}
+
+// ListWorkspaceBoards возвращает архивные доски пространства, в котором состоит пользователь.
+func (s *ArchiveService) ListWorkspaceBoards(ctx context.Context, userID, workspaceID string) ([]*board.Board, error) {
+	if _, err := workspaceMember(ctx, s.workspaces, userID, workspaceID); err != nil {
+		return nil, err
+	}
+
+	boards, err := s.archive.ListWorkspaceBoards(ctx, workspaceID)
+	if err != nil {
+		return nil, internalError("list archived workspace boards", err)
+	}
+	return boards, nil
+}
+
+// ListSharedBoards возвращает архивные доски, на которые пользователя пригласили.
+func (s *ArchiveService) ListSharedBoards(ctx context.Context, userID string) ([]*board.Board, error) {
+	boards, err := s.archive.ListSharedBoards(ctx, userID)
+	if err != nil {
+		return nil, internalError("list archived shared boards", err)
+	}
+	return boards, nil
+}
+
+// ListTasks возвращает архивные задачи колонки.
+func (s *ArchiveService) ListTasks(ctx context.Context, userID, boardID, columnID string) ([]*task.Task, error) {
+	var v validator
+	v.required("board_id", boardID)
+	v.required("column_id", columnID)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.archive.ListTasks(ctx, boardID, columnID, userID)
+	if err != nil {
+		return nil, internalError("list archived tasks", err)
+	}
+	return tasks, nil
+}
+
+// ArchiveBoard убирает в архив доску, которой может управлять пользователь.
+func (s *ArchiveService) ArchiveBoard(ctx context.Context, userID, boardID string) (*board.Board, error) {
+	return s.setBoardArchived(ctx, userID, boardID, true)
+}
+
+// UnarchiveBoard возвращает доску из архива.
+func (s *ArchiveService) UnarchiveBoard(ctx context.Context, userID, boardID string) (*board.Board, error) {
+	return s.setBoardArchived(ctx, userID, boardID, false)
+}
+
+func (s *ArchiveService) setBoardArchived(ctx context.Context, userID, boardID string, archived bool) (*board.Board, error) {
+	if err := requireBoardManage(ctx, s.boards, userID, boardID); err != nil {
+		return nil, err
+	}
+
+	b := &board.Board{ID: boardID}
+	var err error
+	if archived {
+		err = s.archive.ArchiveBoard(ctx, b, userID)
+	} else {
+		err = s.archive.UnarchiveBoard(ctx, b, userID)
+	}
+	if err != nil {
+		return nil, mapBoardError("set board archived", err)
+	}
+	return b, nil
+}
+
+// ArchiveTask убирает задачу в архив.
+func (s *ArchiveService) ArchiveTask(ctx context.Context, userID, boardID, taskID string) (*task.Task, error) {
+	return s.setTaskArchived(ctx, userID, boardID, taskID, true)
+}
+
+// UnarchiveTask возвращает задачу из архива.
+func (s *ArchiveService) UnarchiveTask(ctx context.Context, userID, boardID, taskID string) (*task.Task, error) {
+	return s.setTaskArchived(ctx, userID, boardID, taskID, false)
+}
+
+func (s *ArchiveService) setTaskArchived(ctx context.Context, userID, boardID, taskID string, archived bool) (*task.Task, error) {
+	if err := validateTaskRef(boardID, taskID); err != nil {
+		return nil, err
+	}
+
+	t := &task.Task{ID: taskID, BoardID: boardID}
+	var err error
+	if archived {
+		err = s.archive.ArchiveTask(ctx, t, userID)
+	} else {
+		err = s.archive.UnarchiveTask(ctx, t, userID)
+	}
+	if err != nil {
+		return nil, mapTaskError("task not found", "set task archived", err)
+	}
+	return t, nil
+}
+
+// ArchiveColumn убирает в архив все задачи колонки и возвращает их число.
+func (s *ArchiveService) ArchiveColumn(ctx context.Context, userID, boardID, columnID string) (int, error) {
+	var v validator
+	v.required("board_id", boardID)
+	v.required("column_id", columnID)
+	if err := v.err(); err != nil {
+		return 0, err
+	}
+
+	n, err := s.archive.ArchiveColumn(ctx, boardID, columnID, userID)
+	if err != nil {
+		if errors.Is(err, column.ErrNotFound) {
+			return 0, notFoundError(CodeColumnNotFound, "board or column not found", err)
+		}
+		return 0, internalError("archive column", err)
+	}
+	return n, nil
+}
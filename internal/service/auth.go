@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/invitation"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	"github.com/VladislavDraga398/kanban-backend/internal/logging"
+)
+
+// UserStore — операции хранилища, необходимые сценариям аутентификации.
+type UserStore interface {
+	Create(ctx context.Context, u *user.User) error
+	GetByID(ctx context.Context, id string) (*user.User, error)
+	GetByEmail(ctx context.Context, email string) (*user.User, error)
+	MarkEmailVerified(ctx context.Context, id string) error
+	UpdatePassword(ctx context.Context, id, passwordHash string) error
+	UpdateEmail(ctx context.Context, id, email string) error
+	UpdateProfile(ctx context.Context, u *user.User) error
+}
+
+// AuthService реализует регистрацию и вход по email/паролю.
+type AuthService struct {
+	users           UserStore
+	jwtSecret       []byte
+	jwtTTL          time.Duration
+	keys            *auth.KeySet
+	recorder        Recorder
+	guard           LoginGuard
+	policy          *auth.PasswordPolicy
+	mail            *accountMailer
+	requireVerified bool
+	identities      user.IdentityRepository
+	twoFactor       user.TwoFactorRepository
+	sessions        *SessionService
+	invitations     *InvitationService
+}
+
+// LoginGuard защищает вход от перебора паролей (например, *ratelimit.Lockout).
+// Ключом служит нормализованный email, поэтому блокируются и попытки для несуществующих адресов.
+type LoginGuard interface {
+	// Check возвращает оставшееся время блокировки ключа.
+	Check(ctx context.Context, key string) (time.Duration, error)
+	// Failed учитывает неудачную попытку.
+	Failed(ctx context.Context, key string) (time.Duration, error)
+	// Succeeded сбрасывает счётчик неудач.
+	Succeeded(ctx context.Context, key string) error
+}
+
+type nopGuard struct{}
+
+func (nopGuard) Check(context.Context, string) (time.Duration, error)  { return 0, nil }
+func (nopGuard) Failed(context.Context, string) (time.Duration, error) { return 0, nil }
+func (nopGuard) Succeeded(context.Context, string) error               { return nil }
+
+// NewAuthService создаёт сервис аутентификации.
+func NewAuthService(users UserStore, jwtSecret string, jwtTTL time.Duration) *AuthService {
+	return &AuthService{users: users, jwtSecret: []byte(jwtSecret), jwtTTL: jwtTTL, recorder: nopRecorder{}, guard: nopGuard{}}
+}
+
+// WithRecorder подключает получателя доменных событий (успешные и неудачные входы).
+func (s *AuthService) WithRecorder(r Recorder) *AuthService {
+	s.recorder = recorderOrNop(r)
+	return s
+}
+
+// WithKeySet подписывает токены доступа асимметричным ключом набора вместо общего секрета HS256.
+// Секрет по-прежнему нужен для внутренних короткоживущих токенов (второй фактор).
+func (s *AuthService) WithKeySet(keys *auth.KeySet) *AuthService {
+	s.keys = keys
+	return s
+}
+
+// WithSessions создаёт сессию на каждый вход и записывает её идентификатор в токен,
+// чтобы токен можно было отозвать до истечения срока.
+func (s *AuthService) WithSessions(sessions *SessionService) *AuthService {
+	s.sessions = sessions
+	return s
+}
+
+// WithInvitations позволяет зарегистрироваться по ссылке из приглашения на доску.
+func (s *AuthService) WithInvitations(invitations *InvitationService) *AuthService {
+	s.invitations = invitations
+	return s
+}
+
+// Session — результат успешной регистрации или входа.
+// Token пуст, если вход требует подтверждённого email, а он ещё не подтверждён,
+// или если нужен второй фактор: тогда заполнен Challenge для LoginTwoFactor.
+type Session struct {
+	User      *user.User
+	Token     string
+	Challenge string
+}
+
+// WithLoginGuard подключает прогрессивную блокировку входа после неудачных попыток.
+func (s *AuthService) WithLoginGuard(g LoginGuard) *AuthService {
+	if g == nil {
+		g = nopGuard{}
+	}
+	s.guard = g
+	return s
+}
+
+// WithPasswordPolicy задаёт требования к паролю при регистрации; nil — только ограничение bcrypt на длину.
+func (s *AuthService) WithPasswordPolicy(p *auth.PasswordPolicy) *AuthService {
+	s.policy = p
+	return s
+}
+
+// Register создаёт пользователя и выпускает для него токен.
+func (s *AuthService) Register(ctx context.Context, email, password string) (*Session, error) {
+	return s.RegisterWithInvitation(ctx, email, password, "")
+}
+
+// RegisterWithInvitation регистрирует пользователя и, если передан токен приглашения, принимает его.
+// Письмо с приглашением уже подтверждает адрес, поэтому при совпадении email повторная проверка не нужна.
+func (s *AuthService) RegisterWithInvitation(ctx context.Context, email, password, invitationToken string) (*Session, error) {
+	email, err := normalizeCredentials(email, password)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPassword(s.policy, "password", password, email); err != nil {
+		return nil, err
+	}
+
+	var inv *invitation.Invitation
+	if invitationToken != "" {
+		if s.invitations == nil {
+			return nil, validationError("invitation_token", "invitations are not enabled")
+		}
+		if inv, err = s.invitations.find(ctx, invitationToken); err != nil {
+			return nil, err
+		}
+	}
+
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return nil, internalError("hash password", err)
+	}
+
+	u := &user.User{
+		Email:        email,
+		PasswordHash: hash,
+	}
+	if err := s.users.Create(ctx, u); err != nil {
+		if errors.Is(err, user.ErrEmailAlreadyUsed) {
+			return nil, conflictError(CodeEmailAlreadyUsed, "email already in use", err)
+		}
+		return nil, internalError("create user", err)
+	}
+
+	if inv != nil {
+		s.invitations.acceptOnRegister(ctx, u.ID, invitationToken)
+		if strings.EqualFold(inv.Email, u.Email) {
+			if err := s.users.MarkEmailVerified(ctx, u.ID); err != nil {
+				return nil, internalError("mark email verified", err)
+			}
+			now := time.Now()
+			u.EmailVerifiedAt = &now
+		}
+	}
+
+	if s.mail != nil && !u.EmailVerified() {
+		s.mail.sendVerification(ctx, u)
+	}
+	if s.requireVerified && !u.EmailVerified() {
+		return &Session{User: u}, nil
+	}
+	return s.issue(ctx, u)
+}
+
+// Login проверяет учётные данные и выпускает токен.
+func (s *AuthService) Login(ctx context.Context, email, password string) (*Session, error) {
+	email, err := normalizeCredentials(email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	key := lockoutKey(email)
+	if left, err := s.guard.Check(ctx, key); err != nil {
+		// Сбой хранилища блокировок не должен закрывать вход всем пользователям.
+		logging.FromContext(ctx).WarnContext(ctx, "login guard check failed", "error", err)
+	} else if left > 0 {
+		s.recorder.LoginFailed()
+		return nil, tooManyRequestsError(CodeAccountLocked, "too many failed login attempts, try again later", left)
+	}
+
+	u, err := s.users.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, user.ErrNotFound) {
+			return nil, s.loginFailed(ctx, key, err)
+		}
+		return nil, internalError("get user", err)
+	}
+
+	if err := verifyPassword(u.PasswordHash, password); err != nil {
+		return nil, s.loginFailed(ctx, key, err)
+	}
+	if err := s.guard.Succeeded(ctx, key); err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "login guard reset failed", "error", err)
+	}
+	// Проверяем после пароля, чтобы по ответу нельзя было узнать статус чужого адреса.
+	if s.requireVerified && !u.EmailVerified() {
+		return nil, forbiddenError(CodeEmailNotVerified, "email address is not verified", nil)
+	}
+	if sess, err := s.requireSecondFactor(ctx, u); sess != nil || err != nil {
+		return sess, err
+	}
+
+	sess, err := s.issue(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	s.recorder.LoginSucceeded()
+	return sess, nil
+}
+
+// verifyPassword сверяет пароль с хэшем. Раньше пароль обрезался по краям перед хэшированием,
+// поэтому при несовпадении пробуем обрезанный вариант: сохранённый пароль при этом не меняется.
+func verifyPassword(hash, password string) error {
+	err := auth.ComparePasswords(hash, password)
+	trimmed := strings.TrimSpace(password)
+	if err == nil || trimmed == password || trimmed == "" {
+		return err
+	}
+	if auth.ComparePasswords(hash, trimmed) == nil {
+		return nil
+	}
+	return err
+}
+
+// loginFailed учитывает неудачный вход. Клиент получает invalid_credentials даже при наступлении
+// блокировки, чтобы ответ не раскрывал, какие адреса существуют; блокировка видна со следующей попытки.
+func (s *AuthService) loginFailed(ctx context.Context, key string, cause error) error {
+	s.recorder.LoginFailed()
+	if locked, err := s.guard.Failed(ctx, key); err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "login guard update failed", "error", err)
+	} else if locked > 0 {
+		logging.FromContext(ctx).WarnContext(ctx, "login locked after failed attempts", "lock", locked.String())
+	}
+	return unauthorizedError(CodeInvalidCredentials, "invalid credentials", cause)
+}
+
+func lockoutKey(email string) string {
+	return "lockout:" + strings.ToLower(email)
+}
+
+func (s *AuthService) issue(ctx context.Context, u *user.User) (*Session, error) {
+	claims := auth.Claims{UserID: u.ID}
+	if s.sessions != nil {
+		sess, err := s.sessions.Start(ctx, u.ID, s.jwtTTL)
+		if err != nil {
+			return nil, err
+		}
+		claims.SessionID = sess.ID
+	}
+
+	var (
+		token string
+		err   error
+	)
+	if s.keys != nil {
+		token, err = s.keys.SignClaims(claims, s.jwtTTL)
+	} else {
+		token, err = auth.GenerateClaimsJWT(claims, s.jwtSecret, s.jwtTTL)
+	}
+	if err != nil {
+		return nil, internalError("sign token", err)
+	}
+	return &Session{User: u, Token: token}, nil
+}
+
+// checkPassword проверяет новый пароль по политике и возвращает все нарушения для поля field.
+func checkPassword(policy *auth.PasswordPolicy, field, password, email string) error {
+	var v validator
+	if policy != nil {
+		for _, msg := range policy.Check(password, email) {
+			v.add(field, msg)
+		}
+	} else if len(password) > auth.MaxPasswordBytes {
+		v.add(field, fmt.Sprintf("password must be at most %d bytes long", auth.MaxPasswordBytes))
+	}
+	return v.err()
+}
+
+// normalizeCredentials нормализует email. Пароль сохраняется как введён: пробелы в нём значимы.
+func normalizeCredentials(email, password string) (string, error) {
+	email = strings.TrimSpace(email)
+
+	var v validator
+	v.required("email", email)
+	v.required("password", password)
+	return email, v.err()
+}
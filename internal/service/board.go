@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/workspace"
+)
+
+// BoardStore — операции хранилища, необходимые сценариям досок.
+type BoardStore interface {
+	ListByOwnerID(ctx context.Context, ownerID string) ([]*board.Board, error)
+	ListShared(ctx context.Context, userID string) ([]*board.Board, error)
+	ListByWorkspace(ctx context.Context, workspaceID string) ([]*board.Board, error)
+	GetByID(ctx context.Context, id, userID string) (*board.Board, error)
+	CanManage(ctx context.Context, id, userID string) (bool, error)
+	ListMembers(ctx context.Context, boardID string) ([]*board.Member, error)
+	RemoveMember(ctx context.Context, boardID, userID string) error
+	Create(ctx context.Context, b *board.Board) error
+	Update(ctx context.Context, b *board.Board, userID string) error
+	Transfer(ctx context.Context, b *board.Board) error
+	Delete(ctx context.Context, id, userID string) error
+}
+
+// BoardService реализует сценарии работы с досками.
+type BoardService struct {
+	boards     BoardStore
+	workspaces workspace.Repository
+}
+
+// NewBoardService создаёт сервис досок.
+func NewBoardService(boards BoardStore) *BoardService {
+	return &BoardService{boards: boards}
+}
+
+// WithWorkspaces включает доски рабочих пространств и перенос досок между пространствами.
+func (s *BoardService) WithWorkspaces(workspaces workspace.Repository) *BoardService {
+	s.workspaces = workspaces
+	return s
+}
+
+// List возвращает личные доски пользователя.
+func (s *BoardService) List(ctx context.Context, userID string) ([]*board.Board, error) {
+	boards, err := s.boards.ListByOwnerID(ctx, userID)
+	if err != nil {
+		return nil, internalError("list boards", err)
+	}
+	return boards, nil
+}
+
+// ListShared возвращает доски, на которые пользователя пригласили.
+func (s *BoardService) ListShared(ctx context.Context, userID string) ([]*board.Board, error) {
+	boards, err := s.boards.ListShared(ctx, userID)
+	if err != nil {
+		return nil, internalError("list shared boards", err)
+	}
+	return boards, nil
+}
+
+// Get возвращает доступную пользователю доску по ID.
+func (s *BoardService) Get(ctx context.Context, userID, boardID string) (*board.Board, error) {
+	if boardID == "" {
+		return nil, validationError("board_id", "board_id is required")
+	}
+
+	b, err := s.boards.GetByID(ctx, boardID, userID)
+	if err != nil {
+		return nil, mapBoardError("get board", err)
+	}
+	return b, nil
+}
+
+// Create создаёт личную доску пользователя.
+func (s *BoardService) Create(ctx context.Context, userID, name string) (*board.Board, error) {
+	return s.create(ctx, &board.Board{OwnerID: userID, Name: name})
+}
+
+// ListByWorkspace возвращает доски пространства, в котором состоит пользователь.
+func (s *BoardService) ListByWorkspace(ctx context.Context, userID, workspaceID string) ([]*board.Board, error) {
+	if _, err := s.member(ctx, userID, workspaceID); err != nil {
+		return nil, err
+	}
+
+	boards, err := s.boards.ListByWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, internalError("list workspace boards", err)
+	}
+	return boards, nil
+}
+
+// CreateInWorkspace создаёт доску в пространстве; создавать доски может любой участник.
+func (s *BoardService) CreateInWorkspace(ctx context.Context, userID, workspaceID, name string) (*board.Board, error) {
+	if _, err := s.member(ctx, userID, workspaceID); err != nil {
+		return nil, err
+	}
+	return s.create(ctx, &board.Board{OwnerID: userID, WorkspaceID: &workspaceID, Name: name})
+}
+
+func (s *BoardService) create(ctx context.Context, b *board.Board) (*board.Board, error) {
+	b.Name = strings.TrimSpace(b.Name)
+	if b.Name == "" {
+		return nil, validationError("name", "name is required")
+	}
+
+	if err := s.boards.Create(ctx, b); err != nil {
+		return nil, internalError("create board", err)
+	}
+	return b, nil
+}
+
+// Transfer переносит доску в пространство workspaceID или, если он nil, в личные доски пользователя.
+// Забрать доску из пространства может его администратор или владелец, перенести в пространство —
+// любой его участник. После переноса владельцем доски становится пользователь.
+func (s *BoardService) Transfer(ctx context.Context, userID, boardID string, workspaceID *string) (*board.Board, error) {
+	if boardID == "" {
+		return nil, validationError("board_id", "board_id is required")
+	}
+	if workspaceID != nil && *workspaceID == "" {
+		return nil, validationError("workspace_id", "workspace_id must not be empty")
+	}
+
+	b, err := s.boards.GetByID(ctx, boardID, userID)
+	if err != nil {
+		return nil, mapBoardError("get board", err)
+	}
+	if b.WorkspaceID == nil && b.OwnerID != userID {
+		// Приглашённые участники видят личную доску, но забрать её себе не могут.
+		return nil, forbiddenError(CodeBoardForbidden, "only the owner can move a personal board", nil)
+	}
+	if b.WorkspaceID != nil {
+		w, err := s.member(ctx, userID, *b.WorkspaceID)
+		if err != nil {
+			return nil, err
+		}
+		if !w.Role.AtLeast(workspace.RoleAdmin) {
+			return nil, forbiddenError(CodeWorkspaceForbidden, "only workspace admins can move boards out of the workspace", nil)
+		}
+	}
+	if workspaceID != nil {
+		if _, err := s.member(ctx, userID, *workspaceID); err != nil {
+			return nil, err
+		}
+	}
+
+	b.OwnerID = userID
+	b.WorkspaceID = workspaceID
+	if err := s.boards.Transfer(ctx, b); err != nil {
+		return nil, mapBoardError("transfer board", err)
+	}
+	return b, nil
+}
+
+// member возвращает пространство с ролью пользователя; недоступное пространство — 404.
+func (s *BoardService) member(ctx context.Context, userID, workspaceID string) (*workspace.Workspace, error) {
+	return workspaceMember(ctx, s.workspaces, userID, workspaceID)
+}
+
+// workspaceMember возвращает пространство, в котором состоит пользователь; без хранилища пространств — 404.
+func workspaceMember(ctx context.Context, workspaces workspace.Repository, userID, workspaceID string) (*workspace.Workspace, error) {
+	if workspaces == nil {
+		return nil, notFoundError(CodeWorkspaceNotFound, "workspace not found", workspace.ErrNotFound)
+	}
+	if workspaceID == "" {
+		return nil, validationError("workspace_id", "workspace_id is required")
+	}
+	w, err := workspaces.GetForMember(ctx, workspaceID, userID)
+	if err != nil {
+		return nil, mapWorkspaceError("get workspace", err)
+	}
+	return w, nil
+}
+
+// Rename меняет название доски, которой может управлять пользователь.
+func (s *BoardService) Rename(ctx context.Context, userID, boardID, name string) (*board.Board, error) {
+	name = strings.TrimSpace(name)
+
+	var v validator
+	v.required("board_id", boardID)
+	v.required("name", name)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	if err := requireBoardManage(ctx, s.boards, userID, boardID); err != nil {
+		return nil, err
+	}
+
+	b := &board.Board{
+		ID:   boardID,
+		Name: name,
+	}
+	if err := s.boards.Update(ctx, b, userID); err != nil {
+		return nil, mapBoardError("update board", err)
+	}
+	return b, nil
+}
+
+// Delete удаляет доску, которой может управлять пользователь.
+func (s *BoardService) Delete(ctx context.Context, userID, boardID string) error {
+	if err := requireBoardManage(ctx, s.boards, userID, boardID); err != nil {
+		return err
+	}
+
+	if err := s.boards.Delete(ctx, boardID, userID); err != nil {
+		return mapBoardError("delete board", err)
+	}
+	return nil
+}
+
+// ListMembers возвращает приглашённых участников доступной пользователю доски.
+func (s *BoardService) ListMembers(ctx context.Context, userID, boardID string) ([]*board.Member, error) {
+	if _, err := s.Get(ctx, userID, boardID); err != nil {
+		return nil, err
+	}
+	members, err := s.boards.ListMembers(ctx, boardID)
+	if err != nil {
+		return nil, internalError("list board members", err)
+	}
+	return members, nil
+}
+
+// RemoveMember исключает участника доски. Покинуть доску может любой участник,
+// исключать других — тот, кто управляет доской.
+func (s *BoardService) RemoveMember(ctx context.Context, userID, boardID, memberID string) error {
+	if boardID == "" {
+		return validationError("board_id", "board_id is required")
+	}
+	if memberID != userID {
+		if err := requireBoardManage(ctx, s.boards, userID, boardID); err != nil {
+			return err
+		}
+	} else if _, err := s.Get(ctx, userID, boardID); err != nil {
+		return err
+	}
+
+	if err := s.boards.RemoveMember(ctx, boardID, memberID); err != nil {
+		if errors.Is(err, board.ErrMemberNotFound) {
+			return notFoundError(CodeBoardMemberNotFound, "board member not found", err)
+		}
+		return internalError("remove board member", err)
+	}
+	return nil
+}
+
+// requireBoardAccess проверяет, что пользователь видит доску и может работать с её колонками и задачами.
+func requireBoardAccess(ctx context.Context, boards BoardStore, userID, boardID string) error {
+	if _, err := boards.GetByID(ctx, boardID, userID); err != nil {
+		return mapBoardError("check board access", err)
+	}
+	return nil
+}
+
+// requireBoardManage проверяет, что пользователь может управлять доской: недоступная доска — 404, доступная без прав — 403.
+func requireBoardManage(ctx context.Context, boards BoardStore, userID, boardID string) error {
+	if boardID == "" {
+		return validationError("board_id", "board_id is required")
+	}
+	ok, err := boards.CanManage(ctx, boardID, userID)
+	if err != nil {
+		return mapBoardError("check board access", err)
+	}
+	if !ok {
+		return forbiddenError(CodeBoardForbidden, "you cannot manage this board", nil)
+	}
+	return nil
+}
+
+func mapBoardError(op string, err error) error {
+	if errors.Is(err, board.ErrNotFound) {
+		return notFoundError(CodeBoardNotFound, "board not found", err)
+	}
+	return internalError(op, err)
+}
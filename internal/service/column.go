@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/column"
+)
+
+// ColumnStore — операции хранилища, необходимые сценариям колонок.
+type ColumnStore interface {
+	ListByBoardOwner(ctx context.Context, boardID, ownerID string) ([]*column.Column, error)
+	CreateInBoard(ctx context.Context, column *column.Column, boardID, ownerID string) error
+	Update(ctx context.Context, c *column.Column, ownerID string) error
+	Delete(ctx context.Context, id, boardID, ownerID string) error
+}
+
+// ColumnService реализует сценарии работы с колонками.
+type ColumnService struct {
+	columns ColumnStore
+	boards  BoardStore
+}
+
+// NewColumnService создаёт сервис колонок; boards проверяет доступ к доске.
+func NewColumnService(columns ColumnStore, boards BoardStore) *ColumnService {
+	return &ColumnService{columns: columns, boards: boards}
+}
+
+// List возвращает колонки доступной пользователю доски.
+func (s *ColumnService) List(ctx context.Context, userID, boardID string) ([]*column.Column, error) {
+	if boardID == "" {
+		return nil, validationError("board_id", "board_id is required")
+	}
+	if err := requireBoardAccess(ctx, s.boards, userID, boardID); err != nil {
+		return nil, err
+	}
+
+	cols, err := s.columns.ListByBoardOwner(ctx, boardID, userID)
+	if err != nil {
+		return nil, internalError("list columns", err)
+	}
+	return cols, nil
+}
+
+// Create добавляет колонку в конец доски пользователя; done отмечает колонку завершённых задач.
+func (s *ColumnService) Create(ctx context.Context, userID, boardID, name string, done bool) (*column.Column, error) {
+	name = strings.TrimSpace(name)
+
+	var v validator
+	v.required("board_id", boardID)
+	v.required("name", name)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	if err := requireBoardAccess(ctx, s.boards, userID, boardID); err != nil {
+		return nil, err
+	}
+
+	c := &column.Column{Name: name, Done: done}
+	if err := s.columns.CreateInBoard(ctx, c, boardID, userID); err != nil {
+		if errors.Is(err, column.ErrNotFound) {
+			return nil, notFoundError(CodeBoardNotFound, "board not found", err)
+		}
+		return nil, internalError("create column", err)
+	}
+	return c, nil
+}
+
+// Update меняет название колонки и, если done не nil, признак колонки завершённых задач.
+func (s *ColumnService) Update(ctx context.Context, userID, boardID, columnID, name string, done *bool) (*column.Column, error) {
+	name = strings.TrimSpace(name)
+
+	var v validator
+	v.required("board_id", boardID)
+	v.required("column_id", columnID)
+	v.required("name", name)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	if err := requireBoardAccess(ctx, s.boards, userID, boardID); err != nil {
+		return nil, err
+	}
+
+	c := &column.Column{
+		ID:      columnID,
+		BoardID: boardID,
+		Name:    name,
+	}
+	if done != nil {
+		c.Done = *done
+	} else {
+		cur, err := s.find(ctx, userID, boardID, columnID)
+		if err != nil {
+			return nil, err
+		}
+		c.Done = cur.Done
+	}
+	if err := s.columns.Update(ctx, c, userID); err != nil {
+		return nil, mapColumnError("update column", err)
+	}
+	return c, nil
+}
+
+// Delete удаляет колонку вместе с её задачами.
+func (s *ColumnService) Delete(ctx context.Context, userID, boardID, columnID string) error {
+	var v validator
+	v.required("board_id", boardID)
+	v.required("column_id", columnID)
+	if err := v.err(); err != nil {
+		return err
+	}
+	if err := requireBoardAccess(ctx, s.boards, userID, boardID); err != nil {
+		return err
+	}
+
+	if err := s.columns.Delete(ctx, columnID, boardID, userID); err != nil {
+		return mapColumnError("delete column", err)
+	}
+	return nil
+}
+
+// find возвращает колонку доски пользователя.
+func (s *ColumnService) find(ctx context.Context, userID, boardID, columnID string) (*column.Column, error) {
+	cols, err := s.columns.ListByBoardOwner(ctx, boardID, userID)
+	if err != nil {
+		return nil, internalError("list columns", err)
+	}
+	for _, c := range cols {
+		if c.ID == columnID {
+			return c, nil
+		}
+	}
+	return nil, notFoundError(CodeColumnNotFound, "column not found", column.ErrNotFound)
+}
+
+func mapColumnError(op string, err error) error {
+	if errors.Is(err, column.ErrNotFound) {
+		return notFoundError(CodeColumnNotFound, "column not found", err)
+	}
+	return internalError(op, err)
+}
@@ -0,0 +1,7 @@
+// Package service содержит прикладные сценарии (use cases) поверх доменных репозиториев.
+//
+// Сервисы отвечают за нормализацию и валидацию входных данных, проверку доступа
+// и перевод ошибок хранилища в типизированные ошибки (*Error). Транзакционная граница
+// каждого сценария совпадает с вызовом репозитория, поэтому сервисы можно переиспользовать
+// из HTTP, CLI или фоновых задач без знания о транспорте.
+package service
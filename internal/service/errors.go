@@ -0,0 +1,199 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Kind классифицирует ошибку сценария независимо от транспорта.
+type Kind uint8
+
+const (
+	// KindInternal — непредвиденная ошибка (БД, подпись токена и т.п.).
+	KindInternal Kind = iota
+	// KindValidation — входные данные не прошли проверку.
+	KindValidation
+	// KindUnauthorized — клиент не аутентифицирован или передал неверные учётные данные.
+	KindUnauthorized
+	// KindForbidden — действие запрещено для текущего пользователя.
+	KindForbidden
+	// KindNotFound — сущность не найдена или недоступна пользователю.
+	KindNotFound
+	// KindConflict — действие конфликтует с текущим состоянием (например, email уже занят).
+	KindConflict
+	// KindTooManyRequests — действие временно запрещено из-за частых попыток (блокировка входа).
+	KindTooManyRequests
+)
+
+// Стабильные машиночитаемые коды ошибок. Клиенты опираются на них, поэтому не переименовываем.
+const (
+	CodeInternal             = "internal_error"
+	CodeValidation           = "validation_failed"
+	CodeInvalidCredentials   = "invalid_credentials"
+	CodeEmailAlreadyUsed     = "email_already_used"
+	CodeBoardNotFound        = "board_not_found"
+	CodeColumnNotFound       = "column_not_found"
+	CodeTaskNotFound         = "task_not_found"
+	CodeAccountLocked        = "account_locked"
+	CodeInvalidToken         = "invalid_token"
+	CodeEmailNotVerified     = "email_not_verified"
+	CodeUserNotFound         = "user_not_found"
+	CodeAccessTokenNotFound  = "access_token_not_found"
+	CodeInvalidChallenge     = "invalid_challenge"
+	CodeInvalidTwoFactorCode = "invalid_two_factor_code"
+	CodeTwoFactorEnabled     = "two_factor_already_enabled"
+	CodeTwoFactorNotEnabled  = "two_factor_not_enabled"
+	CodeSessionNotFound      = "session_not_found"
+	CodeWorkspaceNotFound    = "workspace_not_found"
+	CodeMemberNotFound       = "workspace_member_not_found"
+	CodeMemberExists         = "workspace_member_exists"
+	CodeWorkspaceForbidden   = "workspace_permission_denied"
+	CodeWorkspaceNotEmpty    = "workspace_not_empty"
+	CodeLastOwner            = "workspace_last_owner"
+	CodeBoardForbidden       = "board_permission_denied"
+	CodeBoardMemberNotFound  = "board_member_not_found"
+	CodeBoardMemberExists    = "board_member_exists"
+	CodeInvitationNotFound   = "invitation_not_found"
+	CodeShareLinkNotFound    = "share_link_not_found"
+	CodeTaskVersionNotFound  = "task_version_not_found"
+	CodeTrashItemNotFound    = "trash_item_not_found"
+	CodeColumnInTrash        = "column_in_trash"
+	CodePasswordNotSet       = "password_not_set"
+)
+
+// FieldViolation описывает ошибку валидации конкретного поля.
+type FieldViolation struct {
+	Field   string
+	Message string
+}
+
+// Error — типизированная ошибка сценария.
+// Code и Message безопасно отдавать клиенту, Err хранит исходную причину для логов и errors.Is.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Fields  []FieldViolation
+	// RetryAfter — через сколько можно повторить действие (для KindTooManyRequests).
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// KindOf возвращает вид ошибки сценария; для любых других ошибок — KindInternal.
+func KindOf(err error) Kind {
+	var se *Error
+	if errors.As(err, &se) {
+		return se.Kind
+	}
+	return KindInternal
+}
+
+// CodeOf возвращает машиночитаемый код ошибки сценария.
+func CodeOf(err error) string {
+	var se *Error
+	if errors.As(err, &se) && se.Kind != KindInternal {
+		return se.Code
+	}
+	return CodeInternal
+}
+
+// MessageOf возвращает клиентское сообщение ошибки сценария.
+func MessageOf(err error) string {
+	var se *Error
+	if errors.As(err, &se) && se.Kind != KindInternal {
+		return se.Message
+	}
+	return "internal server error"
+}
+
+// FieldsOf возвращает ошибки валидации по полям, если они есть.
+func FieldsOf(err error) []FieldViolation {
+	var se *Error
+	if errors.As(err, &se) {
+		return se.Fields
+	}
+	return nil
+}
+
+// RetryAfterOf возвращает, через сколько можно повторить действие; 0 — не задано.
+func RetryAfterOf(err error) time.Duration {
+	var se *Error
+	if errors.As(err, &se) {
+		return se.RetryAfter
+	}
+	return 0
+}
+
+// validator накапливает нарушения по полям, чтобы вернуть их одной ошибкой.
+type validator struct {
+	fields []FieldViolation
+}
+
+func (v *validator) required(field, value string) {
+	if value == "" {
+		v.add(field, field+" is required")
+	}
+}
+
+func (v *validator) add(field, msg string) {
+	v.fields = append(v.fields, FieldViolation{Field: field, Message: msg})
+}
+
+func (v *validator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	msgs := make([]string, 0, len(v.fields))
+	for _, f := range v.fields {
+		msgs = append(msgs, f.Message)
+	}
+	return &Error{
+		Kind:    KindValidation,
+		Code:    CodeValidation,
+		Message: strings.Join(msgs, "; "),
+		Fields:  v.fields,
+	}
+}
+
+func validationError(field, msg string) error {
+	var v validator
+	v.add(field, msg)
+	return v.err()
+}
+
+func unauthorizedError(code, msg string, err error) error {
+	return &Error{Kind: KindUnauthorized, Code: code, Message: msg, Err: err}
+}
+
+func forbiddenError(code, msg string, err error) error {
+	return &Error{Kind: KindForbidden, Code: code, Message: msg, Err: err}
+}
+
+func notFoundError(code, msg string, err error) error {
+	return &Error{Kind: KindNotFound, Code: code, Message: msg, Err: err}
+}
+
+func conflictError(code, msg string, err error) error {
+	return &Error{Kind: KindConflict, Code: code, Message: msg, Err: err}
+}
+
+func tooManyRequestsError(code, msg string, retryAfter time.Duration) error {
+	return &Error{Kind: KindTooManyRequests, Code: code, Message: msg, RetryAfter: retryAfter}
+}
+
+// internalError оборачивает неожиданную ошибку, сохраняя название операции для логов.
+func internalError(op string, err error) error {
+	return &Error{Kind: KindInternal, Code: CodeInternal, Message: op, Err: err}
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	netmail "net/mail"
+	"strings"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/invitation"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	"github.com/VladislavDraga398/kanban-backend/internal/logging"
+	"github.com/VladislavDraga398/kanban-backend/internal/mail"
+)
+
+// defaultInviteTTL — срок действия приглашения, если EmailSettings.InviteTTL не задан.
+const defaultInviteTTL = 7 * 24 * time.Hour
+
+// InvitationService приглашает пользователей на доски по email. Ссылка из письма подходит
+// и зарегистрированному пользователю, и тому, кто регистрируется по приглашению.
+type InvitationService struct {
+	invitations invitation.Repository
+	boards      BoardStore
+	users       user.Repository
+	mailer      mail.Mailer
+	settings    EmailSettings
+	now         func() time.Time
+}
+
+// NewInvitationService создаёт сервис приглашений.
+func NewInvitationService(invitations invitation.Repository, boards BoardStore, users user.Repository, mailer mail.Mailer, settings EmailSettings) *InvitationService {
+	if settings.InviteTTL <= 0 {
+		settings.InviteTTL = defaultInviteTTL
+	}
+	return &InvitationService{
+		invitations: invitations,
+		boards:      boards,
+		users:       users,
+		mailer:      mailer,
+		settings:    settings,
+		now:         time.Now,
+	}
+}
+
+// Create приглашает email на доску и отправляет письмо со ссылкой. Приглашать может тот,
+// кто управляет доской; повторное приглашение того же адреса заменяет прежнее. Пустая роль — member.
+func (s *InvitationService) Create(ctx context.Context, userID, boardID, email string, role board.Role) (*invitation.Invitation, error) {
+	email = strings.TrimSpace(email)
+	if role == "" {
+		role = board.RoleMember
+	}
+
+	var v validator
+	v.required("board_id", boardID)
+	if email == "" {
+		v.add("email", "email is required")
+	} else if addr, err := netmail.ParseAddress(email); err != nil || addr.Address != email {
+		v.add("email", "must be a valid email address")
+	}
+	if !role.Valid() {
+		v.add("role", "must be one of member, admin")
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.requireManage(ctx, userID, boardID); err != nil {
+		return nil, err
+	}
+	if u, err := s.users.GetByEmail(ctx, email); err == nil {
+		if _, err := s.boards.GetByID(ctx, boardID, u.ID); err == nil {
+			return nil, conflictError(CodeBoardMemberExists, "user already has access to the board", nil)
+		}
+	} else if !errors.Is(err, user.ErrNotFound) {
+		return nil, internalError("get user", err)
+	}
+
+	raw, hash, err := auth.NewOpaqueToken()
+	if err != nil {
+		return nil, internalError("generate token", err)
+	}
+	inv := &invitation.Invitation{
+		BoardID:   boardID,
+		Email:     email,
+		Role:      role,
+		InvitedBy: userID,
+		Hash:      hash,
+		ExpiresAt: s.now().Add(s.settings.InviteTTL),
+	}
+	if err := s.invitations.Create(ctx, inv); err != nil {
+		return nil, internalError("create invitation", err)
+	}
+
+	inviter := "Пользователь Kanban"
+	if u, err := s.users.GetByID(ctx, userID); err == nil {
+		inviter = u.Email
+		if u.DisplayName != "" {
+			inviter = u.DisplayName + " (" + u.Email + ")"
+		}
+	}
+	deliver(ctx, s.mailer, mail.Message{
+		To:      email,
+		Subject: fmt.Sprintf("Приглашение на доску «%s» в Kanban", inv.BoardName),
+		Body: fmt.Sprintf("%s приглашает вас на доску «%s».\n\nЧтобы принять приглашение, перейдите по ссылке:\n\n%s\n\n"+
+			"Если у вас ещё нет аккаунта, зарегистрируйтесь по этой же ссылке. Приглашение действует %s "+
+			"и сработает один раз; по ссылке его можно и отклонить.\n",
+			inviter, inv.BoardName, tokenLink(s.settings.BaseURL, "/accept-invitation", raw), s.settings.InviteTTL),
+	})
+	return inv, nil
+}
+
+// List возвращает действующие приглашения доски.
+func (s *InvitationService) List(ctx context.Context, userID, boardID string) ([]*invitation.Invitation, error) {
+	if err := s.requireManage(ctx, userID, boardID); err != nil {
+		return nil, err
+	}
+	list, err := s.invitations.ListPending(ctx, boardID, s.now())
+	if err != nil {
+		return nil, internalError("list invitations", err)
+	}
+	return list, nil
+}
+
+// Revoke отзывает действующее приглашение: ссылка из письма перестаёт работать.
+func (s *InvitationService) Revoke(ctx context.Context, userID, boardID, id string) error {
+	if id == "" {
+		return validationError("invitation_id", "invitation_id is required")
+	}
+	if err := s.requireManage(ctx, userID, boardID); err != nil {
+		return err
+	}
+	if err := s.invitations.Revoke(ctx, id, boardID); err != nil {
+		if errors.Is(err, invitation.ErrNotFound) {
+			return notFoundError(CodeInvitationNotFound, "invitation not found", err)
+		}
+		return internalError("revoke invitation", err)
+	}
+	return nil
+}
+
+// Accept принимает приглашение от имени пользователя и возвращает доску.
+func (s *InvitationService) Accept(ctx context.Context, userID, token string) (*board.Board, error) {
+	if err := requiredToken(token); err != nil {
+		return nil, err
+	}
+	inv, err := s.invitations.Accept(ctx, auth.HashOpaqueToken(token), userID, s.now())
+	if err != nil {
+		return nil, invitationError("accept invitation", err)
+	}
+	b, err := s.boards.GetByID(ctx, inv.BoardID, userID)
+	if err != nil {
+		return nil, mapBoardError("get board", err)
+	}
+	return b, nil
+}
+
+// Decline отклоняет приглашение; аккаунт для этого не нужен.
+func (s *InvitationService) Decline(ctx context.Context, token string) error {
+	if err := requiredToken(token); err != nil {
+		return err
+	}
+	if _, err := s.invitations.Decline(ctx, auth.HashOpaqueToken(token), s.now()); err != nil {
+		return invitationError("decline invitation", err)
+	}
+	return nil
+}
+
+// find возвращает действующее приглашение по токену — для регистрации по приглашению.
+func (s *InvitationService) find(ctx context.Context, token string) (*invitation.Invitation, error) {
+	inv, err := s.invitations.FindPending(ctx, auth.HashOpaqueToken(token), s.now())
+	if err != nil {
+		return nil, invitationError("find invitation", err)
+	}
+	return inv, nil
+}
+
+// acceptOnRegister принимает приглашение за только что зарегистрированного пользователя. Регистрация
+// к этому моменту уже состоялась, поэтому сбой (например, приглашение успели отозвать) только логируется.
+func (s *InvitationService) acceptOnRegister(ctx context.Context, userID, token string) {
+	if _, err := s.invitations.Accept(ctx, auth.HashOpaqueToken(token), userID, s.now()); err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "failed to accept invitation on register", "error", err)
+	}
+}
+
+func (s *InvitationService) requireManage(ctx context.Context, userID, boardID string) error {
+	return requireBoardManage(ctx, s.boards, userID, boardID)
+}
+
+// invitationError превращает недействительное приглашение в 400 invalid_token, остальное — во внутреннюю ошибку.
+func invitationError(op string, err error) error {
+	if errors.Is(err, invitation.ErrInvalid) {
+		return &Error{Kind: KindValidation, Code: CodeInvalidToken, Message: "invitation is invalid or expired", Err: err}
+	}
+	return internalError(op, err)
+}
@@ -0,0 +1,24 @@
+package service
+
+// Recorder получает доменные события сценариев, например для метрик.
+type Recorder interface {
+	TaskCreated()
+	TaskMoved()
+	LoginSucceeded()
+	LoginFailed()
+}
+
+// nopRecorder — получатель по умолчанию, игнорирующий события.
+type nopRecorder struct{}
+
+func (nopRecorder) TaskCreated()    {}
+func (nopRecorder) TaskMoved()      {}
+func (nopRecorder) LoginSucceeded() {}
+func (nopRecorder) LoginFailed()    {}
+
+func recorderOrNop(r Recorder) Recorder {
+	if r == nil {
+		return nopRecorder{}
+	}
+	return r
+}
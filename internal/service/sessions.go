@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/session"
+	"github.com/VladislavDraga398/kanban-backend/internal/logging"
+)
+
+const (
+	// sessionCacheTTL — сколько проверка токена доверяет закэшированному состоянию сессии, не обращаясь к базе.
+	// Отзыв на этом экземпляре виден сразу, на остальных — не позже чем через этот интервал.
+	sessionCacheTTL = 30 * time.Second
+	// sessionCacheSize — предел числа закэшированных сессий; при переполнении кэш очищается целиком.
+	sessionCacheSize   = 10000
+	maxUserAgentLength = 512
+)
+
+// ClientInfo — устройство, с которого выполняется вход; сохраняется в сессии.
+type ClientInfo struct {
+	UserAgent string
+	IP        string
+}
+
+type clientInfoKey struct{}
+
+// WithClientInfo кладёт в контекст сведения о клиенте для создаваемой при входе сессии.
+func WithClientInfo(ctx context.Context, c ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoKey{}, c)
+}
+
+func clientInfoFromContext(ctx context.Context) ClientInfo {
+	c, _ := ctx.Value(clientInfoKey{}).(ClientInfo)
+	return c
+}
+
+// SessionService ведёт сессии входа: создаёт их при выдаче токена, перечисляет, отзывает
+// и проверяет для middleware, что сессия токена ещё действует.
+type SessionService struct {
+	repo session.Repository
+	now  func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cachedSession
+}
+
+type cachedSession struct {
+	userID    string
+	active    bool
+	expiresAt time.Time
+	checkedAt time.Time
+}
+
+// NewSessionService создаёт сервис сессий.
+func NewSessionService(repo session.Repository) *SessionService {
+	return &SessionService{repo: repo, now: time.Now, cache: map[string]cachedSession{}}
+}
+
+// Start создаёт сессию пользователя со сроком ttl для клиента из контекста (WithClientInfo).
+func (s *SessionService) Start(ctx context.Context, userID string, ttl time.Duration) (*session.Session, error) {
+	c := clientInfoFromContext(ctx)
+	sess := &session.Session{
+		UserID:    userID,
+		UserAgent: truncateRunes(c.UserAgent, maxUserAgentLength),
+		IP:        c.IP,
+		ExpiresAt: s.now().Add(ttl),
+	}
+	if err := s.repo.Create(ctx, sess); err != nil {
+		return nil, internalError("create session", err)
+	}
+	s.remember(sess.ID, cachedSession{userID: userID, active: true, expiresAt: sess.ExpiresAt, checkedAt: s.now()})
+	return sess, nil
+}
+
+// List возвращает активные сессии пользователя.
+func (s *SessionService) List(ctx context.Context, userID string) ([]*session.Session, error) {
+	sessions, err := s.repo.ListActive(ctx, userID, s.now())
+	if err != nil {
+		return nil, internalError("list sessions", err)
+	}
+	return sessions, nil
+}
+
+// Revoke отзывает сессию пользователя; её токен перестаёт приниматься.
+func (s *SessionService) Revoke(ctx context.Context, userID, id string) error {
+	if err := s.repo.Revoke(ctx, id, userID); err != nil {
+		if errors.Is(err, session.ErrNotFound) {
+			return notFoundError(CodeSessionNotFound, "session not found", err)
+		}
+		return internalError("revoke session", err)
+	}
+	s.forget(id)
+	return nil
+}
+
+// RevokeAll отзывает все сессии пользователя, кроме exceptID (пусто — все), и возвращает их число.
+func (s *SessionService) RevokeAll(ctx context.Context, userID, exceptID string) (int, error) {
+	ids, err := s.repo.RevokeAll(ctx, userID, exceptID)
+	if err != nil {
+		return 0, internalError("revoke sessions", err)
+	}
+	s.forget(ids...)
+	return len(ids), nil
+}
+
+// VerifySession проверяет, что сессия токена принадлежит пользователю и не отозвана.
+// Для отозванной, истёкшей или неизвестной сессии возвращает auth.ErrSessionRevoked.
+func (s *SessionService) VerifySession(ctx context.Context, userID, id string) error {
+	now := s.now()
+	s.mu.Lock()
+	c, ok := s.cache[id]
+	s.mu.Unlock()
+	if !ok || now.Sub(c.checkedAt) >= sessionCacheTTL {
+		sess, err := s.repo.Get(ctx, id)
+		switch {
+		case errors.Is(err, session.ErrNotFound):
+			c = cachedSession{checkedAt: now}
+		case err != nil:
+			return fmt.Errorf("get session: %w", err)
+		default:
+			c = cachedSession{userID: sess.UserID, active: sess.RevokedAt == nil, expiresAt: sess.ExpiresAt, checkedAt: now}
+			if c.active && now.Sub(sess.LastSeenAt) >= lastUsedResolution {
+				// Отметка активности вспомогательная: её сбой не должен отклонять запрос.
+				if err := s.repo.TouchLastSeen(ctx, id, now); err != nil {
+					logging.FromContext(ctx).WarnContext(ctx, "session last-seen update failed", "error", err)
+				}
+			}
+		}
+		s.remember(id, c)
+	}
+
+	if !c.active || c.userID != userID || !now.Before(c.expiresAt) {
+		return auth.ErrSessionRevoked
+	}
+	return nil
+}
+
+func (s *SessionService) remember(id string, c cachedSession) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.cache) >= sessionCacheSize {
+		clear(s.cache)
+	}
+	s.cache[id] = c
+}
+
+func (s *SessionService) forget(ids ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range ids {
+		delete(s.cache, id)
+	}
+}
+
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/sharelink"
+)
+
+// shareLinkHintLength — сколько первых символов токена показывать в списке ссылок.
+const shareLinkHintLength = 4
+
+// ShareLinkService выпускает и отзывает публичные ссылки на доски и отдаёт доску по ссылке без входа.
+type ShareLinkService struct {
+	links  sharelink.Repository
+	boards BoardStore
+	now    func() time.Time
+}
+
+// NewShareLinkService создаёт сервис публичных ссылок.
+func NewShareLinkService(links sharelink.Repository, boards BoardStore) *ShareLinkService {
+	return &ShareLinkService{links: links, boards: boards, now: time.Now}
+}
+
+// Create выпускает ссылку на доску, которой управляет пользователь; expiresAt nil — бессрочная ссылка.
+// Открытое значение токена возвращается только здесь: храним лишь хэш.
+func (s *ShareLinkService) Create(ctx context.Context, userID, boardID string, expiresAt *time.Time) (*sharelink.Link, string, error) {
+	if expiresAt != nil && !expiresAt.After(s.now()) {
+		return nil, "", validationError("expires_at", "must be in the future")
+	}
+	if err := requireBoardManage(ctx, s.boards, userID, boardID); err != nil {
+		return nil, "", err
+	}
+
+	raw, hash, err := auth.NewOpaqueToken()
+	if err != nil {
+		return nil, "", internalError("generate share token", err)
+	}
+	l := &sharelink.Link{
+		BoardID:   boardID,
+		CreatedBy: userID,
+		Hash:      hash,
+		Hint:      raw[:shareLinkHintLength],
+		ExpiresAt: expiresAt,
+	}
+	if err := s.links.Create(ctx, l); err != nil {
+		return nil, "", internalError("create share link", err)
+	}
+	return l, raw, nil
+}
+
+// List возвращает ссылки доски, включая истёкшие.
+func (s *ShareLinkService) List(ctx context.Context, userID, boardID string) ([]*sharelink.Link, error) {
+	if err := requireBoardManage(ctx, s.boards, userID, boardID); err != nil {
+		return nil, err
+	}
+	links, err := s.links.ListByBoard(ctx, boardID)
+	if err != nil {
+		return nil, internalError("list share links", err)
+	}
+	return links, nil
+}
+
+// Revoke отзывает ссылку: доска по ней сразу перестаёт открываться.
+func (s *ShareLinkService) Revoke(ctx context.Context, userID, boardID, id string) error {
+	if id == "" {
+		return validationError("link_id", "link_id is required")
+	}
+	if err := requireBoardManage(ctx, s.boards, userID, boardID); err != nil {
+		return err
+	}
+	if err := s.links.Delete(ctx, id, boardID); err != nil {
+		if errors.Is(err, sharelink.ErrNotFound) {
+			return notFoundError(CodeShareLinkNotFound, "share link not found", err)
+		}
+		return internalError("delete share link", err)
+	}
+	return nil
+}
+
+// Snapshot возвращает доску с колонками и задачами по токену ссылки. Неизвестная, отозванная
+// и истёкшая ссылки неотличимы друг от друга — 404.
+func (s *ShareLinkService) Snapshot(ctx context.Context, token string) (*sharelink.Snapshot, error) {
+	if token == "" {
+		return nil, notFoundError(CodeShareLinkNotFound, "share link not found", sharelink.ErrNotFound)
+	}
+	snap, err := s.links.Snapshot(ctx, auth.HashOpaqueToken(token), s.now())
+	if err != nil {
+		if errors.Is(err, sharelink.ErrNotFound) {
+			return nil, notFoundError(CodeShareLinkNotFound, "share link not found", err)
+		}
+		return nil, internalError("get shared board", err)
+	}
+	return snap, nil
+}
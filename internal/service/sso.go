@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	"github.com/VladislavDraga398/kanban-backend/internal/logging"
+)
+
+// ExternalLogin — пользователь, которого аутентифицировал внешний провайдер входа (OIDC).
+type ExternalLogin struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// WithIdentities включает вход через внешних провайдеров с привязкой к пользователям.
+func (s *AuthService) WithIdentities(identities user.IdentityRepository) *AuthService {
+	s.identities = identities
+	return s
+}
+
+// LoginExternal находит пользователя по привязке к провайдеру, а при первом входе привязывает
+// существующего пользователя с тем же подтверждённым email или создаёт нового без пароля.
+// Если локальный адрес не был подтверждён, пароль и сессии прежнего регистратора сбрасываются.
+// При включённой 2FA вместо сессии возвращается challenge, как и при входе по паролю.
+func (s *AuthService) LoginExternal(ctx context.Context, in ExternalLogin) (*Session, error) {
+	if s.identities == nil {
+		return nil, internalError("external login", errors.New("identity repository is not configured"))
+	}
+
+	var u *user.User
+	id, err := s.identities.Find(ctx, in.Provider, in.Subject)
+	switch {
+	case err == nil:
+		u, err = s.users.GetByID(ctx, id.UserID)
+		if err != nil {
+			return nil, internalError("get linked user", err)
+		}
+	case errors.Is(err, user.ErrIdentityNotFound):
+		u, err = s.linkExternal(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, internalError("find identity", err)
+	}
+	if sess, err := s.requireSecondFactor(ctx, u); sess != nil || err != nil {
+		return sess, err
+	}
+
+	sess, err := s.issue(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	s.recorder.LoginSucceeded()
+	return sess, nil
+}
+
+func (s *AuthService) linkExternal(ctx context.Context, in ExternalLogin) (*user.User, error) {
+	email := strings.TrimSpace(in.Email)
+	// Привязка по email безопасна, только если провайдер подтвердил владение адресом.
+	if email == "" || !in.EmailVerified {
+		return nil, forbiddenError(CodeEmailNotVerified, "identity provider did not return a verified email", nil)
+	}
+
+	u, err := s.users.GetByEmail(ctx, email)
+	switch {
+	case err == nil:
+	case errors.Is(err, user.ErrNotFound):
+		// Пароль не задаётся: войти такой пользователь может только через провайдера или сбросив пароль.
+		u = &user.User{Email: email, DisplayName: strings.TrimSpace(in.Name)}
+		if err := s.users.Create(ctx, u); err != nil {
+			if errors.Is(err, user.ErrEmailAlreadyUsed) {
+				return nil, conflictError(CodeEmailAlreadyUsed, "email already in use", err)
+			}
+			return nil, internalError("create user", err)
+		}
+		if u.DisplayName != "" {
+			if err := s.users.UpdateProfile(ctx, u); err != nil {
+				logging.FromContext(ctx).WarnContext(ctx, "set display name from identity provider failed", "error", err)
+			}
+		}
+	default:
+		return nil, internalError("get user", err)
+	}
+
+	if !u.EmailVerified() {
+		if u.PasswordHash != "" {
+			if err := s.dropUnverifiedCredentials(ctx, u); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.users.MarkEmailVerified(ctx, u.ID); err != nil {
+			return nil, internalError("mark email verified", err)
+		}
+		now := time.Now()
+		u.EmailVerifiedAt = &now
+	}
+
+	if err := s.identities.Link(ctx, &user.Identity{Provider: in.Provider, Subject: in.Subject, UserID: u.ID, Email: email}); err != nil {
+		return nil, internalError("link identity", err)
+	}
+	return u, nil
+}
+
+// dropUnverifiedCredentials отбирает учётную запись с неподтверждённым email у того, кто её зарегистрировал:
+// адрес мог занять чужой человек до владельца, который теперь подтвердил его через провайдера.
+// Пароль, второй фактор и сессии регистратора перестают действовать.
+func (s *AuthService) dropUnverifiedCredentials(ctx context.Context, u *user.User) error {
+	if err := s.users.UpdatePassword(ctx, u.ID, ""); err != nil {
+		return internalError("clear password", err)
+	}
+	u.PasswordHash = ""
+	if s.twoFactor != nil {
+		if err := s.twoFactor.Delete(ctx, u.ID); err != nil && !errors.Is(err, user.ErrTwoFactorNotFound) {
+			return internalError("delete two-factor settings", err)
+		}
+	}
+	if s.sessions != nil {
+		if _, err := s.sessions.RevokeAll(ctx, u.ID, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
+)
+
+// TaskStore — операции хранилища, необходимые сценариям задач.
+type TaskStore interface {
+	ListByColumnOwner(ctx context.Context, boardID, columnID, ownerID string) ([]*task.Task, error)
+	CreateInColumn(ctx context.Context, task *task.Task, boardID, columnID, ownerID string) error
+	Update(ctx context.Context, task *task.Task, ownerID string) error
+	Delete(ctx context.Context, id, boardID, columnID, ownerID string) error
+	MoveToColumn(ctx context.Context, task *task.Task, columnID, ownerID string) error
+}
+
+// TaskInput — редактируемые поля задачи.
+type TaskInput struct {
+	Title       string
+	Description string
+}
+
+// TaskService реализует сценарии работы с задачами.
+type TaskService struct {
+	tasks    TaskStore
+	boards   BoardStore
+	recorder Recorder
+}
+
+// NewTaskService создаёт сервис задач; boards проверяет доступ к доске.
+func NewTaskService(tasks TaskStore, boards BoardStore) *TaskService {
+	return &TaskService{tasks: tasks, boards: boards, recorder: nopRecorder{}}
+}
+
+// WithRecorder подключает получателя доменных событий (создание и перемещение задач).
+func (s *TaskService) WithRecorder(r Recorder) *TaskService {
+	s.recorder = recorderOrNop(r)
+	return s
+}
+
+// List возвращает задачи колонки доступной пользователю доски.
+func (s *TaskService) List(ctx context.Context, userID, boardID, columnID string) ([]*task.Task, error) {
+	var v validator
+	v.required("board_id", boardID)
+	v.required("column_id", columnID)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	if err := requireBoardAccess(ctx, s.boards, userID, boardID); err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.tasks.ListByColumnOwner(ctx, boardID, columnID, userID)
+	if err != nil {
+		return nil, internalError("list tasks", err)
+	}
+	return tasks, nil
+}
+
+// Create добавляет задачу в конец колонки.
+func (s *TaskService) Create(ctx context.Context, userID, boardID, columnID string, in TaskInput) (*task.Task, error) {
+	in = normalizeTaskInput(in)
+
+	var v validator
+	v.required("board_id", boardID)
+	v.required("column_id", columnID)
+	v.required("title", in.Title)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	if err := requireBoardAccess(ctx, s.boards, userID, boardID); err != nil {
+		return nil, err
+	}
+
+	t := &task.Task{
+		Title:       in.Title,
+		Description: in.Description,
+	}
+	if err := s.tasks.CreateInColumn(ctx, t, boardID, columnID, userID); err != nil {
+		if errors.Is(err, task.ErrNotFound) {
+			return nil, notFoundError(CodeColumnNotFound, "board or column not found", err)
+		}
+		return nil, internalError("create task", err)
+	}
+	s.recorder.TaskCreated()
+	return t, nil
+}
+
+// Update меняет заголовок и описание задачи.
+func (s *TaskService) Update(ctx context.Context, userID, boardID, columnID, taskID string, in TaskInput) (*task.Task, error) {
+	in = normalizeTaskInput(in)
+
+	var v validator
+	v.required("board_id", boardID)
+	v.required("column_id", columnID)
+	v.required("task_id", taskID)
+	v.required("title", in.Title)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	if err := requireBoardAccess(ctx, s.boards, userID, boardID); err != nil {
+		return nil, err
+	}
+
+	t := &task.Task{
+		ID:          taskID,
+		BoardID:     boardID,
+		ColumnID:    columnID,
+		Title:       in.Title,
+		Description: in.Description,
+	}
+	if err := s.tasks.Update(ctx, t, userID); err != nil {
+		return nil, mapTaskError("task not found", "update task", err)
+	}
+	return t, nil
+}
+
+// Delete удаляет задачу.
+func (s *TaskService) Delete(ctx context.Context, userID, boardID, columnID, taskID string) error {
+	var v validator
+	v.required("board_id", boardID)
+	v.required("column_id", columnID)
+	v.required("task_id", taskID)
+	if err := v.err(); err != nil {
+		return err
+	}
+	if err := requireBoardAccess(ctx, s.boards, userID, boardID); err != nil {
+		return err
+	}
+
+	if err := s.tasks.Delete(ctx, taskID, boardID, columnID, userID); err != nil {
+		return mapTaskError("task not found", "delete task", err)
+	}
+	return nil
+}
+
+// Move переносит задачу в конец другой колонки той же доски.
+func (s *TaskService) Move(ctx context.Context, userID, boardID, taskID, columnID string) (*task.Task, error) {
+	columnID = strings.TrimSpace(columnID)
+
+	var v validator
+	v.required("board_id", boardID)
+	v.required("task_id", taskID)
+	v.required("column_id", columnID)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	if err := requireBoardAccess(ctx, s.boards, userID, boardID); err != nil {
+		return nil, err
+	}
+
+	t := &task.Task{
+		ID:      taskID,
+		BoardID: boardID,
+	}
+	if err := s.tasks.MoveToColumn(ctx, t, columnID, userID); err != nil {
+		return nil, mapTaskError("task or column not found", "move task", err)
+	}
+	s.recorder.TaskMoved()
+	return t, nil
+}
+
+func normalizeTaskInput(in TaskInput) TaskInput {
+	in.Title = strings.TrimSpace(in.Title)
+	in.Description = strings.TrimSpace(in.Description)
+	return in
+}
+
+func mapTaskError(notFoundMsg, op string, err error) error {
+	if errors.Is(err, task.ErrNotFound) {
+		return notFoundError(CodeTaskNotFound, notFoundMsg, err)
+	}
+	return internalError(op, err)
+}
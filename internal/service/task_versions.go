@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
+)
+
+// FieldChange — значение поля задачи до и после.
+type FieldChange struct {
+	From string
+	To   string
+}
+
+// VersionDiff — поля задачи, которые отличаются между версиями From и To.
+// From 0 означает пустую задачу: так выглядит сравнение с первой версией.
+type VersionDiff struct {
+	From    int
+	To      int
+	Changes map[string]FieldChange
+}
+
+// TaskVersionService отдаёт историю версий задачи и восстанавливает старые версии.
+type TaskVersionService struct {
+	versions task.VersionRepository
+}
+
+// NewTaskVersionService создаёт сервис версий задач.
+func NewTaskVersionService(versions task.VersionRepository) *TaskVersionService {
+	return &TaskVersionService{versions: versions}
+}
+
+// List возвращает версии задачи, новые первыми.
+func (s *TaskVersionService) List(ctx context.Context, userID, boardID, taskID string) ([]*task.Version, error) {
+	if err := validateTaskRef(boardID, taskID); err != nil {
+		return nil, err
+	}
+	versions, err := s.versions.ListVersions(ctx, boardID, taskID, userID)
+	if err != nil {
+		return nil, mapTaskVersionError("list task versions", err)
+	}
+	return versions, nil
+}
+
+// Diff сравнивает версию с версией against; against nil — с предыдущей версией.
+func (s *TaskVersionService) Diff(ctx context.Context, userID, boardID, taskID string, version int, against *int) (*VersionDiff, error) {
+	from := version - 1
+	if against != nil {
+		from = *against
+	}
+
+	var v validator
+	v.required("board_id", boardID)
+	v.required("task_id", taskID)
+	if version < 1 {
+		v.add("version", "must be a positive integer")
+	}
+	if from < 0 {
+		v.add("against", "must not be negative")
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	to, err := s.versions.GetVersion(ctx, boardID, taskID, version, userID)
+	if err != nil {
+		return nil, mapTaskVersionError("get task version", err)
+	}
+	base := &task.Version{}
+	if from > 0 {
+		if base, err = s.versions.GetVersion(ctx, boardID, taskID, from, userID); err != nil {
+			return nil, mapTaskVersionError("get task version", err)
+		}
+	}
+
+	diff := &VersionDiff{From: from, To: version, Changes: map[string]FieldChange{}}
+	for field, pair := range map[string][2]string{
+		"title":       {base.Title, to.Title},
+		"description": {base.Description, to.Description},
+		"column_id":   {base.ColumnID, to.ColumnID},
+	} {
+		if pair[0] != pair[1] {
+			diff.Changes[field] = FieldChange{From: pair[0], To: pair[1]}
+		}
+	}
+	return diff, nil
+}
+
+// Restore возвращает задаче содержимое версии; результат сохраняется новой версией.
+func (s *TaskVersionService) Restore(ctx context.Context, userID, boardID, taskID string, version int) (*task.Task, error) {
+	if err := validateTaskRef(boardID, taskID); err != nil {
+		return nil, err
+	}
+	if version < 1 {
+		return nil, validationError("version", "must be a positive integer")
+	}
+
+	t := &task.Task{ID: taskID, BoardID: boardID}
+	if err := s.versions.Restore(ctx, t, version, userID); err != nil {
+		return nil, mapTaskVersionError("restore task version", err)
+	}
+	return t, nil
+}
+
+func validateTaskRef(boardID, taskID string) error {
+	var v validator
+	v.required("board_id", boardID)
+	v.required("task_id", taskID)
+	return v.err()
+}
+
+func mapTaskVersionError(op string, err error) error {
+	if errors.Is(err, task.ErrVersionNotFound) {
+		return notFoundError(CodeTaskVersionNotFound, "task version not found", err)
+	}
+	return mapTaskError("task not found", op, err)
+}
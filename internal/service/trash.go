@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/column"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/trash"
+)
+
+// defaultTrashRetention — сколько элементы лежат в корзине, если срок не задан.
+const defaultTrashRetention = 30 * 24 * time.Hour
+
+// TrashService показывает корзину пользователя и возвращает из неё доски, колонки и задачи.
+type TrashService struct {
+	trash     trash.Repository
+	retention time.Duration
+}
+
+// NewTrashService создаёт сервис корзины; retention — срок хранения до окончательного удаления.
+func NewTrashService(items trash.Repository, retention time.Duration) *TrashService {
+	if retention <= 0 {
+		retention = defaultTrashRetention
+	}
+	return &TrashService{trash: items, retention: retention}
+}
+
+// List возвращает корзину пользователя, недавно удалённое первым.
+func (s *TrashService) List(ctx context.Context, userID string) ([]*trash.Item, error) {
+	items, err := s.trash.List(ctx, userID)
+	if err != nil {
+		return nil, internalError("list trash", err)
+	}
+	return items, nil
+}
+
+// PurgeAt — когда элемент, удалённый в deletedAt, будет удалён окончательно.
+func (s *TrashService) PurgeAt(deletedAt time.Time) time.Time {
+	return deletedAt.Add(s.retention)
+}
+
+// Purge окончательно удаляет то, что пролежало в корзине дольше срока хранения на момент now.
+func (s *TrashService) Purge(ctx context.Context, now time.Time) (int64, error) {
+	n, err := s.trash.Purge(ctx, now.Add(-s.retention))
+	if err != nil {
+		return 0, internalError("purge trash", err)
+	}
+	return n, nil
+}
+
+// RestoreBoard возвращает доску из корзины.
+func (s *TrashService) RestoreBoard(ctx context.Context, userID, id string) (*board.Board, error) {
+	if id == "" {
+		return nil, validationError("id", "id is required")
+	}
+	b := &board.Board{ID: id}
+	if err := s.trash.RestoreBoard(ctx, b, userID); err != nil {
+		return nil, mapTrashError("restore board", err)
+	}
+	return b, nil
+}
+
+// RestoreColumn возвращает колонку из корзины вместе с задачами, удалёнными вместе с ней.
+func (s *TrashService) RestoreColumn(ctx context.Context, userID, id string) (*column.Column, error) {
+	if id == "" {
+		return nil, validationError("id", "id is required")
+	}
+	c := &column.Column{ID: id}
+	if err := s.trash.RestoreColumn(ctx, c, userID); err != nil {
+		return nil, mapTrashError("restore column", err)
+	}
+	return c, nil
+}
+
+// RestoreTask возвращает задачу из корзины.
+func (s *TrashService) RestoreTask(ctx context.Context, userID, id string) (*task.Task, error) {
+	if id == "" {
+		return nil, validationError("id", "id is required")
+	}
+	t := &task.Task{ID: id}
+	if err := s.trash.RestoreTask(ctx, t, userID); err != nil {
+		return nil, mapTrashError("restore task", err)
+	}
+	return t, nil
+}
+
+func mapTrashError(op string, err error) error {
+	switch {
+	case errors.Is(err, trash.ErrNotFound):
+		return notFoundError(CodeTrashItemNotFound, "trash item not found", err)
+	case errors.Is(err, trash.ErrParentDeleted):
+		return conflictError(CodeColumnInTrash, "the task's column is in the trash, restore the column first", err)
+	}
+	return internalError(op, err)
+}
@@ -0,0 +1,321 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	"github.com/VladislavDraga398/kanban-backend/internal/logging"
+)
+
+const (
+	// challengeTTL — сколько действует промежуточный токен между паролем и вторым фактором.
+	challengeTTL = 5 * time.Minute
+	// TOTPIssuer — название сервиса в приложении-аутентификаторе.
+	TOTPIssuer = "Kanban"
+)
+
+// WithTwoFactor включает второй фактор при входе для пользователей, подключивших TOTP.
+func (s *AuthService) WithTwoFactor(repo user.TwoFactorRepository) *AuthService {
+	s.twoFactor = repo
+	return s
+}
+
+// challengeKey выводит из JWT-секрета отдельный ключ для промежуточных токенов, чтобы такой токен
+// нельзя было предъявить вместо токена доступа.
+func challengeKey(secret []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("2fa-challenge"))
+	return mac.Sum(nil)
+}
+
+// requireSecondFactor выпускает промежуточный токен, если у пользователя включена 2FA; nil — не требуется.
+func (s *AuthService) requireSecondFactor(ctx context.Context, u *user.User) (*Session, error) {
+	if s.twoFactor == nil {
+		return nil, nil
+	}
+	tf, err := s.twoFactor.Get(ctx, u.ID)
+	if errors.Is(err, user.ErrTwoFactorNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, internalError("get two-factor settings", err)
+	}
+	if !tf.Enabled() {
+		return nil, nil
+	}
+
+	challenge, err := auth.GenerateJWT(u.ID, challengeKey(s.jwtSecret), challengeTTL)
+	if err != nil {
+		return nil, internalError("sign challenge", err)
+	}
+	return &Session{User: u, Challenge: challenge}, nil
+}
+
+// LoginTwoFactor завершает вход вторым фактором: кодом из приложения или одноразовым кодом восстановления.
+func (s *AuthService) LoginTwoFactor(ctx context.Context, challenge, code, recoveryCode string) (*Session, error) {
+	var v validator
+	v.required("challenge_token", challenge)
+	if (code == "") == (recoveryCode == "") {
+		v.add("code", "exactly one of code and recovery_code is required")
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	if s.twoFactor == nil {
+		return nil, internalError("two-factor login", errors.New("two-factor repository is not configured"))
+	}
+
+	userID, err := auth.ParseJWT(challenge, challengeKey(s.jwtSecret))
+	if err != nil {
+		return nil, unauthorizedError(CodeInvalidChallenge, "challenge token is invalid or expired", err)
+	}
+
+	key := "2fa:" + userID
+	if left, err := s.guard.Check(ctx, key); err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "login guard check failed", "error", err)
+	} else if left > 0 {
+		s.recorder.LoginFailed()
+		return nil, tooManyRequestsError(CodeAccountLocked, "too many failed login attempts, try again later", left)
+	}
+
+	tf, err := s.twoFactor.Get(ctx, userID)
+	if err != nil && !errors.Is(err, user.ErrTwoFactorNotFound) {
+		return nil, internalError("get two-factor settings", err)
+	}
+	// 2FA отключили, пока шёл вход: промежуточный токен больше не годится.
+	if tf == nil || !tf.Enabled() {
+		return nil, unauthorizedError(CodeInvalidChallenge, "challenge token is invalid or expired", err)
+	}
+
+	if code != "" {
+		step, ok := auth.ValidateTOTP(tf.Secret, code, time.Now())
+		if ok {
+			if ok, err = s.twoFactor.UseStep(ctx, userID, step); err != nil {
+				return nil, internalError("use totp step", err)
+			}
+		}
+		if !ok {
+			return nil, s.secondFactorFailed(ctx, key, nil)
+		}
+	} else {
+		err := s.twoFactor.UseRecoveryCode(ctx, userID, auth.HashRecoveryCode(recoveryCode))
+		if errors.Is(err, user.ErrRecoveryCodeInvalid) {
+			return nil, s.secondFactorFailed(ctx, key, err)
+		}
+		if err != nil {
+			return nil, internalError("use recovery code", err)
+		}
+	}
+	if err := s.guard.Succeeded(ctx, key); err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "login guard reset failed", "error", err)
+	}
+
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, user.ErrNotFound) {
+			return nil, unauthorizedError(CodeInvalidChallenge, "challenge token is invalid or expired", err)
+		}
+		return nil, internalError("get user", err)
+	}
+
+	sess, err := s.issue(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	s.recorder.LoginSucceeded()
+	return sess, nil
+}
+
+func (s *AuthService) secondFactorFailed(ctx context.Context, key string, cause error) error {
+	s.recorder.LoginFailed()
+	if _, err := s.guard.Failed(ctx, key); err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "login guard update failed", "error", err)
+	}
+	return unauthorizedError(CodeInvalidTwoFactorCode, "two-factor code is incorrect", cause)
+}
+
+// TwoFactorService подключает и отключает TOTP для текущего пользователя (/me/2fa).
+type TwoFactorService struct {
+	users AccountStore
+	repo  user.TwoFactorRepository
+	now   func() time.Time
+}
+
+// NewTwoFactorService создаёт сервис управления двухфакторной аутентификацией.
+func NewTwoFactorService(users AccountStore, repo user.TwoFactorRepository) *TwoFactorService {
+	return &TwoFactorService{users: users, repo: repo, now: time.Now}
+}
+
+// TwoFactorEnrollment — данные для добавления учётной записи в приложение-аутентификатор.
+type TwoFactorEnrollment struct {
+	Secret string
+	URI    string
+}
+
+// Status возвращает настройки 2FA; если пользователь её не подключал — выключенные настройки.
+func (s *TwoFactorService) Status(ctx context.Context, userID string) (*user.TwoFactor, error) {
+	tf, err := s.repo.Get(ctx, userID)
+	if errors.Is(err, user.ErrTwoFactorNotFound) {
+		return &user.TwoFactor{UserID: userID}, nil
+	}
+	if err != nil {
+		return nil, internalError("get two-factor settings", err)
+	}
+	return tf, nil
+}
+
+// Enroll выпускает новый секрет. 2FA включится только после Confirm с первым кодом из приложения.
+func (s *TwoFactorService) Enroll(ctx context.Context, userID string) (*TwoFactorEnrollment, error) {
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, mapUserError("get user", err)
+	}
+	if tf, err := s.Status(ctx, userID); err != nil {
+		return nil, err
+	} else if tf.Enabled() {
+		return nil, conflictError(CodeTwoFactorEnabled, "two-factor authentication is already enabled", nil)
+	}
+
+	secret, err := auth.NewTOTPSecret()
+	if err != nil {
+		return nil, internalError("generate totp secret", err)
+	}
+	if err := s.repo.Begin(ctx, userID, secret); err != nil {
+		return nil, internalError("begin two-factor enrollment", err)
+	}
+	return &TwoFactorEnrollment{Secret: secret, URI: auth.TOTPURI(TOTPIssuer, u.Email, secret)}, nil
+}
+
+// Confirm проверяет первый код, включает 2FA и возвращает коды восстановления (показываются один раз).
+func (s *TwoFactorService) Confirm(ctx context.Context, userID, code string) ([]string, error) {
+	if err := validationRequired("code", code); err != nil {
+		return nil, err
+	}
+	tf, err := s.Status(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tf.Enabled() {
+		return nil, conflictError(CodeTwoFactorEnabled, "two-factor authentication is already enabled", nil)
+	}
+	if tf.Secret == "" {
+		return nil, conflictError(CodeTwoFactorNotEnabled, "two-factor enrollment has not been started", nil)
+	}
+
+	step, ok := auth.ValidateTOTP(tf.Secret, code, s.now())
+	if !ok {
+		return nil, validationError("code", "code is incorrect")
+	}
+	raw, hashes, err := auth.NewRecoveryCodes(auth.RecoveryCodeCount)
+	if err != nil {
+		return nil, internalError("generate recovery codes", err)
+	}
+	if err := s.repo.Confirm(ctx, userID, step, hashes); err != nil {
+		if errors.Is(err, user.ErrTwoFactorNotFound) {
+			return nil, conflictError(CodeTwoFactorEnabled, "two-factor authentication is already enabled", err)
+		}
+		return nil, internalError("confirm two-factor", err)
+	}
+	return raw, nil
+}
+
+// TwoFactorProof подтверждает отключение 2FA и выпуск новых кодов восстановления: паролем, а у учётной
+// записи без пароля (только вход через провайдера) — кодом из приложения или кодом восстановления.
+type TwoFactorProof struct {
+	Password     string
+	Code         string
+	RecoveryCode string
+}
+
+// Disable отключает 2FA после подтверждения.
+func (s *TwoFactorService) Disable(ctx context.Context, userID string, proof TwoFactorProof) error {
+	if err := s.checkEnabled(ctx, userID, proof); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, userID); err != nil && !errors.Is(err, user.ErrTwoFactorNotFound) {
+		return internalError("delete two-factor", err)
+	}
+	return nil
+}
+
+// RegenerateRecoveryCodes заменяет все коды восстановления новыми после подтверждения.
+func (s *TwoFactorService) RegenerateRecoveryCodes(ctx context.Context, userID string, proof TwoFactorProof) ([]string, error) {
+	if err := s.checkEnabled(ctx, userID, proof); err != nil {
+		return nil, err
+	}
+	raw, hashes, err := auth.NewRecoveryCodes(auth.RecoveryCodeCount)
+	if err != nil {
+		return nil, internalError("generate recovery codes", err)
+	}
+	if err := s.repo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, internalError("replace recovery codes", err)
+	}
+	return raw, nil
+}
+
+// checkEnabled проверяет подтверждение и то, что 2FA включена.
+func (s *TwoFactorService) checkEnabled(ctx context.Context, userID string, proof TwoFactorProof) error {
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return mapUserError("get user", err)
+	}
+	if u.PasswordHash != "" {
+		if err := validationRequired("password", proof.Password); err != nil {
+			return err
+		}
+		if err := auth.ComparePasswords(u.PasswordHash, proof.Password); err != nil {
+			return validationError("password", "password is incorrect")
+		}
+	}
+
+	tf, err := s.Status(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !tf.Enabled() {
+		return conflictError(CodeTwoFactorNotEnabled, "two-factor authentication is not enabled", nil)
+	}
+	if u.PasswordHash == "" {
+		return s.checkCode(ctx, userID, tf, proof)
+	}
+	return nil
+}
+
+// checkCode подтверждает действие вторым фактором вместо пароля. Использованный код повторно не подойдёт.
+func (s *TwoFactorService) checkCode(ctx context.Context, userID string, tf *user.TwoFactor, proof TwoFactorProof) error {
+	if (proof.Code == "") == (proof.RecoveryCode == "") {
+		return validationError("code", "account has no password: exactly one of code and recovery_code is required")
+	}
+	if proof.Code != "" {
+		step, ok := auth.ValidateTOTP(tf.Secret, proof.Code, s.now())
+		if ok {
+			var err error
+			if ok, err = s.repo.UseStep(ctx, userID, step); err != nil {
+				return internalError("use totp step", err)
+			}
+		}
+		if !ok {
+			return validationError("code", "code is incorrect")
+		}
+		return nil
+	}
+	err := s.repo.UseRecoveryCode(ctx, userID, auth.HashRecoveryCode(proof.RecoveryCode))
+	if errors.Is(err, user.ErrRecoveryCodeInvalid) {
+		return validationError("recovery_code", "recovery code is incorrect")
+	}
+	if err != nil {
+		return internalError("use recovery code", err)
+	}
+	return nil
+}
+
+func validationRequired(field, value string) error {
+	var v validator
+	v.required(field, value)
+	return v.err()
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/workspace"
+)
+
+const maxWorkspaceNameLength = 100
+
+// WorkspaceService управляет рабочими пространствами и их участниками.
+// Участник видит пространство и его состав, администратор переименовывает пространство
+// и управляет участниками с ролью member, владелец — всем, включая удаление пространства.
+type WorkspaceService struct {
+	workspaces workspace.Repository
+	users      user.Repository
+}
+
+// NewWorkspaceService создаёт сервис рабочих пространств.
+func NewWorkspaceService(workspaces workspace.Repository, users user.Repository) *WorkspaceService {
+	return &WorkspaceService{workspaces: workspaces, users: users}
+}
+
+// List возвращает пространства, в которых состоит пользователь.
+func (s *WorkspaceService) List(ctx context.Context, userID string) ([]*workspace.Workspace, error) {
+	list, err := s.workspaces.ListByMember(ctx, userID)
+	if err != nil {
+		return nil, internalError("list workspaces", err)
+	}
+	return list, nil
+}
+
+// Create создаёт пространство; пользователь становится его владельцем.
+func (s *WorkspaceService) Create(ctx context.Context, userID, name string) (*workspace.Workspace, error) {
+	name, err := workspaceName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &workspace.Workspace{Name: name}
+	if err := s.workspaces.Create(ctx, w, userID); err != nil {
+		return nil, internalError("create workspace", err)
+	}
+	return w, nil
+}
+
+// Get возвращает пространство с ролью пользователя в нём.
+func (s *WorkspaceService) Get(ctx context.Context, userID, workspaceID string) (*workspace.Workspace, error) {
+	return s.require(ctx, userID, workspaceID, workspace.RoleMember)
+}
+
+// Rename меняет название пространства (admin и выше).
+func (s *WorkspaceService) Rename(ctx context.Context, userID, workspaceID, name string) (*workspace.Workspace, error) {
+	name, err := workspaceName(name)
+	if err != nil {
+		return nil, err
+	}
+	w, err := s.require(ctx, userID, workspaceID, workspace.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	w.Name = name
+	if err := s.workspaces.Rename(ctx, w); err != nil {
+		return nil, mapWorkspaceError("rename workspace", err)
+	}
+	return w, nil
+}
+
+// Delete удаляет пространство (только owner). Доски сначала нужно удалить или перенести;
+// удалённые доски остаются в корзине удалившего их пользователя до конца срока хранения.
+func (s *WorkspaceService) Delete(ctx context.Context, userID, workspaceID string) error {
+	if _, err := s.require(ctx, userID, workspaceID, workspace.RoleOwner); err != nil {
+		return err
+	}
+	if err := s.workspaces.Delete(ctx, workspaceID); err != nil {
+		return mapWorkspaceError("delete workspace", err)
+	}
+	return nil
+}
+
+// ListMembers возвращает участников пространства.
+func (s *WorkspaceService) ListMembers(ctx context.Context, userID, workspaceID string) ([]*workspace.Member, error) {
+	if _, err := s.require(ctx, userID, workspaceID, workspace.RoleMember); err != nil {
+		return nil, err
+	}
+	members, err := s.workspaces.ListMembers(ctx, workspaceID)
+	if err != nil {
+		return nil, internalError("list workspace members", err)
+	}
+	return members, nil
+}
+
+// AddMember добавляет в пространство зарегистрированного пользователя с указанным email.
+// Пустая роль означает member.
+func (s *WorkspaceService) AddMember(ctx context.Context, userID, workspaceID, email string, role workspace.Role) (*workspace.Member, error) {
+	email = strings.TrimSpace(email)
+	if role == "" {
+		role = workspace.RoleMember
+	}
+
+	var v validator
+	v.required("email", email)
+	if !role.Valid() {
+		v.add("role", "must be one of member, admin, owner")
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	w, err := s.require(ctx, userID, workspaceID, workspace.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if err := canAssign(w.Role, role); err != nil {
+		return nil, err
+	}
+
+	u, err := s.users.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, user.ErrNotFound) {
+			return nil, notFoundError(CodeUserNotFound, "user not found", err)
+		}
+		return nil, internalError("get user", err)
+	}
+
+	m := &workspace.Member{
+		WorkspaceID: workspaceID,
+		UserID:      u.ID,
+		Email:       u.Email,
+		DisplayName: u.DisplayName,
+		Role:        role,
+	}
+	if err := s.workspaces.AddMember(ctx, m); err != nil {
+		return nil, mapWorkspaceError("add workspace member", err)
+	}
+	return m, nil
+}
+
+// SetMemberRole меняет роль участника. Администратор управляет только ролью member,
+// владелец — любыми ролями; последний владелец не может сложить с себя полномочия.
+func (s *WorkspaceService) SetMemberRole(ctx context.Context, userID, workspaceID, memberID string, role workspace.Role) (*workspace.Member, error) {
+	if !role.Valid() {
+		return nil, validationError("role", "must be one of member, admin, owner")
+	}
+
+	w, err := s.require(ctx, userID, workspaceID, workspace.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	m, err := s.workspaces.GetMember(ctx, workspaceID, memberID)
+	if err != nil {
+		return nil, mapWorkspaceError("get workspace member", err)
+	}
+	// Понизить себя до member можно при любой роли.
+	if memberID != userID || role != workspace.RoleMember {
+		if err := canAssign(w.Role, m.Role); err != nil {
+			return nil, err
+		}
+		if err := canAssign(w.Role, role); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.workspaces.SetMemberRole(ctx, workspaceID, memberID, role); err != nil {
+		return nil, mapWorkspaceError("set workspace member role", err)
+	}
+	m.Role = role
+	return m, nil
+}
+
+// RemoveMember исключает участника. Покинуть пространство может любой участник, кроме последнего
+// владельца; исключать других может администратор (только member) или владелец.
+func (s *WorkspaceService) RemoveMember(ctx context.Context, userID, workspaceID, memberID string) error {
+	w, err := s.require(ctx, userID, workspaceID, workspace.RoleMember)
+	if err != nil {
+		return err
+	}
+	if memberID != userID {
+		if !w.Role.AtLeast(workspace.RoleAdmin) {
+			return forbiddenError(CodeWorkspaceForbidden, "only workspace admins can remove members", nil)
+		}
+		m, err := s.workspaces.GetMember(ctx, workspaceID, memberID)
+		if err != nil {
+			return mapWorkspaceError("get workspace member", err)
+		}
+		if err := canAssign(w.Role, m.Role); err != nil {
+			return err
+		}
+	}
+
+	if err := s.workspaces.RemoveMember(ctx, workspaceID, memberID); err != nil {
+		return mapWorkspaceError("remove workspace member", err)
+	}
+	return nil
+}
+
+// require возвращает пространство, если пользователь состоит в нём с ролью не ниже min.
+func (s *WorkspaceService) require(ctx context.Context, userID, workspaceID string, min workspace.Role) (*workspace.Workspace, error) {
+	if workspaceID == "" {
+		return nil, validationError("workspace_id", "workspace_id is required")
+	}
+	w, err := s.workspaces.GetForMember(ctx, workspaceID, userID)
+	if err != nil {
+		return nil, mapWorkspaceError("get workspace", err)
+	}
+	if !w.Role.AtLeast(min) {
+		return nil, forbiddenError(CodeWorkspaceForbidden, fmt.Sprintf("requires workspace role %s", min), nil)
+	}
+	return w, nil
+}
+
+// canAssign проверяет, что участник с ролью actor может выдать или отозвать роль role:
+// администраторы распоряжаются только ролью member.
+func canAssign(actor, role workspace.Role) error {
+	if role != workspace.RoleMember && !actor.AtLeast(workspace.RoleOwner) {
+		return forbiddenError(CodeWorkspaceForbidden, "only workspace owners can manage admins and owners", nil)
+	}
+	return nil
+}
+
+func workspaceName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	var v validator
+	v.required("name", name)
+	if utf8.RuneCountInString(name) > maxWorkspaceNameLength {
+		v.add("name", fmt.Sprintf("must be at most %d characters long", maxWorkspaceNameLength))
+	}
+	return name, v.err()
+}
+
+func mapWorkspaceError(op string, err error) error {
+	switch {
+	case errors.Is(err, workspace.ErrNotFound):
+		return notFoundError(CodeWorkspaceNotFound, "workspace not found", err)
+	case errors.Is(err, workspace.ErrMemberNotFound):
+		return notFoundError(CodeMemberNotFound, "workspace member not found", err)
+	case errors.Is(err, workspace.ErrMemberExists):
+		return conflictError(CodeMemberExists, "user is already a workspace member", err)
+	case errors.Is(err, workspace.ErrNotEmpty):
+		return conflictError(CodeWorkspaceNotEmpty, "delete or transfer workspace boards first", err)
+	case errors.Is(err, workspace.ErrLastOwner):
+		return conflictError(CodeLastOwner, "workspace must keep at least one owner", err)
+	}
+	return internalError(op, err)
+}
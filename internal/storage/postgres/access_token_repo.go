@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/accesstoken"
+)
+
+// AccessTokenRepository хранит личные токены доступа в таблице access_tokens.
+type AccessTokenRepository struct {
+	db *sql.DB
+}
+
+func NewAccessTokenRepository(db *DB) accesstoken.Repository {
+	return &AccessTokenRepository{db: db.DB}
+}
+
+const accessTokenColumns = `id, user_id, name, token_hash, token_hint, scopes, expires_at, last_used_at, created_at`
+
+func (r *AccessTokenRepository) Create(ctx context.Context, t *accesstoken.Token) error {
+	ctx, span := startSpan(ctx, "AccessTokenRepository.Create")
+	defer span.End()
+
+	const q = `
+		INSERT INTO access_tokens (user_id, name, token_hash, token_hint, scopes, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at;
+	`
+	err := r.db.QueryRowContext(ctx, q, t.UserID, t.Name, t.Hash, t.Hint, joinScopes(t.Scopes), t.ExpiresAt).
+		Scan(&t.ID, &t.CreatedAt)
+	if err != nil {
+		return queryError(ctx, "AccessTokenRepository.Create", err)
+	}
+	return nil
+}
+
+func (r *AccessTokenRepository) ListByUser(ctx context.Context, userID string) ([]*accesstoken.Token, error) {
+	ctx, span := startSpan(ctx, "AccessTokenRepository.ListByUser")
+	defer span.End()
+
+	const q = `SELECT ` + accessTokenColumns + ` FROM access_tokens WHERE user_id = $1 ORDER BY created_at DESC;`
+
+	rows, err := r.db.QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, queryError(ctx, "AccessTokenRepository.ListByUser", err)
+	}
+	defer rows.Close()
+
+	var res []*accesstoken.Token
+	for rows.Next() {
+		t, err := scanAccessToken(rows)
+		if err != nil {
+			return nil, queryError(ctx, "AccessTokenRepository.ListByUser", err)
+		}
+		res = append(res, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, "AccessTokenRepository.ListByUser", err)
+	}
+	return res, nil
+}
+
+func (r *AccessTokenRepository) GetByHash(ctx context.Context, hash string) (*accesstoken.Token, error) {
+	ctx, span := startSpan(ctx, "AccessTokenRepository.GetByHash")
+	defer span.End()
+
+	const q = `SELECT ` + accessTokenColumns + ` FROM access_tokens WHERE token_hash = $1;`
+
+	t, err := scanAccessToken(r.db.QueryRowContext(ctx, q, hash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, accesstoken.ErrNotFound
+		}
+		return nil, queryError(ctx, "AccessTokenRepository.GetByHash", err)
+	}
+	return t, nil
+}
+
+func (r *AccessTokenRepository) Delete(ctx context.Context, id, userID string) error {
+	ctx, span := startSpan(ctx, "AccessTokenRepository.Delete")
+	defer span.End()
+
+	const q = `DELETE FROM access_tokens WHERE id = $1 AND user_id = $2;`
+
+	res, err := r.db.ExecContext(ctx, q, id, userID)
+	if err != nil {
+		return queryError(ctx, "AccessTokenRepository.Delete", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return queryError(ctx, "AccessTokenRepository.Delete", err)
+	}
+	if n == 0 {
+		return accesstoken.ErrNotFound
+	}
+	return nil
+}
+
+func (r *AccessTokenRepository) TouchLastUsed(ctx context.Context, id string, at time.Time) error {
+	ctx, span := startSpan(ctx, "AccessTokenRepository.TouchLastUsed")
+	defer span.End()
+
+	const q = `UPDATE access_tokens SET last_used_at = $2 WHERE id = $1;`
+
+	if _, err := r.db.ExecContext(ctx, q, id, at); err != nil {
+		return queryError(ctx, "AccessTokenRepository.TouchLastUsed", err)
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAccessToken(row rowScanner) (*accesstoken.Token, error) {
+	var (
+		t      accesstoken.Token
+		scopes string
+	)
+	if err := row.Scan(&t.ID, &t.UserID, &t.Name, &t.Hash, &t.Hint, &scopes, &t.ExpiresAt, &t.LastUsedAt, &t.CreatedAt); err != nil {
+		return nil, err
+	}
+	for _, s := range strings.Split(scopes, ",") {
+		if s != "" {
+			t.Scopes = append(t.Scopes, auth.Scope(s))
+		}
+	}
+	return &t, nil
+}
+
+func joinScopes(scopes []auth.Scope) string {
+	parts := make([]string, len(scopes))
+	for i, s := range scopes {
+		parts[i] = string(s)
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/activity"
+)
+
+// ActivityRepository читает журнал действий из таблицы board_activity.
+type ActivityRepository struct {
+	db *sql.DB
+}
+
+func NewActivityRepository(db *DB) activity.Repository {
+	return &ActivityRepository{db: db.DB}
+}
+
+func (r *ActivityRepository) List(ctx context.Context, f activity.Filter) ([]*activity.Entry, error) {
+	ctx, span := startSpan(ctx, "ActivityRepository.List")
+	defer span.End()
+
+	args := []any{f.BoardID}
+	where := []string{"board_id = $1"}
+	if f.TaskID != "" {
+		args = append(args, f.TaskID)
+		where = append(where, fmt.Sprintf("entity_type = 'task' AND entity_id = $%d", len(args)))
+	}
+	if len(f.Actions) > 0 {
+		actions := make([]string, len(f.Actions))
+		for i, a := range f.Actions {
+			actions[i] = string(a)
+		}
+		args = append(args, actions)
+		where = append(where, fmt.Sprintf("action = ANY($%d)", len(args)))
+	}
+	if f.Before > 0 {
+		args = append(args, f.Before)
+		where = append(where, fmt.Sprintf("id < $%d", len(args)))
+	}
+	args = append(args, f.Limit)
+
+	q := `
+		SELECT id, board_id, actor_id, action, entity_type, entity_id, before, after, created_at
+		FROM board_activity
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY id DESC
+		LIMIT $` + fmt.Sprint(len(args)) + `;
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, queryError(ctx, "ActivityRepository.List", err)
+	}
+	defer rows.Close()
+
+	var res []*activity.Entry
+	for rows.Next() {
+		var (
+			e             activity.Entry
+			actorID       sql.NullString
+			before, after []byte
+		)
+		if err := rows.Scan(&e.ID, &e.BoardID, &actorID, &e.Action, &e.EntityType, &e.EntityID, &before, &after, &e.CreatedAt); err != nil {
+			return nil, queryError(ctx, "ActivityRepository.List", err)
+		}
+		e.ActorID = actorID.String
+		if e.Before, err = unmarshalFields(before); err != nil {
+			return nil, queryError(ctx, "ActivityRepository.List", err)
+		}
+		if e.After, err = unmarshalFields(after); err != nil {
+			return nil, queryError(ctx, "ActivityRepository.List", err)
+		}
+		res = append(res, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, "ActivityRepository.List", err)
+	}
+	return res, nil
+}
+
+// recordActivity добавляет запись в журнал в транзакции изменения: если запись не сохранилась,
+// откатывается и само изменение.
+func recordActivity(ctx context.Context, tx *sql.Tx, e activity.Entry) error {
+	before, err := marshalFields(e.Before)
+	if err != nil {
+		return err
+	}
+	after, err := marshalFields(e.After)
+	if err != nil {
+		return err
+	}
+
+	const q = `
+		INSERT INTO board_activity (board_id, actor_id, action, entity_type, entity_id, before, after)
+		VALUES ($1, NULLIF($2, '')::uuid, $3, $4, $5, $6, $7);
+	`
+	qctx, qspan := startQuery(ctx, "recordActivity", q)
+	_, err = tx.ExecContext(qctx, q, e.BoardID, e.ActorID, e.Action, e.EntityType, e.EntityID, before, after)
+	endQuery(qspan, err)
+	return err
+}
+
+// recordChange записывает изменение сущности, если поменялось хотя бы одно поле.
+func recordChange(ctx context.Context, tx *sql.Tx, e activity.Entry, before, after activity.Fields) error {
+	e.Before, e.After = activity.Diff(before, after)
+	if len(e.After) == 0 {
+		return nil
+	}
+	return recordActivity(ctx, tx, e)
+}
+
+func marshalFields(f activity.Fields) ([]byte, error) {
+	if f == nil {
+		return nil, nil
+	}
+	return json.Marshal(f)
+}
+
+func unmarshalFields(raw []byte) (activity.Fields, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	var f activity.Fields
+	if err := json.Unmarshal(raw, &f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
@@ -0,0 +1,391 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/activity"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/column"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
+)
+
+// ArchiveRepository — реализация archive.Repository поверх *sql.DB.
+type ArchiveRepository struct {
+	db *sql.DB
+}
+
+// NewArchiveRepository создаёт репозиторий архива.
+func NewArchiveRepository(db *DB) *ArchiveRepository {
+	return &ArchiveRepository{db: db.DB}
+}
+
+// ListBoards возвращает архивные личные доски пользователя.
+func (r *ArchiveRepository) ListBoards(ctx context.Context, userID string) ([]*board.Board, error) {
+	ctx, span := startSpan(ctx, "ArchiveRepository.ListBoards")
+	defer span.End()
+
+	const q = `
+		SELECT ` + boardColumns + `
+		FROM boards b
+		WHERE b.owner_id = $1 AND b.workspace_id IS NULL AND b.deleted_at IS NULL AND b.archived_at IS NOT NULL
+		ORDER BY b.archived_at DESC, b.id;
+	`
+	return r.listBoards(ctx, "ArchiveRepository.ListBoards", q, userID)
+}
+
+// ListWorkspaceBoards возвращает архивные доски пространства; членство проверяет сервис.
+func (r *ArchiveRepository) ListWorkspaceBoards(ctx context.Context, workspaceID string) ([]*board.Board, error) {
+	ctx, span := startSpan(ctx, "ArchiveRepository.ListWorkspaceBoards")
+	defer span.End()
+
+	const q = `
+		SELECT ` + boardColumns + `
+		FROM boards b
+		WHERE b.workspace_id = $1 AND b.deleted_at IS NULL AND b.archived_at IS NOT NULL
+		ORDER BY b.archived_at DESC, b.id;
+	`
+	return r.listBoards(ctx, "ArchiveRepository.ListWorkspaceBoards", q, workspaceID)
+}
+
+// ListSharedBoards возвращает архивные доски, куда пользователя пригласили.
+func (r *ArchiveRepository) ListSharedBoards(ctx context.Context, userID string) ([]*board.Board, error) {
+	ctx, span := startSpan(ctx, "ArchiveRepository.ListSharedBoards")
+	defer span.End()
+
+	const q = `
+		SELECT b.id, b.owner_id, b.workspace_id, b.name, b.archived_at, b.created_at, b.updated_at
+		FROM boards b
+		JOIN board_members bm ON bm.board_id = b.id
+		WHERE bm.user_id = $1 AND b.deleted_at IS NULL AND b.archived_at IS NOT NULL
+		ORDER BY b.archived_at DESC, b.id;
+	`
+	return r.listBoards(ctx, "ArchiveRepository.ListSharedBoards", q, userID)
+}
+
+func (r *ArchiveRepository) listBoards(ctx context.Context, op, q string, args ...any) ([]*board.Board, error) {
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, queryError(ctx, op, err)
+	}
+	defer rows.Close()
+
+	var res []*board.Board
+	for rows.Next() {
+		b, err := scanBoard(rows)
+		if err != nil {
+			return nil, queryError(ctx, op, err)
+		}
+		res = append(res, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, op, err)
+	}
+	return res, nil
+}
+
+// ListTasks возвращает архивные задачи колонки доступной доски.
+func (r *ArchiveRepository) ListTasks(ctx context.Context, boardID, columnID, userID string) ([]*task.Task, error) {
+	ctx, span := startSpan(ctx, "ArchiveRepository.ListTasks")
+	defer span.End()
+
+	q := `
+		SELECT t.id, t.board_id, t.column_id, t.title, t.description, t.position, t.archived_at, t.created_at, t.updated_at
+		FROM tasks t
+		JOIN boards b ON b.id = t.board_id
+		WHERE t.board_id = $1
+		  AND t.column_id = $2
+		  AND t.deleted_at IS NULL
+		  AND t.archived_at IS NOT NULL
+		  AND ` + boardAccessible("b", "$3") + `
+		ORDER BY t.position, t.created_at;
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, boardID, columnID, userID)
+	if err != nil {
+		return nil, queryError(ctx, "ArchiveRepository.ListTasks", err)
+	}
+	defer rows.Close()
+
+	var res []*task.Task
+	for rows.Next() {
+		var t task.Task
+		if err := rows.Scan(&t.ID, &t.BoardID, &t.ColumnID, &t.Title, &t.Description, &t.Position, &t.ArchivedAt, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return nil, queryError(ctx, "ArchiveRepository.ListTasks", err)
+		}
+		res = append(res, &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, "ArchiveRepository.ListTasks", err)
+	}
+	return res, nil
+}
+
+// ArchiveBoard убирает в архив доску, которой может управлять пользователь.
+func (r *ArchiveRepository) ArchiveBoard(ctx context.Context, b *board.Board, userID string) error {
+	return r.setBoardArchived(ctx, "ArchiveRepository.ArchiveBoard", b, userID, true)
+}
+
+// UnarchiveBoard возвращает доску из архива.
+func (r *ArchiveRepository) UnarchiveBoard(ctx context.Context, b *board.Board, userID string) error {
+	return r.setBoardArchived(ctx, "ArchiveRepository.UnarchiveBoard", b, userID, false)
+}
+
+func (r *ArchiveRepository) setBoardArchived(ctx context.Context, op string, b *board.Board, userID string, archived bool) error {
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, op, err)
+	}
+	defer rollback(ctx, tx)
+
+	lock := `SELECT ` + boardColumns + ` FROM boards b WHERE b.id = $1 AND ` + boardManageable("b", "$2") + ` FOR UPDATE;`
+	cur, err := scanBoard(tx.QueryRowContext(ctx, lock, b.ID, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return board.ErrNotFound
+		}
+		return queryError(ctx, op, err)
+	}
+	// Повторный запрос ничего не меняет и не попадает в журнал.
+	if (cur.ArchivedAt != nil) == archived {
+		*b = *cur
+		return nil
+	}
+
+	const q = `
+		UPDATE boards
+		SET archived_at = CASE WHEN $2::boolean THEN NOW() END, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + boardColumns + `;
+	`
+	updated, err := scanBoard(tx.QueryRowContext(ctx, q, b.ID, archived))
+	if err != nil {
+		return queryError(ctx, op, err)
+	}
+
+	action := activity.BoardUnarchived
+	if archived {
+		action = activity.BoardArchived
+	}
+	err = recordActivity(ctx, tx, activity.Entry{
+		BoardID: updated.ID, ActorID: userID, Action: action,
+		EntityType: activity.EntityBoard, EntityID: updated.ID,
+	})
+	if err != nil {
+		return queryError(ctx, op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return queryError(ctx, op, err)
+	}
+	*b = *updated
+	return nil
+}
+
+// ArchiveTask убирает задачу в архив; она сохраняет колонку и позицию.
+func (r *ArchiveRepository) ArchiveTask(ctx context.Context, t *task.Task, userID string) error {
+	return r.setTaskArchived(ctx, "ArchiveRepository.ArchiveTask", t, userID, true)
+}
+
+// UnarchiveTask возвращает задачу из архива на прежнюю позицию, а если её заняли — в конец колонки.
+func (r *ArchiveRepository) UnarchiveTask(ctx context.Context, t *task.Task, userID string) error {
+	return r.setTaskArchived(ctx, "ArchiveRepository.UnarchiveTask", t, userID, false)
+}
+
+func (r *ArchiveRepository) setTaskArchived(ctx context.Context, op string, t *task.Task, userID string, archived bool) error {
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, op, err)
+	}
+	defer rollback(ctx, tx)
+
+	lock := `
+		SELECT t.id, t.board_id, t.column_id, t.title, t.description, t.position, t.archived_at, t.created_at, t.updated_at
+		FROM tasks t
+		JOIN boards b ON b.id = t.board_id
+		WHERE t.id = $1
+		  AND t.board_id = $2
+		  AND t.deleted_at IS NULL
+		  AND ` + boardAccessible("b", "$3") + `
+		FOR UPDATE OF t;
+	`
+	var cur task.Task
+	err = tx.QueryRowContext(ctx, lock, t.ID, t.BoardID, userID).
+		Scan(&cur.ID, &cur.BoardID, &cur.ColumnID, &cur.Title, &cur.Description, &cur.Position, &cur.ArchivedAt, &cur.CreatedAt, &cur.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return task.ErrNotFound
+		}
+		return queryError(ctx, op, err)
+	}
+	if (cur.ArchivedAt != nil) == archived {
+		*t = cur
+		return nil
+	}
+
+	const q = `
+		UPDATE tasks t
+		SET archived_at = CASE WHEN $2::boolean THEN NOW() END, updated_at = NOW(),
+		    position = CASE
+		        WHEN NOT $2::boolean AND EXISTS (SELECT 1 FROM tasks o WHERE o.column_id = t.column_id AND o.position = t.position AND o.id <> t.id)
+		        THEN (SELECT MAX(o.position) + 1 FROM tasks o WHERE o.column_id = t.column_id)
+		        ELSE t.position
+		    END
+		WHERE t.id = $1
+		RETURNING t.id, t.board_id, t.column_id, t.title, t.description, t.position, t.archived_at, t.created_at, t.updated_at;
+	`
+	err = tx.QueryRowContext(ctx, q, t.ID, archived).
+		Scan(&t.ID, &t.BoardID, &t.ColumnID, &t.Title, &t.Description, &t.Position, &t.ArchivedAt, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		return queryError(ctx, op, err)
+	}
+
+	action := activity.TaskUnarchived
+	if archived {
+		action = activity.TaskArchived
+	}
+	err = recordActivity(ctx, tx, activity.Entry{
+		BoardID: t.BoardID, ActorID: userID, Action: action,
+		EntityType: activity.EntityTask, EntityID: t.ID,
+	})
+	if err != nil {
+		return queryError(ctx, op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return queryError(ctx, op, err)
+	}
+	return nil
+}
+
+// ArchiveColumn убирает в архив все активные задачи колонки доступной доски.
+func (r *ArchiveRepository) ArchiveColumn(ctx context.Context, boardID, columnID, userID string) (int, error) {
+	ctx, span := startSpan(ctx, "ArchiveRepository.ArchiveColumn")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return 0, queryError(ctx, "ArchiveRepository.ArchiveColumn", err)
+	}
+	defer rollback(ctx, tx)
+
+	lock := `
+		SELECT 1
+		FROM columns c
+		JOIN boards b ON b.id = c.board_id
+		WHERE c.id = $1
+		  AND c.board_id = $2
+		  AND c.deleted_at IS NULL
+		  AND ` + boardAccessible("b", "$3") + `
+		FOR UPDATE OF c;
+	`
+	if err := tx.QueryRowContext(ctx, lock, columnID, boardID, userID).Scan(new(int)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, column.ErrNotFound
+		}
+		return 0, queryError(ctx, "ArchiveRepository.ArchiveColumn", err)
+	}
+
+	const q = `
+		UPDATE tasks
+		SET archived_at = NOW(), updated_at = NOW()
+		WHERE column_id = $1 AND deleted_at IS NULL AND archived_at IS NULL
+		RETURNING id, board_id;
+	`
+	archived, err := archiveTasks(ctx, tx, q, userID, columnID)
+	if err != nil {
+		return 0, queryError(ctx, "ArchiveRepository.ArchiveColumn", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, queryError(ctx, "ArchiveRepository.ArchiveColumn", err)
+	}
+	return int(archived), nil
+}
+
+// AutoArchive убирает в архив задачи, которые попали в колонку «готово» раньше before.
+// В журнал они записываются без автора.
+func (r *ArchiveRepository) AutoArchive(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := startSpan(ctx, "ArchiveRepository.AutoArchive")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return 0, queryError(ctx, "ArchiveRepository.AutoArchive", err)
+	}
+	defer rollback(ctx, tx)
+
+	locked, err := tryJobLock(ctx, tx, autoArchiveLockKey)
+	if err != nil {
+		return 0, queryError(ctx, "ArchiveRepository.AutoArchive", err)
+	}
+	if !locked {
+		return 0, nil
+	}
+
+	const q = `
+		UPDATE tasks t
+		SET archived_at = NOW(), updated_at = NOW()
+		FROM columns c, boards b
+		WHERE c.id = t.column_id
+		  AND b.id = t.board_id
+		  AND c.done
+		  AND c.deleted_at IS NULL
+		  AND b.deleted_at IS NULL
+		  AND t.deleted_at IS NULL
+		  AND t.archived_at IS NULL
+		  AND t.column_entered_at < $1
+		RETURNING t.id, t.board_id;
+	`
+	archived, err := archiveTasks(ctx, tx, q, "", before)
+	if err != nil {
+		return 0, queryError(ctx, "ArchiveRepository.AutoArchive", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, queryError(ctx, "ArchiveRepository.AutoArchive", err)
+	}
+	return archived, nil
+}
+
+// archiveTasks выполняет UPDATE, который возвращает id и board_id архивированных задач,
+// и записывает каждую в журнал от имени actorID.
+func archiveTasks(ctx context.Context, tx *sql.Tx, q, actorID string, args ...any) (int64, error) {
+	qctx, qspan := startQuery(ctx, "archiveTasks", q)
+	rows, err := tx.QueryContext(qctx, q, args...)
+	if err != nil {
+		endQuery(qspan, err)
+		return 0, err
+	}
+	var entries []activity.Entry
+	for rows.Next() {
+		e := activity.Entry{ActorID: actorID, Action: activity.TaskArchived, EntityType: activity.EntityTask}
+		if err := rows.Scan(&e.EntityID, &e.BoardID); err != nil {
+			rows.Close()
+			endQuery(qspan, err)
+			return 0, err
+		}
+		entries = append(entries, e)
+	}
+	rows.Close()
+	err = rows.Err()
+	endQuery(qspan, err)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, e := range entries {
+		if err := recordActivity(ctx, tx, e); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(entries)), nil
+}
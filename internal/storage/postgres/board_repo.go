@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/activity"
 	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
 )
 
@@ -18,114 +19,388 @@ func NewBoardRepository(db *DB) *BoardRepository {
 	return &BoardRepository{db: db.DB}
 }
 
+// boardAccessible — SQL-условие доступа пользователя (параметр userParam) к доске с псевдонимом b:
+// своя личная доска, доска пространства, в котором он состоит, или доска, куда его пригласили.
+// Доски в корзине недоступны.
+func boardAccessible(b, userParam string) string {
+	return `(` + b + `.deleted_at IS NULL AND ` + boardAccessRights(b, userParam) + `)`
+}
+
+// boardManageable — SQL-условие права управлять доской, которая не лежит в корзине.
+func boardManageable(b, userParam string) string {
+	return `(` + b + `.deleted_at IS NULL AND ` + boardManageRights(b, userParam) + `)`
+}
+
+// boardAccessRights — права доступа из boardAccessible без учёта корзины.
+func boardAccessRights(b, userParam string) string {
+	return `((` + b + `.workspace_id IS NULL AND ` + b + `.owner_id = ` + userParam + `)
+		OR EXISTS (SELECT 1 FROM workspace_members wm
+		           WHERE wm.workspace_id = ` + b + `.workspace_id AND wm.user_id = ` + userParam + `)
+		OR EXISTS (SELECT 1 FROM board_members bm
+		           WHERE bm.board_id = ` + b + `.id AND bm.user_id = ` + userParam + `))`
+}
+
+// boardManageRights — право управлять доской без учёта корзины: своя личная доска, роль admin или owner
+// в пространстве доски, созданная пользователем доска, пока он состоит в пространстве,
+// либо роль admin среди приглашённых участников доски.
+func boardManageRights(b, userParam string) string {
+	return `((` + b + `.workspace_id IS NULL AND ` + b + `.owner_id = ` + userParam + `)
+		OR EXISTS (SELECT 1 FROM workspace_members wm
+		           WHERE wm.workspace_id = ` + b + `.workspace_id AND wm.user_id = ` + userParam + `
+		             AND (wm.role IN ('owner', 'admin') OR ` + b + `.owner_id = ` + userParam + `))
+		OR EXISTS (SELECT 1 FROM board_members bm
+		           WHERE bm.board_id = ` + b + `.id AND bm.user_id = ` + userParam + ` AND bm.role = 'admin'))`
+}
+
+const boardColumns = `id, owner_id, workspace_id, name, archived_at, created_at, updated_at`
+
 func (r *BoardRepository) ListByOwnerID(ctx context.Context, ownerID string) ([]*board.Board, error) {
+	ctx, span := startSpan(ctx, "BoardRepository.ListByOwnerID")
+	defer span.End()
+
 	const q = `
-        SELECT id, owner_id, name, created_at, updated_at
+        SELECT ` + boardColumns + `
         FROM boards
-        WHERE owner_id = $1
+        WHERE owner_id = $1 AND workspace_id IS NULL AND deleted_at IS NULL AND archived_at IS NULL
         ORDER BY created_at;
     `
 
-	rows, err := r.db.QueryContext(ctx, q, ownerID)
+	return r.list(ctx, "BoardRepository.ListByOwnerID", q, ownerID)
+}
+
+// ListShared возвращает доски, куда пользователя пригласили.
+func (r *BoardRepository) ListShared(ctx context.Context, userID string) ([]*board.Board, error) {
+	ctx, span := startSpan(ctx, "BoardRepository.ListShared")
+	defer span.End()
+
+	const q = `
+        SELECT b.id, b.owner_id, b.workspace_id, b.name, b.archived_at, b.created_at, b.updated_at
+        FROM boards b
+        JOIN board_members bm ON bm.board_id = b.id
+        WHERE bm.user_id = $1 AND b.deleted_at IS NULL AND b.archived_at IS NULL
+        ORDER BY bm.joined_at;
+    `
+
+	return r.list(ctx, "BoardRepository.ListShared", q, userID)
+}
+
+// CanManage сообщает, может ли пользователь управлять доступной ему доской.
+func (r *BoardRepository) CanManage(ctx context.Context, id, userID string) (bool, error) {
+	ctx, span := startSpan(ctx, "BoardRepository.CanManage")
+	defer span.End()
+
+	q := `
+        SELECT ` + boardManageable("b", "$2") + `
+        FROM boards b
+        WHERE b.id = $1 AND ` + boardAccessible("b", "$2") + `;
+    `
+
+	var ok bool
+	if err := r.db.QueryRowContext(ctx, q, id, userID).Scan(&ok); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, board.ErrNotFound
+		}
+		return false, queryError(ctx, "BoardRepository.CanManage", err)
+	}
+	return ok, nil
+}
+
+// ListMembers возвращает приглашённых участников доски.
+func (r *BoardRepository) ListMembers(ctx context.Context, boardID string) ([]*board.Member, error) {
+	ctx, span := startSpan(ctx, "BoardRepository.ListMembers")
+	defer span.End()
+
+	const q = `
+        SELECT bm.board_id, bm.user_id, u.email, u.display_name, bm.role, bm.joined_at
+        FROM board_members bm
+        JOIN users u ON u.id = bm.user_id
+        WHERE bm.board_id = $1
+        ORDER BY bm.joined_at, u.email;
+    `
+
+	rows, err := r.db.QueryContext(ctx, q, boardID)
 	if err != nil {
-		return nil, err
+		return nil, queryError(ctx, "BoardRepository.ListMembers", err)
+	}
+	defer rows.Close()
+
+	var res []*board.Member
+	for rows.Next() {
+		var m board.Member
+		if err := rows.Scan(&m.BoardID, &m.UserID, &m.Email, &m.DisplayName, &m.Role, &m.JoinedAt); err != nil {
+			return nil, queryError(ctx, "BoardRepository.ListMembers", err)
+		}
+		res = append(res, &m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, "BoardRepository.ListMembers", err)
+	}
+	return res, nil
+}
+
+// RemoveMember исключает приглашённого участника доски.
+func (r *BoardRepository) RemoveMember(ctx context.Context, boardID, userID string) error {
+	ctx, span := startSpan(ctx, "BoardRepository.RemoveMember")
+	defer span.End()
+
+	const q = `DELETE FROM board_members WHERE board_id = $1 AND user_id = $2;`
+
+	res, err := r.db.ExecContext(ctx, q, boardID, userID)
+	if err != nil {
+		return queryError(ctx, "BoardRepository.RemoveMember", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return queryError(ctx, "BoardRepository.RemoveMember", err)
+	}
+	if n == 0 {
+		return board.ErrMemberNotFound
+	}
+	return nil
+}
+
+// ListByWorkspace возвращает доски рабочего пространства.
+func (r *BoardRepository) ListByWorkspace(ctx context.Context, workspaceID string) ([]*board.Board, error) {
+	ctx, span := startSpan(ctx, "BoardRepository.ListByWorkspace")
+	defer span.End()
+
+	const q = `
+        SELECT ` + boardColumns + `
+        FROM boards
+        WHERE workspace_id = $1 AND deleted_at IS NULL AND archived_at IS NULL
+        ORDER BY created_at;
+    `
+
+	return r.list(ctx, "BoardRepository.ListByWorkspace", q, workspaceID)
+}
+
+func (r *BoardRepository) list(ctx context.Context, op, q string, args ...any) ([]*board.Board, error) {
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, queryError(ctx, op, err)
 	}
 	defer rows.Close()
 
 	var res []*board.Board
 	for rows.Next() {
-		var b board.Board
-		if err := rows.Scan(&b.ID, &b.OwnerID, &b.Name, &b.CreatedAt, &b.UpdatedAt); err != nil {
-			return nil, err
+		b, err := scanBoard(rows)
+		if err != nil {
+			return nil, queryError(ctx, op, err)
 		}
-		res = append(res, &b)
+		res = append(res, b)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, err
+		return nil, queryError(ctx, op, err)
 	}
 
 	return res, nil
 }
 
-// Create создаёт доску для конкретного пользователя.
+// Create создаёт личную доску пользователя или доску пространства b.WorkspaceID.
 func (r *BoardRepository) Create(ctx context.Context, b *board.Board) error {
+	ctx, span := startSpan(ctx, "BoardRepository.Create")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, "BoardRepository.Create", err)
+	}
+	defer rollback(ctx, tx)
+
 	const q = `
-        INSERT INTO boards (owner_id, name)
-        VALUES ($1, $2)
+        INSERT INTO boards (owner_id, workspace_id, name)
+        VALUES ($1, $2, $3)
         RETURNING id, created_at, updated_at;
     `
 
-	err := r.db.QueryRowContext(ctx, q, b.OwnerID, b.Name).
+	err = tx.QueryRowContext(ctx, q, b.OwnerID, b.WorkspaceID, b.Name).
 		Scan(&b.ID, &b.CreatedAt, &b.UpdatedAt)
 	if err != nil {
-		return err
+		return queryError(ctx, "BoardRepository.Create", err)
+	}
+
+	err = recordActivity(ctx, tx, activity.Entry{
+		BoardID: b.ID, ActorID: b.OwnerID, Action: activity.BoardCreated,
+		EntityType: activity.EntityBoard, EntityID: b.ID, After: activity.BoardFields(b),
+	})
+	if err != nil {
+		return queryError(ctx, "BoardRepository.Create", err)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return queryError(ctx, "BoardRepository.Create", err)
+	}
 	return nil
 }
 
-// GetByID возвращает доску по id и владельцу.
-func (r *BoardRepository) GetByID(ctx context.Context, id, ownerID string) (*board.Board, error) {
-	const q = `
-        SELECT id, owner_id, name, created_at, updated_at
-        FROM boards
-        WHERE id = $1 AND owner_id = $2;
+// GetByID возвращает доску по id, если она доступна пользователю.
+func (r *BoardRepository) GetByID(ctx context.Context, id, userID string) (*board.Board, error) {
+	ctx, span := startSpan(ctx, "BoardRepository.GetByID")
+	defer span.End()
+
+	q := `
+        SELECT ` + boardColumns + `
+        FROM boards b
+        WHERE b.id = $1 AND ` + boardAccessible("b", "$2") + `;
     `
 
-	var b board.Board
-	err := r.db.QueryRowContext(ctx, q, id, ownerID).
-		Scan(&b.ID, &b.OwnerID, &b.Name, &b.CreatedAt, &b.UpdatedAt)
+	b, err := scanBoard(r.db.QueryRowContext(ctx, q, id, userID))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, board.ErrNotFound
 		}
-		return nil, err
+		return nil, queryError(ctx, "BoardRepository.GetByID", err)
 	}
 
-	return &b, nil
+	return b, nil
 }
 
 // Update меняет название доски.
-func (r *BoardRepository) Update(ctx context.Context, b *board.Board) error {
+func (r *BoardRepository) Update(ctx context.Context, b *board.Board, userID string) error {
+	ctx, span := startSpan(ctx, "BoardRepository.Update")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, "BoardRepository.Update", err)
+	}
+	defer rollback(ctx, tx)
+
+	lock := `
+        SELECT ` + boardColumns + `
+        FROM boards b
+        WHERE b.id = $1 AND ` + boardManageable("b", "$2") + `
+        FOR UPDATE;
+    `
+	before, err := scanBoard(tx.QueryRowContext(ctx, lock, b.ID, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return board.ErrNotFound
+		}
+		return queryError(ctx, "BoardRepository.Update", err)
+	}
+
 	const q = `
         UPDATE boards
         SET name = $1, updated_at = NOW()
-        WHERE id = $2 AND owner_id = $3
-        RETURNING id, owner_id, name, created_at, updated_at;
+        WHERE id = $2
+        RETURNING ` + boardColumns + `;
     `
 
-	err := r.db.QueryRowContext(ctx, q, b.Name, b.ID, b.OwnerID).
-		Scan(&b.ID, &b.OwnerID, &b.Name, &b.CreatedAt, &b.UpdatedAt)
+	updated, err := scanBoard(tx.QueryRowContext(ctx, q, b.Name, b.ID))
+	if err != nil {
+		return queryError(ctx, "BoardRepository.Update", err)
+	}
+
+	err = recordChange(ctx, tx, activity.Entry{
+		BoardID: b.ID, ActorID: userID, Action: activity.BoardUpdated,
+		EntityType: activity.EntityBoard, EntityID: b.ID,
+	}, activity.BoardFields(before), activity.BoardFields(updated))
+	if err != nil {
+		return queryError(ctx, "BoardRepository.Update", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return queryError(ctx, "BoardRepository.Update", err)
+	}
+	*b = *updated
+	return nil
+}
+
+// Transfer меняет пространство и владельца доски. Перенос выполняет новый владелец,
+// поэтому он и записывается автором действия.
+func (r *BoardRepository) Transfer(ctx context.Context, b *board.Board) error {
+	ctx, span := startSpan(ctx, "BoardRepository.Transfer")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, "BoardRepository.Transfer", err)
+	}
+	defer rollback(ctx, tx)
+
+	const lock = `SELECT ` + boardColumns + ` FROM boards WHERE id = $1 AND deleted_at IS NULL FOR UPDATE;`
+	before, err := scanBoard(tx.QueryRowContext(ctx, lock, b.ID))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return board.ErrNotFound
 		}
-		return err
+		return queryError(ctx, "BoardRepository.Transfer", err)
 	}
 
+	const q = `
+        UPDATE boards
+        SET owner_id = $1, workspace_id = $2, updated_at = NOW()
+        WHERE id = $3
+        RETURNING ` + boardColumns + `;
+    `
+
+	updated, err := scanBoard(tx.QueryRowContext(ctx, q, b.OwnerID, b.WorkspaceID, b.ID))
+	if err != nil {
+		return queryError(ctx, "BoardRepository.Transfer", err)
+	}
+
+	err = recordChange(ctx, tx, activity.Entry{
+		BoardID: b.ID, ActorID: b.OwnerID, Action: activity.BoardTransferred,
+		EntityType: activity.EntityBoard, EntityID: b.ID,
+	}, activity.BoardFields(before), activity.BoardFields(updated))
+	if err != nil {
+		return queryError(ctx, "BoardRepository.Transfer", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return queryError(ctx, "BoardRepository.Transfer", err)
+	}
+	*b = *updated
 	return nil
 }
 
-// Delete удаляет доску пользователя.
-func (r *BoardRepository) Delete(ctx context.Context, id, ownerID string) error {
-	const q = `
-        DELETE FROM boards
-        WHERE id = $1 AND owner_id = $2;
-    `
+// Delete переносит в корзину доску, которой может управлять пользователь. Колонки и задачи остаются
+// на месте: пока доска в корзине, они недоступны вместе с ней.
+func (r *BoardRepository) Delete(ctx context.Context, id, userID string) error {
+	ctx, span := startSpan(ctx, "BoardRepository.Delete")
+	defer span.End()
 
-	res, err := r.db.ExecContext(ctx, q, id, ownerID)
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
 	if err != nil {
-		return err
+		return queryError(ctx, "BoardRepository.Delete", err)
 	}
+	defer rollback(ctx, tx)
 
-	n, err := res.RowsAffected()
+	q := `
+        UPDATE boards b
+        SET deleted_at = NOW(), deleted_by = $2
+        WHERE b.id = $1 AND ` + boardManageable("b", "$2") + `
+        RETURNING ` + boardColumns + `;
+    `
+
+	deleted, err := scanBoard(tx.QueryRowContext(ctx, q, id, userID))
 	if err != nil {
-		return err
+		if errors.Is(err, sql.ErrNoRows) {
+			return board.ErrNotFound
+		}
+		return queryError(ctx, "BoardRepository.Delete", err)
 	}
-	if n == 0 {
-		return board.ErrNotFound
+
+	err = recordActivity(ctx, tx, activity.Entry{
+		BoardID: id, ActorID: userID, Action: activity.BoardDeleted,
+		EntityType: activity.EntityBoard, EntityID: id, Before: activity.BoardFields(deleted),
+	})
+	if err != nil {
+		return queryError(ctx, "BoardRepository.Delete", err)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return queryError(ctx, "BoardRepository.Delete", err)
+	}
 	return nil
 }
+
+func scanBoard(row rowScanner) (*board.Board, error) {
+	var b board.Board
+	if err := row.Scan(&b.ID, &b.OwnerID, &b.WorkspaceID, &b.Name, &b.ArchivedAt, &b.CreatedAt, &b.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/activity"
 	"github.com/VladislavDraga398/kanban-backend/internal/domain/column"
 )
 
@@ -20,133 +21,223 @@ func NewColumnRepository(db *DB) *ColumnRepository {
 
 // Create — простое создание колонки по board_id (без проверки владельца доски).
 func (r *ColumnRepository) Create(ctx context.Context, c *column.Column) error {
+	ctx, span := startSpan(ctx, "ColumnRepository.Create")
+	defer span.End()
+
 	const q = `
-		INSERT INTO columns (board_id, name, position)
+		INSERT INTO columns (board_id, name, position, done)
 		VALUES ($1, $2, COALESCE(
 			(SELECT MAX(position) + 1 FROM columns WHERE board_id = $1),
 			1
-		))
+		), $3)
 		RETURNING id, position, created_at, updated_at;
 	`
 
-	return r.db.QueryRowContext(ctx, q, c.BoardID, c.Name).
-		Scan(&c.ID, &c.Position, &c.CreatedAt, &c.UpdatedAt)
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, "ColumnRepository.Create", err)
+	}
+	defer rollback(ctx, tx)
+
+	if err := tx.QueryRowContext(ctx, q, c.BoardID, c.Name, c.Done).
+		Scan(&c.ID, &c.Position, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return queryError(ctx, "ColumnRepository.Create", err)
+	}
+
+	// Автор неизвестен: запись журнала остаётся без actor_id.
+	err = recordActivity(ctx, tx, activity.Entry{
+		BoardID: c.BoardID, Action: activity.ColumnCreated,
+		EntityType: activity.EntityColumn, EntityID: c.ID, After: activity.ColumnFields(c),
+	})
+	if err != nil {
+		return queryError(ctx, "ColumnRepository.Create", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return queryError(ctx, "ColumnRepository.Create", err)
+	}
+	return nil
 }
 
 // ListByBoardID — все колонки по board_id (без проверки владельца).
 func (r *ColumnRepository) ListByBoardID(ctx context.Context, boardID string) ([]column.Column, error) {
+	ctx, span := startSpan(ctx, "ColumnRepository.ListByBoardID")
+	defer span.End()
+
 	const q = `
-		SELECT id, board_id, name, position, created_at, updated_at
+		SELECT id, board_id, name, position, done, created_at, updated_at
 		FROM columns
-		WHERE board_id = $1
+		WHERE board_id = $1 AND deleted_at IS NULL
 		ORDER BY position, created_at;
 	`
 
 	rows, err := r.db.QueryContext(ctx, q, boardID)
 	if err != nil {
-		return nil, err
+		return nil, queryError(ctx, "ColumnRepository.ListByBoardID", err)
 	}
 	defer rows.Close()
 
 	var res []column.Column
 	for rows.Next() {
 		var c column.Column
-		if err := rows.Scan(&c.ID, &c.BoardID, &c.Name, &c.Position, &c.CreatedAt, &c.UpdatedAt); err != nil {
-			return nil, err
+		if err := rows.Scan(&c.ID, &c.BoardID, &c.Name, &c.Position, &c.Done, &c.CreatedAt, &c.UpdatedAt); err != nil {
+			return nil, queryError(ctx, "ColumnRepository.ListByBoardID", err)
 		}
 		res = append(res, c)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, err
+		return nil, queryError(ctx, "ColumnRepository.ListByBoardID", err)
 	}
 
 	return res, nil
 }
 
-// Update — обновляет имя и позицию колонки с проверкой владельца доски.
+// Update — обновляет имя, позицию и признак «готово» колонки с проверкой владельца доски.
 func (r *ColumnRepository) Update(ctx context.Context, c *column.Column, ownerID string) error {
+	ctx, span := startSpan(ctx, "ColumnRepository.Update")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, "ColumnRepository.Update", err)
+	}
+	defer rollback(ctx, tx)
+
+	lock := `
+		SELECT c.name, c.position, c.done
+		FROM columns c
+		JOIN boards b ON b.id = c.board_id
+		WHERE c.id = $1
+		  AND c.board_id = $2
+		  AND c.deleted_at IS NULL
+		  AND ` + boardAccessible("b", "$3") + `
+		FOR UPDATE OF c;
+	`
+	var before column.Column
+	if err := tx.QueryRowContext(ctx, lock, c.ID, c.BoardID, ownerID).Scan(&before.Name, &before.Position, &before.Done); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return column.ErrNotFound
+		}
+		return queryError(ctx, "ColumnRepository.Update", err)
+	}
+
 	const q = `
 		UPDATE columns AS c
 		SET name = $1,
 		    position = COALESCE(NULLIF($2, 0), c.position),
+		    done = $3,
 		    updated_at = NOW()
-		FROM boards b
-		WHERE c.id = $3
-		  AND c.board_id = $4
-		  AND b.id = c.board_id
-		  AND b.owner_id = $5
-		RETURNING c.id, c.board_id, c.name, c.position, c.created_at, c.updated_at;
+		WHERE c.id = $4
+		  AND c.board_id = $5
+		RETURNING c.id, c.board_id, c.name, c.position, c.done, c.created_at, c.updated_at;
 	`
 
-	err := r.db.QueryRowContext(ctx, q, c.Name, c.Position, c.ID, c.BoardID, ownerID).
-		Scan(&c.ID, &c.BoardID, &c.Name, &c.Position, &c.CreatedAt, &c.UpdatedAt)
+	err = tx.QueryRowContext(ctx, q, c.Name, c.Position, c.Done, c.ID, c.BoardID).
+		Scan(&c.ID, &c.BoardID, &c.Name, &c.Position, &c.Done, &c.CreatedAt, &c.UpdatedAt)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return column.ErrNotFound
-		}
-		return err
+		return queryError(ctx, "ColumnRepository.Update", err)
 	}
 
+	err = recordChange(ctx, tx, activity.Entry{
+		BoardID: c.BoardID, ActorID: ownerID, Action: activity.ColumnUpdated,
+		EntityType: activity.EntityColumn, EntityID: c.ID,
+	}, activity.ColumnFields(&before), activity.ColumnFields(c))
+	if err != nil {
+		return queryError(ctx, "ColumnRepository.Update", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return queryError(ctx, "ColumnRepository.Update", err)
+	}
 	return nil
 }
 
-// Delete — удаляет колонку по id и board_id с проверкой владельца доски.
+// Delete — переносит колонку в корзину вместе с её задачами, проверяя доступ к доске.
+// Задачи получают тот же deleted_at, по нему RestoreColumn находит их при восстановлении.
 func (r *ColumnRepository) Delete(ctx context.Context, id, boardID, ownerID string) error {
-	const q = `
-		DELETE FROM columns AS c
-		USING boards b
+	ctx, span := startSpan(ctx, "ColumnRepository.Delete")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, "ColumnRepository.Delete", err)
+	}
+	defer rollback(ctx, tx)
+
+	q := `
+		UPDATE columns AS c
+		SET deleted_at = NOW(), deleted_by = $3
+		FROM boards b
 		WHERE c.id = $1
 		  AND c.board_id = $2
+		  AND c.deleted_at IS NULL
 		  AND b.id = c.board_id
-		  AND b.owner_id = $3;
+		  AND ` + boardAccessible("b", "$3") + `
+		RETURNING c.name, c.position;
 	`
 
-	res, err := r.db.ExecContext(ctx, q, id, boardID, ownerID)
-	if err != nil {
-		return err
+	var deleted column.Column
+	if err := tx.QueryRowContext(ctx, q, id, boardID, ownerID).Scan(&deleted.Name, &deleted.Position); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return column.ErrNotFound
+		}
+		return queryError(ctx, "ColumnRepository.Delete", err)
 	}
 
-	n, err := res.RowsAffected()
-	if err != nil {
-		return err
+	const tasks = `
+		UPDATE tasks
+		SET deleted_at = NOW(), deleted_by = $2
+		WHERE column_id = $1 AND deleted_at IS NULL;
+	`
+	if _, err := tx.ExecContext(ctx, tasks, id, ownerID); err != nil {
+		return queryError(ctx, "ColumnRepository.Delete", err)
 	}
-	if n == 0 {
-		return column.ErrNotFound
+
+	err = recordActivity(ctx, tx, activity.Entry{
+		BoardID: boardID, ActorID: ownerID, Action: activity.ColumnDeleted,
+		EntityType: activity.EntityColumn, EntityID: id, Before: activity.ColumnFields(&deleted),
+	})
+	if err != nil {
+		return queryError(ctx, "ColumnRepository.Delete", err)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return queryError(ctx, "ColumnRepository.Delete", err)
+	}
 	return nil
 }
 
 // ListByBoardOwner — колонки доски, которая принадлежит ownerID.
 func (r *ColumnRepository) ListByBoardOwner(ctx context.Context, boardID, ownerID string) ([]*column.Column, error) {
-	const (
-		q = `
-		SELECT c.id, c.board_id, c.name, c.position, c.created_at, c.updated_at
+	ctx, span := startSpan(ctx, "ColumnRepository.ListByBoardOwner")
+	defer span.End()
+
+	q := `
+		SELECT c.id, c.board_id, c.name, c.position, c.done, c.created_at, c.updated_at
 		FROM columns c
 		JOIN boards b ON c.board_id = b.id
-		WHERE c.board_id = $1 AND b.owner_id = $2
+		WHERE c.board_id = $1 AND c.deleted_at IS NULL AND ` + boardAccessible("b", "$2") + `
 		ORDER BY c.position, c.created_at;
 	`
-	)
 
 	rows, err := r.db.QueryContext(ctx, q, boardID, ownerID)
 	if err != nil {
-		return nil, err
+		return nil, queryError(ctx, "ColumnRepository.ListByBoardOwner", err)
 	}
 	defer rows.Close()
 
 	var res []*column.Column
 	for rows.Next() {
 		var c column.Column
-		if err := rows.Scan(&c.ID, &c.BoardID, &c.Name, &c.Position, &c.CreatedAt, &c.UpdatedAt); err != nil {
-			return nil, err
+		if err := rows.Scan(&c.ID, &c.BoardID, &c.Name, &c.Position, &c.Done, &c.CreatedAt, &c.UpdatedAt); err != nil {
+			return nil, queryError(ctx, "ColumnRepository.ListByBoardOwner", err)
 		}
 		res = append(res, &c)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, err
+		return nil, queryError(ctx, "ColumnRepository.ListByBoardOwner", err)
 	}
 
 	return res, nil
@@ -154,11 +245,14 @@ func (r *ColumnRepository) ListByBoardOwner(ctx context.Context, boardID, ownerI
 
 // CreateInBoard — создаёт колонку в доске конкретного пользователя.
 func (r *ColumnRepository) CreateInBoard(ctx context.Context, c *column.Column, boardID, ownerID string) error {
-	const insert = `
+	ctx, span := startSpan(ctx, "ColumnRepository.CreateInBoard")
+	defer span.End()
+
+	insert := `
 		WITH locked_board AS (
 			SELECT id
-			FROM boards
-			WHERE id = $1 AND owner_id = $2
+			FROM boards b
+			WHERE b.id = $1 AND ` + boardAccessible("b", "$2") + `
 			FOR UPDATE
 		),
 		next_pos AS (
@@ -166,21 +260,38 @@ func (r *ColumnRepository) CreateInBoard(ctx context.Context, c *column.Column,
 			FROM columns c
 			JOIN locked_board lb ON c.board_id = lb.id
 		)
-		INSERT INTO columns (board_id, name, position)
-		SELECT lb.id, $3, np.pos
+		INSERT INTO columns (board_id, name, position, done)
+		SELECT lb.id, $3, np.pos, $4
 		FROM locked_board lb
 		CROSS JOIN next_pos np
-		RETURNING id, board_id, name, position, created_at, updated_at;
+		RETURNING id, board_id, name, position, done, created_at, updated_at;
 	`
 
-	err := r.db.QueryRowContext(ctx, insert, boardID, ownerID, c.Name).
-		Scan(&c.ID, &c.BoardID, &c.Name, &c.Position, &c.CreatedAt, &c.UpdatedAt)
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, "ColumnRepository.CreateInBoard", err)
+	}
+	defer rollback(ctx, tx)
+
+	err = tx.QueryRowContext(ctx, insert, boardID, ownerID, c.Name, c.Done).
+		Scan(&c.ID, &c.BoardID, &c.Name, &c.Position, &c.Done, &c.CreatedAt, &c.UpdatedAt)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return column.ErrNotFound
 		}
-		return err
+		return queryError(ctx, "ColumnRepository.CreateInBoard", err)
 	}
 
+	err = recordActivity(ctx, tx, activity.Entry{
+		BoardID: c.BoardID, ActorID: ownerID, Action: activity.ColumnCreated,
+		EntityType: activity.EntityColumn, EntityID: c.ID, After: activity.ColumnFields(c),
+	})
+	if err != nil {
+		return queryError(ctx, "ColumnRepository.CreateInBoard", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return queryError(ctx, "ColumnRepository.CreateInBoard", err)
+	}
 	return nil
 }
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+)
+
+// IdentityRepository хранит привязки к внешним провайдерам входа в таблице user_identities.
+type IdentityRepository struct {
+	db *sql.DB
+}
+
+func NewIdentityRepository(db *DB) user.IdentityRepository {
+	return &IdentityRepository{db: db.DB}
+}
+
+func (r *IdentityRepository) Find(ctx context.Context, provider, subject string) (*user.Identity, error) {
+	ctx, span := startSpan(ctx, "IdentityRepository.Find")
+	defer span.End()
+
+	const q = `
+		SELECT provider, subject, user_id, email, created_at
+		FROM user_identities
+		WHERE provider = $1 AND subject = $2;
+	`
+	var id user.Identity
+	err := r.db.QueryRowContext(ctx, q, provider, subject).
+		Scan(&id.Provider, &id.Subject, &id.UserID, &id.Email, &id.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, user.ErrIdentityNotFound
+		}
+		return nil, queryError(ctx, "IdentityRepository.Find", err)
+	}
+	return &id, nil
+}
+
+func (r *IdentityRepository) Link(ctx context.Context, id *user.Identity) error {
+	ctx, span := startSpan(ctx, "IdentityRepository.Link")
+	defer span.End()
+
+	// Повторная привязка той же пары (гонка двух входов) оставляет первую запись.
+	const q = `
+		INSERT INTO user_identities (provider, subject, user_id, email)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (provider, subject) DO UPDATE SET provider = EXCLUDED.provider
+		RETURNING user_id, created_at;
+	`
+	if err := r.db.QueryRowContext(ctx, q, id.Provider, id.Subject, id.UserID, id.Email).Scan(&id.UserID, &id.CreatedAt); err != nil {
+		return queryError(ctx, "IdentityRepository.Link", err)
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/invitation"
+)
+
+// InvitationRepository хранит приглашения на доски в таблице board_invitations.
+type InvitationRepository struct {
+	db *sql.DB
+}
+
+// NewInvitationRepository создаёт репозиторий приглашений поверх пула соединений.
+func NewInvitationRepository(db *DB) *InvitationRepository {
+	return &InvitationRepository{db: db.DB}
+}
+
+const invitationColumns = `i.id, i.board_id, b.name, i.email, i.role, i.invited_by, i.token_hash, i.status, i.expires_at, i.created_at`
+
+// Create сохраняет приглашение, заменяя действующее приглашение того же адреса на ту же доску.
+func (r *InvitationRepository) Create(ctx context.Context, inv *invitation.Invitation) error {
+	ctx, span := startSpan(ctx, "InvitationRepository.Create")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, "InvitationRepository.Create", err)
+	}
+	defer rollback(ctx, tx)
+
+	const revoke = `
+		DELETE FROM board_invitations
+		WHERE board_id = $1 AND lower(email) = lower($2) AND status = 'pending';
+	`
+	if _, err := tx.ExecContext(ctx, revoke, inv.BoardID, inv.Email); err != nil {
+		return queryError(ctx, "InvitationRepository.Create", err)
+	}
+
+	const insert = `
+		INSERT INTO board_invitations (board_id, email, role, invited_by, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, status, created_at, (SELECT name FROM boards WHERE id = $1);
+	`
+	err = tx.QueryRowContext(ctx, insert, inv.BoardID, inv.Email, inv.Role, inv.InvitedBy, inv.Hash, inv.ExpiresAt).
+		Scan(&inv.ID, &inv.Status, &inv.CreatedAt, &inv.BoardName)
+	if err != nil {
+		return queryError(ctx, "InvitationRepository.Create", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return queryError(ctx, "InvitationRepository.Create", err)
+	}
+	return nil
+}
+
+// ListPending возвращает действующие приглашения доски, старые первыми.
+func (r *InvitationRepository) ListPending(ctx context.Context, boardID string, now time.Time) ([]*invitation.Invitation, error) {
+	ctx, span := startSpan(ctx, "InvitationRepository.ListPending")
+	defer span.End()
+
+	const q = `
+		SELECT ` + invitationColumns + `
+		FROM board_invitations i
+		JOIN boards b ON b.id = i.board_id
+		WHERE i.board_id = $1 AND i.status = 'pending' AND i.expires_at > $2
+		ORDER BY i.created_at;
+	`
+	rows, err := r.db.QueryContext(ctx, q, boardID, now)
+	if err != nil {
+		return nil, queryError(ctx, "InvitationRepository.ListPending", err)
+	}
+	defer rows.Close()
+
+	var res []*invitation.Invitation
+	for rows.Next() {
+		inv, err := scanInvitation(rows)
+		if err != nil {
+			return nil, queryError(ctx, "InvitationRepository.ListPending", err)
+		}
+		res = append(res, inv)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, "InvitationRepository.ListPending", err)
+	}
+	return res, nil
+}
+
+// Revoke удаляет приглашение доски, ещё ожидающее ответа; иначе — invitation.ErrNotFound.
+func (r *InvitationRepository) Revoke(ctx context.Context, id, boardID string) error {
+	ctx, span := startSpan(ctx, "InvitationRepository.Revoke")
+	defer span.End()
+
+	const q = `DELETE FROM board_invitations WHERE id = $1 AND board_id = $2 AND status = 'pending';`
+
+	res, err := r.db.ExecContext(ctx, q, id, boardID)
+	if err != nil {
+		return queryError(ctx, "InvitationRepository.Revoke", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return queryError(ctx, "InvitationRepository.Revoke", err)
+	}
+	if n == 0 {
+		return invitation.ErrNotFound
+	}
+	return nil
+}
+
+// FindPending ищет действующее приглашение по хэшу токена; иначе — invitation.ErrInvalid.
+func (r *InvitationRepository) FindPending(ctx context.Context, hash string, now time.Time) (*invitation.Invitation, error) {
+	ctx, span := startSpan(ctx, "InvitationRepository.FindPending")
+	defer span.End()
+
+	const q = `
+		SELECT ` + invitationColumns + `
+		FROM board_invitations i
+		JOIN boards b ON b.id = i.board_id
+		WHERE i.token_hash = $1 AND i.status = 'pending' AND i.expires_at > $2 AND b.deleted_at IS NULL;
+	`
+	inv, err := scanInvitation(r.db.QueryRowContext(ctx, q, hash, now))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, invitation.ErrInvalid
+		}
+		return nil, queryError(ctx, "InvitationRepository.FindPending", err)
+	}
+	return inv, nil
+}
+
+// Accept принимает приглашение и в той же транзакции добавляет пользователя в участники доски.
+func (r *InvitationRepository) Accept(ctx context.Context, hash, userID string, now time.Time) (*invitation.Invitation, error) {
+	ctx, span := startSpan(ctx, "InvitationRepository.Accept")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, queryError(ctx, "InvitationRepository.Accept", err)
+	}
+	defer rollback(ctx, tx)
+
+	inv, err := respond(ctx, tx, hash, invitation.StatusAccepted, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// Повторное приглашение может повысить роль участника, но не понизить её.
+	const member = `
+		INSERT INTO board_members (board_id, user_id, role)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (board_id, user_id) DO UPDATE SET role = EXCLUDED.role
+		WHERE EXCLUDED.role = 'admin';
+	`
+	if _, err := tx.ExecContext(ctx, member, inv.BoardID, userID, inv.Role); err != nil {
+		return nil, queryError(ctx, "InvitationRepository.Accept", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, queryError(ctx, "InvitationRepository.Accept", err)
+	}
+	return inv, nil
+}
+
+// Decline отклоняет приглашение; повторный ответ по тому же токену — invitation.ErrInvalid.
+func (r *InvitationRepository) Decline(ctx context.Context, hash string, now time.Time) (*invitation.Invitation, error) {
+	ctx, span := startSpan(ctx, "InvitationRepository.Decline")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, queryError(ctx, "InvitationRepository.Decline", err)
+	}
+	defer rollback(ctx, tx)
+	inv, err := respond(ctx, tx, hash, invitation.StatusDeclined, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, queryError(ctx, "InvitationRepository.Decline", err)
+	}
+	return inv, nil
+}
+
+// respond переводит действующее приглашение в status; одновременный ответ по тому же токену получит ErrInvalid.
+func respond(ctx context.Context, tx *sql.Tx, hash string, status invitation.Status, now time.Time) (*invitation.Invitation, error) {
+	const q = `
+		UPDATE board_invitations i
+		SET status = $2, responded_at = $3
+		FROM boards b
+		WHERE b.id = i.board_id AND b.deleted_at IS NULL
+		  AND i.token_hash = $1 AND i.status = 'pending' AND i.expires_at > $3
+		RETURNING ` + invitationColumns + `;
+	`
+	inv, err := scanInvitation(tx.QueryRowContext(ctx, q, hash, status, now))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, invitation.ErrInvalid
+		}
+		return nil, queryError(ctx, "InvitationRepository.respond", err)
+	}
+	return inv, nil
+}
+
+func scanInvitation(row rowScanner) (*invitation.Invitation, error) {
+	var inv invitation.Invitation
+	err := row.Scan(&inv.ID, &inv.BoardID, &inv.BoardName, &inv.Email, &inv.Role, &inv.InvitedBy,
+		&inv.Hash, &inv.Status, &inv.ExpiresAt, &inv.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
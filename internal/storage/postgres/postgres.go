@@ -1,12 +1,23 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/logging"
 )
 
+const tracerName = "github.com/VladislavDraga398/kanban-backend/internal/storage/postgres"
+
 // DB - обертка над *sql.DB, для навески модов/репозитории.
 type DB struct {
 	*sql.DB
@@ -32,3 +43,82 @@ func New(dsn string) (*DB, error) {
 	}
 	return &DB{db}, nil
 }
+
+// startSpan открывает дочерний span операции репозитория. Провайдер берётся из родительского
+// span, поэтому без трассировки запроса (или вне HTTP) это no-op.
+func startSpan(ctx context.Context, op string) (context.Context, trace.Span) {
+	return trace.SpanFromContext(ctx).TracerProvider().Tracer(tracerName).Start(ctx, op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("db.system.name", "postgresql")),
+	)
+}
+
+// startQuery открывает span отдельного SQL-запроса внутри многошаговой операции.
+func startQuery(ctx context.Context, name, statement string) (context.Context, trace.Span) {
+	ctx, span := startSpan(ctx, name)
+	span.SetAttributes(attribute.String("db.query.text", strings.Join(strings.Fields(statement), " ")))
+	return ctx, span
+}
+
+// endQuery закрывает span запроса; sql.ErrNoRows ошибкой не считается.
+func endQuery(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// queryError логирует неожиданную ошибку БД логгером запроса, отмечает её в span операции
+// и добавляет к ней имя операции.
+func queryError(ctx context.Context, op string, err error) error {
+	logging.FromContext(ctx).DebugContext(ctx, "postgres query failed", "op", op, "error", err)
+	span := trace.SpanFromContext(ctx)
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	return fmt.Errorf("%s: %w", op, err)
+}
+
+// rollback откатывает транзакцию; сбой отката только логируется — исходная ошибка важнее.
+func rollback(ctx context.Context, tx *sql.Tx) {
+	if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+		logging.FromContext(ctx).WarnContext(ctx, "postgres rollback failed", "error", err)
+	}
+}
+
+// Ключи advisory-блокировок фоновых задач: на нескольких репликах каждую выполняет одна.
+const (
+	purgeTrashLockKey  int64 = 0x6b616e62616e01
+	autoArchiveLockKey int64 = 0x6b616e62616e02
+)
+
+// tryJobLock берёт транзакционную advisory-блокировку фоновой задачи; false — её уже выполняет другой экземпляр.
+func tryJobLock(ctx context.Context, tx *sql.Tx, key int64) (bool, error) {
+	var locked bool
+	err := tx.QueryRowContext(ctx, `SELECT pg_try_advisory_xact_lock($1);`, key).Scan(&locked)
+	return locked, err
+}
+
+// ExpectedSchemaVersion — версия схемы (последняя миграция в migrations/), с которой работает приложение.
+const ExpectedSchemaVersion = 16
+
+// SchemaVersion возвращает максимальную применённую версию из schema_migrations.
+func (db *DB) SchemaVersion(ctx context.Context) (int, error) {
+	var version sql.NullInt64
+	if err := db.QueryRowContext(ctx, `SELECT MAX(version) FROM schema_migrations;`).Scan(&version); err != nil {
+		return 0, err
+	}
+	return int(version.Int64), nil
+}
+
+// CheckMigrations проверяет, что применены все миграции, ожидаемые приложением.
+func (db *DB) CheckMigrations(ctx context.Context) error {
+	version, err := db.SchemaVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("read schema version: %w", err)
+	}
+	if version < ExpectedSchemaVersion {
+		return fmt.Errorf("schema version %d is behind expected %d", version, ExpectedSchemaVersion)
+	}
+	return nil
+}
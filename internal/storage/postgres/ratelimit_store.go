@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/ratelimit"
+)
+
+// RateLimitStore — реализация ratelimit.Store поверх таблицы rate_limits;
+// состояние общее для всех реплик, атомарность обеспечивает блокировка строки.
+type RateLimitStore struct {
+	db *sql.DB
+}
+
+// NewRateLimitStore создаёт хранилище ограничителей.
+func NewRateLimitStore(db *DB) *RateLimitStore {
+	return &RateLimitStore{db: db.DB}
+}
+
+// Update читает состояние key под FOR UPDATE, применяет fn и сохраняет результат в одной транзакции.
+func (r *RateLimitStore) Update(ctx context.Context, key string, fn func(s *ratelimit.State)) error {
+	ctx, span := startSpan(ctx, "RateLimitStore.Update")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, "RateLimitStore.Update", err)
+	}
+
+	// Строку создаём заранее, чтобы конкурентные вызовы сериализовались на её блокировке.
+	const ensure = `
+		INSERT INTO rate_limits (key) VALUES ($1)
+		ON CONFLICT (key) DO NOTHING;
+	`
+	if _, err := tx.ExecContext(ctx, ensure, key); err != nil {
+		rollback(ctx, tx)
+		return queryError(ctx, "RateLimitStore.Update", err)
+	}
+
+	const sel = `
+		SELECT tokens, failures, locked_until, updated_at
+		FROM rate_limits
+		WHERE key = $1
+		FOR UPDATE;
+	`
+	s, err := scanState(tx.QueryRowContext(ctx, sel, key))
+	if err != nil {
+		rollback(ctx, tx)
+		return queryError(ctx, "RateLimitStore.Update", err)
+	}
+
+	fn(&s)
+
+	const upd = `
+		UPDATE rate_limits
+		SET tokens = $2, failures = $3, locked_until = $4, updated_at = $5
+		WHERE key = $1;
+	`
+	if _, err := tx.ExecContext(ctx, upd, key, s.Tokens, s.Failures, nullTime(s.LockedUntil), nullTime(s.UpdatedAt)); err != nil {
+		rollback(ctx, tx)
+		return queryError(ctx, "RateLimitStore.Update", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		rollback(ctx, tx)
+		return queryError(ctx, "RateLimitStore.Update", err)
+	}
+	return nil
+}
+
+// Get возвращает состояние key.
+func (r *RateLimitStore) Get(ctx context.Context, key string) (ratelimit.State, bool, error) {
+	ctx, span := startSpan(ctx, "RateLimitStore.Get")
+	defer span.End()
+
+	const q = `
+		SELECT tokens, failures, locked_until, updated_at
+		FROM rate_limits
+		WHERE key = $1;
+	`
+	s, err := scanState(r.db.QueryRowContext(ctx, q, key))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ratelimit.State{}, false, nil
+		}
+		return ratelimit.State{}, false, queryError(ctx, "RateLimitStore.Get", err)
+	}
+	return s, true, nil
+}
+
+// Delete удаляет состояние key.
+func (r *RateLimitStore) Delete(ctx context.Context, key string) error {
+	ctx, span := startSpan(ctx, "RateLimitStore.Delete")
+	defer span.End()
+
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM rate_limits WHERE key = $1;`, key); err != nil {
+		return queryError(ctx, "RateLimitStore.Delete", err)
+	}
+	return nil
+}
+
+// Prune удаляет ключи без обращений с момента before, кроме действующих блокировок.
+func (r *RateLimitStore) Prune(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := startSpan(ctx, "RateLimitStore.Prune")
+	defer span.End()
+
+	const q = `
+		DELETE FROM rate_limits
+		WHERE (updated_at IS NULL OR updated_at < $1)
+		  AND (locked_until IS NULL OR locked_until < NOW());
+	`
+	res, err := r.db.ExecContext(ctx, q, before)
+	if err != nil {
+		return 0, queryError(ctx, "RateLimitStore.Prune", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, queryError(ctx, "RateLimitStore.Prune", err)
+	}
+	return n, nil
+}
+
+func scanState(row *sql.Row) (ratelimit.State, error) {
+	var (
+		s                      ratelimit.State
+		lockedUntil, updatedAt sql.NullTime
+	)
+	if err := row.Scan(&s.Tokens, &s.Failures, &lockedUntil, &updatedAt); err != nil {
+		return ratelimit.State{}, err
+	}
+	if lockedUntil.Valid {
+		s.LockedUntil = lockedUntil.Time
+	}
+	if updatedAt.Valid {
+		s.UpdatedAt = updatedAt.Time
+	}
+	return s, nil
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
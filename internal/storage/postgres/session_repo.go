@@ -0,0 +1,164 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/session"
+)
+
+// SessionRepository хранит сессии входа в таблице sessions.
+type SessionRepository struct {
+	db *sql.DB
+}
+
+// NewSessionRepository создаёт репозиторий сессий поверх пула соединений.
+func NewSessionRepository(db *DB) *SessionRepository {
+	return &SessionRepository{db: db.DB}
+}
+
+const sessionColumns = `id, user_id, user_agent, ip, created_at, last_seen_at, expires_at, revoked_at`
+
+// Create сохраняет новую сессию и заполняет её ID и отметки времени.
+func (r *SessionRepository) Create(ctx context.Context, s *session.Session) error {
+	ctx, span := startSpan(ctx, "SessionRepository.Create")
+	defer span.End()
+
+	const q = `
+		INSERT INTO sessions (user_id, user_agent, ip, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, last_seen_at;
+	`
+	err := r.db.QueryRowContext(ctx, q, s.UserID, s.UserAgent, s.IP, s.ExpiresAt).
+		Scan(&s.ID, &s.CreatedAt, &s.LastSeenAt)
+	if err != nil {
+		return queryError(ctx, "SessionRepository.Create", err)
+	}
+	return nil
+}
+
+// Get возвращает сессию по ID, в том числе отозванную или истёкшую; иначе — session.ErrNotFound.
+func (r *SessionRepository) Get(ctx context.Context, id string) (*session.Session, error) {
+	ctx, span := startSpan(ctx, "SessionRepository.Get")
+	defer span.End()
+
+	const q = `SELECT ` + sessionColumns + ` FROM sessions WHERE id = $1;`
+
+	s, err := scanSession(r.db.QueryRowContext(ctx, q, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, session.ErrNotFound
+		}
+		return nil, queryError(ctx, "SessionRepository.Get", err)
+	}
+	return s, nil
+}
+
+// ListActive возвращает действующие на момент now сессии пользователя, недавно активные первыми.
+func (r *SessionRepository) ListActive(ctx context.Context, userID string, now time.Time) ([]*session.Session, error) {
+	ctx, span := startSpan(ctx, "SessionRepository.ListActive")
+	defer span.End()
+
+	const q = `
+		SELECT ` + sessionColumns + ` FROM sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2
+		ORDER BY last_seen_at DESC;
+	`
+	rows, err := r.db.QueryContext(ctx, q, userID, now)
+	if err != nil {
+		return nil, queryError(ctx, "SessionRepository.ListActive", err)
+	}
+	defer rows.Close()
+
+	var res []*session.Session
+	for rows.Next() {
+		s, err := scanSession(rows)
+		if err != nil {
+			return nil, queryError(ctx, "SessionRepository.ListActive", err)
+		}
+		res = append(res, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, "SessionRepository.ListActive", err)
+	}
+	return res, nil
+}
+
+// Revoke отзывает действующую сессию пользователя; иначе — session.ErrNotFound.
+func (r *SessionRepository) Revoke(ctx context.Context, id, userID string) error {
+	ctx, span := startSpan(ctx, "SessionRepository.Revoke")
+	defer span.End()
+
+	const q = `
+		UPDATE sessions SET revoked_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL AND expires_at > NOW();
+	`
+	res, err := r.db.ExecContext(ctx, q, id, userID)
+	if err != nil {
+		return queryError(ctx, "SessionRepository.Revoke", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return queryError(ctx, "SessionRepository.Revoke", err)
+	}
+	if n == 0 {
+		return session.ErrNotFound
+	}
+	return nil
+}
+
+// RevokeAll отзывает все действующие сессии пользователя, кроме exceptID, и возвращает их ID.
+func (r *SessionRepository) RevokeAll(ctx context.Context, userID, exceptID string) ([]string, error) {
+	ctx, span := startSpan(ctx, "SessionRepository.RevokeAll")
+	defer span.End()
+
+	// Истёкшие сессии не трогаем: их токены и так не принимаются.
+	const q = `
+		UPDATE sessions SET revoked_at = NOW()
+		WHERE user_id = $1 AND id::TEXT <> $2 AND revoked_at IS NULL AND expires_at > NOW()
+		RETURNING id;
+	`
+	rows, err := r.db.QueryContext(ctx, q, userID, exceptID)
+	if err != nil {
+		return nil, queryError(ctx, "SessionRepository.RevokeAll", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, queryError(ctx, "SessionRepository.RevokeAll", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, "SessionRepository.RevokeAll", err)
+	}
+	return ids, nil
+}
+
+// TouchLastSeen сдвигает время последней активности сессии вперёд до at.
+func (r *SessionRepository) TouchLastSeen(ctx context.Context, id string, at time.Time) error {
+	ctx, span := startSpan(ctx, "SessionRepository.TouchLastSeen")
+	defer span.End()
+
+	const q = `UPDATE sessions SET last_seen_at = $2 WHERE id = $1 AND last_seen_at < $2;`
+
+	if _, err := r.db.ExecContext(ctx, q, id, at); err != nil {
+		return queryError(ctx, "SessionRepository.TouchLastSeen", err)
+	}
+	return nil
+}
+
+func scanSession(row rowScanner) (*session.Session, error) {
+	var s session.Session
+	err := row.Scan(&s.ID, &s.UserID, &s.UserAgent, &s.IP, &s.CreatedAt, &s.LastSeenAt, &s.ExpiresAt, &s.RevokedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/column"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/sharelink"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
+)
+
+// ShareLinkRepository хранит публичные ссылки на доски в таблице board_share_links.
+type ShareLinkRepository struct {
+	db *sql.DB
+}
+
+func NewShareLinkRepository(db *DB) sharelink.Repository {
+	return &ShareLinkRepository{db: db.DB}
+}
+
+const shareLinkColumns = `id, board_id, created_by, token_hash, token_hint, expires_at, created_at`
+
+func (r *ShareLinkRepository) Create(ctx context.Context, l *sharelink.Link) error {
+	ctx, span := startSpan(ctx, "ShareLinkRepository.Create")
+	defer span.End()
+
+	const q = `
+		INSERT INTO board_share_links (board_id, created_by, token_hash, token_hint, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at;
+	`
+	err := r.db.QueryRowContext(ctx, q, l.BoardID, l.CreatedBy, l.Hash, l.Hint, l.ExpiresAt).
+		Scan(&l.ID, &l.CreatedAt)
+	if err != nil {
+		return queryError(ctx, "ShareLinkRepository.Create", err)
+	}
+	return nil
+}
+
+func (r *ShareLinkRepository) ListByBoard(ctx context.Context, boardID string) ([]*sharelink.Link, error) {
+	ctx, span := startSpan(ctx, "ShareLinkRepository.ListByBoard")
+	defer span.End()
+
+	const q = `SELECT ` + shareLinkColumns + ` FROM board_share_links WHERE board_id = $1 ORDER BY created_at DESC;`
+
+	rows, err := r.db.QueryContext(ctx, q, boardID)
+	if err != nil {
+		return nil, queryError(ctx, "ShareLinkRepository.ListByBoard", err)
+	}
+	defer rows.Close()
+
+	var res []*sharelink.Link
+	for rows.Next() {
+		var l sharelink.Link
+		if err := rows.Scan(&l.ID, &l.BoardID, &l.CreatedBy, &l.Hash, &l.Hint, &l.ExpiresAt, &l.CreatedAt); err != nil {
+			return nil, queryError(ctx, "ShareLinkRepository.ListByBoard", err)
+		}
+		res = append(res, &l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, "ShareLinkRepository.ListByBoard", err)
+	}
+	return res, nil
+}
+
+func (r *ShareLinkRepository) Delete(ctx context.Context, id, boardID string) error {
+	ctx, span := startSpan(ctx, "ShareLinkRepository.Delete")
+	defer span.End()
+
+	const q = `DELETE FROM board_share_links WHERE id = $1 AND board_id = $2;`
+
+	res, err := r.db.ExecContext(ctx, q, id, boardID)
+	if err != nil {
+		return queryError(ctx, "ShareLinkRepository.Delete", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return queryError(ctx, "ShareLinkRepository.Delete", err)
+	}
+	if n == 0 {
+		return sharelink.ErrNotFound
+	}
+	return nil
+}
+
+// Snapshot читает доску, колонки и задачи в одной транзакции REPEATABLE READ,
+// чтобы задача не оказалась в колонке, которой нет в снимке.
+func (r *ShareLinkRepository) Snapshot(ctx context.Context, hash string, now time.Time) (*sharelink.Snapshot, error) {
+	ctx, span := startSpan(ctx, "ShareLinkRepository.Snapshot")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, queryError(ctx, "ShareLinkRepository.Snapshot", err)
+	}
+	defer rollback(ctx, tx)
+
+	const boardQ = `
+		SELECT b.id, b.owner_id, b.workspace_id, b.name, b.archived_at, b.created_at, b.updated_at
+		FROM board_share_links l
+		JOIN boards b ON b.id = l.board_id
+		WHERE l.token_hash = $1 AND (l.expires_at IS NULL OR l.expires_at > $2) AND b.deleted_at IS NULL;
+	`
+	b, err := scanBoard(tx.QueryRowContext(ctx, boardQ, hash, now))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sharelink.ErrNotFound
+		}
+		return nil, queryError(ctx, "ShareLinkRepository.Snapshot", err)
+	}
+	snap := &sharelink.Snapshot{Board: b}
+
+	const columnsQ = `
+		SELECT id, board_id, name, position, created_at, updated_at
+		FROM columns
+		WHERE board_id = $1 AND deleted_at IS NULL
+		ORDER BY position, created_at;
+	`
+	rows, err := tx.QueryContext(ctx, columnsQ, b.ID)
+	if err != nil {
+		return nil, queryError(ctx, "ShareLinkRepository.Snapshot", err)
+	}
+	for rows.Next() {
+		var c column.Column
+		if err := rows.Scan(&c.ID, &c.BoardID, &c.Name, &c.Position, &c.CreatedAt, &c.UpdatedAt); err != nil {
+			rows.Close()
+			return nil, queryError(ctx, "ShareLinkRepository.Snapshot", err)
+		}
+		snap.Columns = append(snap.Columns, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, "ShareLinkRepository.Snapshot", err)
+	}
+
+	const tasksQ = `
+		SELECT id, board_id, column_id, title, description, position, created_at, updated_at
+		FROM tasks
+		WHERE board_id = $1 AND deleted_at IS NULL AND archived_at IS NULL
+		ORDER BY position, created_at;
+	`
+	rows, err = tx.QueryContext(ctx, tasksQ, b.ID)
+	if err != nil {
+		return nil, queryError(ctx, "ShareLinkRepository.Snapshot", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var t task.Task
+		if err := rows.Scan(&t.ID, &t.BoardID, &t.ColumnID, &t.Title, &t.Description, &t.Position, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return nil, queryError(ctx, "ShareLinkRepository.Snapshot", err)
+		}
+		snap.Tasks = append(snap.Tasks, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, "ShareLinkRepository.Snapshot", err)
+	}
+	return snap, nil
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/activity"
 	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
 )
 
@@ -15,6 +16,15 @@ type TaskRepository struct {
 
 // Create создает задачу.
 func (r *TaskRepository) Create(ctx context.Context, t *task.Task) error {
+	ctx, span := startSpan(ctx, "TaskRepository.Create")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, "TaskRepository.Create", err)
+	}
+	defer rollback(ctx, tx)
+
 	const getPos = `
 		SELECT COALESCE(MAX(position) + 1, 1)
 		FROM tasks
@@ -22,8 +32,8 @@ func (r *TaskRepository) Create(ctx context.Context, t *task.Task) error {
 	`
 
 	var pos int
-	if err := r.db.QueryRowContext(ctx, getPos, t.ColumnID).Scan(&pos); err != nil {
-		return err
+	if err := tx.QueryRowContext(ctx, getPos, t.ColumnID).Scan(&pos); err != nil {
+		return queryError(ctx, "TaskRepository.Create", err)
 	}
 
 	const insert = `
@@ -32,28 +42,46 @@ func (r *TaskRepository) Create(ctx context.Context, t *task.Task) error {
 		RETURNING id, created_at, updated_at;
 	`
 
-	if err := r.db.QueryRowContext(ctx, insert, t.BoardID, t.ColumnID, t.Title, t.Description, pos).
+	if err := tx.QueryRowContext(ctx, insert, t.BoardID, t.ColumnID, t.Title, t.Description, pos).
 		Scan(&t.ID, &t.CreatedAt, &t.UpdatedAt); err != nil {
-		return err
+		return queryError(ctx, "TaskRepository.Create", err)
 	}
-
 	t.Position = pos
+
+	// Автор неизвестен: версия и запись журнала остаются без actor_id.
+	if err := recordVersion(ctx, tx, t, ""); err != nil {
+		return queryError(ctx, "TaskRepository.Create", err)
+	}
+	err = recordActivity(ctx, tx, activity.Entry{
+		BoardID: t.BoardID, Action: activity.TaskCreated,
+		EntityType: activity.EntityTask, EntityID: t.ID, After: activity.TaskFields(t),
+	})
+	if err != nil {
+		return queryError(ctx, "TaskRepository.Create", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return queryError(ctx, "TaskRepository.Create", err)
+	}
 	return nil
 }
 
-// ListByBoard — все задачи доски.
+// ListByBoard — все задачи доски, кроме архивных.
 func (r *TaskRepository) ListByBoard(ctx context.Context, boardID string) ([]task.Task, error) {
+	ctx, span := startSpan(ctx, "TaskRepository.ListByBoard")
+	defer span.End()
+
 	const (
 		q = `
 		SELECT id, board_id, column_id, title, description, position, created_at, updated_at
 		FROM tasks
-		WHERE board_id = $1
+		WHERE board_id = $1 AND deleted_at IS NULL AND archived_at IS NULL
 		ORDER BY position, created_at;
 	`
 	)
 	rows, err := r.db.QueryContext(ctx, q, boardID)
 	if err != nil {
-		return nil, err
+		return nil, queryError(ctx, "TaskRepository.ListByBoard", err)
 	}
 	defer rows.Close()
 
@@ -70,30 +98,33 @@ func (r *TaskRepository) ListByBoard(ctx context.Context, boardID string) ([]tas
 			&t.CreatedAt,
 			&t.UpdatedAt,
 		); err != nil {
-			return nil, err
+			return nil, queryError(ctx, "TaskRepository.ListByBoard", err)
 		}
 		res = append(res, t)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, err
+		return nil, queryError(ctx, "TaskRepository.ListByBoard", err)
 	}
 
 	return res, nil
 }
 
-// ListByColumn — все задачи колонки.
+// ListByColumn — все задачи колонки, кроме архивных.
 func (r *TaskRepository) ListByColumn(ctx context.Context, columnID string) ([]task.Task, error) {
+	ctx, span := startSpan(ctx, "TaskRepository.ListByColumn")
+	defer span.End()
+
 	const q = `
 		SELECT id, board_id, column_id, title, description, position, created_at, updated_at
 		FROM tasks
-		WHERE column_id = $1
+		WHERE column_id = $1 AND deleted_at IS NULL AND archived_at IS NULL
 		ORDER BY position, created_at;
 	`
 
 	rows, err := r.db.QueryContext(ctx, q, columnID)
 	if err != nil {
-		return nil, err
+		return nil, queryError(ctx, "TaskRepository.ListByColumn", err)
 	}
 	defer rows.Close()
 
@@ -110,13 +141,13 @@ func (r *TaskRepository) ListByColumn(ctx context.Context, columnID string) ([]t
 			&t.CreatedAt,
 			&t.UpdatedAt,
 		); err != nil {
-			return nil, err
+			return nil, queryError(ctx, "TaskRepository.ListByColumn", err)
 		}
 		res = append(res, t)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, err
+		return nil, queryError(ctx, "TaskRepository.ListByColumn", err)
 	}
 
 	return res, nil
@@ -124,22 +155,49 @@ func (r *TaskRepository) ListByColumn(ctx context.Context, columnID string) ([]t
 
 // Update обновляет задачу, проверяя владельца доски.
 func (r *TaskRepository) Update(ctx context.Context, t *task.Task, ownerID string) error {
+	ctx, span := startSpan(ctx, "TaskRepository.Update")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, "TaskRepository.Update", err)
+	}
+	defer rollback(ctx, tx)
+
+	lock := `
+		SELECT t.column_id, t.title, t.description, t.position
+		FROM tasks t
+		JOIN boards b ON b.id = t.board_id
+		WHERE t.id = $1
+		  AND t.board_id = $2
+		  AND t.deleted_at IS NULL
+		  AND ` + boardAccessible("b", "$3") + `
+		FOR UPDATE OF t;
+	`
+	var before task.Task
+	err = tx.QueryRowContext(ctx, lock, t.ID, t.BoardID, ownerID).
+		Scan(&before.ColumnID, &before.Title, &before.Description, &before.Position)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return task.ErrNotFound
+		}
+		return queryError(ctx, "TaskRepository.Update", err)
+	}
+
 	const q = `
 		UPDATE tasks AS t
 		SET column_id = $1,
 		    title = $2,
 		    description = $3,
 		    position = COALESCE(NULLIF($4, 0), t.position),
+		    column_entered_at = CASE WHEN t.column_id = $1 THEN t.column_entered_at ELSE NOW() END,
 		    updated_at = NOW()
-		FROM boards b
 		WHERE t.id = $5
 		  AND t.board_id = $6
-		  AND b.id = t.board_id
-		  AND b.owner_id = $7
-		RETURNING t.id, t.board_id, t.column_id, t.title, t.description, t.position, t.created_at, t.updated_at;
+		RETURNING t.id, t.board_id, t.column_id, t.title, t.description, t.position, t.archived_at, t.created_at, t.updated_at;
 	`
 
-	if err := r.db.QueryRowContext(
+	if err := tx.QueryRowContext(
 		ctx,
 		q,
 		t.ColumnID,
@@ -148,7 +206,6 @@ func (r *TaskRepository) Update(ctx context.Context, t *task.Task, ownerID strin
 		t.Position,
 		t.ID,
 		t.BoardID,
-		ownerID,
 	).Scan(
 		&t.ID,
 		&t.BoardID,
@@ -156,43 +213,77 @@ func (r *TaskRepository) Update(ctx context.Context, t *task.Task, ownerID strin
 		&t.Title,
 		&t.Description,
 		&t.Position,
+		&t.ArchivedAt,
 		&t.CreatedAt,
 		&t.UpdatedAt,
 	); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return task.ErrNotFound
+		return queryError(ctx, "TaskRepository.Update", err)
+	}
+
+	if contentChanged(&before, t) {
+		if err := recordVersion(ctx, tx, t, ownerID); err != nil {
+			return queryError(ctx, "TaskRepository.Update", err)
 		}
-		return err
+	}
+	err = recordChange(ctx, tx, activity.Entry{
+		BoardID: t.BoardID, ActorID: ownerID, Action: activity.TaskUpdated,
+		EntityType: activity.EntityTask, EntityID: t.ID,
+	}, activity.TaskFields(&before), activity.TaskFields(t))
+	if err != nil {
+		return queryError(ctx, "TaskRepository.Update", err)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return queryError(ctx, "TaskRepository.Update", err)
+	}
 	return nil
 }
 
-// Delete удаляет задачу по id, убеждаясь, что она принадлежит указанной доске и колонке, и доска принадлежит ownerID.
+// Delete переносит задачу в корзину, убеждаясь, что она принадлежит указанной доске и колонке, и доска принадлежит ownerID.
 func (r *TaskRepository) Delete(ctx context.Context, id, boardID, columnID, ownerID string) error {
-	const q = `
-		DELETE FROM tasks AS t
-		USING boards b
+	ctx, span := startSpan(ctx, "TaskRepository.Delete")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, "TaskRepository.Delete", err)
+	}
+	defer rollback(ctx, tx)
+
+	q := `
+		UPDATE tasks AS t
+		SET deleted_at = NOW(), deleted_by = $4
+		FROM boards b
 		WHERE t.id = $1
 		  AND t.board_id = $2
 		  AND t.column_id = $3
+		  AND t.deleted_at IS NULL
 		  AND b.id = t.board_id
-		  AND b.owner_id = $4;
+		  AND ` + boardAccessible("b", "$4") + `
+		RETURNING t.column_id, t.title, t.description, t.position;
 	`
 
-	res, err := r.db.ExecContext(ctx, q, id, boardID, columnID, ownerID)
+	var deleted task.Task
+	err = tx.QueryRowContext(ctx, q, id, boardID, columnID, ownerID).
+		Scan(&deleted.ColumnID, &deleted.Title, &deleted.Description, &deleted.Position)
 	if err != nil {
-		return err
+		if errors.Is(err, sql.ErrNoRows) {
+			return task.ErrNotFound
+		}
+		return queryError(ctx, "TaskRepository.Delete", err)
 	}
 
-	n, err := res.RowsAffected()
+	err = recordActivity(ctx, tx, activity.Entry{
+		BoardID: boardID, ActorID: ownerID, Action: activity.TaskDeleted,
+		EntityType: activity.EntityTask, EntityID: id, Before: activity.TaskFields(&deleted),
+	})
 	if err != nil {
-		return err
-	}
-	if n == 0 {
-		return task.ErrNotFound
+		return queryError(ctx, "TaskRepository.Delete", err)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return queryError(ctx, "TaskRepository.Delete", err)
+	}
 	return nil
 }
 
@@ -201,9 +292,12 @@ func NewTaskRepository(db *DB) *TaskRepository {
 	return &TaskRepository{db: db.DB}
 }
 
-// ListByColumnOwner — все задачи колонки, если доска принадлежит ownerID.
+// ListByColumnOwner — все задачи колонки, кроме архивных, если доска принадлежит ownerID.
 func (r *TaskRepository) ListByColumnOwner(ctx context.Context, boardID, columnID, ownerID string) ([]*task.Task, error) {
-	const q = `
+	ctx, span := startSpan(ctx, "TaskRepository.ListByColumnOwner")
+	defer span.End()
+
+	q := `
 		SELECT t.id,
 		       t.board_id,
 		       t.column_id,
@@ -216,13 +310,15 @@ func (r *TaskRepository) ListByColumnOwner(ctx context.Context, boardID, columnI
 		JOIN boards b ON t.board_id = b.id
 		WHERE t.board_id = $1
 		  AND t.column_id = $2
-		  AND b.owner_id = $3
+		  AND t.deleted_at IS NULL
+		  AND t.archived_at IS NULL
+		  AND ` + boardAccessible("b", "$3") + `
 		ORDER BY t.position, t.created_at;
 	`
 
 	rows, err := r.db.QueryContext(ctx, q, boardID, columnID, ownerID)
 	if err != nil {
-		return nil, err
+		return nil, queryError(ctx, "TaskRepository.ListByColumnOwner", err)
 	}
 	defer rows.Close()
 
@@ -239,13 +335,13 @@ func (r *TaskRepository) ListByColumnOwner(ctx context.Context, boardID, columnI
 			&tt.CreatedAt,
 			&tt.UpdatedAt,
 		); err != nil {
-			return nil, err
+			return nil, queryError(ctx, "TaskRepository.ListByColumnOwner", err)
 		}
 		res = append(res, &tt)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, err
+		return nil, queryError(ctx, "TaskRepository.ListByColumnOwner", err)
 	}
 
 	return res, nil
@@ -253,14 +349,18 @@ func (r *TaskRepository) ListByColumnOwner(ctx context.Context, boardID, columnI
 
 // CreateInColumn — создать задачу в колонке конкретного пользователя.
 func (r *TaskRepository) CreateInColumn(ctx context.Context, t *task.Task, boardID, columnID, ownerID string) error {
-	const insert = `
+	ctx, span := startSpan(ctx, "TaskRepository.CreateInColumn")
+	defer span.End()
+
+	insert := `
 		WITH locked_column AS (
 			SELECT c.id, c.board_id
 			FROM columns c
 			JOIN boards b ON c.board_id = b.id
 			WHERE c.id = $1
 			  AND c.board_id = $2
-			  AND b.owner_id = $3
+			  AND c.deleted_at IS NULL
+			  AND ` + boardAccessible("b", "$3") + `
 			FOR UPDATE
 		),
 		next_pos AS (
@@ -275,7 +375,13 @@ func (r *TaskRepository) CreateInColumn(ctx context.Context, t *task.Task, board
 		RETURNING id, board_id, column_id, title, description, position, created_at, updated_at;
 	`
 
-	if err := r.db.QueryRowContext(ctx, insert, columnID, boardID, ownerID, t.Title, t.Description).
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, "TaskRepository.CreateInColumn", err)
+	}
+	defer rollback(ctx, tx)
+
+	if err := tx.QueryRowContext(ctx, insert, columnID, boardID, ownerID, t.Title, t.Description).
 		Scan(
 			&t.ID,
 			&t.BoardID,
@@ -289,44 +395,64 @@ func (r *TaskRepository) CreateInColumn(ctx context.Context, t *task.Task, board
 		if errors.Is(err, sql.ErrNoRows) {
 			return task.ErrNotFound
 		}
-		return err
+		return queryError(ctx, "TaskRepository.CreateInColumn", err)
+	}
+
+	if err := recordVersion(ctx, tx, t, ownerID); err != nil {
+		return queryError(ctx, "TaskRepository.CreateInColumn", err)
+	}
+	err = recordActivity(ctx, tx, activity.Entry{
+		BoardID: t.BoardID, ActorID: ownerID, Action: activity.TaskCreated,
+		EntityType: activity.EntityTask, EntityID: t.ID, After: activity.TaskFields(t),
+	})
+	if err != nil {
+		return queryError(ctx, "TaskRepository.CreateInColumn", err)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return queryError(ctx, "TaskRepository.CreateInColumn", err)
+	}
 	return nil
 }
 
 // MoveToColumn — переместить задачу в другую колонку атомарно с корректировкой позиций и проверкой владельца доски.
 func (r *TaskRepository) MoveToColumn(ctx context.Context, t *task.Task, newColumnID, ownerID string) error {
+	ctx, span := startSpan(ctx, "TaskRepository.MoveToColumn")
+	defer span.End()
+
 	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
 	if err != nil {
-		return err
+		return queryError(ctx, "TaskRepository.MoveToColumn", err)
 	}
 	defer func() {
 		// В случае паники откатываем транзакцию
 		if p := recover(); p != nil {
-			_ = tx.Rollback()
+			rollback(ctx, tx)
 			panic(p)
 		}
 	}()
 
 	// 1) Убедиться, что доска принадлежит ownerID.
-	const checkBoard = `
-        SELECT 1 FROM boards WHERE id = $1 AND owner_id = $2 FOR UPDATE;
+	checkBoard := `
+        SELECT 1 FROM boards b WHERE b.id = $1 AND ` + boardAccessible("b", "$2") + ` FOR UPDATE;
     `
-	if err := tx.QueryRowContext(ctx, checkBoard, t.BoardID, ownerID).Scan(new(int)); err != nil {
+	qctx, qspan := startQuery(ctx, "MoveToColumn.lock_board", checkBoard)
+	err = tx.QueryRowContext(qctx, checkBoard, t.BoardID, ownerID).Scan(new(int))
+	endQuery(qspan, err)
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			_ = tx.Rollback()
+			rollback(ctx, tx)
 			return task.ErrNotFound
 		}
-		_ = tx.Rollback()
-		return err
+		rollback(ctx, tx)
+		return queryError(ctx, "TaskRepository.MoveToColumn", err)
 	}
 
 	// 2) Прочитать задачу и залочить строку для корректного удаления из старой колонки.
 	const selTask = `
-        SELECT id, board_id, column_id, position, title, description, created_at, updated_at
+        SELECT id, board_id, column_id, position, title, description, archived_at, created_at, updated_at
         FROM tasks
-        WHERE id = $1 AND board_id = $2
+        WHERE id = $1 AND board_id = $2 AND deleted_at IS NULL
         FOR UPDATE;
     `
 	var (
@@ -335,15 +461,18 @@ func (r *TaskRepository) MoveToColumn(ctx context.Context, t *task.Task, newColu
 	)
 	var title, description string
 	var createdAt, updatedAt sql.NullTime
-	if err := tx.QueryRowContext(ctx, selTask, t.ID, t.BoardID).Scan(
-		&curID, &curBoardID, &curColumnID, &curPos, &title, &description, &createdAt, &updatedAt,
-	); err != nil {
+	qctx, qspan = startQuery(ctx, "MoveToColumn.lock_task", selTask)
+	err = tx.QueryRowContext(qctx, selTask, t.ID, t.BoardID).Scan(
+		&curID, &curBoardID, &curColumnID, &curPos, &title, &description, &t.ArchivedAt, &createdAt, &updatedAt,
+	)
+	endQuery(qspan, err)
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			_ = tx.Rollback()
+			rollback(ctx, tx)
 			return task.ErrNotFound
 		}
-		_ = tx.Rollback()
-		return err
+		rollback(ctx, tx)
+		return queryError(ctx, "TaskRepository.MoveToColumn", err)
 	}
 
 	// Если перенос в ту же колонку, оставляем порядок как есть и возвращаем текущее состояние задачи.
@@ -361,24 +490,27 @@ func (r *TaskRepository) MoveToColumn(ctx context.Context, t *task.Task, newColu
 			t.UpdatedAt = updatedAt.Time
 		}
 		if err := tx.Commit(); err != nil {
-			_ = tx.Rollback()
-			return err
+			rollback(ctx, tx)
+			return queryError(ctx, "TaskRepository.MoveToColumn", err)
 		}
 		return nil
 	}
 
 	// 3) Проверить, что новая колонка относится к той же доске и залочить строку колонки.
 	const checkCol = `
-        SELECT id FROM columns WHERE id = $1 AND board_id = $2 FOR UPDATE;
+        SELECT id FROM columns WHERE id = $1 AND board_id = $2 AND deleted_at IS NULL FOR UPDATE;
     `
 	var lockedColumnID string
-	if err := tx.QueryRowContext(ctx, checkCol, newColumnID, curBoardID).Scan(&lockedColumnID); err != nil {
+	qctx, qspan = startQuery(ctx, "MoveToColumn.lock_column", checkCol)
+	err = tx.QueryRowContext(qctx, checkCol, newColumnID, curBoardID).Scan(&lockedColumnID)
+	endQuery(qspan, err)
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			_ = tx.Rollback()
+			rollback(ctx, tx)
 			return task.ErrNotFound
 		}
-		_ = tx.Rollback()
-		return err
+		rollback(ctx, tx)
+		return queryError(ctx, "TaskRepository.MoveToColumn", err)
 	}
 
 	// 4) Освободить позицию в исходной колонке (сдвинуть вниз все задачи правее удаляемой).
@@ -387,9 +519,12 @@ func (r *TaskRepository) MoveToColumn(ctx context.Context, t *task.Task, newColu
         SET position = position - 1
         WHERE column_id = $1 AND position > $2;
     `
-	if _, err := tx.ExecContext(ctx, compactSrc, curColumnID, curPos); err != nil {
-		_ = tx.Rollback()
-		return err
+	qctx, qspan = startQuery(ctx, "MoveToColumn.compact_source", compactSrc)
+	_, err = tx.ExecContext(qctx, compactSrc, curColumnID, curPos)
+	endQuery(qspan, err)
+	if err != nil {
+		rollback(ctx, tx)
+		return queryError(ctx, "TaskRepository.MoveToColumn", err)
 	}
 
 	// 5) Найти позицию для вставки в новую колонку (в конец).
@@ -399,41 +534,68 @@ func (r *TaskRepository) MoveToColumn(ctx context.Context, t *task.Task, newColu
         WHERE column_id = $1;
     `
 	var newPos int
-	if err := tx.QueryRowContext(ctx, getDstPos, newColumnID).Scan(&newPos); err != nil {
-		_ = tx.Rollback()
-		return err
+	qctx, qspan = startQuery(ctx, "MoveToColumn.next_position", getDstPos)
+	err = tx.QueryRowContext(qctx, getDstPos, newColumnID).Scan(&newPos)
+	endQuery(qspan, err)
+	if err != nil {
+		rollback(ctx, tx)
+		return queryError(ctx, "TaskRepository.MoveToColumn", err)
 	}
 
-	// 6) Обновить саму задачу: колонка, позиция и updated_at.
+	// 6) Обновить саму задачу: колонка, позиция, время попадания в колонку и updated_at.
 	const updTask = `
         UPDATE tasks
         SET column_id = $1,
             position  = $2,
+            column_entered_at = NOW(),
             updated_at = NOW()
         WHERE id = $3 AND board_id = $4
-        RETURNING id, board_id, column_id, title, description, position, created_at, updated_at;
+        RETURNING id, board_id, column_id, title, description, position, archived_at, created_at, updated_at;
     `
-	if err := tx.QueryRowContext(ctx, updTask, newColumnID, newPos, curID, curBoardID).Scan(
+	qctx, qspan = startQuery(ctx, "MoveToColumn.update_task", updTask)
+	err = tx.QueryRowContext(qctx, updTask, newColumnID, newPos, curID, curBoardID).Scan(
 		&t.ID,
 		&t.BoardID,
 		&t.ColumnID,
 		&t.Title,
 		&t.Description,
 		&t.Position,
+		&t.ArchivedAt,
 		&t.CreatedAt,
 		&t.UpdatedAt,
-	); err != nil {
+	)
+	endQuery(qspan, err)
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			_ = tx.Rollback()
+			rollback(ctx, tx)
 			return task.ErrNotFound
 		}
-		_ = tx.Rollback()
-		return err
+		rollback(ctx, tx)
+		return queryError(ctx, "TaskRepository.MoveToColumn", err)
 	}
 
-	if err := tx.Commit(); err != nil {
-		_ = tx.Rollback()
-		return err
+	// 7) Сохранить новую версию задачи и записать перенос в журнал действий доски.
+	if err := recordVersion(ctx, tx, t, ownerID); err != nil {
+		rollback(ctx, tx)
+		return queryError(ctx, "TaskRepository.MoveToColumn", err)
+	}
+	err = recordActivity(ctx, tx, activity.Entry{
+		BoardID: curBoardID, ActorID: ownerID, Action: activity.TaskMoved,
+		EntityType: activity.EntityTask, EntityID: curID,
+		Before: activity.Fields{"column_id": curColumnID, "position": curPos},
+		After:  activity.Fields{"column_id": t.ColumnID, "position": t.Position},
+	})
+	if err != nil {
+		rollback(ctx, tx)
+		return queryError(ctx, "TaskRepository.MoveToColumn", err)
+	}
+
+	_, qspan = startQuery(ctx, "MoveToColumn.commit", "COMMIT")
+	err = tx.Commit()
+	endQuery(qspan, err)
+	if err != nil {
+		rollback(ctx, tx)
+		return queryError(ctx, "TaskRepository.MoveToColumn", err)
 	}
 	return nil
 }
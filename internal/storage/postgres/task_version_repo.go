@@ -0,0 +1,219 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/activity"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
+)
+
+// Версии задач хранятся в task_versions; TaskRepository реализует и task.VersionRepository.
+
+// recordVersion сохраняет текущее состояние задачи следующей версией. Строка задачи к этому
+// моменту заблокирована транзакцией, поэтому номера версий не пересекаются.
+func recordVersion(ctx context.Context, tx *sql.Tx, t *task.Task, actorID string) error {
+	const q = `
+		INSERT INTO task_versions (task_id, version, column_id, title, description, actor_id)
+		SELECT $1, COALESCE(MAX(version), 0) + 1, $2, $3, $4, NULLIF($5, '')::uuid
+		FROM task_versions
+		WHERE task_id = $1;
+	`
+	qctx, qspan := startQuery(ctx, "recordVersion", q)
+	_, err := tx.ExecContext(qctx, q, t.ID, t.ColumnID, t.Title, t.Description, actorID)
+	endQuery(qspan, err)
+	return err
+}
+
+// contentChanged сообщает, изменилось ли то, что хранится в версии задачи.
+func contentChanged(before, after *task.Task) bool {
+	return before.Title != after.Title || before.Description != after.Description || before.ColumnID != after.ColumnID
+}
+
+const taskVersionColumns = `v.task_id, v.version, v.column_id, v.title, v.description, v.actor_id, v.created_at`
+
+func scanTaskVersion(row rowScanner) (*task.Version, error) {
+	var (
+		v       task.Version
+		actorID sql.NullString
+	)
+	if err := row.Scan(&v.TaskID, &v.Version, &v.ColumnID, &v.Title, &v.Description, &actorID, &v.CreatedAt); err != nil {
+		return nil, err
+	}
+	v.ActorID = actorID.String
+	return &v, nil
+}
+
+// checkTaskAccess проверяет, что задача есть на доске, доступной ownerID.
+func (r *TaskRepository) checkTaskAccess(ctx context.Context, op, boardID, taskID, ownerID string) error {
+	q := `
+		SELECT 1
+		FROM tasks t
+		JOIN boards b ON b.id = t.board_id
+		WHERE t.id = $1 AND t.board_id = $2 AND t.deleted_at IS NULL AND ` + boardAccessible("b", "$3") + `;
+	`
+	if err := r.db.QueryRowContext(ctx, q, taskID, boardID, ownerID).Scan(new(int)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return task.ErrNotFound
+		}
+		return queryError(ctx, op, err)
+	}
+	return nil
+}
+
+// ListVersions возвращает версии задачи, новые первыми.
+func (r *TaskRepository) ListVersions(ctx context.Context, boardID, taskID, ownerID string) ([]*task.Version, error) {
+	ctx, span := startSpan(ctx, "TaskRepository.ListVersions")
+	defer span.End()
+
+	if err := r.checkTaskAccess(ctx, "TaskRepository.ListVersions", boardID, taskID, ownerID); err != nil {
+		return nil, err
+	}
+
+	const q = `
+		SELECT ` + taskVersionColumns + `
+		FROM task_versions v
+		WHERE v.task_id = $1
+		ORDER BY v.version DESC;
+	`
+	rows, err := r.db.QueryContext(ctx, q, taskID)
+	if err != nil {
+		return nil, queryError(ctx, "TaskRepository.ListVersions", err)
+	}
+	defer rows.Close()
+
+	var res []*task.Version
+	for rows.Next() {
+		v, err := scanTaskVersion(rows)
+		if err != nil {
+			return nil, queryError(ctx, "TaskRepository.ListVersions", err)
+		}
+		res = append(res, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, "TaskRepository.ListVersions", err)
+	}
+	return res, nil
+}
+
+// GetVersion возвращает версию задачи: task.ErrNotFound, если задача недоступна,
+// task.ErrVersionNotFound, если нет такой версии.
+func (r *TaskRepository) GetVersion(ctx context.Context, boardID, taskID string, version int, ownerID string) (*task.Version, error) {
+	ctx, span := startSpan(ctx, "TaskRepository.GetVersion")
+	defer span.End()
+
+	if err := r.checkTaskAccess(ctx, "TaskRepository.GetVersion", boardID, taskID, ownerID); err != nil {
+		return nil, err
+	}
+
+	const q = `SELECT ` + taskVersionColumns + ` FROM task_versions v WHERE v.task_id = $1 AND v.version = $2;`
+	v, err := scanTaskVersion(r.db.QueryRowContext(ctx, q, taskID, version))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, task.ErrVersionNotFound
+		}
+		return nil, queryError(ctx, "TaskRepository.GetVersion", err)
+	}
+	return v, nil
+}
+
+// Restore возвращает задаче содержимое версии. Если колонки версии на доске уже нет,
+// задача остаётся в текущей колонке; при переносе она встаёт в конец колонки.
+func (r *TaskRepository) Restore(ctx context.Context, t *task.Task, version int, ownerID string) error {
+	ctx, span := startSpan(ctx, "TaskRepository.Restore")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, "TaskRepository.Restore", err)
+	}
+	defer rollback(ctx, tx)
+
+	lock := `
+		SELECT t.column_id, t.title, t.description, t.position
+		FROM tasks t
+		JOIN boards b ON b.id = t.board_id
+		WHERE t.id = $1
+		  AND t.board_id = $2
+		  AND t.deleted_at IS NULL
+		  AND ` + boardAccessible("b", "$3") + `
+		FOR UPDATE OF t;
+	`
+	var cur task.Task
+	err = tx.QueryRowContext(ctx, lock, t.ID, t.BoardID, ownerID).
+		Scan(&cur.ColumnID, &cur.Title, &cur.Description, &cur.Position)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return task.ErrNotFound
+		}
+		return queryError(ctx, "TaskRepository.Restore", err)
+	}
+
+	const selVersion = `SELECT column_id, title, description FROM task_versions WHERE task_id = $1 AND version = $2;`
+	var v task.Version
+	if err := tx.QueryRowContext(ctx, selVersion, t.ID, version).Scan(&v.ColumnID, &v.Title, &v.Description); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return task.ErrVersionNotFound
+		}
+		return queryError(ctx, "TaskRepository.Restore", err)
+	}
+
+	columnID, position := cur.ColumnID, cur.Position
+	if v.ColumnID != cur.ColumnID {
+		const checkCol = `SELECT 1 FROM columns WHERE id = $1 AND board_id = $2 AND deleted_at IS NULL FOR UPDATE;`
+		err := tx.QueryRowContext(ctx, checkCol, v.ColumnID, t.BoardID).Scan(new(int))
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			// Колонку удалили или она в корзине — восстанавливаем только содержимое.
+		case err != nil:
+			return queryError(ctx, "TaskRepository.Restore", err)
+		default:
+			const getDstPos = `SELECT COALESCE(MAX(position) + 1, 1) FROM tasks WHERE column_id = $1;`
+			if err := tx.QueryRowContext(ctx, getDstPos, v.ColumnID).Scan(&position); err != nil {
+				return queryError(ctx, "TaskRepository.Restore", err)
+			}
+			columnID = v.ColumnID
+		}
+	}
+
+	const upd = `
+		UPDATE tasks
+		SET column_id = $1,
+		    title = $2,
+		    description = $3,
+		    position = $4,
+		    column_entered_at = CASE WHEN column_id = $1 THEN column_entered_at ELSE NOW() END,
+		    updated_at = NOW()
+		WHERE id = $5 AND board_id = $6
+		RETURNING id, board_id, column_id, title, description, position, archived_at, created_at, updated_at;
+	`
+	err = tx.QueryRowContext(ctx, upd, columnID, v.Title, v.Description, position, t.ID, t.BoardID).
+		Scan(&t.ID, &t.BoardID, &t.ColumnID, &t.Title, &t.Description, &t.Position, &t.ArchivedAt, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		return queryError(ctx, "TaskRepository.Restore", err)
+	}
+	// Исходную колонку сжимаем, только когда задача её уже покинула: иначе сдвиг упрётся в uq_tasks_column_position.
+	if columnID != cur.ColumnID {
+		const compactSrc = `UPDATE tasks SET position = position - 1 WHERE column_id = $1 AND position > $2;`
+		if _, err := tx.ExecContext(ctx, compactSrc, cur.ColumnID, cur.Position); err != nil {
+			return queryError(ctx, "TaskRepository.Restore", err)
+		}
+	}
+
+	if err := recordVersion(ctx, tx, t, ownerID); err != nil {
+		return queryError(ctx, "TaskRepository.Restore", err)
+	}
+	err = recordChange(ctx, tx, activity.Entry{
+		BoardID: t.BoardID, ActorID: ownerID, Action: activity.TaskRestored,
+		EntityType: activity.EntityTask, EntityID: t.ID,
+	}, activity.TaskFields(&cur), activity.TaskFields(t))
+	if err != nil {
+		return queryError(ctx, "TaskRepository.Restore", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return queryError(ctx, "TaskRepository.Restore", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+)
+
+// TokenRepository хранит одноразовые токены из писем в таблице account_tokens.
+type TokenRepository struct {
+	db *sql.DB
+}
+
+func NewTokenRepository(db *DB) user.TokenRepository {
+	return &TokenRepository{db: db.DB}
+}
+
+func (r *TokenRepository) Issue(ctx context.Context, t *user.Token) error {
+	ctx, span := startSpan(ctx, "TokenRepository.Issue")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, "TokenRepository.Issue", err)
+	}
+
+	// Действует только последняя ссылка: повторный запрос письма гасит прежние токены.
+	const revoke = `
+		UPDATE account_tokens
+		SET used_at = NOW()
+		WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL;
+	`
+	if _, err := tx.ExecContext(ctx, revoke, t.UserID, string(t.Purpose)); err != nil {
+		rollback(ctx, tx)
+		return queryError(ctx, "TokenRepository.Issue", err)
+	}
+
+	const q = `
+		INSERT INTO account_tokens (user_id, purpose, token_hash, email, expires_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5)
+		RETURNING id, created_at;
+	`
+	if err := tx.QueryRowContext(ctx, q, t.UserID, string(t.Purpose), t.Hash, t.Email, t.ExpiresAt).Scan(&t.ID, &t.CreatedAt); err != nil {
+		rollback(ctx, tx)
+		return queryError(ctx, "TokenRepository.Issue", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		rollback(ctx, tx)
+		return queryError(ctx, "TokenRepository.Issue", err)
+	}
+	return nil
+}
+
+func (r *TokenRepository) Find(ctx context.Context, purpose user.TokenPurpose, hash string) (*user.Token, error) {
+	ctx, span := startSpan(ctx, "TokenRepository.Find")
+	defer span.End()
+
+	const q = `
+		SELECT id, user_id, purpose, token_hash, COALESCE(email, ''), expires_at, used_at, created_at
+		FROM account_tokens
+		WHERE token_hash = $1 AND purpose = $2 AND used_at IS NULL AND expires_at > NOW();
+	`
+	t, err := scanToken(r.db.QueryRowContext(ctx, q, hash, string(purpose)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, user.ErrTokenInvalid
+		}
+		return nil, queryError(ctx, "TokenRepository.Find", err)
+	}
+	return t, nil
+}
+
+func (r *TokenRepository) Consume(ctx context.Context, purpose user.TokenPurpose, hash string) (*user.Token, error) {
+	ctx, span := startSpan(ctx, "TokenRepository.Consume")
+	defer span.End()
+
+	// Условие used_at IS NULL в UPDATE гарантирует, что из конкурентных запросов пройдёт только один.
+	const q = `
+		UPDATE account_tokens
+		SET used_at = NOW()
+		WHERE token_hash = $1 AND purpose = $2 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING id, user_id, purpose, token_hash, COALESCE(email, ''), expires_at, used_at, created_at;
+	`
+	t, err := scanToken(r.db.QueryRowContext(ctx, q, hash, string(purpose)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, user.ErrTokenInvalid
+		}
+		return nil, queryError(ctx, "TokenRepository.Consume", err)
+	}
+	return t, nil
+}
+
+func scanToken(row *sql.Row) (*user.Token, error) {
+	var t user.Token
+	if err := row.Scan(&t.ID, &t.UserID, &t.Purpose, &t.Hash, &t.Email, &t.ExpiresAt, &t.UsedAt, &t.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,286 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/activity"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/column"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/trash"
+)
+
+// TrashRepository — реализация trash.Repository поверх *sql.DB.
+type TrashRepository struct {
+	db *sql.DB
+}
+
+// NewTrashRepository создаёт репозиторий корзины.
+func NewTrashRepository(db *DB) *TrashRepository {
+	return &TrashRepository{db: db.DB}
+}
+
+// List возвращает корзину пользователя. Колонки и задачи досок из корзины не показываются —
+// они вернутся вместе с доской; задачи колонок из корзины — вместе с колонкой.
+func (r *TrashRepository) List(ctx context.Context, userID string) ([]*trash.Item, error) {
+	ctx, span := startSpan(ctx, "TrashRepository.List")
+	defer span.End()
+
+	q := `
+		SELECT 'board', b.id, b.id, '', b.name, b.deleted_at, b.deleted_by
+		FROM boards b
+		WHERE b.deleted_at IS NOT NULL AND ` + boardManageRights("b", "$1") + `
+		UNION ALL
+		SELECT 'column', c.id, c.board_id, '', c.name, c.deleted_at, c.deleted_by
+		FROM columns c
+		JOIN boards b ON b.id = c.board_id
+		WHERE c.deleted_at IS NOT NULL AND ` + boardAccessible("b", "$1") + `
+		UNION ALL
+		SELECT 'task', t.id, t.board_id, t.column_id::text, t.title, t.deleted_at, t.deleted_by
+		FROM tasks t
+		JOIN columns c ON c.id = t.column_id
+		JOIN boards b ON b.id = t.board_id
+		WHERE t.deleted_at IS NOT NULL AND c.deleted_at IS NULL AND ` + boardAccessible("b", "$1") + `
+		ORDER BY 6 DESC, 2;
+	`
+	rows, err := r.db.QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, queryError(ctx, "TrashRepository.List", err)
+	}
+	defer rows.Close()
+
+	var res []*trash.Item
+	for rows.Next() {
+		var (
+			it        trash.Item
+			deletedBy sql.NullString
+		)
+		if err := rows.Scan(&it.Kind, &it.ID, &it.BoardID, &it.ColumnID, &it.Name, &it.DeletedAt, &deletedBy); err != nil {
+			return nil, queryError(ctx, "TrashRepository.List", err)
+		}
+		it.DeletedBy = deletedBy.String
+		res = append(res, &it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, "TrashRepository.List", err)
+	}
+	return res, nil
+}
+
+// RestoreBoard возвращает доску из корзины тому, кто может ею управлять.
+func (r *TrashRepository) RestoreBoard(ctx context.Context, b *board.Board, userID string) error {
+	ctx, span := startSpan(ctx, "TrashRepository.RestoreBoard")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, "TrashRepository.RestoreBoard", err)
+	}
+	defer rollback(ctx, tx)
+
+	q := `
+		UPDATE boards b
+		SET deleted_at = NULL, deleted_by = NULL, updated_at = NOW()
+		WHERE b.id = $1 AND b.deleted_at IS NOT NULL AND ` + boardManageRights("b", "$2") + `
+		RETURNING ` + boardColumns + `;
+	`
+	restored, err := scanBoard(tx.QueryRowContext(ctx, q, b.ID, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return trash.ErrNotFound
+		}
+		return queryError(ctx, "TrashRepository.RestoreBoard", err)
+	}
+
+	err = recordActivity(ctx, tx, activity.Entry{
+		BoardID: restored.ID, ActorID: userID, Action: activity.BoardRestored,
+		EntityType: activity.EntityBoard, EntityID: restored.ID, After: activity.BoardFields(restored),
+	})
+	if err != nil {
+		return queryError(ctx, "TrashRepository.RestoreBoard", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return queryError(ctx, "TrashRepository.RestoreBoard", err)
+	}
+	*b = *restored
+	return nil
+}
+
+// RestoreColumn возвращает колонку из корзины на прежнее место (или в конец доски, если место
+// уже занято) вместе с задачами, удалёнными вместе с ней.
+func (r *TrashRepository) RestoreColumn(ctx context.Context, c *column.Column, userID string) error {
+	ctx, span := startSpan(ctx, "TrashRepository.RestoreColumn")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, "TrashRepository.RestoreColumn", err)
+	}
+	defer rollback(ctx, tx)
+
+	// Блокировка доски не даёт параллельно занять место колонки.
+	lock := `
+		SELECT c.deleted_at
+		FROM columns c
+		JOIN boards b ON b.id = c.board_id
+		WHERE c.id = $1 AND c.deleted_at IS NOT NULL AND ` + boardAccessible("b", "$2") + `
+		FOR UPDATE OF c, b;
+	`
+	var deletedAt time.Time
+	if err := tx.QueryRowContext(ctx, lock, c.ID, userID).Scan(&deletedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return trash.ErrNotFound
+		}
+		return queryError(ctx, "TrashRepository.RestoreColumn", err)
+	}
+
+	const restore = `
+		UPDATE columns c
+		SET deleted_at = NULL, deleted_by = NULL, updated_at = NOW(),
+		    position = CASE
+		        WHEN EXISTS (SELECT 1 FROM columns o WHERE o.board_id = c.board_id AND o.position = c.position AND o.id <> c.id)
+		        THEN (SELECT MAX(o.position) + 1 FROM columns o WHERE o.board_id = c.board_id)
+		        ELSE c.position
+		    END
+		WHERE c.id = $1
+		RETURNING c.id, c.board_id, c.name, c.position, c.done, c.created_at, c.updated_at;
+	`
+	err = tx.QueryRowContext(ctx, restore, c.ID).
+		Scan(&c.ID, &c.BoardID, &c.Name, &c.Position, &c.Done, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		return queryError(ctx, "TrashRepository.RestoreColumn", err)
+	}
+
+	const tasks = `
+		UPDATE tasks
+		SET deleted_at = NULL, deleted_by = NULL
+		WHERE column_id = $1 AND deleted_at = $2;
+	`
+	if _, err := tx.ExecContext(ctx, tasks, c.ID, deletedAt); err != nil {
+		return queryError(ctx, "TrashRepository.RestoreColumn", err)
+	}
+
+	err = recordActivity(ctx, tx, activity.Entry{
+		BoardID: c.BoardID, ActorID: userID, Action: activity.ColumnRestored,
+		EntityType: activity.EntityColumn, EntityID: c.ID, After: activity.ColumnFields(c),
+	})
+	if err != nil {
+		return queryError(ctx, "TrashRepository.RestoreColumn", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return queryError(ctx, "TrashRepository.RestoreColumn", err)
+	}
+	return nil
+}
+
+// RestoreTask возвращает задачу из корзины на прежнее место в колонке (или в конец колонки,
+// если место уже занято). Пока колонка в корзине, задача не восстанавливается.
+func (r *TrashRepository) RestoreTask(ctx context.Context, t *task.Task, userID string) error {
+	ctx, span := startSpan(ctx, "TrashRepository.RestoreTask")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, "TrashRepository.RestoreTask", err)
+	}
+	defer rollback(ctx, tx)
+
+	// Блокировка колонки не даёт параллельно занять место задачи.
+	lock := `
+		SELECT c.deleted_at IS NOT NULL
+		FROM tasks t
+		JOIN columns c ON c.id = t.column_id
+		JOIN boards b ON b.id = t.board_id
+		WHERE t.id = $1 AND t.deleted_at IS NOT NULL AND ` + boardAccessible("b", "$2") + `
+		FOR UPDATE OF t, c;
+	`
+	var columnDeleted bool
+	if err := tx.QueryRowContext(ctx, lock, t.ID, userID).Scan(&columnDeleted); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return trash.ErrNotFound
+		}
+		return queryError(ctx, "TrashRepository.RestoreTask", err)
+	}
+	if columnDeleted {
+		return trash.ErrParentDeleted
+	}
+
+	const restore = `
+		UPDATE tasks t
+		SET deleted_at = NULL, deleted_by = NULL, updated_at = NOW(),
+		    position = CASE
+		        WHEN EXISTS (SELECT 1 FROM tasks o WHERE o.column_id = t.column_id AND o.position = t.position AND o.id <> t.id)
+		        THEN (SELECT MAX(o.position) + 1 FROM tasks o WHERE o.column_id = t.column_id)
+		        ELSE t.position
+		    END
+		WHERE t.id = $1
+		RETURNING t.id, t.board_id, t.column_id, t.title, t.description, t.position, t.archived_at, t.created_at, t.updated_at;
+	`
+	err = tx.QueryRowContext(ctx, restore, t.ID).
+		Scan(&t.ID, &t.BoardID, &t.ColumnID, &t.Title, &t.Description, &t.Position, &t.ArchivedAt, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		return queryError(ctx, "TrashRepository.RestoreTask", err)
+	}
+
+	err = recordActivity(ctx, tx, activity.Entry{
+		BoardID: t.BoardID, ActorID: userID, Action: activity.TaskRestored,
+		EntityType: activity.EntityTask, EntityID: t.ID, After: activity.TaskFields(t),
+	})
+	if err != nil {
+		return queryError(ctx, "TrashRepository.RestoreTask", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return queryError(ctx, "TrashRepository.RestoreTask", err)
+	}
+	return nil
+}
+
+// Purge окончательно удаляет доски, колонки и задачи, попавшие в корзину раньше before.
+// Вместе с доской удаляются её колонки, задачи и журнал действий, вместе с колонкой — её задачи.
+// Пока очистку выполняет другой экземпляр сервиса, возвращает 0.
+func (r *TrashRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := startSpan(ctx, "TrashRepository.Purge")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return 0, queryError(ctx, "TrashRepository.Purge", err)
+	}
+	defer rollback(ctx, tx)
+
+	locked, err := tryJobLock(ctx, tx, purgeTrashLockKey)
+	if err != nil {
+		return 0, queryError(ctx, "TrashRepository.Purge", err)
+	}
+	if !locked {
+		return 0, nil
+	}
+
+	var total int64
+	for _, q := range []string{
+		`DELETE FROM boards WHERE deleted_at < $1;`,
+		`DELETE FROM columns WHERE deleted_at < $1;`,
+		`DELETE FROM tasks WHERE deleted_at < $1;`,
+	} {
+		res, err := tx.ExecContext(ctx, q, before)
+		if err != nil {
+			return 0, queryError(ctx, "TrashRepository.Purge", err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return 0, queryError(ctx, "TrashRepository.Purge", err)
+		}
+		total += n
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, queryError(ctx, "TrashRepository.Purge", err)
+	}
+	return total, nil
+}
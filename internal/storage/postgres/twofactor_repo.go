@@ -0,0 +1,204 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+)
+
+// TwoFactorRepository хранит настройки TOTP в user_totp и коды восстановления в user_recovery_codes.
+type TwoFactorRepository struct {
+	db *sql.DB
+}
+
+func NewTwoFactorRepository(db *DB) user.TwoFactorRepository {
+	return &TwoFactorRepository{db: db.DB}
+}
+
+func (r *TwoFactorRepository) Get(ctx context.Context, userID string) (*user.TwoFactor, error) {
+	ctx, span := startSpan(ctx, "TwoFactorRepository.Get")
+	defer span.End()
+
+	const q = `
+		SELECT t.user_id, t.secret, t.confirmed_at, t.last_used_step, t.created_at,
+		       (SELECT COUNT(*) FROM user_recovery_codes c WHERE c.user_id = t.user_id AND c.used_at IS NULL)
+		FROM user_totp t
+		WHERE t.user_id = $1;
+	`
+	var tf user.TwoFactor
+	err := r.db.QueryRowContext(ctx, q, userID).
+		Scan(&tf.UserID, &tf.Secret, &tf.ConfirmedAt, &tf.LastUsedStep, &tf.CreatedAt, &tf.RecoveryCodesLeft)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, user.ErrTwoFactorNotFound
+		}
+		return nil, queryError(ctx, "TwoFactorRepository.Get", err)
+	}
+	return &tf, nil
+}
+
+func (r *TwoFactorRepository) Begin(ctx context.Context, userID, secret string) error {
+	ctx, span := startSpan(ctx, "TwoFactorRepository.Begin")
+	defer span.End()
+
+	// Включённую 2FA повторное подключение не затирает: сначала её нужно отключить.
+	const q = `
+		INSERT INTO user_totp (user_id, secret)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, last_used_step = 0, created_at = NOW()
+		WHERE user_totp.confirmed_at IS NULL;
+	`
+	if _, err := r.db.ExecContext(ctx, q, userID, secret); err != nil {
+		return queryError(ctx, "TwoFactorRepository.Begin", err)
+	}
+	return nil
+}
+
+func (r *TwoFactorRepository) Confirm(ctx context.Context, userID string, step int64, recoveryHashes []string) error {
+	ctx, span := startSpan(ctx, "TwoFactorRepository.Confirm")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, "TwoFactorRepository.Confirm", err)
+	}
+
+	const q = `
+		UPDATE user_totp
+		SET confirmed_at = NOW(), last_used_step = $2
+		WHERE user_id = $1 AND confirmed_at IS NULL;
+	`
+	res, err := tx.ExecContext(ctx, q, userID, step)
+	if err != nil {
+		rollback(ctx, tx)
+		return queryError(ctx, "TwoFactorRepository.Confirm", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		rollback(ctx, tx)
+		return queryError(ctx, "TwoFactorRepository.Confirm", err)
+	}
+	if n == 0 {
+		rollback(ctx, tx)
+		return user.ErrTwoFactorNotFound
+	}
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, recoveryHashes); err != nil {
+		rollback(ctx, tx)
+		return queryError(ctx, "TwoFactorRepository.Confirm", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		rollback(ctx, tx)
+		return queryError(ctx, "TwoFactorRepository.Confirm", err)
+	}
+	return nil
+}
+
+func (r *TwoFactorRepository) UseStep(ctx context.Context, userID string, step int64) (bool, error) {
+	ctx, span := startSpan(ctx, "TwoFactorRepository.UseStep")
+	defer span.End()
+
+	const q = `
+		UPDATE user_totp
+		SET last_used_step = $2
+		WHERE user_id = $1 AND confirmed_at IS NOT NULL AND last_used_step < $2;
+	`
+	res, err := r.db.ExecContext(ctx, q, userID, step)
+	if err != nil {
+		return false, queryError(ctx, "TwoFactorRepository.UseStep", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, queryError(ctx, "TwoFactorRepository.UseStep", err)
+	}
+	return n > 0, nil
+}
+
+func (r *TwoFactorRepository) UseRecoveryCode(ctx context.Context, userID, hash string) error {
+	ctx, span := startSpan(ctx, "TwoFactorRepository.UseRecoveryCode")
+	defer span.End()
+
+	const q = `
+		UPDATE user_recovery_codes
+		SET used_at = NOW()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL;
+	`
+	res, err := r.db.ExecContext(ctx, q, userID, hash)
+	if err != nil {
+		return queryError(ctx, "TwoFactorRepository.UseRecoveryCode", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return queryError(ctx, "TwoFactorRepository.UseRecoveryCode", err)
+	}
+	if n == 0 {
+		return user.ErrRecoveryCodeInvalid
+	}
+	return nil
+}
+
+func (r *TwoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, userID string, hashes []string) error {
+	ctx, span := startSpan(ctx, "TwoFactorRepository.ReplaceRecoveryCodes")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, "TwoFactorRepository.ReplaceRecoveryCodes", err)
+	}
+	if err := replaceRecoveryCodes(ctx, tx, userID, hashes); err != nil {
+		rollback(ctx, tx)
+		return queryError(ctx, "TwoFactorRepository.ReplaceRecoveryCodes", err)
+	}
+	if err := tx.Commit(); err != nil {
+		rollback(ctx, tx)
+		return queryError(ctx, "TwoFactorRepository.ReplaceRecoveryCodes", err)
+	}
+	return nil
+}
+
+func (r *TwoFactorRepository) Delete(ctx context.Context, userID string) error {
+	ctx, span := startSpan(ctx, "TwoFactorRepository.Delete")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, "TwoFactorRepository.Delete", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_recovery_codes WHERE user_id = $1;`, userID); err != nil {
+		rollback(ctx, tx)
+		return queryError(ctx, "TwoFactorRepository.Delete", err)
+	}
+	res, err := tx.ExecContext(ctx, `DELETE FROM user_totp WHERE user_id = $1;`, userID)
+	if err != nil {
+		rollback(ctx, tx)
+		return queryError(ctx, "TwoFactorRepository.Delete", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		rollback(ctx, tx)
+		return queryError(ctx, "TwoFactorRepository.Delete", err)
+	}
+	if n == 0 {
+		rollback(ctx, tx)
+		return user.ErrTwoFactorNotFound
+	}
+	if err := tx.Commit(); err != nil {
+		rollback(ctx, tx)
+		return queryError(ctx, "TwoFactorRepository.Delete", err)
+	}
+	return nil
+}
+
+// replaceRecoveryCodes удаляет все коды пользователя (и использованные) и сохраняет новые.
+func replaceRecoveryCodes(ctx context.Context, tx *sql.Tx, userID string, hashes []string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_recovery_codes WHERE user_id = $1;`, userID); err != nil {
+		return err
+	}
+	const q = `INSERT INTO user_recovery_codes (user_id, code_hash) SELECT $1, UNNEST($2::TEXT[]);`
+	_, err := tx.ExecContext(ctx, q, userID, hashes)
+	return err
+}
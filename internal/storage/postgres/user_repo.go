@@ -18,63 +18,257 @@ func NewUserRepository(db *DB) user.Repository {
 	return &UserRepository{db: db.DB}
 }
 
+const userColumns = `id, email, password_hash, email_verified_at, display_name, avatar_url, timezone, locale, created_at, updated_at`
+
+func scanUser(row *sql.Row) (*user.User, error) {
+	var u user.User
+	err := row.Scan(&u.ID, &u.Email, &u.PasswordHash, &u.EmailVerifiedAt,
+		&u.DisplayName, &u.AvatarURL, &u.Timezone, &u.Locale, &u.CreatedAt, &u.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
 func (r *UserRepository) Create(ctx context.Context, u *user.User) error {
+	ctx, span := startSpan(ctx, "UserRepository.Create")
+	defer span.End()
+
 	const q = `
 		INSERT INTO users (email, password_hash)
 		VALUES ($1, $2)
-		RETURNING id, created_at;
+		RETURNING id, created_at, updated_at;
 	`
 
 	err := r.db.QueryRowContext(ctx, q, u.Email, u.PasswordHash).
-		Scan(&u.ID, &u.CreatedAt)
+		Scan(&u.ID, &u.CreatedAt, &u.UpdatedAt)
 	if err != nil {
 		// Нарушение UNIQUE (email) → бизнес-ошибка домена
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
 			return user.ErrEmailAlreadyUsed
 		}
-		return err
+		return queryError(ctx, "UserRepository.Create", err)
 	}
 
 	return nil
 }
 
 func (r *UserRepository) GetByID(ctx context.Context, id string) (*user.User, error) {
+	ctx, span := startSpan(ctx, "UserRepository.GetByID")
+	defer span.End()
+
 	const q = `
-		SELECT id, email, password_hash, created_at
+		SELECT ` + userColumns + `
 		FROM users
 		WHERE id = $1;
 	`
 
-	var u user.User
-	err := r.db.QueryRowContext(ctx, q, id).
-		Scan(&u.ID, &u.Email, &u.PasswordHash, &u.CreatedAt)
+	u, err := scanUser(r.db.QueryRowContext(ctx, q, id))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, user.ErrNotFound
 		}
-		return nil, err
+		return nil, queryError(ctx, "UserRepository.GetByID", err)
 	}
 
-	return &u, nil
+	return u, nil
 }
 
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*user.User, error) {
+	ctx, span := startSpan(ctx, "UserRepository.GetByEmail")
+	defer span.End()
+
 	const q = `
-		SELECT id, email, password_hash, created_at
+		SELECT ` + userColumns + `
 		FROM users
 		WHERE email = $1;
 	`
 
-	var u user.User
-	err := r.db.QueryRowContext(ctx, q, email).
-		Scan(&u.ID, &u.Email, &u.PasswordHash, &u.CreatedAt)
+	u, err := scanUser(r.db.QueryRowContext(ctx, q, email))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, user.ErrNotFound
 		}
-		return nil, err
+		return nil, queryError(ctx, "UserRepository.GetByEmail", err)
 	}
 
-	return &u, nil
+	return u, nil
+}
+
+func (r *UserRepository) MarkEmailVerified(ctx context.Context, id string) error {
+	ctx, span := startSpan(ctx, "UserRepository.MarkEmailVerified")
+	defer span.End()
+
+	const q = `
+		UPDATE users
+		SET email_verified_at = COALESCE(email_verified_at, NOW())
+		WHERE id = $1;
+	`
+
+	res, err := r.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return queryError(ctx, "UserRepository.MarkEmailVerified", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return queryError(ctx, "UserRepository.MarkEmailVerified", err)
+	}
+	if n == 0 {
+		return user.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) UpdatePassword(ctx context.Context, id, passwordHash string) error {
+	ctx, span := startSpan(ctx, "UserRepository.UpdatePassword")
+	defer span.End()
+
+	const q = `
+		UPDATE users
+		SET password_hash = $2, updated_at = NOW()
+		WHERE id = $1;
+	`
+
+	res, err := r.db.ExecContext(ctx, q, id, passwordHash)
+	if err != nil {
+		return queryError(ctx, "UserRepository.UpdatePassword", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return queryError(ctx, "UserRepository.UpdatePassword", err)
+	}
+	if n == 0 {
+		return user.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) UpdateProfile(ctx context.Context, u *user.User) error {
+	ctx, span := startSpan(ctx, "UserRepository.UpdateProfile")
+	defer span.End()
+
+	const q = `
+		UPDATE users
+		SET display_name = $2, avatar_url = $3, timezone = $4, locale = $5, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at;
+	`
+
+	err := r.db.QueryRowContext(ctx, q, u.ID, u.DisplayName, u.AvatarURL, u.Timezone, u.Locale).
+		Scan(&u.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return user.ErrNotFound
+		}
+		return queryError(ctx, "UserRepository.UpdateProfile", err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) UpdateEmail(ctx context.Context, id, email string) error {
+	ctx, span := startSpan(ctx, "UserRepository.UpdateEmail")
+	defer span.End()
+
+	// Новый адрес подтверждён переходом по ссылке, поэтому сразу отмечаем его.
+	const q = `
+		UPDATE users
+		SET email = $2, email_verified_at = NOW(), updated_at = NOW()
+		WHERE id = $1;
+	`
+
+	res, err := r.db.ExecContext(ctx, q, id, email)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return user.ErrEmailAlreadyUsed
+		}
+		return queryError(ctx, "UserRepository.UpdateEmail", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return queryError(ctx, "UserRepository.UpdateEmail", err)
+	}
+	if n == 0 {
+		return user.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete удаляет пользователя; доски, колонки и задачи удаляются каскадом (ON DELETE CASCADE).
+func (r *UserRepository) Delete(ctx context.Context, id string) error {
+	ctx, span := startSpan(ctx, "UserRepository.Delete")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, "UserRepository.Delete", err)
+	}
+
+	// Пространства, где пользователь — единственный владелец, переходят следующему участнику:
+	// сначала администратору, затем тому, кто вступил раньше.
+	const promote = `
+		UPDATE workspace_members m
+		SET role = 'owner'
+		FROM (
+			SELECT DISTINCT ON (c.workspace_id) c.workspace_id, c.user_id
+			FROM workspace_members c
+			JOIN workspace_members me ON me.workspace_id = c.workspace_id AND me.user_id = $1 AND me.role = 'owner'
+			WHERE c.user_id <> $1
+			  AND NOT EXISTS (SELECT 1 FROM workspace_members o
+			                  WHERE o.workspace_id = c.workspace_id AND o.role = 'owner' AND o.user_id <> $1)
+			ORDER BY c.workspace_id, c.role = 'admin' DESC, c.joined_at
+		) s
+		WHERE m.workspace_id = s.workspace_id AND m.user_id = s.user_id;
+	`
+	// Доски пространств, созданные пользователем, переходят владельцу пространства, чтобы не удалиться вместе с ним.
+	const reassign = `
+		UPDATE boards b
+		SET owner_id = (SELECT o.user_id FROM workspace_members o
+		                WHERE o.workspace_id = b.workspace_id AND o.role = 'owner' AND o.user_id <> $1
+		                ORDER BY o.joined_at LIMIT 1)
+		WHERE b.owner_id = $1
+		  AND EXISTS (SELECT 1 FROM workspace_members o
+		              WHERE o.workspace_id = b.workspace_id AND o.role = 'owner' AND o.user_id <> $1);
+	`
+	// Пространства, где больше никого нет, удаляются вместе с досками.
+	const orphaned = `
+		DELETE FROM workspaces w
+		WHERE EXISTS (SELECT 1 FROM workspace_members m WHERE m.workspace_id = w.id AND m.user_id = $1)
+		  AND NOT EXISTS (SELECT 1 FROM workspace_members m WHERE m.workspace_id = w.id AND m.user_id <> $1);
+	`
+	for _, q := range []string{promote, reassign, orphaned} {
+		if _, err := tx.ExecContext(ctx, q, id); err != nil {
+			rollback(ctx, tx)
+			return queryError(ctx, "UserRepository.Delete", err)
+		}
+	}
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = $1;`, id)
+	if err != nil {
+		rollback(ctx, tx)
+		return queryError(ctx, "UserRepository.Delete", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		rollback(ctx, tx)
+		return queryError(ctx, "UserRepository.Delete", err)
+	}
+	if n == 0 {
+		rollback(ctx, tx)
+		return user.ErrNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return queryError(ctx, "UserRepository.Delete", err)
+	}
+	return nil
 }
@@ -0,0 +1,330 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/workspace"
+)
+
+// WorkspaceRepository хранит рабочие пространства и их участников.
+type WorkspaceRepository struct {
+	db *sql.DB
+}
+
+func NewWorkspaceRepository(db *DB) workspace.Repository {
+	return &WorkspaceRepository{db: db.DB}
+}
+
+func (r *WorkspaceRepository) Create(ctx context.Context, w *workspace.Workspace, ownerID string) error {
+	ctx, span := startSpan(ctx, "WorkspaceRepository.Create")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, "WorkspaceRepository.Create", err)
+	}
+	defer rollback(ctx, tx)
+
+	const insert = `
+		INSERT INTO workspaces (name)
+		VALUES ($1)
+		RETURNING id, created_at, updated_at;
+	`
+	if err := tx.QueryRowContext(ctx, insert, w.Name).Scan(&w.ID, &w.CreatedAt, &w.UpdatedAt); err != nil {
+		return queryError(ctx, "WorkspaceRepository.Create", err)
+	}
+
+	const member = `INSERT INTO workspace_members (workspace_id, user_id, role) VALUES ($1, $2, $3);`
+	if _, err := tx.ExecContext(ctx, member, w.ID, ownerID, workspace.RoleOwner); err != nil {
+		return queryError(ctx, "WorkspaceRepository.Create", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return queryError(ctx, "WorkspaceRepository.Create", err)
+	}
+	w.Role = workspace.RoleOwner
+	return nil
+}
+
+func (r *WorkspaceRepository) ListByMember(ctx context.Context, userID string) ([]*workspace.Workspace, error) {
+	ctx, span := startSpan(ctx, "WorkspaceRepository.ListByMember")
+	defer span.End()
+
+	const q = `
+		SELECT w.id, w.name, m.role, w.created_at, w.updated_at
+		FROM workspaces w
+		JOIN workspace_members m ON m.workspace_id = w.id
+		WHERE m.user_id = $1
+		ORDER BY w.created_at;
+	`
+	rows, err := r.db.QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, queryError(ctx, "WorkspaceRepository.ListByMember", err)
+	}
+	defer rows.Close()
+
+	var res []*workspace.Workspace
+	for rows.Next() {
+		w, err := scanWorkspace(rows)
+		if err != nil {
+			return nil, queryError(ctx, "WorkspaceRepository.ListByMember", err)
+		}
+		res = append(res, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, "WorkspaceRepository.ListByMember", err)
+	}
+	return res, nil
+}
+
+func (r *WorkspaceRepository) GetForMember(ctx context.Context, id, userID string) (*workspace.Workspace, error) {
+	ctx, span := startSpan(ctx, "WorkspaceRepository.GetForMember")
+	defer span.End()
+
+	const q = `
+		SELECT w.id, w.name, m.role, w.created_at, w.updated_at
+		FROM workspaces w
+		JOIN workspace_members m ON m.workspace_id = w.id
+		WHERE w.id = $1 AND m.user_id = $2;
+	`
+	w, err := scanWorkspace(r.db.QueryRowContext(ctx, q, id, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, workspace.ErrNotFound
+		}
+		return nil, queryError(ctx, "WorkspaceRepository.GetForMember", err)
+	}
+	return w, nil
+}
+
+func (r *WorkspaceRepository) Rename(ctx context.Context, w *workspace.Workspace) error {
+	ctx, span := startSpan(ctx, "WorkspaceRepository.Rename")
+	defer span.End()
+
+	const q = `
+		UPDATE workspaces
+		SET name = $1, updated_at = NOW()
+		WHERE id = $2
+		RETURNING created_at, updated_at;
+	`
+	if err := r.db.QueryRowContext(ctx, q, w.Name, w.ID).Scan(&w.CreatedAt, &w.UpdatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return workspace.ErrNotFound
+		}
+		return queryError(ctx, "WorkspaceRepository.Rename", err)
+	}
+	return nil
+}
+
+func (r *WorkspaceRepository) Delete(ctx context.Context, id string) error {
+	ctx, span := startSpan(ctx, "WorkspaceRepository.Delete")
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, "WorkspaceRepository.Delete", err)
+	}
+	defer rollback(ctx, tx)
+
+	// Блокировка строки пространства не даёт параллельно создать в нём доску.
+	const lock = `SELECT 1 FROM workspaces WHERE id = $1 FOR UPDATE;`
+	if err := tx.QueryRowContext(ctx, lock, id).Scan(new(int)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return workspace.ErrNotFound
+		}
+		return queryError(ctx, "WorkspaceRepository.Delete", err)
+	}
+
+	// Доски из корзины пространство не держат.
+	var hasBoards bool
+	const boards = `SELECT EXISTS (SELECT 1 FROM boards WHERE workspace_id = $1 AND deleted_at IS NULL);`
+	if err := tx.QueryRowContext(ctx, boards, id).Scan(&hasBoards); err != nil {
+		return queryError(ctx, "WorkspaceRepository.Delete", err)
+	}
+	if hasBoards {
+		return workspace.ErrNotEmpty
+	}
+
+	// Каскад удалил бы их до конца срока хранения: доски из корзины становятся личными досками
+	// того, кто их удалил (или создателя), и ждут восстановления или очистки у него.
+	const detach = `
+		UPDATE boards SET workspace_id = NULL, owner_id = COALESCE(deleted_by, owner_id)
+		WHERE workspace_id = $1 AND deleted_at IS NOT NULL;
+	`
+	if _, err := tx.ExecContext(ctx, detach, id); err != nil {
+		return queryError(ctx, "WorkspaceRepository.Delete", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM workspaces WHERE id = $1;`, id); err != nil {
+		return queryError(ctx, "WorkspaceRepository.Delete", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return queryError(ctx, "WorkspaceRepository.Delete", err)
+	}
+	return nil
+}
+
+const memberColumns = `m.workspace_id, m.user_id, u.email, u.display_name, m.role, m.joined_at`
+
+func (r *WorkspaceRepository) ListMembers(ctx context.Context, workspaceID string) ([]*workspace.Member, error) {
+	ctx, span := startSpan(ctx, "WorkspaceRepository.ListMembers")
+	defer span.End()
+
+	const q = `
+		SELECT ` + memberColumns + `
+		FROM workspace_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.workspace_id = $1
+		ORDER BY m.joined_at, u.email;
+	`
+	rows, err := r.db.QueryContext(ctx, q, workspaceID)
+	if err != nil {
+		return nil, queryError(ctx, "WorkspaceRepository.ListMembers", err)
+	}
+	defer rows.Close()
+
+	var res []*workspace.Member
+	for rows.Next() {
+		m, err := scanMember(rows)
+		if err != nil {
+			return nil, queryError(ctx, "WorkspaceRepository.ListMembers", err)
+		}
+		res = append(res, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryError(ctx, "WorkspaceRepository.ListMembers", err)
+	}
+	return res, nil
+}
+
+func (r *WorkspaceRepository) GetMember(ctx context.Context, workspaceID, userID string) (*workspace.Member, error) {
+	ctx, span := startSpan(ctx, "WorkspaceRepository.GetMember")
+	defer span.End()
+
+	const q = `
+		SELECT ` + memberColumns + `
+		FROM workspace_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.workspace_id = $1 AND m.user_id = $2;
+	`
+	m, err := scanMember(r.db.QueryRowContext(ctx, q, workspaceID, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, workspace.ErrMemberNotFound
+		}
+		return nil, queryError(ctx, "WorkspaceRepository.GetMember", err)
+	}
+	return m, nil
+}
+
+func (r *WorkspaceRepository) AddMember(ctx context.Context, m *workspace.Member) error {
+	ctx, span := startSpan(ctx, "WorkspaceRepository.AddMember")
+	defer span.End()
+
+	const q = `
+		INSERT INTO workspace_members (workspace_id, user_id, role)
+		VALUES ($1, $2, $3)
+		RETURNING joined_at;
+	`
+	if err := r.db.QueryRowContext(ctx, q, m.WorkspaceID, m.UserID, m.Role).Scan(&m.JoinedAt); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return workspace.ErrMemberExists
+		}
+		return queryError(ctx, "WorkspaceRepository.AddMember", err)
+	}
+	return nil
+}
+
+func (r *WorkspaceRepository) SetMemberRole(ctx context.Context, workspaceID, userID string, role workspace.Role) error {
+	return r.changeMember(ctx, "WorkspaceRepository.SetMemberRole", workspaceID, userID, role,
+		`UPDATE workspace_members SET role = $3 WHERE workspace_id = $1 AND user_id = $2;`, role)
+}
+
+func (r *WorkspaceRepository) RemoveMember(ctx context.Context, workspaceID, userID string) error {
+	return r.changeMember(ctx, "WorkspaceRepository.RemoveMember", workspaceID, userID, "",
+		`DELETE FROM workspace_members WHERE workspace_id = $1 AND user_id = $2;`)
+}
+
+// changeMember выполняет q над участником, следя, чтобы в пространстве остался владелец:
+// newRole — роль участника после изменения (пустая — участник исключается).
+func (r *WorkspaceRepository) changeMember(ctx context.Context, op, workspaceID, userID string, newRole workspace.Role, q string, args ...any) error {
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return queryError(ctx, op, err)
+	}
+	defer rollback(ctx, tx)
+
+	// Строки участников блокируются целиком, чтобы два владельца не разжаловали друг друга одновременно.
+	const sel = `
+		SELECT user_id, role FROM workspace_members
+		WHERE workspace_id = $1
+		FOR UPDATE;
+	`
+	rows, err := tx.QueryContext(ctx, sel, workspaceID)
+	if err != nil {
+		return queryError(ctx, op, err)
+	}
+	var (
+		current     workspace.Role
+		otherOwners int
+	)
+	for rows.Next() {
+		var (
+			id   string
+			role workspace.Role
+		)
+		if err := rows.Scan(&id, &role); err != nil {
+			_ = rows.Close()
+			return queryError(ctx, op, err)
+		}
+		switch {
+		case id == userID:
+			current = role
+		case role == workspace.RoleOwner:
+			otherOwners++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return queryError(ctx, op, err)
+	}
+	_ = rows.Close()
+
+	if current == "" {
+		return workspace.ErrMemberNotFound
+	}
+	if current == workspace.RoleOwner && newRole != workspace.RoleOwner && otherOwners == 0 {
+		return workspace.ErrLastOwner
+	}
+
+	if _, err := tx.ExecContext(ctx, q, append([]any{workspaceID, userID}, args...)...); err != nil {
+		return queryError(ctx, op, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return queryError(ctx, op, err)
+	}
+	return nil
+}
+
+func scanWorkspace(row rowScanner) (*workspace.Workspace, error) {
+	var w workspace.Workspace
+	if err := row.Scan(&w.ID, &w.Name, &w.Role, &w.CreatedAt, &w.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+func scanMember(row rowScanner) (*workspace.Member, error) {
+	var m workspace.Member
+	if err := row.Scan(&m.WorkspaceID, &m.UserID, &m.Email, &m.DisplayName, &m.Role, &m.JoinedAt); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
@@ -0,0 +1,8 @@
+-- Учёт применённых миграций: /readyz сверяет максимальную версию с ожидаемой приложением
+-- (postgres.ExpectedSchemaVersion). Каждая следующая миграция добавляет сюда свою версию.
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version    INT PRIMARY KEY,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO schema_migrations (version) VALUES (1), (2) ON CONFLICT DO NOTHING;
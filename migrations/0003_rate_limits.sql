@@ -0,0 +1,13 @@
+-- Состояние ограничителей частоты и блокировок входа для нескольких реплик.
+-- Ключи имеют префикс по назначению: auth:ip:..., auth:email:..., lockout:...
+CREATE TABLE IF NOT EXISTS rate_limits (
+    key          TEXT PRIMARY KEY,
+    tokens       DOUBLE PRECISION NOT NULL DEFAULT 0,
+    failures     INT NOT NULL DEFAULT 0,
+    locked_until TIMESTAMPTZ,
+    updated_at   TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS rate_limits_updated_at_idx ON rate_limits(updated_at);
+
+INSERT INTO schema_migrations (version) VALUES (3) ON CONFLICT DO NOTHING;
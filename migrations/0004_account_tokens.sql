@@ -0,0 +1,19 @@
+-- Подтверждение email и одноразовые токены из писем (подтверждение, сброс пароля).
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMPTZ;
+
+-- Уже зарегистрированные пользователи считаются подтверждёнными, чтобы REQUIRE_VERIFIED_EMAIL их не заблокировал.
+UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS account_tokens (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    purpose    TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS account_tokens_user_purpose_idx ON account_tokens(user_id, purpose);
+
+INSERT INTO schema_migrations (version) VALUES (4) ON CONFLICT DO NOTHING;
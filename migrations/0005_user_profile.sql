@@ -0,0 +1,11 @@
+-- Профиль пользователя и смена email с подтверждением нового адреса.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS display_name TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS avatar_url   TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS timezone     TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locale       TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+-- Новый адрес, который станет email пользователя после перехода по ссылке (purpose = change_email).
+ALTER TABLE account_tokens ADD COLUMN IF NOT EXISTS email TEXT;
+
+INSERT INTO schema_migrations (version) VALUES (5) ON CONFLICT DO NOTHING;
@@ -0,0 +1,17 @@
+-- Личные токены доступа для скриптов и интеграций; хранится только хэш токена.
+CREATE TABLE IF NOT EXISTS access_tokens (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id      UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name         TEXT NOT NULL,
+    token_hash   TEXT NOT NULL UNIQUE,
+    token_hint   TEXT NOT NULL,
+    -- Разрешения через запятую: read, write, admin.
+    scopes       TEXT NOT NULL,
+    expires_at   TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS access_tokens_user_idx ON access_tokens(user_id, created_at DESC);
+
+INSERT INTO schema_migrations (version) VALUES (6) ON CONFLICT DO NOTHING;
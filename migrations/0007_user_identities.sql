@@ -0,0 +1,14 @@
+-- Привязки пользователей к внешним провайдерам входа (OpenID Connect).
+-- Пользователи, созданные через SSO, не имеют пароля: password_hash у них пустой.
+CREATE TABLE IF NOT EXISTS user_identities (
+    provider   TEXT NOT NULL,
+    subject    TEXT NOT NULL,
+    user_id    UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email      TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (provider, subject)
+);
+
+CREATE INDEX IF NOT EXISTS user_identities_user_idx ON user_identities(user_id);
+
+INSERT INTO schema_migrations (version) VALUES (7) ON CONFLICT DO NOTHING;
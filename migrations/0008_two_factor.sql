@@ -0,0 +1,20 @@
+-- Двухфакторная аутентификация по TOTP. Секрет хранится открыто: сервер должен вычислять коды.
+-- Пока confirmed_at пуст, подключение не завершено и на вход не влияет.
+CREATE TABLE IF NOT EXISTS user_totp (
+    user_id        UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret         TEXT NOT NULL,
+    confirmed_at   TIMESTAMPTZ,
+    -- Шаг последнего принятого кода: повторно тот же код не принимается.
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Одноразовые коды восстановления; хранится только хэш.
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+    user_id   UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash TEXT NOT NULL,
+    used_at   TIMESTAMPTZ,
+    PRIMARY KEY (user_id, code_hash)
+);
+
+INSERT INTO schema_migrations (version) VALUES (8) ON CONFLICT DO NOTHING;
@@ -0,0 +1,16 @@
+-- Сессии входа: идентификатор сессии записывается в JWT (claim sid), и отозванная сессия
+-- отклоняет свой токен до истечения его срока.
+CREATE TABLE IF NOT EXISTS sessions (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id      UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    user_agent   TEXT NOT NULL DEFAULT '',
+    ip           TEXT NOT NULL DEFAULT '',
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at   TIMESTAMPTZ NOT NULL,
+    revoked_at   TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS sessions_user_idx ON sessions(user_id, last_seen_at DESC);
+
+INSERT INTO schema_migrations (version) VALUES (9) ON CONFLICT DO NOTHING;
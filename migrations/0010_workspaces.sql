@@ -0,0 +1,26 @@
+-- Рабочие пространства (организации) с участниками и ролями.
+CREATE TABLE IF NOT EXISTS workspaces (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name       TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS workspace_members (
+    workspace_id UUID NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    user_id      UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- owner, admin или member.
+    role         TEXT NOT NULL,
+    joined_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (workspace_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS workspace_members_user_idx ON workspace_members(user_id);
+
+-- Доска принадлежит пространству, если workspace_id задан; иначе это личная доска owner_id.
+-- У доски пространства owner_id — пользователь, который её создал или перенёс.
+ALTER TABLE boards ADD COLUMN IF NOT EXISTS workspace_id UUID REFERENCES workspaces(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS boards_workspace_id_idx ON boards(workspace_id);
+
+INSERT INTO schema_migrations (version) VALUES (10) ON CONFLICT DO NOTHING;
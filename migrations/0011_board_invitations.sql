@@ -0,0 +1,31 @@
+-- Участники доски, получившие доступ по приглашению.
+CREATE TABLE IF NOT EXISTS board_members (
+    board_id  UUID NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    user_id   UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- member или admin.
+    role      TEXT NOT NULL,
+    joined_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (board_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS board_members_user_idx ON board_members(user_id);
+
+-- Приглашения на доску по email. Хранится только хэш токена из письма;
+-- принятое или отклонённое приглашение больше не действует.
+CREATE TABLE IF NOT EXISTS board_invitations (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    board_id     UUID NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    email        TEXT NOT NULL,
+    role         TEXT NOT NULL,
+    invited_by   UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash   TEXT NOT NULL UNIQUE,
+    -- pending, accepted или declined.
+    status       TEXT NOT NULL DEFAULT 'pending',
+    expires_at   TIMESTAMPTZ NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    responded_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS board_invitations_board_idx ON board_invitations(board_id, created_at);
+
+INSERT INTO schema_migrations (version) VALUES (11) ON CONFLICT DO NOTHING;
@@ -0,0 +1,16 @@
+-- Публичные ссылки только для чтения на доску. Хранится только хэш токена из ссылки;
+-- отзыв ссылки удаляет строку.
+CREATE TABLE IF NOT EXISTS board_share_links (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    board_id   UUID NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    created_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL UNIQUE,
+    token_hint TEXT NOT NULL,
+    -- NULL — бессрочная ссылка.
+    expires_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS board_share_links_board_idx ON board_share_links(board_id, created_at);
+
+INSERT INTO schema_migrations (version) VALUES (12) ON CONFLICT DO NOTHING;
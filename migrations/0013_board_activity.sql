@@ -0,0 +1,20 @@
+-- Журнал действий с доской, её колонками и задачами. Записи только добавляются, в той же
+-- транзакции, что и изменение; before и after хранят изменённые поля. Журнал удаляется вместе с доской.
+CREATE TABLE IF NOT EXISTS board_activity (
+    id          BIGSERIAL PRIMARY KEY,
+    board_id    UUID NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    -- NULL, если автор удалил аккаунт.
+    actor_id    UUID REFERENCES users(id) ON DELETE SET NULL,
+    action      TEXT NOT NULL,
+    -- board, column или task.
+    entity_type TEXT NOT NULL,
+    entity_id   UUID NOT NULL,
+    before      JSONB,
+    after       JSONB,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS board_activity_board_idx ON board_activity(board_id, id DESC);
+CREATE INDEX IF NOT EXISTS board_activity_entity_idx ON board_activity(board_id, entity_type, entity_id, id DESC);
+
+INSERT INTO schema_migrations (version) VALUES (13) ON CONFLICT DO NOTHING;
@@ -0,0 +1,21 @@
+-- История версий задач: заголовок, описание и колонка после каждого изменения содержимого.
+-- Колонка хранится без внешнего ключа: версию можно восстановить и после удаления колонки.
+CREATE TABLE IF NOT EXISTS task_versions (
+    task_id     UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    version     INT NOT NULL,
+    column_id   UUID NOT NULL,
+    title       TEXT NOT NULL,
+    description TEXT NOT NULL,
+    -- NULL, если автор неизвестен или удалил аккаунт.
+    actor_id    UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (task_id, version)
+);
+
+-- Текущее состояние существующих задач становится их первой версией.
+INSERT INTO task_versions (task_id, version, column_id, title, description, created_at)
+SELECT id, 1, column_id, title, description, updated_at
+FROM tasks
+ON CONFLICT DO NOTHING;
+
+INSERT INTO schema_migrations (version) VALUES (14) ON CONFLICT DO NOTHING;
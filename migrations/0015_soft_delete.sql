@@ -0,0 +1,16 @@
+-- Мягкое удаление: удалённые доски, колонки и задачи лежат в корзине, пока их не удалит
+-- фоновая очистка. Колонка удаляется вместе со своими задачами с тем же deleted_at.
+-- Удалённые строки сохраняют свои позиции, поэтому восстановленный элемент встаёт на прежнее место.
+ALTER TABLE boards  ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE boards  ADD COLUMN IF NOT EXISTS deleted_by UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE columns ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE columns ADD COLUMN IF NOT EXISTS deleted_by UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE tasks   ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE tasks   ADD COLUMN IF NOT EXISTS deleted_by UUID REFERENCES users(id) ON DELETE SET NULL;
+
+-- Корзина и очистка читают только удалённые строки.
+CREATE INDEX IF NOT EXISTS boards_deleted_at_idx  ON boards(deleted_at)  WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS columns_deleted_at_idx ON columns(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS tasks_deleted_at_idx   ON tasks(deleted_at)   WHERE deleted_at IS NOT NULL;
+
+INSERT INTO schema_migrations (version) VALUES (15) ON CONFLICT DO NOTHING;
@@ -0,0 +1,19 @@
+-- Архив: архивные доски и задачи не попадают в обычные списки, но открываются по ID
+-- и выбираются через ?archived=true. Архивная задача сохраняет свою позицию.
+ALTER TABLE boards ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
+ALTER TABLE tasks  ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
+
+-- Колонка «готово»: задачи, пролежавшие в ней дольше AUTO_ARCHIVE_AFTER, архивируются автоматически.
+ALTER TABLE columns ADD COLUMN IF NOT EXISTS done BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Когда задача попала в текущую колонку; для уже существующих задач берём время последнего изменения.
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS column_entered_at TIMESTAMPTZ;
+UPDATE tasks SET column_entered_at = updated_at WHERE column_entered_at IS NULL;
+ALTER TABLE tasks ALTER COLUMN column_entered_at SET DEFAULT NOW();
+ALTER TABLE tasks ALTER COLUMN column_entered_at SET NOT NULL;
+
+-- Автоархивация перебирает только активные задачи.
+CREATE INDEX IF NOT EXISTS tasks_column_entered_at_idx ON tasks(column_id, column_entered_at)
+    WHERE archived_at IS NULL AND deleted_at IS NULL;
+
+INSERT INTO schema_migrations (version) VALUES (16) ON CONFLICT DO NOTHING;
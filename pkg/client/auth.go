@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+type credentials struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// Register регистрирует пользователя и сохраняет выданный токен в клиенте.
+func (c *Client) Register(ctx context.Context, email, password string) (*AuthResult, error) {
+	return c.authenticate(ctx, "/api/v1/auth/register", email, password)
+}
+
+// Login выполняет вход и сохраняет выданный токен в клиенте.
+func (c *Client) Login(ctx context.Context, email, password string) (*AuthResult, error) {
+	return c.authenticate(ctx, "/api/v1/auth/login", email, password)
+}
+
+// LoginTwoFactor завершает вход кодом из приложения-аутентификатора и сохраняет выданный токен.
+func (c *Client) LoginTwoFactor(ctx context.Context, challengeToken, code string) (*AuthResult, error) {
+	return c.secondFactor(ctx, map[string]string{"challenge_token": challengeToken, "code": code})
+}
+
+// LoginRecoveryCode завершает вход одноразовым кодом восстановления и сохраняет выданный токен.
+func (c *Client) LoginRecoveryCode(ctx context.Context, challengeToken, recoveryCode string) (*AuthResult, error) {
+	return c.secondFactor(ctx, map[string]string{"challenge_token": challengeToken, "recovery_code": recoveryCode})
+}
+
+// VerifyEmail подтверждает email токеном из письма.
+func (c *Client) VerifyEmail(ctx context.Context, token string) error {
+	return c.do(ctx, http.MethodPost, "/api/v1/auth/verify-email", map[string]string{"token": token}, nil)
+}
+
+// ForgotPassword запрашивает письмо со ссылкой для сброса пароля.
+func (c *Client) ForgotPassword(ctx context.Context, email string) error {
+	return c.do(ctx, http.MethodPost, "/api/v1/auth/forgot-password", map[string]string{"email": email}, nil)
+}
+
+// ResetPassword задаёт новый пароль токеном из письма.
+func (c *Client) ResetPassword(ctx context.Context, token, password string) error {
+	return c.do(ctx, http.MethodPost, "/api/v1/auth/reset-password", map[string]string{"token": token, "password": password}, nil)
+}
+
+func (c *Client) authenticate(ctx context.Context, path, email, password string) (*AuthResult, error) {
+	var res AuthResult
+	if err := c.do(ctx, http.MethodPost, path, credentials{Email: email, Password: password}, &res); err != nil {
+		return nil, err
+	}
+	if res.Token != "" {
+		c.SetToken(res.Token)
+	}
+	return &res, nil
+}
+
+func (c *Client) secondFactor(ctx context.Context, body map[string]string) (*AuthResult, error) {
+	var res AuthResult
+	if err := c.do(ctx, http.MethodPost, "/api/v1/auth/login/2fa", body, &res); err != nil {
+		return nil, err
+	}
+	c.SetToken(res.Token)
+	return &res, nil
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+type boardRequest struct {
+	Name string `json:"name"`
+}
+
+// ListBoards возвращает доски текущего пользователя.
+func (c *Client) ListBoards(ctx context.Context) ([]Board, error) {
+	var res []Board
+	if err := c.do(ctx, http.MethodGet, "/api/v1/boards", nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetBoard возвращает доску по ID.
+func (c *Client) GetBoard(ctx context.Context, boardID string) (*Board, error) {
+	if err := requireIDs(boardID); err != nil {
+		return nil, err
+	}
+	var res Board
+	if err := c.do(ctx, http.MethodGet, "/api/v1/boards/"+escape(boardID), nil, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// CreateBoard создаёт доску.
+func (c *Client) CreateBoard(ctx context.Context, name string) (*Board, error) {
+	var res Board
+	if err := c.do(ctx, http.MethodPost, "/api/v1/boards", boardRequest{Name: name}, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// RenameBoard меняет название доски.
+func (c *Client) RenameBoard(ctx context.Context, boardID, name string) (*Board, error) {
+	if err := requireIDs(boardID); err != nil {
+		return nil, err
+	}
+	var res Board
+	if err := c.do(ctx, http.MethodPut, "/api/v1/boards/"+escape(boardID), boardRequest{Name: name}, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// DeleteBoard удаляет доску.
+func (c *Client) DeleteBoard(ctx context.Context, boardID string) error {
+	if err := requireIDs(boardID); err != nil {
+		return err
+	}
+	return c.do(ctx, http.MethodDelete, "/api/v1/boards/"+escape(boardID), nil, nil)
+}
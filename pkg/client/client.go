@@ -0,0 +1,247 @@
+// Package client — типизированный Go-клиент для Kanban Backend API.
+//
+// Клиент потокобезопасен: токен можно обновлять из хука WithTokenRefresher,
+// а идемпотентные запросы (GET, PUT, DELETE) повторяются при сетевых сбоях и ответах 429/502/503/504.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenRefresher возвращает новый токен, когда сервер ответил 401.
+type TokenRefresher func(ctx context.Context) (string, error)
+
+// Client — клиент API.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	refresh    TokenRefresher
+	maxRetries int
+	backoff    time.Duration
+	userAgent  string
+
+	mu    sync.RWMutex
+	token string
+}
+
+// Option настраивает клиент.
+type Option func(*Client)
+
+// WithHTTPClient задаёт собственный *http.Client (таймауты, транспорт, прокси).
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+// WithToken задаёт токен доступа, который передаётся в Authorization: Bearer.
+func WithToken(token string) Option {
+	return func(c *Client) { c.token = token }
+}
+
+// WithTokenRefresher задаёт хук обновления токена; запрос после обновления повторяется один раз.
+func WithTokenRefresher(fn TokenRefresher) Option {
+	return func(c *Client) { c.refresh = fn }
+}
+
+// WithRetry задаёт число повторов идемпотентных запросов и базовую задержку (растёт линейно).
+func WithRetry(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.backoff = backoff
+	}
+}
+
+// WithUserAgent задаёт заголовок User-Agent.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) { c.userAgent = ua }
+}
+
+// New создаёт клиент для сервера baseURL, например "http://localhost:8083".
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("parse base url: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("base url must be absolute: %q", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		maxRetries: 2,
+		backoff:    200 * time.Millisecond,
+		userAgent:  "kanban-go-client",
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// Token возвращает текущий токен доступа.
+func (c *Client) Token() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.token
+}
+
+// SetToken заменяет токен доступа.
+func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// do выполняет запрос: сериализует in, повторяет идемпотентные запросы, обновляет токен при 401
+// и декодирует успешный ответ в out (если out != nil).
+func (c *Client) do(ctx context.Context, method, path string, in, out any) error {
+	var payload []byte
+	if in != nil {
+		var err error
+		if payload, err = json.Marshal(in); err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+	}
+
+	attempts := 1
+	if isIdempotent(method) && c.maxRetries > 0 {
+		attempts += c.maxRetries
+	}
+
+	refreshed := false
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if err := sleep(ctx, time.Duration(attempt)*c.backoff); err != nil {
+				return err
+			}
+		}
+
+		resp, err := c.send(ctx, method, path, payload)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			lastErr = err
+			continue
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized && c.refresh != nil && !refreshed {
+			drain(resp)
+			token, err := c.refresh(ctx)
+			if err != nil {
+				return fmt.Errorf("refresh token: %w", err)
+			}
+			c.SetToken(token)
+			refreshed = true
+			attempt--
+			continue
+		}
+
+		if isRetryableStatus(resp.StatusCode) && attempt+1 < attempts {
+			lastErr = decodeError(resp)
+			continue
+		}
+
+		if resp.StatusCode >= 400 {
+			return decodeError(resp)
+		}
+		return decodeBody(resp, out)
+	}
+	return lastErr
+}
+
+func (c *Client) send(ctx context.Context, method, path string, payload []byte) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL.String()+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json, application/problem+json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	if token := c.Token(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return c.httpClient.Do(req)
+}
+
+func decodeBody(resp *http.Response, out any) error {
+	defer drain(resp)
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+func drain(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+	_ = resp.Body.Close()
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// escape экранирует идентификатор для подстановки в путь.
+func escape(id string) string {
+	return url.PathEscape(id)
+}
+
+var errEmptyID = errors.New("id must not be empty")
+
+func requireIDs(ids ...string) error {
+	for _, id := range ids {
+		if id == "" {
+			return errEmptyID
+		}
+	}
+	return nil
+}
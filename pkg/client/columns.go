@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+type columnRequest struct {
+	Name string `json:"name"`
+}
+
+func columnsPath(boardID string) string {
+	return "/api/v1/boards/" + escape(boardID) + "/columns"
+}
+
+// ListColumns возвращает колонки доски по порядку.
+func (c *Client) ListColumns(ctx context.Context, boardID string) ([]Column, error) {
+	if err := requireIDs(boardID); err != nil {
+		return nil, err
+	}
+	var res []Column
+	if err := c.do(ctx, http.MethodGet, columnsPath(boardID), nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateColumn добавляет колонку в конец доски.
+func (c *Client) CreateColumn(ctx context.Context, boardID, name string) (*Column, error) {
+	if err := requireIDs(boardID); err != nil {
+		return nil, err
+	}
+	var res Column
+	if err := c.do(ctx, http.MethodPost, columnsPath(boardID), columnRequest{Name: name}, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// RenameColumn меняет название колонки.
+func (c *Client) RenameColumn(ctx context.Context, boardID, columnID, name string) (*Column, error) {
+	if err := requireIDs(boardID, columnID); err != nil {
+		return nil, err
+	}
+	var res Column
+	path := columnsPath(boardID) + "/" + escape(columnID)
+	if err := c.do(ctx, http.MethodPut, path, columnRequest{Name: name}, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// DeleteColumn удаляет колонку вместе с задачами.
+func (c *Client) DeleteColumn(ctx context.Context, boardID, columnID string) error {
+	if err := requireIDs(boardID, columnID); err != nil {
+		return err
+	}
+	return c.do(ctx, http.MethodDelete, columnsPath(boardID)+"/"+escape(columnID), nil, nil)
+}
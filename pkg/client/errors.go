@@ -0,0 +1,79 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// FieldError — нарушение валидации конкретного поля.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error — ошибка API в формате RFC 7807, повторяет httputil.ErrorResponse сервера.
+type Error struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+func (e *Error) Error() string {
+	msg := e.Detail
+	if msg == "" {
+		msg = e.Title
+	}
+	if e.Code != "" {
+		return fmt.Sprintf("kanban api: %d %s: %s", e.Status, e.Code, msg)
+	}
+	return fmt.Sprintf("kanban api: %d: %s", e.Status, msg)
+}
+
+// StatusOf возвращает HTTP-статус ошибки API или 0, если err не *Error.
+func StatusOf(err error) int {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Status
+	}
+	return 0
+}
+
+// CodeOf возвращает машиночитаемый код ошибки API или "".
+func CodeOf(err error) string {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return ""
+}
+
+// IsNotFound сообщает, что сущность не найдена.
+func IsNotFound(err error) bool {
+	return StatusOf(err) == http.StatusNotFound
+}
+
+// IsUnauthorized сообщает, что токен отсутствует, истёк или учётные данные неверны.
+func IsUnauthorized(err error) bool {
+	return StatusOf(err) == http.StatusUnauthorized
+}
+
+func decodeError(resp *http.Response) error {
+	defer drain(resp)
+
+	apiErr := &Error{Status: resp.StatusCode, Title: http.StatusText(resp.StatusCode)}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err == nil && len(body) > 0 {
+		_ = json.Unmarshal(body, apiErr)
+	}
+	// Статус из заголовка ответа надёжнее, чем тело (например, ответ прокси).
+	apiErr.Status = resp.StatusCode
+	return apiErr
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+type moveTaskRequest struct {
+	ColumnID string `json:"column_id"`
+}
+
+func tasksPath(boardID, columnID string) string {
+	return columnsPath(boardID) + "/" + escape(columnID) + "/tasks"
+}
+
+// ListTasks возвращает задачи колонки по порядку.
+func (c *Client) ListTasks(ctx context.Context, boardID, columnID string) ([]Task, error) {
+	if err := requireIDs(boardID, columnID); err != nil {
+		return nil, err
+	}
+	var res []Task
+	if err := c.do(ctx, http.MethodGet, tasksPath(boardID, columnID), nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateTask добавляет задачу в конец колонки.
+func (c *Client) CreateTask(ctx context.Context, boardID, columnID string, in TaskInput) (*Task, error) {
+	if err := requireIDs(boardID, columnID); err != nil {
+		return nil, err
+	}
+	var res Task
+	if err := c.do(ctx, http.MethodPost, tasksPath(boardID, columnID), in, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// UpdateTask меняет заголовок и описание задачи.
+func (c *Client) UpdateTask(ctx context.Context, boardID, columnID, taskID string, in TaskInput) (*Task, error) {
+	if err := requireIDs(boardID, columnID, taskID); err != nil {
+		return nil, err
+	}
+	var res Task
+	path := tasksPath(boardID, columnID) + "/" + escape(taskID)
+	if err := c.do(ctx, http.MethodPut, path, in, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// DeleteTask удаляет задачу.
+func (c *Client) DeleteTask(ctx context.Context, boardID, columnID, taskID string) error {
+	if err := requireIDs(boardID, columnID, taskID); err != nil {
+		return err
+	}
+	return c.do(ctx, http.MethodDelete, tasksPath(boardID, columnID)+"/"+escape(taskID), nil, nil)
+}
+
+// MoveTask переносит задачу в конец колонки columnID той же доски.
+func (c *Client) MoveTask(ctx context.Context, boardID, taskID, columnID string) (*Task, error) {
+	if err := requireIDs(boardID, taskID, columnID); err != nil {
+		return nil, err
+	}
+	var res Task
+	path := "/api/v1/boards/" + escape(boardID) + "/tasks/" + escape(taskID) + "/move"
+	if err := c.do(ctx, http.MethodPatch, path, moveTaskRequest{ColumnID: columnID}, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
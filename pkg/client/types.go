@@ -0,0 +1,61 @@
+package client
+
+import "time"
+
+// AuthResult — ответ регистрации и входа.
+// Token пуст после регистрации, если сервер требует подтверждённого email, и после входа
+// с включённой 2FA: тогда TwoFactorRequired и ChallengeToken для LoginTwoFactor.
+type AuthResult struct {
+	ID                string    `json:"id"`
+	Email             string    `json:"email"`
+	EmailVerified     bool      `json:"email_verified,omitempty"`
+	CreatedAt         time.Time `json:"created_at,omitzero"`
+	Token             string    `json:"token,omitempty"`
+	TwoFactorRequired bool      `json:"two_factor_required,omitempty"`
+	ChallengeToken    string    `json:"challenge_token,omitempty"`
+}
+
+// Board — канбан-доска.
+type Board struct {
+	ID      string `json:"id"`
+	OwnerID string `json:"owner_id"`
+	// WorkspaceID — рабочее пространство доски; пусто у личной доски.
+	WorkspaceID string `json:"workspace_id,omitempty"`
+	Name        string `json:"name"`
+	// ArchivedAt — когда доску убрали в архив; nil у активной доски.
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// Column — колонка доски.
+type Column struct {
+	ID       string `json:"id"`
+	BoardID  string `json:"board_id"`
+	Name     string `json:"name"`
+	Position int    `json:"position"`
+	// Done — колонка завершённых задач, из неё работает автоархивация.
+	Done      bool      `json:"done"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Task — карточка задачи.
+type Task struct {
+	ID          string `json:"id"`
+	BoardID     string `json:"board_id"`
+	ColumnID    string `json:"column_id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Position    int    `json:"position"`
+	// ArchivedAt — когда задачу убрали в архив; nil у активной задачи.
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// TaskInput — редактируемые поля задачи.
+type TaskInput struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
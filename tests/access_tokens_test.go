@@ -0,0 +1,264 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/accesstoken"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	myhttp "github.com/VladislavDraga398/kanban-backend/internal/http"
+	pg "github.com/VladislavDraga398/kanban-backend/internal/storage/postgres"
+)
+
+// memAccessTokenRepo — accesstoken.Repository в памяти.
+type memAccessTokenRepo struct {
+	mu     sync.Mutex
+	tokens []*accesstoken.Token
+}
+
+func (m *memAccessTokenRepo) Create(ctx context.Context, t *accesstoken.Token) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t.ID, t.CreatedAt = "pat-"+t.Name, time.Now()
+	m.tokens = append(m.tokens, t)
+	return nil
+}
+
+func (m *memAccessTokenRepo) ListByUser(ctx context.Context, userID string) ([]*accesstoken.Token, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var res []*accesstoken.Token
+	for _, t := range m.tokens {
+		if t.UserID == userID {
+			res = append(res, t)
+		}
+	}
+	return res, nil
+}
+
+func (m *memAccessTokenRepo) GetByHash(ctx context.Context, hash string) (*accesstoken.Token, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, t := range m.tokens {
+		if t.Hash == hash {
+			return t, nil
+		}
+	}
+	return nil, accesstoken.ErrNotFound
+}
+
+func (m *memAccessTokenRepo) Delete(ctx context.Context, id, userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, t := range m.tokens {
+		if t.ID == id && t.UserID == userID {
+			m.tokens = append(m.tokens[:i], m.tokens[i+1:]...)
+			return nil
+		}
+	}
+	return accesstoken.ErrNotFound
+}
+
+func (m *memAccessTokenRepo) TouchLastUsed(ctx context.Context, id string, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, t := range m.tokens {
+		if t.ID == id {
+			t.LastUsedAt = &at
+		}
+	}
+	return nil
+}
+
+func accessTokenRouter(repo *memAccessTokenRepo) http.Handler {
+	return myhttp.NewRouter(myhttp.Deps{
+		UserRepo: &stubUserRepo{
+			getByIDFn: func(ctx context.Context, id string) (*user.User, error) {
+				return &user.User{ID: id, Email: "owner@example.com"}, nil
+			},
+		},
+		BoardRepo: &stubBoardRepo{
+			listFn: func(ctx context.Context, ownerID string) ([]*board.Board, error) {
+				return []*board.Board{{ID: "b1", OwnerID: ownerID, Name: "Board"}}, nil
+			},
+			createFn: func(ctx context.Context, b *board.Board) error { b.ID = "b2"; return nil },
+		},
+		ColumnRepo:      &stubColumnRepo{},
+		TaskRepo:        &stubTaskRepo{},
+		AccessTokenRepo: repo,
+		JWTSecret:       testSecret,
+		JWTTTL:          time.Hour,
+	})
+}
+
+// createAccessToken выпускает токен через API от имени JWT сессии и возвращает ответ.
+func createAccessToken(t *testing.T, router http.Handler, req map[string]any) map[string]any {
+	t.Helper()
+	rec := doJSONRequest(router, http.MethodPost, "/api/v1/me/tokens", req, bearer(mustToken(t, "owner-1")))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create access token: %d %s", rec.Code, rec.Body.String())
+	}
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	return body
+}
+
+func TestAccessTokenLifecycle(t *testing.T) {
+	repo := &memAccessTokenRepo{}
+	router := accessTokenRouter(repo)
+
+	created := createAccessToken(t, router, map[string]any{"name": "ci", "scopes": []string{"read"}})
+	raw, _ := created["token"].(string)
+	if !strings.HasPrefix(raw, auth.AccessTokenPrefix) || !strings.HasPrefix(raw, created["token_hint"].(string)) {
+		t.Fatalf("unexpected token %q (hint %v)", raw, created["token_hint"])
+	}
+	if repo.tokens[0].Hash == raw || repo.tokens[0].Hash != auth.HashOpaqueToken(raw) {
+		t.Fatalf("only the token hash must be stored")
+	}
+
+	if rec := doJSONRequest(router, http.MethodGet, "/api/v1/boards", nil, bearer(raw)); rec.Code != http.StatusOK {
+		t.Fatalf("read with token: %d %s", rec.Code, rec.Body.String())
+	}
+	rec := doJSONRequest(router, http.MethodPost, "/api/v1/boards", map[string]string{"name": "New"}, bearer(raw))
+	if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), "insufficient_scope") ||
+		!strings.Contains(rec.Header().Get("WWW-Authenticate"), `scope="write"`) {
+		t.Fatalf("expected insufficient_scope, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = doJSONRequest(router, http.MethodGet, "/api/v1/me/tokens", nil, bearer(mustToken(t, "owner-1")))
+	var list []map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil || len(list) != 1 {
+		t.Fatalf("list tokens: %d %s", rec.Code, rec.Body.String())
+	}
+	if _, ok := list[0]["token"]; ok || list[0]["last_used_at"] == nil {
+		t.Fatalf("list must hide the token and show last use: %v", list[0])
+	}
+
+	if rec := doJSONRequest(router, http.MethodDelete, "/api/v1/me/tokens/"+created["id"].(string), nil, bearer(mustToken(t, "owner-1"))); rec.Code != http.StatusNoContent {
+		t.Fatalf("revoke: %d %s", rec.Code, rec.Body.String())
+	}
+	if rec := doJSONRequest(router, http.MethodGet, "/api/v1/boards", nil, bearer(raw)); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("revoked token must be rejected, got %d", rec.Code)
+	}
+	if rec := doJSONRequest(router, http.MethodDelete, "/api/v1/me/tokens/"+created["id"].(string), nil, bearer(mustToken(t, "owner-1"))); rec.Code != http.StatusNotFound {
+		t.Fatalf("second revoke: expected 404, got %d", rec.Code)
+	}
+}
+
+func TestAccessTokenScopesAndExpiry(t *testing.T) {
+	repo := &memAccessTokenRepo{}
+	router := accessTokenRouter(repo)
+	session := bearer(mustToken(t, "owner-1"))
+
+	rec := doJSONRequest(router, http.MethodPost, "/api/v1/me/tokens", map[string]any{
+		"name": "", "scopes": []string{"read", "root"}, "expires_at": time.Now().Add(-time.Hour),
+	}, session)
+	var body map[string]any
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+	fields := fieldsOf(body)
+	if rec.Code != http.StatusBadRequest || !fields["name"] || !fields["scopes"] || !fields["expires_at"] {
+		t.Fatalf("expected field errors, got %d %v", rec.Code, body)
+	}
+
+	write := createAccessToken(t, router, map[string]any{"name": "deploy", "scopes": []string{"write"}})["token"].(string)
+	if rec := doJSONRequest(router, http.MethodPost, "/api/v1/boards", map[string]string{"name": "New"}, bearer(write)); rec.Code != http.StatusCreated {
+		t.Fatalf("write token must create boards, got %d %s", rec.Code, rec.Body.String())
+	}
+	if rec := doJSONRequest(router, http.MethodGet, "/api/v1/me", nil, bearer(write)); rec.Code != http.StatusOK {
+		t.Fatalf("write token must read profile, got %d", rec.Code)
+	}
+	// Токен не может управлять учётной записью и выпускать другие токены без admin.
+	if rec := doJSONRequest(router, http.MethodPatch, "/api/v1/me", map[string]string{"display_name": "x"}, bearer(write)); rec.Code != http.StatusForbidden {
+		t.Fatalf("write token must not patch profile, got %d", rec.Code)
+	}
+	if rec := doJSONRequest(router, http.MethodGet, "/api/v1/me/tokens", nil, bearer(write)); rec.Code != http.StatusForbidden {
+		t.Fatalf("write token must not list tokens, got %d", rec.Code)
+	}
+
+	admin := createAccessToken(t, router, map[string]any{"name": "admin", "scopes": []string{"admin"}})["token"].(string)
+	if rec := doJSONRequest(router, http.MethodGet, "/api/v1/me/tokens", nil, bearer(admin)); rec.Code != http.StatusOK {
+		t.Fatalf("admin token must list tokens, got %d", rec.Code)
+	}
+
+	soon := createAccessToken(t, router, map[string]any{
+		"name": "short", "scopes": []string{"read"}, "expires_at": time.Now().Add(time.Hour),
+	})
+	past := time.Now().Add(-time.Second)
+	repo.tokens[len(repo.tokens)-1].ExpiresAt = &past
+	if rec := doJSONRequest(router, http.MethodGet, "/api/v1/boards", nil, bearer(soon["token"].(string))); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expired token must be rejected, got %d", rec.Code)
+	}
+}
+
+func TestAccessTokensDisabledWithoutRepo(t *testing.T) {
+	router := myhttp.NewRouter(myhttp.Deps{
+		UserRepo:   &stubUserRepo{},
+		BoardRepo:  &stubBoardRepo{},
+		ColumnRepo: &stubColumnRepo{},
+		TaskRepo:   &stubTaskRepo{},
+		JWTSecret:  testSecret,
+		JWTTTL:     time.Hour,
+	})
+	if rec := doJSONRequest(router, http.MethodGet, "/api/v1/boards", nil, bearer(auth.AccessTokenPrefix+"whatever")); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Code)
+	}
+	if rec := doJSONRequest(router, http.MethodGet, "/api/v1/me/tokens", nil, bearer(mustToken(t, "owner-1"))); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected /me/tokens to be unrouted, got %d", rec.Code)
+	}
+}
+
+func TestIntegration_AccessTokenRepository(t *testing.T) {
+	dsn, stop := startPostgres(t)
+	defer stop()
+
+	db, err := pg.New(dsn)
+	if err != nil {
+		t.Fatalf("connect db: %v", err)
+	}
+	defer db.Close()
+	applyMigrations(t, db.DB)
+
+	ctx := context.Background()
+	u := &user.User{Email: "pat@example.com", PasswordHash: "hash"}
+	if err := pg.NewUserRepository(db).Create(ctx, u); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	repo := pg.NewAccessTokenRepository(db)
+	expires := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	tok := &accesstoken.Token{UserID: u.ID, Name: "ci", Scopes: []auth.Scope{auth.ScopeRead, auth.ScopeWrite}, Hash: "h1", Hint: "kbn_pat_abcd", ExpiresAt: &expires}
+	if err := repo.Create(ctx, tok); err != nil {
+		t.Fatalf("create token: %v", err)
+	}
+
+	got, err := repo.GetByHash(ctx, "h1")
+	if err != nil || got.ID != tok.ID || len(got.Scopes) != 2 || got.Scopes[1] != auth.ScopeWrite || got.LastUsedAt != nil {
+		t.Fatalf("get by hash: %+v %v", got, err)
+	}
+	if err := repo.TouchLastUsed(ctx, tok.ID, time.Now()); err != nil {
+		t.Fatalf("touch: %v", err)
+	}
+	list, err := repo.ListByUser(ctx, u.ID)
+	if err != nil || len(list) != 1 || list[0].LastUsedAt == nil || !list[0].ExpiresAt.Equal(expires) {
+		t.Fatalf("list: %+v %v", list, err)
+	}
+
+	if err := repo.Delete(ctx, tok.ID, "00000000-0000-0000-0000-000000000000"); err != accesstoken.ErrNotFound {
+		t.Fatalf("delete by another user: expected ErrNotFound, got %v", err)
+	}
+	if err := repo.Delete(ctx, tok.ID, u.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := repo.GetByHash(ctx, "h1"); err != accesstoken.ErrNotFound {
+		t.Fatalf("expected ErrNotFound after delete, got %v", err)
+	}
+}
@@ -0,0 +1,364 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	myhttp "github.com/VladislavDraga398/kanban-backend/internal/http"
+	"github.com/VladislavDraga398/kanban-backend/internal/mail"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
+	pg "github.com/VladislavDraga398/kanban-backend/internal/storage/postgres"
+)
+
+// accountFixture — роутер с пользователями и токенами в памяти для сценариев писем.
+type accountFixture struct {
+	router http.Handler
+	outbox *mail.Outbox
+
+	mu     sync.Mutex
+	users  map[string]*user.User
+	tokens map[string]*user.Token
+}
+
+func newAccountFixture(t *testing.T, settings service.EmailSettings, opts ...func(*myhttp.Deps)) *accountFixture {
+	t.Helper()
+	f := &accountFixture{outbox: mail.NewOutbox(), users: map[string]*user.User{}, tokens: map[string]*user.Token{}}
+
+	users := &stubUserRepo{
+		createFn: func(ctx context.Context, u *user.User) error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			u.ID, u.CreatedAt = "user-"+u.Email, time.Now()
+			f.users[u.Email] = u
+			return nil
+		},
+		getByEmailF: func(ctx context.Context, email string) (*user.User, error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if u, ok := f.users[email]; ok {
+				return u, nil
+			}
+			return nil, user.ErrNotFound
+		},
+		getByIDFn: func(ctx context.Context, id string) (*user.User, error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			for _, u := range f.users {
+				if u.ID == id {
+					return u, nil
+				}
+			}
+			return nil, user.ErrNotFound
+		},
+		verifyFn: func(ctx context.Context, id string) error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			now := time.Now()
+			for _, u := range f.users {
+				if u.ID == id && u.EmailVerifiedAt == nil {
+					u.EmailVerifiedAt = &now
+				}
+			}
+			return nil
+		},
+		passwordFn: func(ctx context.Context, id, hash string) error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			for _, u := range f.users {
+				if u.ID == id {
+					u.PasswordHash = hash
+				}
+			}
+			return nil
+		},
+		profileFn: func(ctx context.Context, u *user.User) error {
+			u.UpdatedAt = time.Now()
+			return nil
+		},
+		emailFn: func(ctx context.Context, id, email string) error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if _, taken := f.users[email]; taken {
+				return user.ErrEmailAlreadyUsed
+			}
+			for old, u := range f.users {
+				if u.ID == id {
+					now := time.Now()
+					u.Email, u.EmailVerifiedAt = email, &now
+					delete(f.users, old)
+					f.users[email] = u
+				}
+			}
+			return nil
+		},
+		deleteFn: func(ctx context.Context, id string) error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			for email, u := range f.users {
+				if u.ID == id {
+					delete(f.users, email)
+					return nil
+				}
+			}
+			return user.ErrNotFound
+		},
+	}
+
+	valid := func(purpose user.TokenPurpose, hash string) (*user.Token, error) {
+		tk, ok := f.tokens[hash]
+		if !ok || tk.Purpose != purpose || tk.UsedAt != nil || !tk.ExpiresAt.After(time.Now()) {
+			return nil, user.ErrTokenInvalid
+		}
+		return tk, nil
+	}
+	tokens := &stubTokenRepo{
+		issueFn: func(ctx context.Context, tk *user.Token) error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			now := time.Now()
+			for _, old := range f.tokens {
+				if old.UserID == tk.UserID && old.Purpose == tk.Purpose && old.UsedAt == nil {
+					old.UsedAt = &now
+				}
+			}
+			f.tokens[tk.Hash] = tk
+			return nil
+		},
+		findFn: func(ctx context.Context, purpose user.TokenPurpose, hash string) (*user.Token, error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			return valid(purpose, hash)
+		},
+		consumeFn: func(ctx context.Context, purpose user.TokenPurpose, hash string) (*user.Token, error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			tk, err := valid(purpose, hash)
+			if err != nil {
+				return nil, err
+			}
+			now := time.Now()
+			tk.UsedAt = &now
+			return tk, nil
+		},
+	}
+
+	if settings.BaseURL == "" {
+		settings.BaseURL = "https://app.example.com/"
+	}
+	if settings.VerifyTTL == 0 {
+		settings.VerifyTTL = time.Hour
+	}
+	if settings.ResetTTL == 0 {
+		settings.ResetTTL = time.Hour
+	}
+	deps := myhttp.Deps{
+		UserRepo:   users,
+		BoardRepo:  &stubBoardRepo{},
+		ColumnRepo: &stubColumnRepo{},
+		TaskRepo:   &stubTaskRepo{},
+		TokenRepo:  tokens,
+		Mailer:     f.outbox,
+		Emails:     settings,
+		JWTSecret:  testSecret,
+		JWTTTL:     time.Hour,
+	}
+	for _, opt := range opts {
+		opt(&deps)
+	}
+	f.router = myhttp.NewRouter(deps)
+	return f
+}
+
+var linkToken = regexp.MustCompile(`https://app\.example\.com/[a-z-]+\?token=(\S+)`)
+
+// tokenFromMail достаёт токен из ссылки в последнем письме адресату.
+func (f *accountFixture) tokenFromMail(t *testing.T, to string) string {
+	t.Helper()
+	msg, ok := f.outbox.Last(to)
+	if !ok {
+		t.Fatalf("no email sent to %s", to)
+	}
+	m := linkToken.FindStringSubmatch(msg.Body)
+	if m == nil {
+		t.Fatalf("no link in email: %q", msg.Body)
+	}
+	token, err := url.QueryUnescape(m[1])
+	if err != nil {
+		t.Fatalf("unescape token: %v", err)
+	}
+	return token
+}
+
+func (f *accountFixture) post(path string, body any) (int, map[string]any) {
+	return f.do(http.MethodPost, path, body, "")
+}
+
+func (f *accountFixture) do(method, path string, body any, token string) (int, map[string]any) {
+	var headers map[string]string
+	if token != "" {
+		headers = bearer(token)
+	}
+	rec := doJSONRequest(f.router, method, path, body, headers)
+	var out map[string]any
+	_ = json.Unmarshal(rec.Body.Bytes(), &out)
+	return rec.Code, out
+}
+
+// register создаёт пользователя и возвращает его токен.
+func (f *accountFixture) register(t *testing.T, email, password string) string {
+	t.Helper()
+	code, body := f.post("/api/v1/auth/register", map[string]string{"email": email, "password": password})
+	token, _ := body["token"].(string)
+	if code != http.StatusCreated || token == "" {
+		t.Fatalf("register %s: %d %v", email, code, body)
+	}
+	return token
+}
+
+func TestVerifyEmailRequiredBeforeLogin(t *testing.T) {
+	f := newAccountFixture(t, service.EmailSettings{RequireVerified: true})
+	creds := map[string]string{"email": "new@example.com", "password": "correct horse"}
+
+	code, body := f.post("/api/v1/auth/register", creds)
+	if code != http.StatusCreated || body["token"] != nil || body["email_verified"] != false {
+		t.Fatalf("register must not issue a token before verification: %d %v", code, body)
+	}
+	if code, body := f.post("/api/v1/auth/login", creds); code != http.StatusForbidden || body["code"] != service.CodeEmailNotVerified {
+		t.Fatalf("expected email_not_verified, got %d %v", code, body)
+	}
+
+	token := f.tokenFromMail(t, "new@example.com")
+	if code, body := f.post("/api/v1/auth/verify-email", map[string]string{"token": token}); code != http.StatusNoContent {
+		t.Fatalf("verify email: %d %v", code, body)
+	}
+	if code, body := f.post("/api/v1/auth/verify-email", map[string]string{"token": token}); code != http.StatusBadRequest || body["code"] != service.CodeInvalidToken {
+		t.Fatalf("token must be single-use, got %d %v", code, body)
+	}
+	if code, body := f.post("/api/v1/auth/login", creds); code != http.StatusOK || body["token"] == "" {
+		t.Fatalf("login after verification: %d %v", code, body)
+	}
+}
+
+func TestPasswordResetFlow(t *testing.T) {
+	f := newAccountFixture(t, service.EmailSettings{})
+	if code, _ := f.post("/api/v1/auth/register", map[string]string{"email": "r@example.com", "password": "old password"}); code != http.StatusCreated {
+		t.Fatalf("register: %d", code)
+	}
+	sent := len(f.outbox.Messages())
+
+	// Неизвестный адрес: тот же ответ, но письмо не уходит.
+	if code, _ := f.post("/api/v1/auth/forgot-password", map[string]string{"email": "ghost@example.com"}); code != http.StatusAccepted {
+		t.Fatalf("forgot password for unknown email: %d", code)
+	}
+	if len(f.outbox.Messages()) != sent {
+		t.Fatalf("no email must be sent to unknown address")
+	}
+
+	if code, _ := f.post("/api/v1/auth/forgot-password", map[string]string{"email": "r@example.com"}); code != http.StatusAccepted {
+		t.Fatalf("forgot password: %d", code)
+	}
+	first := f.tokenFromMail(t, "r@example.com")
+	if code, _ := f.post("/api/v1/auth/forgot-password", map[string]string{"email": "r@example.com"}); code != http.StatusAccepted {
+		t.Fatalf("forgot password: %d", code)
+	}
+	token := f.tokenFromMail(t, "r@example.com")
+
+	if code, body := f.post("/api/v1/auth/reset-password", map[string]string{"token": first, "password": "new password"}); code != http.StatusBadRequest || body["code"] != service.CodeInvalidToken {
+		t.Fatalf("older link must be revoked, got %d %v", code, body)
+	}
+	if code, body := f.post("/api/v1/auth/reset-password", map[string]string{"token": token, "password": "new password"}); code != http.StatusNoContent {
+		t.Fatalf("reset password: %d %v", code, body)
+	}
+
+	u := f.users["r@example.com"]
+	if auth.ComparePasswords(u.PasswordHash, "new password") != nil || !u.EmailVerified() {
+		t.Fatalf("password must be replaced and email verified")
+	}
+	if code, _ := f.post("/api/v1/auth/login", map[string]string{"email": "r@example.com", "password": "old password"}); code != http.StatusUnauthorized {
+		t.Fatalf("old password must stop working, got %d", code)
+	}
+}
+
+func TestResetPasswordKeepsTokenOnPolicyViolation(t *testing.T) {
+	f := newAccountFixture(t, service.EmailSettings{})
+	f.post("/api/v1/auth/register", map[string]string{"email": "p@example.com", "password": "old password"})
+	f.post("/api/v1/auth/forgot-password", map[string]string{"email": "p@example.com"})
+	token := f.tokenFromMail(t, "p@example.com")
+
+	long := strings.Repeat("x", auth.MaxPasswordBytes+1)
+	if code, body := f.post("/api/v1/auth/reset-password", map[string]string{"token": token, "password": long}); code != http.StatusBadRequest || body["code"] != service.CodeValidation {
+		t.Fatalf("expected validation error, got %d %v", code, body)
+	}
+	if code, _ := f.post("/api/v1/auth/reset-password", map[string]string{"token": token, "password": "new password"}); code != http.StatusNoContent {
+		t.Fatalf("token must survive a rejected password, got %d", code)
+	}
+}
+
+// Integration: токены одноразовые, повторная выдача гасит прежние, подтверждение пишется в users.
+func TestIntegration_TokenRepository(t *testing.T) {
+	dsn, stop := startPostgres(t)
+	defer stop()
+
+	db, err := pg.New(dsn)
+	if err != nil {
+		t.Fatalf("connect db: %v", err)
+	}
+	defer db.Close()
+	applyMigrations(t, db.DB)
+
+	ctx := context.Background()
+	users, tokens := pg.NewUserRepository(db), pg.NewTokenRepository(db)
+	u := &user.User{Email: "tokens@example.com", PasswordHash: "hash"}
+	if err := users.Create(ctx, u); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	issue := func(hash string, ttl time.Duration) {
+		t.Helper()
+		tk := &user.Token{UserID: u.ID, Purpose: user.PurposeResetPassword, Hash: hash, ExpiresAt: time.Now().Add(ttl)}
+		if err := tokens.Issue(ctx, tk); err != nil {
+			t.Fatalf("issue token: %v", err)
+		}
+	}
+	issue("first", time.Hour)
+	issue("second", time.Hour)
+
+	if _, err := tokens.Find(ctx, user.PurposeResetPassword, "first"); err != user.ErrTokenInvalid {
+		t.Fatalf("older token must be revoked, got %v", err)
+	}
+	if _, err := tokens.Find(ctx, user.PurposeVerifyEmail, "second"); err != user.ErrTokenInvalid {
+		t.Fatalf("purpose must match, got %v", err)
+	}
+	tk, err := tokens.Consume(ctx, user.PurposeResetPassword, "second")
+	if err != nil || tk.UserID != u.ID || tk.UsedAt == nil {
+		t.Fatalf("consume: %+v %v", tk, err)
+	}
+	if _, err := tokens.Consume(ctx, user.PurposeResetPassword, "second"); err != user.ErrTokenInvalid {
+		t.Fatalf("token must be single-use, got %v", err)
+	}
+
+	issue("expired", -time.Minute)
+	if _, err := tokens.Consume(ctx, user.PurposeResetPassword, "expired"); err != user.ErrTokenInvalid {
+		t.Fatalf("expired token must be rejected, got %v", err)
+	}
+
+	if err := users.MarkEmailVerified(ctx, u.ID); err != nil {
+		t.Fatalf("mark verified: %v", err)
+	}
+	if err := users.UpdatePassword(ctx, u.ID, "new-hash"); err != nil {
+		t.Fatalf("update password: %v", err)
+	}
+	got, err := users.GetByEmail(ctx, u.Email)
+	if err != nil || !got.EmailVerified() || got.PasswordHash != "new-hash" {
+		t.Fatalf("unexpected user: %+v %v", got, err)
+	}
+}
@@ -0,0 +1,273 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/activity"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/column"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	myhttp "github.com/VladislavDraga398/kanban-backend/internal/http"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
+	pg "github.com/VladislavDraga398/kanban-backend/internal/storage/postgres"
+)
+
+// memActivityRepo отдаёт заранее записанный журнал; в тестах без БД записи добавляются вручную.
+type memActivityRepo struct {
+	mu      sync.Mutex
+	entries []*activity.Entry
+}
+
+func (m *memActivityRepo) add(e activity.Entry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e.ID, e.CreatedAt = int64(len(m.entries)+1), time.Now()
+	m.entries = append(m.entries, &e)
+}
+
+func (m *memActivityRepo) List(ctx context.Context, f activity.Filter) ([]*activity.Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var res []*activity.Entry
+	for _, e := range m.entries {
+		if e.BoardID != f.BoardID || (f.Before > 0 && e.ID >= f.Before) {
+			continue
+		}
+		if f.TaskID != "" && (e.EntityType != activity.EntityTask || e.EntityID != f.TaskID) {
+			continue
+		}
+		if len(f.Actions) > 0 {
+			match := false
+			for _, a := range f.Actions {
+				match = match || a == e.Action
+			}
+			if !match {
+				continue
+			}
+		}
+		cp := *e
+		res = append(res, &cp)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID > res[j].ID })
+	if len(res) > f.Limit {
+		res = res[:f.Limit]
+	}
+	return res, nil
+}
+
+func activityItems(t *testing.T, page map[string]any) []map[string]any {
+	t.Helper()
+	raw, _ := page["items"].([]any)
+	items := make([]map[string]any, 0, len(raw))
+	for _, it := range raw {
+		items = append(items, it.(map[string]any))
+	}
+	return items
+}
+
+func TestBoardActivityPaginationAndFilters(t *testing.T) {
+	ws := &memWorkspaceRepo{}
+	ws.boards = &memBoardRepo{ws: ws}
+	entries := &memActivityRepo{}
+	f := newAccountFixture(t, service.EmailSettings{}, func(d *myhttp.Deps) {
+		d.WorkspaceRepo = ws
+		d.BoardRepo = ws.boards
+		d.ActivityRepo = entries
+	})
+	alice := f.register(t, "alice@example.com", "correct horse")
+	bob := f.register(t, "bob@example.com", "correct horse")
+
+	_, b := f.do(http.MethodPost, "/api/v1/boards", map[string]string{"name": "Roadmap"}, alice)
+	boardID := b["id"].(string)
+	boardPath := "/api/v1/boards/" + boardID
+
+	entries.add(activity.Entry{BoardID: boardID, ActorID: "user-alice@example.com", Action: activity.BoardCreated,
+		EntityType: activity.EntityBoard, EntityID: boardID, After: activity.Fields{"name": "Roadmap"}})
+	entries.add(activity.Entry{BoardID: boardID, ActorID: "user-alice@example.com", Action: activity.TaskCreated,
+		EntityType: activity.EntityTask, EntityID: "task-1", After: activity.Fields{"title": "Ship"}})
+	entries.add(activity.Entry{BoardID: boardID, ActorID: "user-alice@example.com", Action: activity.TaskMoved,
+		EntityType: activity.EntityTask, EntityID: "task-1",
+		Before: activity.Fields{"column_id": "col-1"}, After: activity.Fields{"column_id": "col-2"}})
+	entries.add(activity.Entry{BoardID: boardID, Action: activity.TaskCreated,
+		EntityType: activity.EntityTask, EntityID: "task-2", After: activity.Fields{"title": "Test"}})
+	entries.add(activity.Entry{BoardID: "other-board", ActorID: "user-bob@example.com", Action: activity.BoardCreated,
+		EntityType: activity.EntityBoard, EntityID: "other-board"})
+
+	// Страницы идут от новых записей к старым и не пересекаются.
+	var actions []string
+	cursor := ""
+	for pages := 0; ; pages++ {
+		path := boardPath + "/activity?limit=2"
+		if cursor != "" {
+			path += "&cursor=" + cursor
+		}
+		code, page := f.do(http.MethodGet, path, nil, alice)
+		if code != http.StatusOK || pages > 2 {
+			t.Fatalf("activity page %d: %d %v", pages, code, page)
+		}
+		for _, it := range activityItems(t, page) {
+			actions = append(actions, it["action"].(string))
+		}
+		next, _ := page["next_cursor"].(string)
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	want := []string{"task.created", "task.moved", "task.created", "board.created"}
+	if len(actions) != len(want) {
+		t.Fatalf("unexpected activity: %v", actions)
+	}
+	for i := range want {
+		if actions[i] != want[i] {
+			t.Fatalf("unexpected activity order: %v", actions)
+		}
+	}
+
+	_, page := f.do(http.MethodGet, boardPath+"/activity?action=task.moved,board.created", nil, alice)
+	items := activityItems(t, page)
+	if len(items) != 2 || items[0]["action"] != "task.moved" || page["next_cursor"] != nil {
+		t.Fatalf("action filter: %v", page)
+	}
+	if before := items[0]["before"].(map[string]any); before["column_id"] != "col-1" {
+		t.Fatalf("moved entry must keep the previous column: %v", items[0])
+	}
+	_, page = f.do(http.MethodGet, boardPath+"/activity?action=task.created", nil, alice)
+	if items := activityItems(t, page); len(items) != 2 || items[0]["actor_id"] != nil || items[1]["actor_id"] != "user-alice@example.com" {
+		t.Fatalf("entries without actor must have null actor_id: %v", items)
+	}
+
+	_, page = f.do(http.MethodGet, "/api/v1/boards/"+boardID+"/tasks/task-1/activity", nil, alice)
+	if items := activityItems(t, page); len(items) != 2 || items[0]["entity_id"] != "task-1" || items[1]["action"] != "task.created" {
+		t.Fatalf("task activity: %v", page)
+	}
+
+	for _, bad := range []string{"?action=task.exploded", "?cursor=!!!", "?limit=0x", "?limit=500"} {
+		if code, body := f.do(http.MethodGet, boardPath+"/activity"+bad, nil, alice); code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d %v", bad, code, body)
+		}
+	}
+	if code, _ := f.do(http.MethodGet, boardPath+"/activity", nil, bob); code != http.StatusNotFound {
+		t.Fatalf("outsider must not read the activity, got %d", code)
+	}
+	ws.boards.addMember(boardID, "user-bob@example.com", "bob@example.com", board.RoleMember)
+	if code, _ := f.do(http.MethodGet, boardPath+"/activity", nil, bob); code != http.StatusOK {
+		t.Fatalf("board member must read the activity, got %d", code)
+	}
+}
+
+func TestIntegration_ActivityLog(t *testing.T) {
+	dsn, stop := startPostgres(t)
+	defer stop()
+
+	db, err := pg.New(dsn)
+	if err != nil {
+		t.Fatalf("connect db: %v", err)
+	}
+	defer db.Close()
+	applyMigrations(t, db.DB)
+
+	ctx := context.Background()
+	owner := &user.User{Email: "owner@example.com", PasswordHash: "hash"}
+	if err := pg.NewUserRepository(db).Create(ctx, owner); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	boards, columns, tasks := pg.NewBoardRepository(db), pg.NewColumnRepository(db), pg.NewTaskRepository(db)
+
+	b := &board.Board{OwnerID: owner.ID, Name: "Roadmap"}
+	if err := boards.Create(ctx, b); err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+	b.Name = "Roadmap Q3"
+	if err := boards.Update(ctx, b, owner.ID); err != nil {
+		t.Fatalf("update board: %v", err)
+	}
+	// Сохранение без изменений в журнал не попадает.
+	if err := boards.Update(ctx, b, owner.ID); err != nil {
+		t.Fatalf("update board again: %v", err)
+	}
+	todo, done := &column.Column{Name: "Todo"}, &column.Column{Name: "Done"}
+	for _, c := range []*column.Column{todo, done} {
+		if err := columns.CreateInBoard(ctx, c, b.ID, owner.ID); err != nil {
+			t.Fatalf("create column: %v", err)
+		}
+	}
+	tk := &task.Task{Title: "Ship"}
+	if err := tasks.CreateInColumn(ctx, tk, b.ID, todo.ID, owner.ID); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	tk.Title = "Ship v1"
+	if err := tasks.Update(ctx, tk, owner.ID); err != nil {
+		t.Fatalf("update task: %v", err)
+	}
+	if err := tasks.MoveToColumn(ctx, tk, done.ID, owner.ID); err != nil {
+		t.Fatalf("move task: %v", err)
+	}
+	if err := tasks.Delete(ctx, tk.ID, b.ID, done.ID, owner.ID); err != nil {
+		t.Fatalf("delete task: %v", err)
+	}
+	if err := columns.Delete(ctx, todo.ID, b.ID, owner.ID); err != nil {
+		t.Fatalf("delete column: %v", err)
+	}
+	// Отклонённое изменение не оставляет записи.
+	if err := tasks.Delete(ctx, tk.ID, b.ID, done.ID, owner.ID); err != task.ErrNotFound {
+		t.Fatalf("delete task twice: expected ErrNotFound, got %v", err)
+	}
+
+	repo := pg.NewActivityRepository(db)
+	entries, err := repo.List(ctx, activity.Filter{BoardID: b.ID, Limit: 100})
+	if err != nil {
+		t.Fatalf("list activity: %v", err)
+	}
+	want := []activity.Action{
+		activity.ColumnDeleted, activity.TaskDeleted, activity.TaskMoved, activity.TaskUpdated, activity.TaskCreated,
+		activity.ColumnCreated, activity.ColumnCreated, activity.BoardUpdated, activity.BoardCreated,
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("expected %d entries, got %d", len(want), len(entries))
+	}
+	for i, e := range entries {
+		if e.Action != want[i] || e.ActorID != owner.ID {
+			t.Fatalf("entry %d: expected %s by %s, got %+v", i, want[i], owner.ID, e)
+		}
+	}
+	if upd := entries[3]; upd.Before["title"] != "Ship" || upd.After["title"] != "Ship v1" || len(upd.After) != 1 {
+		t.Fatalf("update must store only changed fields: %+v", upd)
+	}
+	if moved := entries[2]; moved.Before["column_id"] != todo.ID || moved.After["column_id"] != done.ID {
+		t.Fatalf("unexpected move entry: %+v", moved)
+	}
+	if deleted := entries[1]; deleted.After != nil || deleted.Before["title"] != "Ship v1" {
+		t.Fatalf("unexpected delete entry: %+v", deleted)
+	}
+
+	taskEntries, err := repo.List(ctx, activity.Filter{BoardID: b.ID, TaskID: tk.ID, Actions: []activity.Action{activity.TaskCreated, activity.TaskMoved}, Limit: 100})
+	if err != nil || len(taskEntries) != 2 || taskEntries[0].Action != activity.TaskMoved {
+		t.Fatalf("task activity: %+v %v", taskEntries, err)
+	}
+	page, err := repo.List(ctx, activity.Filter{BoardID: b.ID, Before: entries[6].ID, Limit: 100})
+	if err != nil || len(page) != 2 || page[0].ID != entries[7].ID {
+		t.Fatalf("cursor page: %+v %v", page, err)
+	}
+
+	// Журнал удаляется вместе с доской.
+	if err := boards.Delete(ctx, b.ID, owner.ID); err != nil {
+		t.Fatalf("delete board: %v", err)
+	}
+	entries, err = repo.List(ctx, activity.Filter{BoardID: b.ID, Limit: 1})
+	if err != nil || len(entries) != 1 || entries[0].Action != activity.BoardDeleted {
+		t.Fatalf("board in the trash must keep its activity: %v %v", entries, err)
+	}
+	if _, err := pg.NewTrashRepository(db).Purge(ctx, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("purge trash: %v", err)
+	}
+	if entries, err := repo.List(ctx, activity.Filter{BoardID: b.ID, Limit: 100}); err != nil || len(entries) != 0 {
+		t.Fatalf("activity must be deleted with the purged board: %v %v", entries, err)
+	}
+}
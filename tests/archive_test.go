@@ -0,0 +1,460 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/activity"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/column"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	myhttp "github.com/VladislavDraga398/kanban-backend/internal/http"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
+	pg "github.com/VladislavDraga398/kanban-backend/internal/storage/postgres"
+)
+
+// memArchiveRepo архивирует доски из boards и задачи из tasks; колонок «готово» он не знает,
+// поэтому автоархивация только запоминает срок, а саму выборку проверяет интеграционный тест.
+type memArchiveRepo struct {
+	mu         sync.Mutex
+	boards     *memBoardRepo
+	tasks      []*task.Task
+	autoBefore []time.Time
+}
+
+func (m *memArchiveRepo) board(id, userID string, manage bool) *board.Board {
+	m.boards.mu.Lock()
+	defer m.boards.mu.Unlock()
+	b := m.boards.find(id)
+	if b == nil || !m.boards.accessible(b, userID) || (manage && !m.boards.manageable(b, userID)) {
+		return nil
+	}
+	return b
+}
+
+func (m *memArchiveRepo) ListBoards(ctx context.Context, userID string) ([]*board.Board, error) {
+	return m.listBoards(func(b *board.Board) bool { return b.WorkspaceID == nil && b.OwnerID == userID }), nil
+}
+
+func (m *memArchiveRepo) ListWorkspaceBoards(ctx context.Context, workspaceID string) ([]*board.Board, error) {
+	return m.listBoards(func(b *board.Board) bool { return b.WorkspaceID != nil && *b.WorkspaceID == workspaceID }), nil
+}
+
+func (m *memArchiveRepo) ListSharedBoards(ctx context.Context, userID string) ([]*board.Board, error) {
+	return m.listBoards(func(b *board.Board) bool { return m.boards.member(b.ID, userID) != nil }), nil
+}
+
+func (m *memArchiveRepo) listBoards(match func(*board.Board) bool) []*board.Board {
+	m.boards.mu.Lock()
+	defer m.boards.mu.Unlock()
+	var res []*board.Board
+	for _, b := range m.boards.boards {
+		if b.ArchivedAt != nil && match(b) {
+			cp := *b
+			res = append(res, &cp)
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].ArchivedAt.After(*res[j].ArchivedAt) })
+	return res
+}
+
+func (m *memArchiveRepo) ListTasks(ctx context.Context, boardID, columnID, userID string) ([]*task.Task, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.board(boardID, userID, false) == nil {
+		return nil, nil
+	}
+	var res []*task.Task
+	for _, t := range m.tasks {
+		if t.BoardID == boardID && t.ColumnID == columnID && t.ArchivedAt != nil {
+			cp := *t
+			res = append(res, &cp)
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Position < res[j].Position })
+	return res, nil
+}
+
+func (m *memArchiveRepo) setBoardArchived(b *board.Board, userID string, archived bool) error {
+	s := m.board(b.ID, userID, true)
+	if s == nil {
+		return board.ErrNotFound
+	}
+	m.boards.mu.Lock()
+	defer m.boards.mu.Unlock()
+	if archived && s.ArchivedAt == nil {
+		now := time.Now()
+		s.ArchivedAt = &now
+	} else if !archived {
+		s.ArchivedAt = nil
+	}
+	*b = *s
+	return nil
+}
+
+func (m *memArchiveRepo) ArchiveBoard(ctx context.Context, b *board.Board, userID string) error {
+	return m.setBoardArchived(b, userID, true)
+}
+
+func (m *memArchiveRepo) UnarchiveBoard(ctx context.Context, b *board.Board, userID string) error {
+	return m.setBoardArchived(b, userID, false)
+}
+
+func (m *memArchiveRepo) setTaskArchived(t *task.Task, userID string, archived bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.board(t.BoardID, userID, false) == nil {
+		return task.ErrNotFound
+	}
+	for _, s := range m.tasks {
+		if s.ID != t.ID || s.BoardID != t.BoardID {
+			continue
+		}
+		switch {
+		case archived && s.ArchivedAt == nil:
+			now := time.Now()
+			s.ArchivedAt = &now
+		case !archived && s.ArchivedAt != nil:
+			s.ArchivedAt = nil
+			m.reslot(s)
+		}
+		*t = *s
+		return nil
+	}
+	return task.ErrNotFound
+}
+
+// reslot ставит задачу из архива в конец колонки, если её позицию уже заняли.
+func (m *memArchiveRepo) reslot(t *task.Task) {
+	taken, last := false, 0
+	for _, o := range m.tasks {
+		if o == t || o.ArchivedAt != nil || o.ColumnID != t.ColumnID {
+			continue
+		}
+		taken = taken || o.Position == t.Position
+		last = max(last, o.Position)
+	}
+	if taken {
+		t.Position = last + 1
+	}
+}
+
+func (m *memArchiveRepo) ArchiveTask(ctx context.Context, t *task.Task, userID string) error {
+	return m.setTaskArchived(t, userID, true)
+}
+
+func (m *memArchiveRepo) UnarchiveTask(ctx context.Context, t *task.Task, userID string) error {
+	return m.setTaskArchived(t, userID, false)
+}
+
+func (m *memArchiveRepo) ArchiveColumn(ctx context.Context, boardID, columnID, userID string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.board(boardID, userID, false) == nil {
+		return 0, column.ErrNotFound
+	}
+	now, n := time.Now(), 0
+	for _, t := range m.tasks {
+		if t.BoardID == boardID && t.ColumnID == columnID && t.ArchivedAt == nil {
+			t.ArchivedAt = &now
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (m *memArchiveRepo) AutoArchive(ctx context.Context, before time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.autoBefore = append(m.autoBefore, before)
+	return 0, nil
+}
+
+func TestArchiveServiceAutoArchive(t *testing.T) {
+	ws := &memWorkspaceRepo{}
+	ws.boards = &memBoardRepo{ws: ws}
+	items := &memArchiveRepo{boards: ws.boards}
+	now := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	if _, err := service.NewArchiveService(items, ws.boards).AutoArchive(context.Background(), now); err != nil || len(items.autoBefore) != 0 {
+		t.Fatalf("auto-archive must be off without a delay: %v %v", items.autoBefore, err)
+	}
+	archives := service.NewArchiveService(items, ws.boards).WithAutoArchiveAfter(14 * 24 * time.Hour)
+	if _, err := archives.AutoArchive(context.Background(), now); err != nil {
+		t.Fatalf("auto-archive: %v", err)
+	}
+	if len(items.autoBefore) != 1 || !items.autoBefore[0].Equal(now.Add(-14*24*time.Hour)) {
+		t.Fatalf("unexpected auto-archive cutoff: %v", items.autoBefore)
+	}
+}
+
+func TestArchiveBoardsAndTasks(t *testing.T) {
+	ws := &memWorkspaceRepo{}
+	ws.boards = &memBoardRepo{ws: ws}
+	items := &memArchiveRepo{boards: ws.boards}
+	f := newAccountFixture(t, service.EmailSettings{}, func(d *myhttp.Deps) {
+		d.WorkspaceRepo = ws
+		d.BoardRepo = ws.boards
+		d.ArchiveRepo = items
+	})
+	alice := f.register(t, "alice@example.com", "correct horse")
+	bob := f.register(t, "bob@example.com", "correct horse")
+
+	_, b := f.do(http.MethodPost, "/api/v1/boards", map[string]string{"name": "Roadmap"}, alice)
+	boardID := b["id"].(string)
+	boardPath := "/api/v1/boards/" + boardID
+	for i, id := range []string{"task-1", "task-2", "task-3"} {
+		items.tasks = append(items.tasks, &task.Task{ID: id, BoardID: boardID, ColumnID: "col-todo", Title: id, Position: i + 1})
+	}
+
+	if code, _ := f.do(http.MethodPost, boardPath+"/archive", nil, bob); code != http.StatusNotFound {
+		t.Fatalf("outsider must not see the board, got %d", code)
+	}
+	ws.boards.addMember(boardID, "user-bob@example.com", "bob@example.com", board.RoleMember)
+	if code, body := f.do(http.MethodPost, boardPath+"/archive", nil, bob); code != http.StatusForbidden || body["code"] != "board_permission_denied" {
+		t.Fatalf("member must not archive the board: %d %v", code, body)
+	}
+
+	code, archived := f.do(http.MethodPost, boardPath+"/archive", nil, alice)
+	if code != http.StatusOK || archived["archived_at"] == nil {
+		t.Fatalf("archive board: %d %v", code, archived)
+	}
+	if _, again := f.do(http.MethodPost, boardPath+"/archive", nil, alice); again["archived_at"] != archived["archived_at"] {
+		t.Fatalf("archiving twice must keep archived_at: %v", again)
+	}
+	if list := listJSON(t, f, "/api/v1/boards", alice); len(list) != 0 {
+		t.Fatalf("archived board must be hidden: %v", list)
+	}
+	if list := listJSON(t, f, "/api/v1/boards?archived=true", alice); len(list) != 1 || list[0]["id"] != boardID {
+		t.Fatalf("archived boards of the owner: %v", list)
+	}
+	if list := listJSON(t, f, "/api/v1/boards?archived=true", bob); len(list) != 0 {
+		t.Fatalf("archived list must hold personal boards only, like the active one: %v", list)
+	}
+	if code, body := f.do(http.MethodGet, "/api/v1/boards?archived=maybe", nil, alice); code != http.StatusBadRequest || body["code"] != "validation_failed" {
+		t.Fatalf("invalid archived flag: %d %v", code, body)
+	}
+	if code, body := f.do(http.MethodPost, boardPath+"/unarchive", nil, alice); code != http.StatusOK || body["archived_at"] != nil {
+		t.Fatalf("unarchive board: %d %v", code, body)
+	}
+	if list := listJSON(t, f, "/api/v1/boards?archived=false", alice); len(list) != 1 {
+		t.Fatalf("unarchived board must be listed: %v", list)
+	}
+
+	code, tk := f.do(http.MethodPost, boardPath+"/tasks/task-1/archive", nil, bob)
+	if code != http.StatusOK || tk["archived_at"] == nil {
+		t.Fatalf("board member must archive a task: %d %v", code, tk)
+	}
+	tasksPath := boardPath + "/columns/col-todo/tasks"
+	if list := listJSON(t, f, tasksPath+"?archived=true", alice); len(list) != 1 || list[0]["id"] != "task-1" {
+		t.Fatalf("archived tasks: %v", list)
+	}
+	items.tasks[1].Position = 1
+	code, tk = f.do(http.MethodPost, boardPath+"/tasks/task-1/unarchive", nil, alice)
+	if code != http.StatusOK || tk["archived_at"] != nil || tk["position"] != float64(4) {
+		t.Fatalf("task must go to the end of the column when its slot is taken: %d %v", code, tk)
+	}
+	if code, body := f.do(http.MethodPost, boardPath+"/tasks/task-9/archive", nil, alice); code != http.StatusNotFound || body["code"] != "task_not_found" {
+		t.Fatalf("archive of a missing task: %d %v", code, body)
+	}
+
+	code, body := f.do(http.MethodPost, boardPath+"/columns/col-todo/archive-tasks", nil, alice)
+	if code != http.StatusOK || body["archived"] != float64(3) {
+		t.Fatalf("archive column: %d %v", code, body)
+	}
+	if list := listJSON(t, f, tasksPath+"?archived=true", alice); len(list) != 3 {
+		t.Fatalf("all tasks of the column must be archived: %v", list)
+	}
+	if code, body := f.do(http.MethodPost, "/api/v1/boards/board-9/columns/col-todo/archive-tasks", nil, alice); code != http.StatusNotFound || body["code"] != "column_not_found" {
+		t.Fatalf("archive column of a missing board: %d %v", code, body)
+	}
+}
+
+func TestArchivedWorkspaceAndSharedBoards(t *testing.T) {
+	ws := &memWorkspaceRepo{}
+	ws.boards = &memBoardRepo{ws: ws}
+	f := newAccountFixture(t, service.EmailSettings{}, func(d *myhttp.Deps) {
+		d.WorkspaceRepo = ws
+		d.BoardRepo = ws.boards
+		d.ArchiveRepo = &memArchiveRepo{boards: ws.boards}
+		d.InvitationRepo = &memInvitationRepo{boards: ws.boards}
+	})
+	alice := f.register(t, "alice@example.com", "correct horse")
+	bob := f.register(t, "bob@example.com", "correct horse")
+	carol := f.register(t, "carol@example.com", "correct horse")
+
+	_, team := f.do(http.MethodPost, "/api/v1/workspaces", map[string]string{"name": "Team"}, alice)
+	teamPath := "/api/v1/workspaces/" + team["id"].(string) + "/boards"
+	if code, body := f.do(http.MethodPost, "/api/v1/workspaces/"+team["id"].(string)+"/members", map[string]string{"email": "bob@example.com"}, alice); code != http.StatusCreated {
+		t.Fatalf("add member: %d %v", code, body)
+	}
+	_, teamBoard := f.do(http.MethodPost, teamPath, map[string]string{"name": "Team roadmap"}, alice)
+	_, shared := f.do(http.MethodPost, "/api/v1/boards", map[string]string{"name": "Shared"}, alice)
+	ws.boards.addMember(shared["id"].(string), "user-carol@example.com", "carol@example.com", board.RoleMember)
+	for _, id := range []any{teamBoard["id"], shared["id"]} {
+		if code, body := f.do(http.MethodPost, "/api/v1/boards/"+id.(string)+"/archive", nil, alice); code != http.StatusOK {
+			t.Fatalf("archive board: %d %v", code, body)
+		}
+	}
+
+	if list := listJSON(t, f, teamPath, bob); len(list) != 0 {
+		t.Fatalf("archived workspace board must be hidden: %v", list)
+	}
+	if list := listJSON(t, f, teamPath+"?archived=true", bob); len(list) != 1 || list[0]["id"] != teamBoard["id"] {
+		t.Fatalf("archived workspace boards: %v", list)
+	}
+	if code, body := f.do(http.MethodGet, teamPath+"?archived=true", nil, carol); code != http.StatusNotFound || body["code"] != service.CodeWorkspaceNotFound {
+		t.Fatalf("outsider must not list archived workspace boards: %d %v", code, body)
+	}
+	if list := listJSON(t, f, "/api/v1/boards/shared", carol); len(list) != 0 {
+		t.Fatalf("archived shared board must be hidden: %v", list)
+	}
+	if list := listJSON(t, f, "/api/v1/boards/shared?archived=true", carol); len(list) != 1 || list[0]["id"] != shared["id"] {
+		t.Fatalf("archived shared boards: %v", list)
+	}
+	if list := listJSON(t, f, "/api/v1/boards?archived=true", alice); len(list) != 1 || list[0]["id"] != shared["id"] {
+		t.Fatalf("personal archive must not include workspace boards: %v", list)
+	}
+}
+
+func TestIntegration_Archive(t *testing.T) {
+	dsn, stop := startPostgres(t)
+	defer stop()
+
+	db, err := pg.New(dsn)
+	if err != nil {
+		t.Fatalf("connect db: %v", err)
+	}
+	defer db.Close()
+	applyMigrations(t, db.DB)
+
+	ctx := context.Background()
+	users := pg.NewUserRepository(db)
+	owner := &user.User{Email: "owner@example.com", PasswordHash: "hash"}
+	outsider := &user.User{Email: "outsider@example.com", PasswordHash: "hash"}
+	for _, u := range []*user.User{owner, outsider} {
+		if err := users.Create(ctx, u); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+	boards, columns, tasks := pg.NewBoardRepository(db), pg.NewColumnRepository(db), pg.NewTaskRepository(db)
+	items := pg.NewArchiveRepository(db)
+
+	b := &board.Board{OwnerID: owner.ID, Name: "Roadmap"}
+	if err := boards.Create(ctx, b); err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+	todo, done := &column.Column{Name: "Todo"}, &column.Column{Name: "Done", Done: true}
+	for _, c := range []*column.Column{todo, done} {
+		if err := columns.CreateInBoard(ctx, c, b.ID, owner.ID); err != nil {
+			t.Fatalf("create column: %v", err)
+		}
+	}
+	var todoTasks []*task.Task
+	for _, title := range []string{"One", "Two", "Three"} {
+		tk := &task.Task{Title: title}
+		if err := tasks.CreateInColumn(ctx, tk, b.ID, todo.ID, owner.ID); err != nil {
+			t.Fatalf("create task: %v", err)
+		}
+		todoTasks = append(todoTasks, tk)
+	}
+
+	// Архивная доска пропадает из списка, но открывается по ID.
+	if err := items.ArchiveBoard(ctx, &board.Board{ID: b.ID}, outsider.ID); err != board.ErrNotFound {
+		t.Fatalf("outsider archive: expected ErrNotFound, got %v", err)
+	}
+	archivedBoard := &board.Board{ID: b.ID}
+	if err := items.ArchiveBoard(ctx, archivedBoard, owner.ID); err != nil || archivedBoard.ArchivedAt == nil {
+		t.Fatalf("archive board: %+v %v", archivedBoard, err)
+	}
+	if list, err := boards.ListByOwnerID(ctx, owner.ID); err != nil || len(list) != 0 {
+		t.Fatalf("archived board must be hidden: %+v %v", list, err)
+	}
+	if list, err := items.ListBoards(ctx, owner.ID); err != nil || len(list) != 1 || list[0].ArchivedAt == nil {
+		t.Fatalf("archived boards: %+v %v", list, err)
+	}
+	if list, err := items.ListBoards(ctx, outsider.ID); err != nil || len(list) != 0 {
+		t.Fatalf("archived boards of another user: %+v %v", list, err)
+	}
+	if list, err := items.ListSharedBoards(ctx, outsider.ID); err != nil || len(list) != 0 {
+		t.Fatalf("archived shared boards of a non-member: %+v %v", list, err)
+	}
+	if got, err := boards.GetByID(ctx, b.ID, owner.ID); err != nil || got.ArchivedAt == nil {
+		t.Fatalf("archived board must stay readable: %+v %v", got, err)
+	}
+	if err := items.UnarchiveBoard(ctx, archivedBoard, owner.ID); err != nil || archivedBoard.ArchivedAt != nil {
+		t.Fatalf("unarchive board: %+v %v", archivedBoard, err)
+	}
+	if list, err := items.ListBoards(ctx, owner.ID); err != nil || len(list) != 0 {
+		t.Fatalf("unarchived board must leave the archive: %+v %v", list, err)
+	}
+
+	// Архивная задача держит позицию; если её заняли, возвращается в конец колонки.
+	one := todoTasks[0]
+	archivedTask := &task.Task{ID: one.ID, BoardID: b.ID}
+	if err := items.ArchiveTask(ctx, archivedTask, owner.ID); err != nil || archivedTask.ArchivedAt == nil {
+		t.Fatalf("archive task: %+v %v", archivedTask, err)
+	}
+	if list, err := tasks.ListByColumnOwner(ctx, b.ID, todo.ID, owner.ID); err != nil || len(list) != 2 {
+		t.Fatalf("archived task must be hidden: %+v %v", list, err)
+	}
+	if list, err := items.ListTasks(ctx, b.ID, todo.ID, owner.ID); err != nil || len(list) != 1 || list[0].ID != one.ID {
+		t.Fatalf("archived tasks: %+v %v", list, err)
+	}
+	if err := items.ArchiveTask(ctx, &task.Task{ID: one.ID, BoardID: b.ID}, outsider.ID); err != task.ErrNotFound {
+		t.Fatalf("outsider archive task: expected ErrNotFound, got %v", err)
+	}
+	moved := todoTasks[2]
+	moved.Position = one.Position
+	if err := tasks.Update(ctx, moved, owner.ID); err != nil {
+		t.Fatalf("take the archived slot: %v", err)
+	}
+	restored := &task.Task{ID: one.ID, BoardID: b.ID}
+	if err := items.UnarchiveTask(ctx, restored, owner.ID); err != nil || restored.ArchivedAt != nil || restored.Position == one.Position {
+		t.Fatalf("task must go to the end of the column: %+v %v", restored, err)
+	}
+
+	// Архивация колонки уносит все её задачи.
+	if n, err := items.ArchiveColumn(ctx, b.ID, todo.ID, owner.ID); err != nil || n != 3 {
+		t.Fatalf("archive column: %d %v", n, err)
+	}
+	if _, err := items.ArchiveColumn(ctx, b.ID, todo.ID, outsider.ID); err != column.ErrNotFound {
+		t.Fatalf("outsider archive column: expected ErrNotFound, got %v", err)
+	}
+	if list, err := items.ListTasks(ctx, b.ID, todo.ID, owner.ID); err != nil || len(list) != 3 {
+		t.Fatalf("archived column tasks: %+v %v", list, err)
+	}
+
+	// Автоархивация берёт только задачи, которые давно лежат в колонке «готово».
+	stale, fresh := &task.Task{Title: "Stale"}, &task.Task{Title: "Fresh"}
+	for _, tk := range []*task.Task{stale, fresh} {
+		if err := tasks.CreateInColumn(ctx, tk, b.ID, done.ID, owner.ID); err != nil {
+			t.Fatalf("create task: %v", err)
+		}
+	}
+	if _, err := db.ExecContext(ctx, `UPDATE tasks SET column_entered_at = NOW() - INTERVAL '30 days' WHERE id = $1`, stale.ID); err != nil {
+		t.Fatalf("age task: %v", err)
+	}
+	if n, err := items.AutoArchive(ctx, time.Now().Add(-14*24*time.Hour)); err != nil || n != 1 {
+		t.Fatalf("auto-archive: %d %v", n, err)
+	}
+	if list, err := tasks.ListByColumnOwner(ctx, b.ID, done.ID, owner.ID); err != nil || len(list) != 1 || list[0].ID != fresh.ID {
+		t.Fatalf("only the stale task must be archived: %+v %v", list, err)
+	}
+
+	entries, err := pg.NewActivityRepository(db).List(ctx, activity.Filter{
+		BoardID: b.ID, Limit: 100,
+		Actions: []activity.Action{activity.BoardArchived, activity.BoardUnarchived, activity.TaskArchived, activity.TaskUnarchived},
+	})
+	if err != nil {
+		t.Fatalf("list activity: %v", err)
+	}
+	if len(entries) != 8 || entries[0].Action != activity.TaskArchived || entries[0].ActorID != "" || entries[0].EntityID != stale.ID {
+		t.Fatalf("unexpected archive activity: %+v", entries)
+	}
+}
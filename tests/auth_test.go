@@ -1,6 +1,9 @@
 package tests
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -47,3 +50,89 @@ func TestJWTInvalidOnExpiry(t *testing.T) {
 		t.Fatalf("expected expired token to be invalid")
 	}
 }
+
+func TestPasswordPolicyCheck(t *testing.T) {
+	policy := auth.PasswordPolicy{MinLength: 10, MinEntropyBits: 40, Breached: auth.DefaultBreachedList()}
+
+	cases := map[string]int{
+		"correct horse battery staple": 0,
+		"short":                        2, // короткий и слабый
+		"Password123":                  1, // в списке утечек
+		"aaaaaaaaaaaaaaaaaaaa":         1, // повторы почти не добавляют энтропии
+		"alice-loves-kanban!":          1, // содержит локальную часть email
+		strings.Repeat("x7Q!", 19):     1, // больше 72 байт
+	}
+	for password, want := range cases {
+		if got := policy.Check(password, "alice@example.com"); len(got) != want {
+			t.Errorf("%q: expected %d violations, got %v", password, want, got)
+		}
+	}
+}
+
+func TestBreachedListLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	if err := os.WriteFile(path, []byte("# leaked\nCorp-Spring-2026\n"), 0o600); err != nil {
+		t.Fatalf("write list: %v", err)
+	}
+
+	list := auth.DefaultBreachedList()
+	bundled := list.Len()
+	if err := list.LoadFile(path); err != nil {
+		t.Fatalf("load list: %v", err)
+	}
+	if list.Len() != bundled+1 || !list.Contains("corp-spring-2026") || !list.Contains("qwerty") {
+		t.Fatalf("unexpected list contents (%d entries)", list.Len())
+	}
+	if err := list.LoadFile(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Fatalf("expected error for missing file")
+	}
+}
+
+func TestTOTPMatchesRFC6238(t *testing.T) {
+	// Тестовые векторы RFC 6238 (SHA-1), последние шесть цифр; секрет — "12345678901234567890" в base32.
+	const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	vectors := map[int64]string{59: "287082", 1111111109: "081804", 1234567890: "005924", 2000000000: "279037"}
+	for unix, want := range vectors {
+		now := time.Unix(unix, 0)
+		got, err := auth.TOTPCode(secret, auth.TOTPStep(now))
+		if err != nil || got != want {
+			t.Fatalf("t=%d: expected %s, got %s (%v)", unix, want, got, err)
+		}
+		if step, ok := auth.ValidateTOTP(secret, want, now.Add(auth.TOTPPeriod)); !ok || step != auth.TOTPStep(now) {
+			t.Fatalf("t=%d: code from the previous step must be accepted", unix)
+		}
+		if _, ok := auth.ValidateTOTP(secret, want, now.Add(3*auth.TOTPPeriod)); ok {
+			t.Fatalf("t=%d: stale code must be rejected", unix)
+		}
+	}
+
+	raw, hashes, err := auth.NewRecoveryCodes(2)
+	if err != nil || len(raw) != 2 || raw[0] == raw[1] || len(raw[0]) != 19 {
+		t.Fatalf("recovery codes: %v %v", raw, err)
+	}
+	if auth.HashRecoveryCode(strings.ToUpper(strings.ReplaceAll(raw[0], "-", " "))) != hashes[0] {
+		t.Fatal("recovery code hash must ignore case, spaces and dashes")
+	}
+}
+
+func TestScopesParseAndAllow(t *testing.T) {
+	scopes, err := auth.ParseScopes([]string{" Read", "write", "read"})
+	if err != nil || len(scopes) != 2 || scopes[0] != auth.ScopeRead || scopes[1] != auth.ScopeWrite {
+		t.Fatalf("unexpected scopes %v (%v)", scopes, err)
+	}
+	if _, err := auth.ParseScopes([]string{"root"}); err == nil {
+		t.Fatal("expected error for unknown scope")
+	}
+
+	if !auth.Allows([]auth.Scope{auth.ScopeWrite}, auth.ScopeRead) || auth.Allows([]auth.Scope{auth.ScopeWrite}, auth.ScopeAdmin) {
+		t.Fatal("write must include read but not admin")
+	}
+	if auth.Allows(nil, auth.ScopeRead) {
+		t.Fatal("empty scopes must allow nothing")
+	}
+
+	raw, hash, err := auth.NewAccessToken()
+	if err != nil || !strings.HasPrefix(raw, auth.AccessTokenPrefix) || hash != auth.HashOpaqueToken(raw) {
+		t.Fatalf("unexpected access token %q / %q (%v)", raw, hash, err)
+	}
+}
@@ -0,0 +1,164 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+	"github.com/VladislavDraga398/kanban-backend/internal/cli"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/column"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	myhttp "github.com/VladislavDraga398/kanban-backend/internal/http"
+)
+
+type cliResult struct {
+	code   int
+	stdout string
+	stderr string
+}
+
+func runCLI(t *testing.T, env map[string]string, stdin string, args ...string) cliResult {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := cli.Run(context.Background(), args, cli.Env{
+		Stdin:  strings.NewReader(stdin),
+		Stdout: &stdout,
+		Stderr: &stderr,
+		Getenv: func(k string) string { return env[k] },
+	})
+	return cliResult{code: code, stdout: stdout.String(), stderr: stderr.String()}
+}
+
+func cliServer(t *testing.T, moved *string) *httptest.Server {
+	t.Helper()
+	hash, err := auth.HashPassword("pass123")
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+	srv := httptest.NewServer(myhttp.NewRouter(myhttp.Deps{
+		UserRepo: &stubUserRepo{
+			getByEmailF: func(ctx context.Context, email string) (*user.User, error) {
+				return &user.User{ID: "owner-1", Email: email, PasswordHash: hash}, nil
+			},
+		},
+		BoardRepo: &stubBoardRepo{
+			listFn: func(ctx context.Context, ownerID string) ([]*board.Board, error) {
+				return []*board.Board{{ID: "b1", OwnerID: ownerID, Name: "Release"}}, nil
+			},
+			getFn: func(ctx context.Context, id, ownerID string) (*board.Board, error) {
+				return &board.Board{ID: id, OwnerID: ownerID, Name: "Release"}, nil
+			},
+		},
+		ColumnRepo: &stubColumnRepo{
+			listByOwnerFn: func(ctx context.Context, boardID, ownerID string) ([]*column.Column, error) {
+				return []*column.Column{
+					{ID: "c1", BoardID: boardID, Name: "Todo", Position: 1},
+					{ID: "c2", BoardID: boardID, Name: "Deployed", Position: 2},
+				}, nil
+			},
+		},
+		TaskRepo: &stubTaskRepo{
+			moveFn: func(ctx context.Context, tk *task.Task, columnID, ownerID string) error {
+				*moved = tk.BoardID + "/" + tk.ID + "->" + columnID
+				tk.ColumnID = columnID
+				tk.Title = "Ship it"
+				return nil
+			},
+		},
+		JWTSecret: testSecret,
+		JWTTTL:    time.Hour,
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestCLILoginStoresTokenAndListsBoards(t *testing.T) {
+	var moved string
+	srv := cliServer(t, &moved)
+	cfgPath := filepath.Join(t.TempDir(), "kanbanctl.json")
+	env := map[string]string{"KANBANCTL_CONFIG": cfgPath}
+
+	res := runCLI(t, env, "pass123\n", "--server", srv.URL, "login", "--email", "a@b.c", "--password-stdin")
+	if res.code != 0 {
+		t.Fatalf("login failed (%d): %s", res.code, res.stderr)
+	}
+
+	info, err := os.Stat(cfgPath)
+	if err != nil {
+		t.Fatalf("config not written: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Fatalf("config must be private, got %v", info.Mode().Perm())
+	}
+
+	// Сервер и токен берутся из конфига.
+	res = runCLI(t, env, "", "-o", "json", "boards", "list")
+	if res.code != 0 {
+		t.Fatalf("boards list failed (%d): %s", res.code, res.stderr)
+	}
+	var boards []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal([]byte(res.stdout), &boards); err != nil {
+		t.Fatalf("decode output %q: %v", res.stdout, err)
+	}
+	if len(boards) != 1 || boards[0].Name != "Release" {
+		t.Fatalf("unexpected boards: %+v", boards)
+	}
+
+	res = runCLI(t, env, "", "--output", "yaml", "boards", "list")
+	if res.code != 0 || !strings.Contains(res.stdout, "name: Release") {
+		t.Fatalf("unexpected yaml output (%d): %s %s", res.code, res.stdout, res.stderr)
+	}
+}
+
+func TestCLIMoveTaskByColumnName(t *testing.T) {
+	var moved string
+	srv := cliServer(t, &moved)
+	env := map[string]string{
+		"KANBANCTL_CONFIG": filepath.Join(t.TempDir(), "none.json"),
+		"KANBAN_SERVER":    srv.URL,
+		"KANBAN_TOKEN":     mustToken(t, "owner-1"),
+	}
+
+	res := runCLI(t, env, "", "tasks", "move", "release", "t1", "Deployed")
+	if res.code != 0 {
+		t.Fatalf("move failed (%d): %s", res.code, res.stderr)
+	}
+	if moved != "b1/t1->c2" {
+		t.Fatalf("unexpected move: %q", moved)
+	}
+	if !strings.Contains(res.stdout, "Ship it") {
+		t.Fatalf("expected table output, got %q", res.stdout)
+	}
+}
+
+func TestCLIReportsAPIErrorsAndUsage(t *testing.T) {
+	var moved string
+	srv := cliServer(t, &moved)
+	env := map[string]string{
+		"KANBANCTL_CONFIG": filepath.Join(t.TempDir(), "none.json"),
+		"KANBAN_SERVER":    srv.URL,
+		"KANBAN_TOKEN":     mustToken(t, "owner-1"),
+	}
+
+	if res := runCLI(t, env, "", "columns", "list", "missing"); res.code != 1 || !strings.Contains(res.stderr, `board "missing" not found`) {
+		t.Fatalf("expected not found error, got %d %q", res.code, res.stderr)
+	}
+	if res := runCLI(t, env, "", "boards", "create"); res.code != 2 {
+		t.Fatalf("expected usage exit code, got %d", res.code)
+	}
+	if res := runCLI(t, env, "", "completion", "bash"); res.code != 0 || !strings.Contains(res.stdout, "complete -F _kanbanctl kanbanctl") {
+		t.Fatalf("unexpected completion output: %d %q", res.code, res.stdout)
+	}
+}
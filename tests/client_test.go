@@ -0,0 +1,179 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	myhttp "github.com/VladislavDraga398/kanban-backend/internal/http"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
+	pg "github.com/VladislavDraga398/kanban-backend/internal/storage/postgres"
+	"github.com/VladislavDraga398/kanban-backend/pkg/client"
+)
+
+func TestClientTypedErrorFromRouter(t *testing.T) {
+	srv := httptest.NewServer(myhttp.NewRouter(myhttp.Deps{
+		UserRepo:   &stubUserRepo{},
+		BoardRepo:  &stubBoardRepo{},
+		ColumnRepo: &stubColumnRepo{},
+		TaskRepo:   &stubTaskRepo{},
+		JWTSecret:  testSecret,
+		JWTTTL:     time.Hour,
+	}))
+	defer srv.Close()
+
+	c, err := client.New(srv.URL, client.WithToken(mustToken(t, "owner-1")), client.WithRetry(0, 0))
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	_, err = c.GetBoard(context.Background(), "missing")
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *client.Error, got %T: %v", err, err)
+	}
+	if !client.IsNotFound(err) || apiErr.Code != service.CodeBoardNotFound || apiErr.RequestID == "" {
+		t.Fatalf("unexpected api error: %+v", apiErr)
+	}
+}
+
+func TestClientRetriesIdempotentRequests(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id":"b1","owner_id":"u1","name":"Board"}]`))
+	}))
+	defer srv.Close()
+
+	c, _ := client.New(srv.URL, client.WithRetry(2, time.Millisecond))
+	boards, err := c.ListBoards(context.Background())
+	if err != nil {
+		t.Fatalf("list boards: %v", err)
+	}
+	if len(boards) != 1 || calls.Load() != 3 {
+		t.Fatalf("expected success on third attempt, got %d boards after %d calls", len(boards), calls.Load())
+	}
+}
+
+func TestClientDoesNotRetryPost(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c, _ := client.New(srv.URL, client.WithRetry(3, time.Millisecond))
+	if _, err := c.CreateBoard(context.Background(), "Board"); client.StatusOf(err) != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 error, got %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("POST must not be retried, got %d calls", calls.Load())
+	}
+}
+
+func TestClientRefreshesTokenOnUnauthorized(t *testing.T) {
+	srv := httptest.NewServer(myhttp.NewRouter(myhttp.Deps{
+		UserRepo: &stubUserRepo{},
+		BoardRepo: &stubBoardRepo{
+			createFn: func(ctx context.Context, b *board.Board) error {
+				b.ID = "b1"
+				return nil
+			},
+		},
+		ColumnRepo: &stubColumnRepo{},
+		TaskRepo:   &stubTaskRepo{},
+		JWTSecret:  testSecret,
+		JWTTTL:     time.Hour,
+	}))
+	defer srv.Close()
+
+	var refreshed atomic.Int32
+	c, _ := client.New(srv.URL,
+		client.WithToken("expired"),
+		client.WithTokenRefresher(func(ctx context.Context) (string, error) {
+			refreshed.Add(1)
+			return mustToken(t, "owner-1"), nil
+		}),
+	)
+
+	b, err := c.CreateBoard(context.Background(), "Board")
+	if err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+	if b.ID != "b1" || refreshed.Load() != 1 {
+		t.Fatalf("expected single refresh and created board, got %+v after %d refreshes", b, refreshed.Load())
+	}
+}
+
+// Integration: клиент против настоящего роутера и postgres-репозиториев.
+func TestIntegration_ClientFlow(t *testing.T) {
+	dsn, stop := startPostgres(t)
+	defer stop()
+
+	db, err := pg.New(dsn)
+	if err != nil {
+		t.Fatalf("connect db: %v", err)
+	}
+	defer db.Close()
+	applyMigrations(t, db.DB)
+
+	srv := httptest.NewServer(myhttp.NewRouter(myhttp.Deps{
+		UserRepo:   pg.NewUserRepository(db),
+		BoardRepo:  pg.NewBoardRepository(db),
+		ColumnRepo: pg.NewColumnRepository(db),
+		TaskRepo:   pg.NewTaskRepository(db),
+		JWTSecret:  "integration-secret",
+		JWTTTL:     time.Hour,
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	c, err := client.New(srv.URL)
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	if _, err := c.Register(ctx, "client@example.com", "password123"); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	b, err := c.CreateBoard(ctx, "Release")
+	if err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+	todo, err := c.CreateColumn(ctx, b.ID, "Todo")
+	if err != nil {
+		t.Fatalf("create column: %v", err)
+	}
+	deployed, err := c.CreateColumn(ctx, b.ID, "Deployed")
+	if err != nil {
+		t.Fatalf("create column: %v", err)
+	}
+	tk, err := c.CreateTask(ctx, b.ID, todo.ID, client.TaskInput{Title: "Ship it"})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	moved, err := c.MoveTask(ctx, b.ID, tk.ID, deployed.ID)
+	if err != nil {
+		t.Fatalf("move task: %v", err)
+	}
+	if moved.ColumnID != deployed.ID {
+		t.Fatalf("task not moved: %+v", moved)
+	}
+
+	if err := c.DeleteBoard(ctx, b.ID); err != nil {
+		t.Fatalf("delete board: %v", err)
+	}
+	if _, err := c.GetBoard(ctx, b.ID); !client.IsNotFound(err) {
+		t.Fatalf("expected not found after delete, got %v", err)
+	}
+}
@@ -1,17 +1,28 @@
 package tests
 
 import (
+	"log/slog"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/VladislavDraga398/kanban-backend/internal/config"
 )
 
-func TestLoadDefaults(t *testing.T) {
+// setRequiredEnv задаёт переменные, без которых Load не стартует.
+func setRequiredEnv(t *testing.T) {
+	t.Helper()
 	t.Setenv("JWT_SECRET", "secret")
+	t.Setenv("MAILER", "memory")
+}
+
+func TestLoadDefaults(t *testing.T) {
+	setRequiredEnv(t)
 	t.Setenv("HTTP_PORT", "")
 	t.Setenv("DB_DSN", "")
 	t.Setenv("JWT_TTL", "")
+	t.Setenv("LOG_FORMAT", "")
+	t.Setenv("LOG_LEVEL", "")
 
 	cfg, err := config.Load()
 	if err != nil {
@@ -29,12 +40,15 @@ func TestLoadDefaults(t *testing.T) {
 	if cfg.JWTTTL != 24*time.Hour {
 		t.Fatalf("expected default ttl 24h, got %s", cfg.JWTTTL)
 	}
+	if cfg.LogFormat != "json" || cfg.LogLevel != slog.LevelInfo {
+		t.Fatalf("expected json/info logging by default, got %s/%s", cfg.LogFormat, cfg.LogLevel)
+	}
 }
 
 func TestLoadEnvOverrides(t *testing.T) {
 	t.Setenv("HTTP_PORT", "9999")
 	t.Setenv("DB_DSN", "postgres://u:p@host/db")
-	t.Setenv("JWT_SECRET", "secret")
+	setRequiredEnv(t)
 	t.Setenv("JWT_TTL", "2h")
 
 	cfg, err := config.Load()
@@ -58,7 +72,7 @@ func TestLoadEnvOverrides(t *testing.T) {
 func TestLoadInvalidTTL(t *testing.T) {
 	t.Setenv("HTTP_PORT", "8083")
 	t.Setenv("DB_DSN", "postgres://u:p@host/db")
-	t.Setenv("JWT_SECRET", "secret")
+	setRequiredEnv(t)
 	t.Setenv("JWT_TTL", "bad-ttl")
 
 	if _, err := config.Load(); err == nil {
@@ -69,6 +83,7 @@ func TestLoadInvalidTTL(t *testing.T) {
 func TestLoadMissingJWTSecret(t *testing.T) {
 	t.Setenv("HTTP_PORT", "8083")
 	t.Setenv("DB_DSN", "postgres://u:p@host/db")
+	setRequiredEnv(t)
 	t.Setenv("JWT_SECRET", "")
 	t.Setenv("JWT_TTL", "2h")
 
@@ -76,3 +91,346 @@ func TestLoadMissingJWTSecret(t *testing.T) {
 		t.Fatalf("expected error for missing JWT_SECRET")
 	}
 }
+
+func TestLoadLogSettings(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("LOG_FORMAT", "text")
+	t.Setenv("LOG_LEVEL", "debug")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.LogFormat != "text" || cfg.LogLevel != slog.LevelDebug {
+		t.Fatalf("log overrides not applied: %s/%s", cfg.LogFormat, cfg.LogLevel)
+	}
+
+	t.Setenv("LOG_LEVEL", "loud")
+	if _, err := config.Load(); err == nil {
+		t.Fatalf("expected error for invalid LOG_LEVEL")
+	}
+
+	t.Setenv("LOG_LEVEL", "")
+	t.Setenv("LOG_FORMAT", "xml")
+	if _, err := config.Load(); err == nil {
+		t.Fatalf("expected error for invalid LOG_FORMAT")
+	}
+}
+
+func TestLoadAdminPort(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("HTTP_PORT", "8083")
+	t.Setenv("ADMIN_HTTP_PORT", "")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.AdminAddr != "" {
+		t.Fatalf("admin listener must be disabled by default, got %q", cfg.AdminAddr)
+	}
+
+	t.Setenv("ADMIN_HTTP_PORT", "9090")
+	if cfg, err = config.Load(); err != nil || cfg.AdminAddr != ":9090" {
+		t.Fatalf("expected :9090, got %+v (%v)", cfg, err)
+	}
+
+	t.Setenv("ADMIN_HTTP_PORT", "8083")
+	if _, err := config.Load(); err == nil {
+		t.Fatalf("expected error when admin port equals HTTP_PORT")
+	}
+}
+
+func TestLoadTracingSettings(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_SERVICE_NAME", "")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.OTLPEndpoint != "" || cfg.ServiceName != "kanban-backend" {
+		t.Fatalf("unexpected tracing defaults: %q %q", cfg.OTLPEndpoint, cfg.ServiceName)
+	}
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4318")
+	t.Setenv("OTEL_SERVICE_NAME", "kanban-api")
+	if cfg, err = config.Load(); err != nil || cfg.OTLPEndpoint != "http://collector:4318" || cfg.ServiceName != "kanban-api" {
+		t.Fatalf("tracing overrides not applied: %+v (%v)", cfg, err)
+	}
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "collector:4318")
+	if _, err := config.Load(); err == nil {
+		t.Fatalf("expected error for endpoint without scheme")
+	}
+}
+
+func TestLoadHealthSettings(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("HEALTH_CHECK_TIMEOUT", "")
+	t.Setenv("SHUTDOWN_DRAIN_DELAY", "5s")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.HealthCheckTimeout != 2*time.Second || cfg.ShutdownDrainDelay != 5*time.Second {
+		t.Fatalf("unexpected health settings: %s %s", cfg.HealthCheckTimeout, cfg.ShutdownDrainDelay)
+	}
+
+	t.Setenv("HEALTH_CHECK_TIMEOUT", "0s")
+	if _, err := config.Load(); err == nil {
+		t.Fatalf("expected error for zero HEALTH_CHECK_TIMEOUT")
+	}
+}
+
+func TestLoadTrashRetention(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("TRASH_RETENTION", "")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.TrashRetention != 30*24*time.Hour {
+		t.Fatalf("unexpected trash retention: %s", cfg.TrashRetention)
+	}
+
+	for _, bad := range []string{"0s", "-1h", "week"} {
+		t.Setenv("TRASH_RETENTION", bad)
+		if _, err := config.Load(); err == nil {
+			t.Fatalf("expected error for TRASH_RETENTION=%s", bad)
+		}
+	}
+}
+
+func TestLoadAutoArchiveAfter(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("AUTO_ARCHIVE_AFTER", "")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.AutoArchiveAfter != 0 {
+		t.Fatalf("auto-archive must be off by default, got %s", cfg.AutoArchiveAfter)
+	}
+
+	t.Setenv("AUTO_ARCHIVE_AFTER", "336h")
+	if cfg, err := config.Load(); err != nil || cfg.AutoArchiveAfter != 14*24*time.Hour {
+		t.Fatalf("unexpected auto-archive delay: %v %v", cfg, err)
+	}
+
+	for _, bad := range []string{"-1h", "fortnight"} {
+		t.Setenv("AUTO_ARCHIVE_AFTER", bad)
+		if _, err := config.Load(); err == nil {
+			t.Fatalf("expected error for AUTO_ARCHIVE_AFTER=%s", bad)
+		}
+	}
+}
+
+func TestLoadRateLimitSettings(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("RATE_LIMIT_STORE", "")
+	t.Setenv("AUTH_RATE_LIMIT_IP", "")
+	t.Setenv("AUTH_RATE_LIMIT_EMAIL", "off")
+	t.Setenv("PUBLIC_RATE_LIMIT", "")
+	t.Setenv("LOGIN_LOCKOUT_THRESHOLD", "")
+	t.Setenv("LOGIN_LOCKOUT_BASE", "")
+	t.Setenv("LOGIN_LOCKOUT_MAX", "")
+	t.Setenv("TRUST_PROXY_HEADERS", "true")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.RateLimitStore != "memory" || cfg.AuthIPRateLimit.String() != "20/1m0s" || cfg.AuthEmailRateLimit.Enabled() {
+		t.Fatalf("unexpected rate limits: %s %s %s", cfg.RateLimitStore, cfg.AuthIPRateLimit, cfg.AuthEmailRateLimit)
+	}
+	if cfg.PublicRateLimit.String() != "60/1m0s" {
+		t.Fatalf("unexpected public rate limit: %s", cfg.PublicRateLimit)
+	}
+	if cfg.LoginLockout.Threshold != 5 || cfg.LoginLockout.Base != time.Minute || cfg.LoginLockout.Max != time.Hour {
+		t.Fatalf("unexpected lockout policy: %+v", cfg.LoginLockout)
+	}
+	if !cfg.TrustProxyHeaders {
+		t.Fatalf("expected trusted proxy headers")
+	}
+
+	for key, bad := range map[string]string{
+		"RATE_LIMIT_STORE":   "redis",
+		"AUTH_RATE_LIMIT_IP": "fast",
+		"PUBLIC_RATE_LIMIT":  "10",
+		"LOGIN_LOCKOUT_BASE": "2h",
+	} {
+		t.Run(key, func(t *testing.T) {
+			t.Setenv(key, bad)
+			if _, err := config.Load(); err == nil {
+				t.Fatalf("expected error for %s=%s", key, bad)
+			}
+		})
+	}
+}
+
+func TestLoadPasswordPolicySettings(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("PASSWORD_MIN_LENGTH", "")
+	t.Setenv("PASSWORD_MIN_ENTROPY", "")
+	t.Setenv("PASSWORD_BREACHED_LIST", " /etc/kanban/breached.txt ")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.PasswordMinLength != 8 || cfg.PasswordMinEntropy != 30 || cfg.PasswordBreachedList != "/etc/kanban/breached.txt" {
+		t.Fatalf("unexpected password policy: %d %v %q", cfg.PasswordMinLength, cfg.PasswordMinEntropy, cfg.PasswordBreachedList)
+	}
+
+	for key, bad := range map[string]string{
+		"PASSWORD_MIN_LENGTH":  "100",
+		"PASSWORD_MIN_ENTROPY": "-1",
+	} {
+		t.Run(key, func(t *testing.T) {
+			t.Setenv(key, bad)
+			if _, err := config.Load(); err == nil {
+				t.Fatalf("expected error for %s=%s", key, bad)
+			}
+		})
+	}
+}
+
+func TestLoadMailSettings(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("MAILER", "")
+	t.Setenv("MAIL_OUTBOX_DIR", "")
+	t.Setenv("MAIL_FROM", "")
+	t.Setenv("SMTP_HOST", "smtp.example.com")
+	t.Setenv("SMTP_PORT", "")
+	t.Setenv("APP_BASE_URL", "")
+	t.Setenv("EMAIL_VERIFY_TTL", "")
+	t.Setenv("PASSWORD_RESET_TTL", "30m")
+	t.Setenv("INVITATION_TTL", "")
+	t.Setenv("REQUIRE_VERIFIED_EMAIL", "true")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.Mailer != "smtp" || cfg.MailOutboxDir == "" || cfg.SMTP.Port != 587 || cfg.SMTP.From == "" {
+		t.Fatalf("unexpected mail settings: %+v", cfg)
+	}
+	if cfg.AppBaseURL != "http://localhost:5173" || cfg.EmailVerifyTTL != 24*time.Hour || cfg.PasswordResetTTL != 30*time.Minute || cfg.InvitationTTL != 7*24*time.Hour || !cfg.RequireVerifiedEmail {
+		t.Fatalf("unexpected account email settings: %+v", cfg)
+	}
+
+	for key, bad := range map[string]string{
+		"MAILER":             "sendgrid",
+		"MAIL_FROM":          "not an address",
+		"SMTP_PORT":          "0",
+		"APP_BASE_URL":       "app.example.com",
+		"PASSWORD_RESET_TTL": "0s",
+		"INVITATION_TTL":     "-1h",
+	} {
+		t.Run(key, func(t *testing.T) {
+			t.Setenv(key, bad)
+			if _, err := config.Load(); err == nil {
+				t.Fatalf("expected error for %s=%s", key, bad)
+			}
+		})
+	}
+
+	for _, mailer := range []string{"", "smtp"} {
+		t.Run("smtp requires host "+mailer, func(t *testing.T) {
+			t.Setenv("MAILER", mailer)
+			t.Setenv("SMTP_HOST", "")
+			if _, err := config.Load(); err == nil {
+				t.Fatalf("expected error without SMTP_HOST")
+			}
+		})
+	}
+	t.Run("file is opt-in", func(t *testing.T) {
+		t.Setenv("MAILER", "file")
+		t.Setenv("SMTP_HOST", "")
+		if cfg, err := config.Load(); err != nil || cfg.Mailer != "file" {
+			t.Fatalf("file mailer: %+v %v", cfg, err)
+		}
+	})
+}
+
+func TestLoadOIDCProviders(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("OIDC_PROVIDERS", "Corp, google-ws")
+	t.Setenv("OIDC_CORP_ISSUER", "https://idp.example.com/realms/corp")
+	t.Setenv("OIDC_CORP_CLIENT_ID", "kanban")
+	t.Setenv("OIDC_CORP_CLIENT_SECRET", "secret")
+	t.Setenv("OIDC_CORP_REDIRECT_URL", "https://kanban.example.com/api/v1/auth/oidc/corp/callback")
+	t.Setenv("OIDC_CORP_SCOPES", "email profile")
+	t.Setenv("OIDC_CORP_DISPLAY_NAME", "Corp SSO")
+	t.Setenv("OIDC_GOOGLE_WS_ISSUER", "https://accounts.google.com")
+	t.Setenv("OIDC_GOOGLE_WS_CLIENT_ID", "client.apps.googleusercontent.com")
+	t.Setenv("OIDC_GOOGLE_WS_REDIRECT_URL", "https://kanban.example.com/api/v1/auth/oidc/google-ws/callback")
+	t.Setenv("OIDC_GOOGLE_WS_SCOPES", "")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if len(cfg.OIDCProviders) != 2 {
+		t.Fatalf("expected 2 providers, got %+v", cfg.OIDCProviders)
+	}
+	corp, google := cfg.OIDCProviders[0], cfg.OIDCProviders[1]
+	if corp.Name != "corp" || corp.DisplayName != "Corp SSO" || corp.ClientSecret != "secret" || strings.Join(corp.Scopes, " ") != "openid email profile" {
+		t.Fatalf("unexpected corp provider: %+v", corp)
+	}
+	if google.Name != "google-ws" || google.ClientID != "client.apps.googleusercontent.com" || strings.Join(google.Scopes, " ") != "openid email profile" {
+		t.Fatalf("unexpected google provider: %+v", google)
+	}
+
+	for key, bad := range map[string]string{
+		"OIDC_PROVIDERS":         "corp,corp",
+		"OIDC_CORP_ISSUER":       "idp.example.com",
+		"OIDC_CORP_CLIENT_ID":    "",
+		"OIDC_CORP_REDIRECT_URL": "",
+	} {
+		t.Run(key, func(t *testing.T) {
+			t.Setenv(key, bad)
+			if _, err := config.Load(); err == nil {
+				t.Fatalf("expected error for %s=%q", key, bad)
+			}
+		})
+	}
+}
+
+func TestLoadJWTKeyFiles(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("JWT_SIGNING_KEY_FILE", " keys/current.pem ")
+	t.Setenv("JWT_VERIFY_KEY_FILES", "keys/old.pem@2026-01-31T00:00:00Z, keys/next.pem")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.JWTSigningKeyFile != "keys/current.pem" || len(cfg.JWTVerifyKeys) != 2 {
+		t.Fatalf("unexpected key config: %q %+v", cfg.JWTSigningKeyFile, cfg.JWTVerifyKeys)
+	}
+	old, next := cfg.JWTVerifyKeys[0], cfg.JWTVerifyKeys[1]
+	if old.Path != "keys/old.pem" || !old.NotAfter.Equal(time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected old key: %+v", old)
+	}
+	if next.Path != "keys/next.pem" || !next.NotAfter.IsZero() {
+		t.Fatalf("unexpected next key: %+v", next)
+	}
+
+	for _, tc := range []struct{ signing, verify string }{
+		{"keys/current.pem", "keys/old.pem@tomorrow"},
+		{"keys/current.pem", "@2026-01-31T00:00:00Z"},
+		{"", "keys/old.pem"},
+	} {
+		t.Setenv("JWT_SIGNING_KEY_FILE", tc.signing)
+		t.Setenv("JWT_VERIFY_KEY_FILES", tc.verify)
+		if _, err := config.Load(); err == nil {
+			t.Fatalf("expected error for signing=%q verify=%q", tc.signing, tc.verify)
+		}
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/VladislavDraga398/kanban-backend/internal/http/handlers"
 	"github.com/VladislavDraga398/kanban-backend/internal/http/httputil"
 	"github.com/VladislavDraga398/kanban-backend/internal/http/middleware"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
 )
 
 // --- stubs shared across tests ---
@@ -29,6 +30,11 @@ type stubUserRepo struct {
 	createFn    func(ctx context.Context, u *user.User) error
 	getByIDFn   func(ctx context.Context, id string) (*user.User, error)
 	getByEmailF func(ctx context.Context, email string) (*user.User, error)
+	verifyFn    func(ctx context.Context, id string) error
+	passwordFn  func(ctx context.Context, id, passwordHash string) error
+	profileFn   func(ctx context.Context, u *user.User) error
+	emailFn     func(ctx context.Context, id, email string) error
+	deleteFn    func(ctx context.Context, id string) error
 }
 
 func (s *stubUserRepo) Create(ctx context.Context, u *user.User) error {
@@ -52,12 +58,79 @@ func (s *stubUserRepo) GetByEmail(ctx context.Context, email string) (*user.User
 	return nil, user.ErrNotFound
 }
 
+func (s *stubUserRepo) MarkEmailVerified(ctx context.Context, id string) error {
+	if s.verifyFn != nil {
+		return s.verifyFn(ctx, id)
+	}
+	return nil
+}
+
+func (s *stubUserRepo) UpdatePassword(ctx context.Context, id, passwordHash string) error {
+	if s.passwordFn != nil {
+		return s.passwordFn(ctx, id, passwordHash)
+	}
+	return nil
+}
+
+func (s *stubUserRepo) UpdateProfile(ctx context.Context, u *user.User) error {
+	if s.profileFn != nil {
+		return s.profileFn(ctx, u)
+	}
+	return nil
+}
+
+func (s *stubUserRepo) UpdateEmail(ctx context.Context, id, email string) error {
+	if s.emailFn != nil {
+		return s.emailFn(ctx, id, email)
+	}
+	return nil
+}
+
+func (s *stubUserRepo) Delete(ctx context.Context, id string) error {
+	if s.deleteFn != nil {
+		return s.deleteFn(ctx, id)
+	}
+	return nil
+}
+
+type stubTokenRepo struct {
+	issueFn   func(ctx context.Context, t *user.Token) error
+	findFn    func(ctx context.Context, purpose user.TokenPurpose, hash string) (*user.Token, error)
+	consumeFn func(ctx context.Context, purpose user.TokenPurpose, hash string) (*user.Token, error)
+}
+
+func (s *stubTokenRepo) Issue(ctx context.Context, t *user.Token) error {
+	if s.issueFn != nil {
+		return s.issueFn(ctx, t)
+	}
+	return nil
+}
+
+func (s *stubTokenRepo) Find(ctx context.Context, purpose user.TokenPurpose, hash string) (*user.Token, error) {
+	if s.findFn != nil {
+		return s.findFn(ctx, purpose, hash)
+	}
+	return nil, user.ErrTokenInvalid
+}
+
+func (s *stubTokenRepo) Consume(ctx context.Context, purpose user.TokenPurpose, hash string) (*user.Token, error) {
+	if s.consumeFn != nil {
+		return s.consumeFn(ctx, purpose, hash)
+	}
+	return nil, user.ErrTokenInvalid
+}
+
 type stubBoardRepo struct {
-	createFn func(ctx context.Context, b *board.Board) error
-	updateFn func(ctx context.Context, b *board.Board) error
-	getFn    func(ctx context.Context, id, ownerID string) (*board.Board, error)
-	listFn   func(ctx context.Context, ownerID string) ([]*board.Board, error)
-	deleteFn func(ctx context.Context, id, ownerID string) error
+	createFn          func(ctx context.Context, b *board.Board) error
+	updateFn          func(ctx context.Context, b *board.Board, userID string) error
+	getFn             func(ctx context.Context, id, ownerID string) (*board.Board, error)
+	listFn            func(ctx context.Context, ownerID string) ([]*board.Board, error)
+	listByWorkspaceFn func(ctx context.Context, workspaceID string) ([]*board.Board, error)
+	listSharedFn      func(ctx context.Context, userID string) ([]*board.Board, error)
+	canManageFn       func(ctx context.Context, id, userID string) (bool, error)
+	listMembersFn     func(ctx context.Context, boardID string) ([]*board.Member, error)
+	transferFn        func(ctx context.Context, b *board.Board) error
+	deleteFn          func(ctx context.Context, id, ownerID string) error
 }
 
 func (s *stubBoardRepo) Create(ctx context.Context, b *board.Board) error {
@@ -67,9 +140,9 @@ func (s *stubBoardRepo) Create(ctx context.Context, b *board.Board) error {
 	return nil
 }
 
-func (s *stubBoardRepo) Update(ctx context.Context, b *board.Board) error {
+func (s *stubBoardRepo) Update(ctx context.Context, b *board.Board, userID string) error {
 	if s.updateFn != nil {
-		return s.updateFn(ctx, b)
+		return s.updateFn(ctx, b, userID)
 	}
 	return nil
 }
@@ -81,6 +154,13 @@ func (s *stubBoardRepo) GetByID(ctx context.Context, id, ownerID string) (*board
 	return nil, board.ErrNotFound
 }
 
+// visibleBoards — хранилище, в котором пользователю доступна любая доска.
+func visibleBoards() *stubBoardRepo {
+	return &stubBoardRepo{getFn: func(ctx context.Context, id, ownerID string) (*board.Board, error) {
+		return &board.Board{ID: id, OwnerID: ownerID}, nil
+	}}
+}
+
 func (s *stubBoardRepo) ListByOwnerID(ctx context.Context, ownerID string) ([]*board.Board, error) {
 	if s.listFn != nil {
 		return s.listFn(ctx, ownerID)
@@ -88,6 +168,45 @@ func (s *stubBoardRepo) ListByOwnerID(ctx context.Context, ownerID string) ([]*b
 	return nil, nil
 }
 
+func (s *stubBoardRepo) ListByWorkspace(ctx context.Context, workspaceID string) ([]*board.Board, error) {
+	if s.listByWorkspaceFn != nil {
+		return s.listByWorkspaceFn(ctx, workspaceID)
+	}
+	return nil, nil
+}
+
+func (s *stubBoardRepo) ListShared(ctx context.Context, userID string) ([]*board.Board, error) {
+	if s.listSharedFn != nil {
+		return s.listSharedFn(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (s *stubBoardRepo) CanManage(ctx context.Context, id, userID string) (bool, error) {
+	if s.canManageFn != nil {
+		return s.canManageFn(ctx, id, userID)
+	}
+	return false, board.ErrNotFound
+}
+
+func (s *stubBoardRepo) ListMembers(ctx context.Context, boardID string) ([]*board.Member, error) {
+	if s.listMembersFn != nil {
+		return s.listMembersFn(ctx, boardID)
+	}
+	return nil, nil
+}
+
+func (s *stubBoardRepo) RemoveMember(ctx context.Context, boardID, userID string) error {
+	return nil
+}
+
+func (s *stubBoardRepo) Transfer(ctx context.Context, b *board.Board) error {
+	if s.transferFn != nil {
+		return s.transferFn(ctx, b)
+	}
+	return nil
+}
+
 func (s *stubBoardRepo) Delete(ctx context.Context, id, ownerID string) error {
 	if s.deleteFn != nil {
 		return s.deleteFn(ctx, id, ownerID)
@@ -226,13 +345,13 @@ func mustToken(t *testing.T, userID string) string {
 // --- tests ---
 
 func TestAuthRegisterSuccess(t *testing.T) {
-	h := handlers.NewAuthHandler(&stubUserRepo{
+	h := handlers.NewAuthHandler(service.NewAuthService(&stubUserRepo{
 		createFn: func(ctx context.Context, u *user.User) error {
 			u.ID = "user-1"
 			u.CreatedAt = time.Unix(1, 0)
 			return nil
 		},
-	}, testSecret, time.Hour)
+	}, testSecret, time.Hour))
 
 	r := chi.NewRouter()
 	r.Post("/api/v1/auth/register", h.Register)
@@ -259,11 +378,11 @@ func TestAuthRegisterSuccess(t *testing.T) {
 
 func TestAuthLoginInvalidPassword(t *testing.T) {
 	hash, _ := auth.HashPassword("correct-pass")
-	h := handlers.NewAuthHandler(&stubUserRepo{
+	h := handlers.NewAuthHandler(service.NewAuthService(&stubUserRepo{
 		getByEmailF: func(ctx context.Context, email string) (*user.User, error) {
 			return &user.User{ID: "u1", Email: email, PasswordHash: hash}, nil
 		},
-	}, testSecret, time.Hour)
+	}, testSecret, time.Hour))
 
 	r := chi.NewRouter()
 	r.Post("/api/v1/auth/login", h.Login)
@@ -277,7 +396,7 @@ func TestAuthLoginInvalidPassword(t *testing.T) {
 }
 
 func TestBoardListUnauthorized(t *testing.T) {
-	h := handlers.NewBoardHandler(&stubBoardRepo{})
+	h := handlers.NewBoardHandler(service.NewBoardService(&stubBoardRepo{}))
 	r := chi.NewRouter()
 	r.Use(middleware.Auth([]byte(testSecret)))
 	r.Get("/api/v1/boards", h.List)
@@ -289,7 +408,7 @@ func TestBoardListUnauthorized(t *testing.T) {
 }
 
 func TestBoardCreateSuccess(t *testing.T) {
-	h := handlers.NewBoardHandler(&stubBoardRepo{
+	h := handlers.NewBoardHandler(service.NewBoardService(&stubBoardRepo{
 		createFn: func(ctx context.Context, b *board.Board) error {
 			b.ID = "board-1"
 			b.OwnerID = "owner-1"
@@ -297,7 +416,7 @@ func TestBoardCreateSuccess(t *testing.T) {
 			b.UpdatedAt = time.Unix(1, 0)
 			return nil
 		},
-	})
+	}))
 
 	r := chi.NewRouter()
 	r.Use(middleware.Auth([]byte(testSecret)))
@@ -323,7 +442,7 @@ func TestBoardCreateSuccess(t *testing.T) {
 }
 
 func TestBoardCreateRejectsUnknownField(t *testing.T) {
-	h := handlers.NewBoardHandler(&stubBoardRepo{})
+	h := handlers.NewBoardHandler(service.NewBoardService(&stubBoardRepo{}))
 
 	r := chi.NewRouter()
 	r.Use(middleware.Auth([]byte(testSecret)))
@@ -347,7 +466,7 @@ func TestBoardCreateRejectsUnknownField(t *testing.T) {
 }
 
 func TestBoardCreateRejectsOversizedBody(t *testing.T) {
-	h := handlers.NewBoardHandler(&stubBoardRepo{})
+	h := handlers.NewBoardHandler(service.NewBoardService(&stubBoardRepo{}))
 
 	r := chi.NewRouter()
 	r.Use(middleware.Auth([]byte(testSecret)))
@@ -371,11 +490,11 @@ func TestBoardCreateRejectsOversizedBody(t *testing.T) {
 }
 
 func TestColumnCreateNotFound(t *testing.T) {
-	h := handlers.NewColumnHandler(&stubColumnRepo{
+	h := handlers.NewColumnHandler(service.NewColumnService(&stubColumnRepo{
 		createInFn: func(ctx context.Context, c *column.Column, boardID, ownerID string) error {
 			return column.ErrNotFound
 		},
-	})
+	}, visibleBoards()))
 
 	r := chi.NewRouter()
 	r.Use(middleware.Auth([]byte(testSecret)))
@@ -390,7 +509,7 @@ func TestColumnCreateNotFound(t *testing.T) {
 }
 
 func TestTaskCreateValidation(t *testing.T) {
-	h := handlers.NewTaskHandler(&stubTaskRepo{})
+	h := handlers.NewTaskHandler(service.NewTaskService(&stubTaskRepo{}, visibleBoards()))
 
 	r := chi.NewRouter()
 	r.Use(middleware.Auth([]byte(testSecret)))
@@ -419,7 +538,7 @@ func TestTaskMoveSuccessThroughRouter(t *testing.T) {
 
 	router := myhttp.NewRouter(myhttp.Deps{
 		UserRepo:   &stubUserRepo{},
-		BoardRepo:  &stubBoardRepo{},
+		BoardRepo:  visibleBoards(),
 		ColumnRepo: &stubColumnRepo{},
 		TaskRepo:   taskRepo,
 		JWTSecret:  testSecret,
@@ -455,7 +574,7 @@ func TestTaskMoveNotFound(t *testing.T) {
 
 	router := myhttp.NewRouter(myhttp.Deps{
 		UserRepo:   &stubUserRepo{},
-		BoardRepo:  &stubBoardRepo{},
+		BoardRepo:  visibleBoards(),
 		ColumnRepo: &stubColumnRepo{},
 		TaskRepo:   taskRepo,
 		JWTSecret:  testSecret,
@@ -472,3 +591,55 @@ func TestTaskMoveNotFound(t *testing.T) {
 		t.Fatalf("expected not found message, got %q", rec.Body.String())
 	}
 }
+
+func TestTaskCreateReportsFieldViolations(t *testing.T) {
+	h := handlers.NewTaskHandler(service.NewTaskService(&stubTaskRepo{}, visibleBoards()))
+
+	r := chi.NewRouter()
+	r.Use(middleware.Auth([]byte(testSecret)))
+	r.Post("/api/v1/boards/{board_id}/columns/{column_id}/tasks", h.Create)
+
+	token := mustToken(t, "owner-1")
+	rec := doJSONRequest(r, http.MethodPost, "/api/v1/boards/b1/columns/c1/tasks", map[string]string{"title": ""}, bearer(token))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != httputil.ProblemContentType {
+		t.Fatalf("unexpected content-type: %s", ct)
+	}
+
+	var p httputil.ErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&p); err != nil {
+		t.Fatalf("decode problem: %v", err)
+	}
+	if p.Code != service.CodeValidation || len(p.Errors) != 1 || p.Errors[0].Field != "title" {
+		t.Fatalf("unexpected problem: %+v", p)
+	}
+}
+
+func TestColumnUpdateNotFoundCode(t *testing.T) {
+	h := handlers.NewColumnHandler(service.NewColumnService(&stubColumnRepo{
+		updateFn: func(ctx context.Context, c *column.Column, ownerID string) error {
+			return column.ErrNotFound
+		},
+	}, visibleBoards()))
+
+	r := chi.NewRouter()
+	r.Use(middleware.Auth([]byte(testSecret)))
+	r.Put("/api/v1/boards/{board_id}/columns/{column_id}", h.Update)
+
+	token := mustToken(t, "owner-1")
+	rec := doJSONRequest(r, http.MethodPut, "/api/v1/boards/b1/columns/c1", map[string]string{"name": "Done"}, bearer(token))
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+	var p httputil.ErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&p); err != nil {
+		t.Fatalf("decode problem: %v", err)
+	}
+	if p.Code != service.CodeColumnNotFound {
+		t.Fatalf("expected %s code, got %q", service.CodeColumnNotFound, p.Code)
+	}
+}
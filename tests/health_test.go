@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/health"
+	myhttp "github.com/VladislavDraga398/kanban-backend/internal/http"
+	"github.com/VladislavDraga398/kanban-backend/internal/metrics"
+	pg "github.com/VladislavDraga398/kanban-backend/internal/storage/postgres"
+)
+
+func healthRouter(checker *health.Checker) http.Handler {
+	return myhttp.NewRouter(myhttp.Deps{
+		UserRepo:   &stubUserRepo{},
+		BoardRepo:  &stubBoardRepo{},
+		ColumnRepo: &stubColumnRepo{},
+		TaskRepo:   &stubTaskRepo{},
+		JWTSecret:  testSecret,
+		JWTTTL:     time.Hour,
+		Health:     checker,
+	})
+}
+
+func TestReadinessReflectsChecksAndDraining(t *testing.T) {
+	var dbErr error
+	checker := health.NewChecker(time.Second)
+	checker.Add("postgres", func(ctx context.Context) error { return dbErr })
+	router := healthRouter(checker)
+
+	if rec := doJSONRequest(router, http.MethodGet, "/readyz", nil, nil); rec.Code != http.StatusOK {
+		t.Fatalf("expected ready, got %d", rec.Code)
+	}
+
+	dbErr = errors.New("connection refused")
+	if rec := doJSONRequest(router, http.MethodGet, "/readyz", nil, nil); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 when db is down, got %d", rec.Code)
+	}
+	if rec := doJSONRequest(router, http.MethodGet, "/livez", nil, nil); rec.Code != http.StatusOK {
+		t.Fatalf("liveness must not depend on db, got %d", rec.Code)
+	}
+
+	dbErr = nil
+	checker.SetDraining()
+	if rec := doJSONRequest(router, http.MethodGet, "/readyz", nil, nil); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 while draining, got %d", rec.Code)
+	}
+}
+
+func TestHealthReportListsChecks(t *testing.T) {
+	checker := health.NewChecker(20 * time.Millisecond)
+	checker.Add("postgres", func(ctx context.Context) error { return nil })
+	checker.Add("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	// Отчёт раскрывает ошибки зависимостей, поэтому основной листенер его не отдаёт.
+	if rec := doJSONRequest(healthRouter(checker), http.MethodGet, "/health", nil, nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("public router must not serve /health, got %d", rec.Code)
+	}
+
+	rec := doJSONRequest(myhttp.NewAdminRouter(metrics.New(), checker), http.MethodGet, "/health", nil, nil)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", rec.Code)
+	}
+
+	var report health.Report
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decode report: %v", err)
+	}
+	if report.Status != health.StatusDown || len(report.Checks) != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if report.Checks[0].Name != "postgres" || report.Checks[0].Status != health.StatusUp {
+		t.Fatalf("unexpected postgres check: %+v", report.Checks[0])
+	}
+	slow := report.Checks[1]
+	if slow.Status != health.StatusDown || slow.Error == "" || slow.LatencyMS < 20 {
+		t.Fatalf("slow check must time out: %+v", slow)
+	}
+}
+
+// Integration: проверки готовности против настоящей БД с применёнными миграциями.
+func TestIntegration_ReadinessChecks(t *testing.T) {
+	dsn, stop := startPostgres(t)
+	defer stop()
+
+	db, err := pg.New(dsn)
+	if err != nil {
+		t.Fatalf("connect db: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if err := db.CheckMigrations(ctx); err == nil {
+		t.Fatalf("expected migration check to fail on empty schema")
+	}
+
+	applyMigrations(t, db.DB)
+	if version, err := db.SchemaVersion(ctx); err != nil || version != pg.ExpectedSchemaVersion {
+		t.Fatalf("expected schema version %d, got %d (%v)", pg.ExpectedSchemaVersion, version, err)
+	}
+
+	checker := health.NewChecker(2 * time.Second)
+	checker.Add("postgres", db.PingContext)
+	checker.Add("migrations", db.CheckMigrations)
+	if rec := doJSONRequest(healthRouter(checker), http.MethodGet, "/readyz", nil, nil); rec.Code != http.StatusOK {
+		t.Fatalf("expected ready, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
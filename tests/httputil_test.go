@@ -1,10 +1,14 @@
 package tests
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+
 	"github.com/VladislavDraga398/kanban-backend/internal/http/httputil"
 )
 
@@ -26,14 +30,30 @@ func TestJSONWritesBodyAndStatus(t *testing.T) {
 	}
 }
 
-func TestErrorUsesJSONEnvelope(t *testing.T) {
+func TestErrorUsesProblemEnvelope(t *testing.T) {
 	rr := httptest.NewRecorder()
-	httputil.Error(rr, http.StatusBadRequest, "bad request")
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/boards/b1", nil)
+	req = req.WithContext(context.WithValue(req.Context(), chimiddleware.RequestIDKey, "req-42"))
+	httputil.Error(rr, req, http.StatusBadRequest, "bad_thing", "bad request")
 
 	if rr.Code != http.StatusBadRequest {
 		t.Fatalf("unexpected status: %d", rr.Code)
 	}
-	if rr.Body.String() == "" {
-		t.Fatalf("expected error body")
+	if ct := rr.Header().Get("Content-Type"); ct != httputil.ProblemContentType {
+		t.Fatalf("unexpected content-type: %s", ct)
+	}
+
+	var p httputil.ErrorResponse
+	if err := json.NewDecoder(rr.Body).Decode(&p); err != nil {
+		t.Fatalf("decode problem: %v", err)
+	}
+	if p.Type != httputil.ProblemTypePrefix+"bad_thing" || p.Code != "bad_thing" || p.Status != http.StatusBadRequest {
+		t.Fatalf("unexpected problem: %+v", p)
+	}
+	if p.Title != "Bad Request" || p.Detail != "bad request" || p.Instance != "/api/v1/boards/b1" {
+		t.Fatalf("unexpected problem: %+v", p)
+	}
+	if p.RequestID != "req-42" {
+		t.Fatalf("expected request id from context, got %q", p.RequestID)
 	}
 }
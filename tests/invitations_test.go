@@ -0,0 +1,417 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/invitation"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	myhttp "github.com/VladislavDraga398/kanban-backend/internal/http"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
+	pg "github.com/VladislavDraga398/kanban-backend/internal/storage/postgres"
+)
+
+// memInvitationRepo хранит приглашения в памяти; принятое приглашение добавляет участника в boards.
+type memInvitationRepo struct {
+	mu          sync.Mutex
+	boards      *memBoardRepo
+	invitations []*invitation.Invitation
+}
+
+func (m *memInvitationRepo) Create(ctx context.Context, inv *invitation.Invitation) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	kept := m.invitations[:0]
+	for _, old := range m.invitations {
+		if old.BoardID != inv.BoardID || !strings.EqualFold(old.Email, inv.Email) || old.Status != invitation.StatusPending {
+			kept = append(kept, old)
+		}
+	}
+	m.invitations = kept
+	inv.ID, inv.Status, inv.CreatedAt = fmt.Sprintf("inv-%d", len(m.invitations)+1), invitation.StatusPending, time.Now()
+	if m.boards != nil {
+		m.boards.mu.Lock()
+		if b := m.boards.find(inv.BoardID); b != nil {
+			inv.BoardName = b.Name
+		}
+		m.boards.mu.Unlock()
+	}
+	cp := *inv
+	m.invitations = append(m.invitations, &cp)
+	return nil
+}
+
+func (m *memInvitationRepo) ListPending(ctx context.Context, boardID string, now time.Time) ([]*invitation.Invitation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var res []*invitation.Invitation
+	for _, inv := range m.invitations {
+		if inv.BoardID == boardID && inv.Status == invitation.StatusPending && inv.ExpiresAt.After(now) {
+			cp := *inv
+			res = append(res, &cp)
+		}
+	}
+	return res, nil
+}
+
+func (m *memInvitationRepo) Revoke(ctx context.Context, id, boardID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, inv := range m.invitations {
+		if inv.ID == id && inv.BoardID == boardID && inv.Status == invitation.StatusPending {
+			m.invitations = append(m.invitations[:i], m.invitations[i+1:]...)
+			return nil
+		}
+	}
+	return invitation.ErrNotFound
+}
+
+func (m *memInvitationRepo) pending(hash string, now time.Time) *invitation.Invitation {
+	for _, inv := range m.invitations {
+		if inv.Hash == hash && inv.Status == invitation.StatusPending && inv.ExpiresAt.After(now) {
+			return inv
+		}
+	}
+	return nil
+}
+
+func (m *memInvitationRepo) FindPending(ctx context.Context, hash string, now time.Time) (*invitation.Invitation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	inv := m.pending(hash, now)
+	if inv == nil {
+		return nil, invitation.ErrInvalid
+	}
+	cp := *inv
+	return &cp, nil
+}
+
+func (m *memInvitationRepo) respond(hash string, status invitation.Status, now time.Time) (*invitation.Invitation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	inv := m.pending(hash, now)
+	if inv == nil {
+		return nil, invitation.ErrInvalid
+	}
+	inv.Status = status
+	cp := *inv
+	return &cp, nil
+}
+
+func (m *memInvitationRepo) Accept(ctx context.Context, hash, userID string, now time.Time) (*invitation.Invitation, error) {
+	inv, err := m.respond(hash, invitation.StatusAccepted, now)
+	if err != nil {
+		return nil, err
+	}
+	if m.boards != nil {
+		m.boards.addMember(inv.BoardID, userID, inv.Email, inv.Role)
+	}
+	return inv, nil
+}
+
+func (m *memInvitationRepo) Decline(ctx context.Context, hash string, now time.Time) (*invitation.Invitation, error) {
+	return m.respond(hash, invitation.StatusDeclined, now)
+}
+
+// expire переносит срок действия всех приглашений в прошлое.
+func (m *memInvitationRepo) expire() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, inv := range m.invitations {
+		inv.ExpiresAt = time.Now().Add(-time.Minute)
+	}
+}
+
+type invitationFixture struct {
+	*accountFixture
+	invitations *memInvitationRepo
+}
+
+func newInvitationFixture(t *testing.T, settings service.EmailSettings) *invitationFixture {
+	t.Helper()
+	ws := &memWorkspaceRepo{}
+	ws.boards = &memBoardRepo{ws: ws}
+	invitations := &memInvitationRepo{boards: ws.boards}
+	f := newAccountFixture(t, settings, func(d *myhttp.Deps) {
+		d.WorkspaceRepo = ws
+		d.BoardRepo = ws.boards
+		d.InvitationRepo = invitations
+	})
+	return &invitationFixture{accountFixture: f, invitations: invitations}
+}
+
+func TestBoardInvitationAcceptByExistingUser(t *testing.T) {
+	f := newInvitationFixture(t, service.EmailSettings{})
+	alice := f.register(t, "alice@example.com", "correct horse")
+	bob := f.register(t, "bob@example.com", "correct horse")
+
+	_, b := f.do(http.MethodPost, "/api/v1/boards", map[string]string{"name": "Roadmap"}, alice)
+	boardPath := "/api/v1/boards/" + b["id"].(string)
+
+	for _, bad := range []map[string]string{
+		{"email": "not an email"},
+		{"email": "bob@example.com", "role": "owner"},
+	} {
+		if code, body := f.do(http.MethodPost, boardPath+"/invitations", bad, alice); code != http.StatusBadRequest {
+			t.Fatalf("invite %v: expected 400, got %d %v", bad, code, body)
+		}
+	}
+	if code, _ := f.do(http.MethodPost, boardPath+"/invitations", map[string]string{"email": "bob@example.com"}, bob); code != http.StatusNotFound {
+		t.Fatalf("outsider must not see the board, got %d", code)
+	}
+
+	code, inv := f.do(http.MethodPost, boardPath+"/invitations", map[string]string{"email": "bob@example.com"}, alice)
+	if code != http.StatusCreated || inv["role"] != "member" || inv["status"] != "pending" || inv["email"] != "bob@example.com" {
+		t.Fatalf("invite: %d %v", code, inv)
+	}
+	msg, _ := f.outbox.Last("bob@example.com")
+	if !strings.Contains(msg.Subject, "Roadmap") {
+		t.Fatalf("unexpected invitation subject: %q", msg.Subject)
+	}
+	if items := listJSON(t, f.accountFixture, boardPath+"/invitations", alice); len(items) != 1 {
+		t.Fatalf("expected 1 pending invitation, got %v", items)
+	}
+
+	token := f.tokenFromMail(t, "bob@example.com")
+	code, accepted := f.do(http.MethodPost, "/api/v1/invitations/accept", map[string]string{"token": token}, bob)
+	if code != http.StatusOK || accepted["name"] != "Roadmap" {
+		t.Fatalf("accept: %d %v", code, accepted)
+	}
+	if code, _ := f.do(http.MethodPost, "/api/v1/invitations/accept", map[string]string{"token": token}, bob); code != http.StatusBadRequest {
+		t.Fatalf("invitation must be single-use, got %d", code)
+	}
+
+	if code, _ := f.do(http.MethodGet, boardPath, nil, bob); code != http.StatusOK {
+		t.Fatalf("member must see the board, got %d", code)
+	}
+	if shared := listJSON(t, f.accountFixture, "/api/v1/boards/shared", bob); len(shared) != 1 || shared[0]["name"] != "Roadmap" {
+		t.Fatalf("unexpected shared boards: %v", shared)
+	}
+	if own := listJSON(t, f.accountFixture, "/api/v1/boards", bob); len(own) != 0 {
+		t.Fatalf("shared board must not be listed as personal: %v", own)
+	}
+	if members := listJSON(t, f.accountFixture, boardPath+"/members", bob); len(members) != 1 || members[0]["user_id"] != "user-bob@example.com" {
+		t.Fatalf("unexpected members: %v", members)
+	}
+	if items := listJSON(t, f.accountFixture, boardPath+"/invitations", alice); len(items) != 0 {
+		t.Fatalf("accepted invitation must not be pending: %v", items)
+	}
+
+	// Участник с ролью member работает с доской, но не управляет ею.
+	if code, body := f.do(http.MethodPut, boardPath, map[string]string{"name": "Mine"}, bob); code != http.StatusForbidden || body["code"] != service.CodeBoardForbidden {
+		t.Fatalf("member must not rename the board: %d %v", code, body)
+	}
+	if code, _ := f.do(http.MethodPost, boardPath+"/invitations", map[string]string{"email": "carol@example.com"}, bob); code != http.StatusForbidden {
+		t.Fatalf("member must not invite, got %d", code)
+	}
+	if code, body := f.do(http.MethodPost, boardPath+"/invitations", map[string]string{"email": "bob@example.com"}, alice); code != http.StatusConflict || body["code"] != service.CodeBoardMemberExists {
+		t.Fatalf("inviting a member again: %d %v", code, body)
+	}
+
+	// Исключённый участник теряет доступ, а приглашение с ролью admin даёт управление доской.
+	if code, _ := f.do(http.MethodDelete, boardPath+"/members/user-bob@example.com", nil, alice); code != http.StatusNoContent {
+		t.Fatalf("remove member, got %d", code)
+	}
+	if code, _ := f.do(http.MethodGet, boardPath, nil, bob); code != http.StatusNotFound {
+		t.Fatalf("removed member must lose access, got %d", code)
+	}
+	f.do(http.MethodPost, boardPath+"/invitations", map[string]string{"email": "bob@example.com", "role": "admin"}, alice)
+	if code, _ := f.do(http.MethodPost, "/api/v1/invitations/accept", map[string]string{"token": f.tokenFromMail(t, "bob@example.com")}, bob); code != http.StatusOK {
+		t.Fatalf("accept admin invitation, got %d", code)
+	}
+	if code, body := f.do(http.MethodPut, boardPath, map[string]string{"name": "Q3"}, bob); code != http.StatusOK {
+		t.Fatalf("board admin must rename the board: %d %v", code, body)
+	}
+	if code, body := f.do(http.MethodPost, boardPath+"/transfer", map[string]any{"workspace_id": nil}, bob); code != http.StatusForbidden || body["code"] != service.CodeBoardForbidden {
+		t.Fatalf("board admin must not take over a personal board: %d %v", code, body)
+	}
+
+	// Покинуть доску может сам участник.
+	if code, _ := f.do(http.MethodDelete, boardPath+"/members/user-bob@example.com", nil, bob); code != http.StatusNoContent {
+		t.Fatalf("leave board, got %d", code)
+	}
+	if code, body := f.do(http.MethodDelete, boardPath+"/members/user-bob@example.com", nil, alice); code != http.StatusNotFound || body["code"] != service.CodeBoardMemberNotFound {
+		t.Fatalf("remove non-member: %d %v", code, body)
+	}
+}
+
+func TestBoardInvitationRegisterDeclineRevokeExpire(t *testing.T) {
+	f := newInvitationFixture(t, service.EmailSettings{RequireVerified: true})
+	creds := map[string]string{"email": "alice@example.com", "password": "correct horse"}
+	f.post("/api/v1/auth/register", creds)
+	f.post("/api/v1/auth/verify-email", map[string]string{"token": f.tokenFromMail(t, "alice@example.com")})
+	_, login := f.post("/api/v1/auth/login", creds)
+	alice, _ := login["token"].(string)
+
+	_, b := f.do(http.MethodPost, "/api/v1/boards", map[string]string{"name": "Roadmap"}, alice)
+	boardPath := "/api/v1/boards/" + b["id"].(string)
+	invite := func(email string) string {
+		t.Helper()
+		if code, body := f.do(http.MethodPost, boardPath+"/invitations", map[string]string{"email": email}, alice); code != http.StatusCreated {
+			t.Fatalf("invite %s: %d %v", email, code, body)
+		}
+		return f.tokenFromMail(t, email)
+	}
+
+	// Регистрация по приглашению: адрес подтверждён письмом, поэтому токен выдаётся сразу.
+	token := invite("new@example.com")
+	code, body := f.post("/api/v1/auth/register", map[string]string{"email": "new@example.com", "password": "correct horse", "invitation_token": token})
+	if code != http.StatusCreated || body["email_verified"] != true || body["token"] == nil {
+		t.Fatalf("register with invitation: %d %v", code, body)
+	}
+	if code, _ := f.do(http.MethodGet, boardPath, nil, body["token"].(string)); code != http.StatusOK {
+		t.Fatalf("registered invitee must see the board, got %d", code)
+	}
+	if code, body := f.post("/api/v1/auth/register", map[string]string{"email": "other@example.com", "password": "correct horse", "invitation_token": token}); code != http.StatusBadRequest || body["code"] != service.CodeInvalidToken {
+		t.Fatalf("used invitation must not register: %d %v", code, body)
+	}
+	if _, ok := f.outbox.Last("other@example.com"); ok {
+		t.Fatalf("user must not be created with an invalid invitation")
+	}
+
+	// Отклонить приглашение можно без аккаунта.
+	token = invite("carol@example.com")
+	if code, _ := f.post("/api/v1/invitations/decline", map[string]string{"token": token}); code != http.StatusNoContent {
+		t.Fatalf("decline, got %d", code)
+	}
+	if code, _ := f.post("/api/v1/invitations/decline", map[string]string{"token": token}); code != http.StatusBadRequest {
+		t.Fatalf("declined invitation must not be reused, got %d", code)
+	}
+
+	// Повторное приглашение заменяет прежнее, отозванное перестаёт действовать.
+	first := invite("dave@example.com")
+	second := invite("dave@example.com")
+	items := listJSON(t, f.accountFixture, boardPath+"/invitations", alice)
+	if len(items) != 1 {
+		t.Fatalf("re-invite must replace the pending invitation: %v", items)
+	}
+	if code, _ := f.post("/api/v1/invitations/decline", map[string]string{"token": first}); code != http.StatusBadRequest {
+		t.Fatalf("replaced invitation must be invalid, got %d", code)
+	}
+	if code, _ := f.do(http.MethodDelete, boardPath+"/invitations/"+items[0]["id"].(string), nil, alice); code != http.StatusNoContent {
+		t.Fatalf("revoke, got %d", code)
+	}
+	if code, body := f.do(http.MethodDelete, boardPath+"/invitations/"+items[0]["id"].(string), nil, alice); code != http.StatusNotFound || body["code"] != service.CodeInvitationNotFound {
+		t.Fatalf("revoke twice: %d %v", code, body)
+	}
+	if code, _ := f.post("/api/v1/invitations/decline", map[string]string{"token": second}); code != http.StatusBadRequest {
+		t.Fatalf("revoked invitation must be invalid, got %d", code)
+	}
+
+	// Просроченное приглашение не видно в списке и не принимается.
+	token = invite("erin@example.com")
+	f.invitations.expire()
+	if items := listJSON(t, f.accountFixture, boardPath+"/invitations", alice); len(items) != 0 {
+		t.Fatalf("expired invitation must not be listed: %v", items)
+	}
+	if code, _ := f.post("/api/v1/invitations/decline", map[string]string{"token": token}); code != http.StatusBadRequest {
+		t.Fatalf("expired invitation must be invalid, got %d", code)
+	}
+}
+
+func TestIntegration_InvitationRepository(t *testing.T) {
+	dsn, stop := startPostgres(t)
+	defer stop()
+
+	db, err := pg.New(dsn)
+	if err != nil {
+		t.Fatalf("connect db: %v", err)
+	}
+	defer db.Close()
+	applyMigrations(t, db.DB)
+
+	ctx := context.Background()
+	users := pg.NewUserRepository(db)
+	newUser := func(email string) *user.User {
+		u := &user.User{Email: email, PasswordHash: "hash"}
+		if err := users.Create(ctx, u); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+		return u
+	}
+	owner, guest := newUser("owner@example.com"), newUser("guest@example.com")
+
+	boards := pg.NewBoardRepository(db)
+	b := &board.Board{OwnerID: owner.ID, Name: "Roadmap"}
+	if err := boards.Create(ctx, b); err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+
+	repo := pg.NewInvitationRepository(db)
+	now := time.Now()
+	newInvitation := func(email string, role board.Role) (*invitation.Invitation, string) {
+		raw, hash, err := auth.NewOpaqueToken()
+		if err != nil {
+			t.Fatalf("token: %v", err)
+		}
+		inv := &invitation.Invitation{BoardID: b.ID, Email: email, Role: role, InvitedBy: owner.ID, Hash: hash, ExpiresAt: now.Add(time.Hour)}
+		if err := repo.Create(ctx, inv); err != nil || inv.ID == "" || inv.BoardName != "Roadmap" {
+			t.Fatalf("create invitation: %+v %v", inv, err)
+		}
+		return inv, raw
+	}
+
+	_, first := newInvitation("Guest@example.com", board.RoleMember)
+	inv, raw := newInvitation("guest@example.com", board.RoleMember)
+	if list, err := repo.ListPending(ctx, b.ID, now); err != nil || len(list) != 1 || list[0].ID != inv.ID {
+		t.Fatalf("re-invite must replace the pending invitation: %v %v", list, err)
+	}
+	if _, err := repo.FindPending(ctx, auth.HashOpaqueToken(first), now); err != invitation.ErrInvalid {
+		t.Fatalf("replaced invitation: expected ErrInvalid, got %v", err)
+	}
+	if _, err := repo.Accept(ctx, auth.HashOpaqueToken(raw), guest.ID, now.Add(2*time.Hour)); err != invitation.ErrInvalid {
+		t.Fatalf("expired invitation: expected ErrInvalid, got %v", err)
+	}
+
+	if _, err := boards.GetByID(ctx, b.ID, guest.ID); err != board.ErrNotFound {
+		t.Fatalf("guest must not see the board before accepting: %v", err)
+	}
+	if _, err := repo.Accept(ctx, auth.HashOpaqueToken(raw), guest.ID, now); err != nil {
+		t.Fatalf("accept: %v", err)
+	}
+	if _, err := repo.Accept(ctx, auth.HashOpaqueToken(raw), guest.ID, now); err != invitation.ErrInvalid {
+		t.Fatalf("accept twice: expected ErrInvalid, got %v", err)
+	}
+	if _, err := boards.GetByID(ctx, b.ID, guest.ID); err != nil {
+		t.Fatalf("member must see the board: %v", err)
+	}
+	if ok, err := boards.CanManage(ctx, b.ID, guest.ID); err != nil || ok {
+		t.Fatalf("member must not manage the board: %v %v", ok, err)
+	}
+	if shared, err := boards.ListShared(ctx, guest.ID); err != nil || len(shared) != 1 {
+		t.Fatalf("list shared: %v %v", shared, err)
+	}
+
+	_, raw = newInvitation("guest@example.com", board.RoleAdmin)
+	if _, err := repo.Accept(ctx, auth.HashOpaqueToken(raw), guest.ID, now); err != nil {
+		t.Fatalf("accept admin invitation: %v", err)
+	}
+	if ok, err := boards.CanManage(ctx, b.ID, guest.ID); err != nil || !ok {
+		t.Fatalf("board admin must manage the board: %v %v", ok, err)
+	}
+	if members, err := boards.ListMembers(ctx, b.ID); err != nil || len(members) != 1 || members[0].Role != board.RoleAdmin || members[0].Email != guest.Email {
+		t.Fatalf("list members: %+v %v", members, err)
+	}
+
+	declined, raw := newInvitation("nobody@example.com", board.RoleMember)
+	if _, err := repo.Decline(ctx, auth.HashOpaqueToken(raw), now); err != nil {
+		t.Fatalf("decline: %v", err)
+	}
+	if err := repo.Revoke(ctx, declined.ID, b.ID); err != invitation.ErrNotFound {
+		t.Fatalf("revoke answered invitation: expected ErrNotFound, got %v", err)
+	}
+
+	if err := boards.RemoveMember(ctx, b.ID, guest.ID); err != nil {
+		t.Fatalf("remove member: %v", err)
+	}
+	if err := boards.RemoveMember(ctx, b.ID, guest.ID); err != board.ErrMemberNotFound {
+		t.Fatalf("remove twice: expected ErrMemberNotFound, got %v", err)
+	}
+}
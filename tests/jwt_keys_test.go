@@ -0,0 +1,240 @@
+package tests
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+	myhttp "github.com/VladislavDraga398/kanban-backend/internal/http"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate rsa key: %v", err)
+	}
+	return key
+}
+
+func newEd25519Key(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate ed25519 key: %v", err)
+	}
+	return key
+}
+
+func newKeySet(t *testing.T, signer crypto.Signer) *auth.KeySet {
+	t.Helper()
+	keys, err := auth.NewKeySet(signer)
+	if err != nil {
+		t.Fatalf("new key set: %v", err)
+	}
+	return keys
+}
+
+func tokenHeader(t *testing.T, token string) map[string]any {
+	t.Helper()
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &jwt.RegisteredClaims{})
+	if err != nil {
+		t.Fatalf("parse token: %v", err)
+	}
+	return parsed.Header
+}
+
+func TestKeySetSignsAndVerifiesByKeyID(t *testing.T) {
+	for name, signer := range map[string]crypto.Signer{"RS256": newRSAKey(t), "EdDSA": newEd25519Key(t)} {
+		t.Run(name, func(t *testing.T) {
+			keys := newKeySet(t, signer)
+			token, err := keys.Sign("user-1", time.Minute)
+			if err != nil {
+				t.Fatalf("sign: %v", err)
+			}
+			if h := tokenHeader(t, token); h["alg"] != name || h["kid"] != keys.SigningKeyID() {
+				t.Fatalf("unexpected header %v", h)
+			}
+			if userID, err := keys.Parse(token); err != nil || userID != "user-1" {
+				t.Fatalf("parse: %q %v", userID, err)
+			}
+
+			expired, _ := keys.Sign("user-1", -time.Minute)
+			if _, err := keys.Parse(expired); err == nil {
+				t.Fatal("expired token must be rejected")
+			}
+			// Токен, подписанный общим секретом, при асимметричных ключах не принимается.
+			hs, _ := auth.GenerateJWT("user-1", []byte(testSecret), time.Minute)
+			if _, err := keys.Parse(hs); err == nil {
+				t.Fatal("HS256 token must be rejected")
+			}
+			// Ключ другого набора с тем же заголовком kid не подходит.
+			other, _ := newKeySet(t, newEd25519Key(t)).Sign("user-1", time.Minute)
+			if _, err := keys.Parse(other); err == nil {
+				t.Fatal("token signed by an unknown key must be rejected")
+			}
+		})
+	}
+
+	// Алгоритм берётся из ключа: подпись Ed25519 под kid RSA-ключа отвергается.
+	rsaKeys := newKeySet(t, newRSAKey(t))
+	forged := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.RegisteredClaims{Subject: "user-1", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))})
+	forged.Header["kid"] = rsaKeys.SigningKeyID()
+	raw, err := forged.SignedString(newEd25519Key(t))
+	if err != nil {
+		t.Fatalf("sign forged token: %v", err)
+	}
+	if _, err := rsaKeys.Parse(raw); err == nil {
+		t.Fatal("algorithm mismatch must be rejected")
+	}
+}
+
+func TestKeySetRotationOverlap(t *testing.T) {
+	oldKey, newKey := newRSAKey(t), newEd25519Key(t)
+	old := newKeySet(t, oldKey)
+	oldToken, err := old.Sign("user-1", time.Hour)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+
+	// После ротации подписывает новый ключ, а старый принимается до конца окна перекрытия.
+	now := time.Now()
+	rotated := newKeySet(t, newKey).WithClock(func() time.Time { return now })
+	if err := rotated.AddVerificationKey(oldKey.Public(), now.Add(30*time.Minute)); err != nil {
+		t.Fatalf("add verification key: %v", err)
+	}
+	if userID, err := rotated.Parse(oldToken); err != nil || userID != "user-1" {
+		t.Fatalf("token signed by the previous key must be accepted during overlap: %v", err)
+	}
+	newToken, _ := rotated.Sign("user-2", time.Hour)
+	if h := tokenHeader(t, newToken); h["kid"] == old.SigningKeyID() {
+		t.Fatal("new tokens must be signed with the new key")
+	}
+	if jwks := rotated.JWKS(); len(jwks.Keys) != 2 || jwks.Keys[0].KeyID != rotated.SigningKeyID() || jwks.Keys[1].KeyID != old.SigningKeyID() {
+		t.Fatalf("expected both keys in JWKS, got %+v", jwks)
+	}
+
+	now = now.Add(31 * time.Minute)
+	if _, err := rotated.Parse(oldToken); err == nil {
+		t.Fatal("previous key must stop verifying after the overlap window")
+	}
+	if jwks := rotated.JWKS(); len(jwks.Keys) != 1 || jwks.Keys[0].KeyID != rotated.SigningKeyID() {
+		t.Fatalf("retired key must not be published, got %+v", jwks)
+	}
+	if _, err := rotated.Parse(newToken); err != nil {
+		t.Fatalf("current key must keep verifying: %v", err)
+	}
+}
+
+func writePEM(t *testing.T, dir, name, typ string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestLoadKeySetFromPEMFiles(t *testing.T) {
+	dir := t.TempDir()
+	current := newEd25519Key(t)
+	der, err := x509.MarshalPKCS8PrivateKey(current)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	signing := writePEM(t, dir, "current.pem", "PRIVATE KEY", der)
+
+	previous := newRSAKey(t)
+	prevPath := writePEM(t, dir, "previous.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(previous))
+	next := newEd25519Key(t)
+	pubDER, err := x509.MarshalPKIXPublicKey(next.Public())
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	nextPath := writePEM(t, dir, "next.pub.pem", "PUBLIC KEY", pubDER)
+
+	keys, err := auth.LoadKeySet(signing, []auth.KeyFile{{Path: prevPath, NotAfter: time.Now().Add(time.Hour)}, {Path: nextPath}})
+	if err != nil {
+		t.Fatalf("load key set: %v", err)
+	}
+	if keys.SigningKeyID() != newKeySet(t, current).SigningKeyID() {
+		t.Fatal("kid must be derived from the key itself")
+	}
+	if n := len(keys.JWKS().Keys); n != 3 {
+		t.Fatalf("expected 3 published keys, got %d", n)
+	}
+	prevToken, _ := newKeySet(t, previous).Sign("user-1", time.Minute)
+	if _, err := keys.Parse(prevToken); err != nil {
+		t.Fatalf("previous key must verify: %v", err)
+	}
+
+	small, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	for name, path := range map[string]string{
+		"public key for signing": nextPath,
+		"short rsa key":          writePEM(t, dir, "small.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(small)),
+		"missing file":           filepath.Join(dir, "missing.pem"),
+		"not pem":                writePEM(t, dir, "garbage.pem", "CERTIFICATE", []byte("garbage")),
+	} {
+		if _, err := auth.LoadKeySet(path, nil); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
+
+func TestRouterPublishesJWKSAndVerifiesAsymmetricTokens(t *testing.T) {
+	keys := newKeySet(t, newRSAKey(t))
+	f := newAccountFixture(t, service.EmailSettings{}, func(d *myhttp.Deps) { d.JWTKeys = keys })
+	token := f.register(t, "rs@example.com", "correct horse")
+	if h := tokenHeader(t, token); h["alg"] != "RS256" || h["kid"] != keys.SigningKeyID() {
+		t.Fatalf("expected RS256 token with kid, got %v", h)
+	}
+	if code, _ := f.do(http.MethodGet, "/api/v1/me", nil, token); code != http.StatusOK {
+		t.Fatalf("asymmetric token must authenticate, got %d", code)
+	}
+	if code, _ := f.do(http.MethodGet, "/api/v1/me", nil, mustToken(t, "user-rs@example.com")); code != http.StatusUnauthorized {
+		t.Fatalf("HS256 token must be rejected once keys are configured, got %d", code)
+	}
+
+	rec := doJSONRequest(f.router, http.MethodGet, "/.well-known/jwks.json", nil, nil)
+	if rec.Code != http.StatusOK || rec.Header().Get("Cache-Control") == "" {
+		t.Fatalf("jwks: %d %v", rec.Code, rec.Header())
+	}
+	var got any
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode jwks: %v", err)
+	}
+	if err := matchSchema(loadSpec(t), map[string]any{"$ref": "#/components/schemas/JWKS"}, got, "$"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Сторонний сервис проверяет токен только по опубликованному ключу, без общего секрета.
+	var set auth.JWKS
+	if err := json.Unmarshal(rec.Body.Bytes(), &set); err != nil || len(set.Keys) != 1 {
+		t.Fatalf("decode jwks: %v %+v", err, set)
+	}
+	jwk := set.Keys[0]
+	n, _ := base64.RawURLEncoding.DecodeString(jwk.N)
+	e, _ := base64.RawURLEncoding.DecodeString(jwk.E)
+	pub := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	parsed, err := jwt.Parse(token, func(tk *jwt.Token) (any, error) { return pub, nil }, jwt.WithValidMethods([]string{jwk.Alg}))
+	if err != nil || !parsed.Valid || parsed.Header["kid"] != jwk.KeyID {
+		t.Fatalf("verify with published key: %v", err)
+	}
+}
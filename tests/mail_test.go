@@ -0,0 +1,140 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/mail"
+)
+
+func TestMailFormat(t *testing.T) {
+	data, err := mail.Format("Kanban <no-reply@example.com>", mail.Message{
+		To:      "user@example.com",
+		Subject: "Сброс пароля",
+		Body:    "Ссылка: https://app.example.com/reset-password?token=abc",
+	}, time.Unix(0, 0).UTC())
+	if err != nil {
+		t.Fatalf("format: %v", err)
+	}
+	msg := string(data)
+	for _, want := range []string{
+		"To: user@example.com\r\n",
+		"Subject: =?utf-8?q?",
+		"Content-Type: text/plain; charset=utf-8\r\n",
+		"token=3Dabc",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Fatalf("message lacks %q:\n%s", want, msg)
+		}
+	}
+
+	if _, err := mail.Format("no-reply@example.com", mail.Message{To: "not an address"}, time.Now()); err == nil {
+		t.Fatalf("expected error for invalid recipient")
+	}
+}
+
+func TestOutboxAndFileMailer(t *testing.T) {
+	outbox := mail.NewOutbox()
+	_ = outbox.Send(context.Background(), mail.Message{To: "a@example.com", Subject: "1"})
+	_ = outbox.Send(context.Background(), mail.Message{To: "b@example.com", Subject: "2"})
+	_ = outbox.Send(context.Background(), mail.Message{To: "a@example.com", Subject: "3"})
+	if last, ok := outbox.Last("a@example.com"); !ok || last.Subject != "3" || len(outbox.Messages()) != 3 {
+		t.Fatalf("unexpected outbox state: %+v", outbox.Messages())
+	}
+
+	dir := filepath.Join(t.TempDir(), "outbox")
+	fm := mail.NewFileMailer(dir, "no-reply@example.com")
+	for i := 0; i < 2; i++ {
+		if err := fm.Send(context.Background(), mail.Message{To: "a@example.com", Subject: "Hi", Body: "body"}); err != nil {
+			t.Fatalf("send to file: %v", err)
+		}
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil || len(files) != 2 {
+		t.Fatalf("expected two .eml files, got %v (%v)", files, err)
+	}
+	data, _ := os.ReadFile(files[0])
+	if !strings.Contains(string(data), "To: a@example.com") {
+		t.Fatalf("unexpected file contents: %s", data)
+	}
+}
+
+// fakeSMTP принимает одно письмо по минимальному диалогу SMTP и отдаёт его в канал.
+func fakeSMTP(t *testing.T) (string, int, <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	got := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		tp := textproto.NewConn(conn)
+		_ = tp.PrintfLine("220 localhost ESMTP")
+		var envelope []string
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+			switch cmd {
+			case "EHLO", "HELO":
+				_ = tp.PrintfLine("250 localhost")
+			case "MAIL", "RCPT":
+				envelope = append(envelope, line)
+				_ = tp.PrintfLine("250 OK")
+			case "DATA":
+				_ = tp.PrintfLine("354 go ahead")
+				body, _ := tp.ReadDotBytes()
+				got <- strings.Join(envelope, "\n") + "\n" + string(body)
+				_ = tp.PrintfLine("250 OK")
+			case "QUIT":
+				_ = tp.PrintfLine("221 bye")
+				return
+			default:
+				_ = tp.PrintfLine("502 not implemented")
+			}
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	portNum, _ := strconv.Atoi(port)
+	return host, portNum, got
+}
+
+func TestSMTPMailerSends(t *testing.T) {
+	host, port, got := fakeSMTP(t)
+	m := mail.NewSMTPMailer(mail.SMTPConfig{Host: host, Port: port, From: "Kanban <no-reply@example.com>"})
+
+	if err := m.Send(context.Background(), mail.Message{To: "user@example.com", Subject: "Hello", Body: "line"}); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	select {
+	case data := <-got:
+		sc := bufio.NewScanner(strings.NewReader(data))
+		sc.Scan()
+		if !strings.HasPrefix(sc.Text(), "MAIL FROM:<no-reply@example.com>") {
+			t.Fatalf("unexpected envelope sender: %q", sc.Text())
+		}
+		if !strings.Contains(data, "RCPT TO:<user@example.com>") || !strings.Contains(data, "Subject: Hello") {
+			t.Fatalf("unexpected message:\n%s", data)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("smtp server received nothing")
+	}
+}
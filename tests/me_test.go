@@ -0,0 +1,223 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
+	pg "github.com/VladislavDraga398/kanban-backend/internal/storage/postgres"
+)
+
+func fieldsOf(body map[string]any) map[string]bool {
+	out := map[string]bool{}
+	errs, _ := body["errors"].([]any)
+	for _, e := range errs {
+		if m, ok := e.(map[string]any); ok {
+			out[m["field"].(string)] = true
+		}
+	}
+	return out
+}
+
+func TestMeProfileGetAndPatch(t *testing.T) {
+	f := newAccountFixture(t, service.EmailSettings{})
+	token := f.register(t, "me@example.com", "correct horse")
+
+	code, body := f.do(http.MethodGet, "/api/v1/me", nil, token)
+	if code != http.StatusOK || body["email"] != "me@example.com" || body["display_name"] != "" {
+		t.Fatalf("get me: %d %v", code, body)
+	}
+
+	code, body = f.do(http.MethodPatch, "/api/v1/me", map[string]string{
+		"display_name": "  Alice  ",
+		"timezone":     "Europe/Moscow",
+		"locale":       "ru-RU",
+	}, token)
+	if code != http.StatusOK || body["display_name"] != "Alice" || body["timezone"] != "Europe/Moscow" || body["locale"] != "ru-RU" {
+		t.Fatalf("patch me: %d %v", code, body)
+	}
+
+	// Частичное обновление не трогает остальные поля.
+	code, body = f.do(http.MethodPatch, "/api/v1/me", map[string]string{"avatar_url": "https://cdn.example.com/a.png"}, token)
+	if code != http.StatusOK || body["display_name"] != "Alice" || body["avatar_url"] != "https://cdn.example.com/a.png" {
+		t.Fatalf("partial patch: %d %v", code, body)
+	}
+
+	code, body = f.do(http.MethodPatch, "/api/v1/me", map[string]string{
+		"avatar_url": "javascript:alert(1)",
+		"timezone":   "Mars/Olympus",
+		"locale":     "русский",
+	}, token)
+	fields := fieldsOf(body)
+	if code != http.StatusBadRequest || !fields["avatar_url"] || !fields["timezone"] || !fields["locale"] {
+		t.Fatalf("expected field errors, got %d %v", code, body)
+	}
+}
+
+func TestMeChangePassword(t *testing.T) {
+	f := newAccountFixture(t, service.EmailSettings{})
+	token := f.register(t, "pw@example.com", "old password")
+
+	code, body := f.do(http.MethodPost, "/api/v1/me/password", map[string]string{"current_password": "wrong", "new_password": "new password"}, token)
+	if code != http.StatusBadRequest || !fieldsOf(body)["current_password"] {
+		t.Fatalf("expected current_password error, got %d %v", code, body)
+	}
+	if code, body := f.do(http.MethodPost, "/api/v1/me/password", map[string]string{"current_password": "old password", "new_password": "new password"}, token); code != http.StatusNoContent {
+		t.Fatalf("change password: %d %v", code, body)
+	}
+	if code, _ := f.post("/api/v1/auth/login", map[string]string{"email": "pw@example.com", "password": "new password"}); code != http.StatusOK {
+		t.Fatalf("login with new password: %d", code)
+	}
+}
+
+func TestMeChangeEmailRequiresVerification(t *testing.T) {
+	f := newAccountFixture(t, service.EmailSettings{})
+	token := f.register(t, "old@example.com", "correct horse")
+	f.register(t, "taken@example.com", "correct horse")
+
+	if code, body := f.do(http.MethodPost, "/api/v1/me/email", map[string]string{"email": "taken@example.com", "password": "correct horse"}, token); code != http.StatusConflict || body["code"] != service.CodeEmailAlreadyUsed {
+		t.Fatalf("expected conflict, got %d %v", code, body)
+	}
+	if code, body := f.do(http.MethodPost, "/api/v1/me/email", map[string]string{"email": "new@example.com", "password": "wrong"}, token); code != http.StatusBadRequest || !fieldsOf(body)["password"] {
+		t.Fatalf("expected password error, got %d %v", code, body)
+	}
+	if code, body := f.do(http.MethodPost, "/api/v1/me/email", map[string]string{"email": "new@example.com", "password": "correct horse"}, token); code != http.StatusAccepted {
+		t.Fatalf("change email: %d %v", code, body)
+	}
+
+	// До подтверждения адрес прежний, на старый ушло уведомление.
+	if _, body := f.do(http.MethodGet, "/api/v1/me", nil, token); body["email"] != "old@example.com" {
+		t.Fatalf("email must not change before verification: %v", body)
+	}
+	if notice, ok := f.outbox.Last("old@example.com"); !ok || notice.Subject != "Запрошена смена email в Kanban" {
+		t.Fatalf("expected notice to the old address, got %+v", notice)
+	}
+
+	verify := f.tokenFromMail(t, "new@example.com")
+	if code, body := f.post("/api/v1/auth/verify-email", map[string]string{"token": verify}); code != http.StatusNoContent {
+		t.Fatalf("verify new email: %d %v", code, body)
+	}
+	if _, body := f.do(http.MethodGet, "/api/v1/me", nil, token); body["email"] != "new@example.com" || body["email_verified"] != true {
+		t.Fatalf("email must change after verification: %v", body)
+	}
+	if code, _ := f.post("/api/v1/auth/login", map[string]string{"email": "new@example.com", "password": "correct horse"}); code != http.StatusOK {
+		t.Fatalf("login with new email: %d", code)
+	}
+}
+
+func TestMeDeleteRequiresConfirmation(t *testing.T) {
+	f := newAccountFixture(t, service.EmailSettings{})
+	token := f.register(t, "bye@example.com", "correct horse")
+
+	code, body := f.do(http.MethodDelete, "/api/v1/me", map[string]string{"password": "wrong", "confirm_email": "other@example.com"}, token)
+	if fields := fieldsOf(body); code != http.StatusBadRequest || !fields["password"] || !fields["confirm_email"] {
+		t.Fatalf("expected confirmation errors, got %d %v", code, body)
+	}
+	if code, body := f.do(http.MethodDelete, "/api/v1/me", map[string]string{"password": "correct horse", "confirm_email": "BYE@example.com"}, token); code != http.StatusNoContent {
+		t.Fatalf("delete me: %d %v", code, body)
+	}
+	if code, body := f.do(http.MethodGet, "/api/v1/me", nil, token); code != http.StatusNotFound || body["code"] != service.CodeUserNotFound {
+		t.Fatalf("expected user_not_found after delete, got %d %v", code, body)
+	}
+}
+
+func TestMeWithoutPassword(t *testing.T) {
+	f := newAccountFixture(t, service.EmailSettings{})
+	token := f.register(t, "sso@example.com", "correct horse")
+	keep := f.register(t, "keep@example.com", "correct horse")
+	// Учётная запись, созданная через внешнего провайдера, пароля не имеет.
+	f.mu.Lock()
+	f.users["sso@example.com"].PasswordHash = ""
+	f.mu.Unlock()
+
+	if code, body := f.do(http.MethodPost, "/api/v1/me/password", map[string]string{"new_password": "x"}, keep); code != http.StatusBadRequest || !fieldsOf(body)["current_password"] {
+		t.Fatalf("current_password must stay required for accounts with a password: %d %v", code, body)
+	}
+	if code, body := f.do(http.MethodDelete, "/api/v1/me", map[string]string{"confirm_email": "keep@example.com"}, keep); code != http.StatusBadRequest || !fieldsOf(body)["password"] {
+		t.Fatalf("password must stay required for accounts with a password: %d %v", code, body)
+	}
+
+	// Одной сессии мало, чтобы задать первый пароль или сменить адрес: пароль задаётся по ссылке из письма.
+	if code, body := f.do(http.MethodPost, "/api/v1/me/password", map[string]string{"new_password": "first password"}, token); code != http.StatusForbidden || body["code"] != service.CodePasswordNotSet {
+		t.Fatalf("first password must require a reset link: %d %v", code, body)
+	}
+	if code, body := f.do(http.MethodPost, "/api/v1/me/email", map[string]string{"email": "new@example.com"}, token); code != http.StatusForbidden || body["code"] != service.CodePasswordNotSet {
+		t.Fatalf("email change without a password must require a reset link first: %d %v", code, body)
+	}
+	if code, _ := f.post("/api/v1/auth/forgot-password", map[string]string{"email": "sso@example.com"}); code != http.StatusAccepted {
+		t.Fatalf("forgot password: %d", code)
+	}
+	reset := map[string]string{"token": f.tokenFromMail(t, "sso@example.com"), "password": "first password"}
+	if code, body := f.post("/api/v1/auth/reset-password", reset); code != http.StatusNoContent {
+		t.Fatalf("set first password: %d %v", code, body)
+	}
+	if code, _ := f.post("/api/v1/auth/login", map[string]string{"email": "sso@example.com", "password": "first password"}); code != http.StatusOK {
+		t.Fatalf("login with first password: %d", code)
+	}
+
+	f.mu.Lock()
+	f.users["sso@example.com"].PasswordHash = ""
+	f.mu.Unlock()
+	if code, body := f.do(http.MethodDelete, "/api/v1/me", map[string]string{"confirm_email": "other@example.com"}, token); code != http.StatusBadRequest || !fieldsOf(body)["confirm_email"] {
+		t.Fatalf("confirm_email must still match: %d %v", code, body)
+	}
+	if code, body := f.do(http.MethodDelete, "/api/v1/me", map[string]string{"confirm_email": "sso@example.com"}, token); code != http.StatusNoContent {
+		t.Fatalf("delete account without password: %d %v", code, body)
+	}
+}
+
+// Integration: профиль сохраняется, смена email подтверждает адрес, удаление каскадно убирает доски.
+func TestIntegration_UserAccount(t *testing.T) {
+	dsn, stop := startPostgres(t)
+	defer stop()
+
+	db, err := pg.New(dsn)
+	if err != nil {
+		t.Fatalf("connect db: %v", err)
+	}
+	defer db.Close()
+	applyMigrations(t, db.DB)
+
+	ctx := context.Background()
+	users, boards := pg.NewUserRepository(db), pg.NewBoardRepository(db)
+	u := &user.User{Email: "account@example.com", PasswordHash: "hash"}
+	if err := users.Create(ctx, u); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	other := &user.User{Email: "other@example.com", PasswordHash: "hash"}
+	if err := users.Create(ctx, other); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	u.DisplayName, u.Timezone, u.Locale = "Alice", "Europe/Moscow", "ru-RU"
+	if err := users.UpdateProfile(ctx, u); err != nil {
+		t.Fatalf("update profile: %v", err)
+	}
+	if err := users.UpdateEmail(ctx, u.ID, other.Email); err != user.ErrEmailAlreadyUsed {
+		t.Fatalf("expected ErrEmailAlreadyUsed, got %v", err)
+	}
+	if err := users.UpdateEmail(ctx, u.ID, "renamed@example.com"); err != nil {
+		t.Fatalf("update email: %v", err)
+	}
+	got, err := users.GetByID(ctx, u.ID)
+	if err != nil || got.DisplayName != "Alice" || got.Email != "renamed@example.com" || !got.EmailVerified() {
+		t.Fatalf("unexpected user: %+v %v", got, err)
+	}
+
+	b := &board.Board{OwnerID: u.ID, Name: "Doomed"}
+	if err := boards.Create(ctx, b); err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+	if err := users.Delete(ctx, u.ID); err != nil {
+		t.Fatalf("delete user: %v", err)
+	}
+	if _, err := boards.GetByID(ctx, b.ID, u.ID); err != board.ErrNotFound {
+		t.Fatalf("owned boards must be deleted, got %v", err)
+	}
+	if err := users.Delete(ctx, u.ID); err != user.ErrNotFound {
+		t.Fatalf("expected ErrNotFound on second delete, got %v", err)
+	}
+}
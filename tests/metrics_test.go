@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	"github.com/VladislavDraga398/kanban-backend/internal/health"
+	myhttp "github.com/VladislavDraga398/kanban-backend/internal/http"
+	"github.com/VladislavDraga398/kanban-backend/internal/metrics"
+)
+
+func TestMetricsEndpointReportsTrafficAndDomainCounters(t *testing.T) {
+	hash, err := auth.HashPassword("pass123")
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+	router := myhttp.NewRouter(myhttp.Deps{
+		UserRepo: &stubUserRepo{
+			getByEmailF: func(ctx context.Context, email string) (*user.User, error) {
+				return &user.User{ID: "owner-1", Email: email, PasswordHash: hash}, nil
+			},
+		},
+		BoardRepo: &stubBoardRepo{
+			getFn: func(ctx context.Context, id, ownerID string) (*board.Board, error) {
+				if id == "missing" {
+					return nil, board.ErrNotFound
+				}
+				return &board.Board{ID: id, OwnerID: ownerID}, nil
+			},
+		},
+		ColumnRepo: &stubColumnRepo{},
+		TaskRepo: &stubTaskRepo{
+			moveFn: func(ctx context.Context, tk *task.Task, columnID, ownerID string) error { return nil },
+		},
+		JWTSecret: testSecret,
+		JWTTTL:    time.Hour,
+		Metrics:   metrics.New(),
+	})
+	headers := bearer(mustToken(t, "owner-1"))
+
+	doJSONRequest(router, http.MethodPost, "/api/v1/auth/login", map[string]string{"email": "a@b.c", "password": "pass123"}, nil)
+	doJSONRequest(router, http.MethodPost, "/api/v1/auth/login", map[string]string{"email": "a@b.c", "password": "wrong"}, nil)
+	doJSONRequest(router, http.MethodPatch, "/api/v1/boards/b1/tasks/t1/move", map[string]string{"column_id": "c2"}, headers)
+	doJSONRequest(router, http.MethodGet, "/api/v1/boards/missing", nil, headers)
+	doJSONRequest(router, http.MethodGet, "/no/such/path", nil, nil)
+
+	rec := doJSONRequest(router, http.MethodGet, "/metrics", nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	body := rec.Body.String()
+
+	for _, want := range []string{
+		`kanban_http_requests_total{method="GET",route="/api/v1/boards/{id}",status="404"} 1`,
+		`kanban_http_requests_total{method="PATCH",route="/api/v1/boards/{board_id}/tasks/{task_id}/move",status="200"} 1`,
+		`kanban_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`kanban_http_request_duration_seconds_count{method="POST",route="/api/v1/auth/login",status="401"} 1`,
+		`kanban_http_requests_in_flight 1`,
+		`kanban_logins_total{result="success"} 1`,
+		`kanban_logins_total{result="failure"} 1`,
+		`kanban_tasks_moved_total 1`,
+		`kanban_tasks_created_total 0`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics output misses %q", want)
+		}
+	}
+}
+
+func TestMetricsOnSeparateAdminRouter(t *testing.T) {
+	m := metrics.New()
+	router := myhttp.NewRouter(myhttp.Deps{
+		UserRepo:      &stubUserRepo{},
+		BoardRepo:     &stubBoardRepo{},
+		ColumnRepo:    &stubColumnRepo{},
+		TaskRepo:      &stubTaskRepo{},
+		JWTSecret:     testSecret,
+		JWTTTL:        time.Hour,
+		Metrics:       m,
+		SeparateAdmin: true,
+	})
+
+	if rec := doJSONRequest(router, http.MethodGet, "/healthz", nil, nil); rec.Code != http.StatusOK {
+		t.Fatalf("healthz: expected 200, got %d", rec.Code)
+	}
+	if rec := doJSONRequest(router, http.MethodGet, "/metrics", nil, nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("public router must not expose /metrics, got %d", rec.Code)
+	}
+
+	rec := doJSONRequest(myhttp.NewAdminRouter(m, health.NewChecker(0)), http.MethodGet, "/metrics", nil, nil)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `kanban_http_requests_total{method="GET",route="/healthz",status="200"} 1`) {
+		t.Fatalf("admin router must serve shared metrics, got %d:\n%s", rec.Code, rec.Body.String())
+	}
+}
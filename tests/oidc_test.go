@@ -0,0 +1,501 @@
+package tests
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	myhttp "github.com/VladislavDraga398/kanban-backend/internal/http"
+	"github.com/VladislavDraga398/kanban-backend/internal/oidc"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
+	pg "github.com/VladislavDraga398/kanban-backend/internal/storage/postgres"
+)
+
+const (
+	mockClientID     = "kanban"
+	mockClientSecret = "s3cret"
+	ssoRedirectURL   = "https://api.example.com/api/v1/auth/oidc/corp/callback"
+)
+
+type mockIdPUser struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type mockGrant struct {
+	challenge   string
+	nonce       string
+	redirectURI string
+	user        mockIdPUser
+}
+
+// mockIdP — OpenID Connect провайдер на httptest: discovery, JWKS, страница входа
+// (сразу «логинит» пользователя user) и token endpoint с проверкой PKCE.
+type mockIdP struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	key         *rsa.PrivateKey
+	kid         string
+	codes       map[string]mockGrant
+	user        mockIdPUser
+	wrongNonce  bool
+	jwksFetches int
+}
+
+func newMockIdP(t *testing.T) *mockIdP {
+	t.Helper()
+	m := &mockIdP{codes: map[string]mockGrant{}, user: mockIdPUser{Subject: "sub-1", Email: "sso@example.com", EmailVerified: true}}
+	m.rotateKey(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeMockJSON(w, map[string]any{
+			"issuer":                                m.URL,
+			"authorization_endpoint":                m.URL + "/authorize",
+			"token_endpoint":                        m.URL + "/token",
+			"jwks_uri":                              m.URL + "/jwks",
+			"token_endpoint_auth_methods_supported": []string{"client_secret_basic"},
+		})
+	})
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.jwksFetches++
+		pub := m.key.PublicKey
+		writeMockJSON(w, map[string]any{"keys": []map[string]string{{
+			"kty": "RSA", "kid": m.kid, "use": "sig", "alg": "RS256",
+			"n": base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("GET /authorize", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("client_id") != mockClientID || q.Get("response_type") != "code" ||
+			q.Get("code_challenge_method") != "S256" || !strings.Contains(q.Get("scope"), "openid") {
+			http.Error(w, "bad authorization request", http.StatusBadRequest)
+			return
+		}
+		m.mu.Lock()
+		code := fmt.Sprintf("code-%d", len(m.codes)+1)
+		m.codes[code] = mockGrant{challenge: q.Get("code_challenge"), nonce: q.Get("nonce"), redirectURI: q.Get("redirect_uri"), user: m.user}
+		m.mu.Unlock()
+		http.Redirect(w, r, q.Get("redirect_uri")+"?"+url.Values{"code": {code}, "state": {q.Get("state")}}.Encode(), http.StatusFound)
+	})
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != mockClientID || secret != mockClientSecret {
+			w.WriteHeader(http.StatusUnauthorized)
+			writeMockJSON(w, map[string]string{"error": "invalid_client"})
+			return
+		}
+		m.mu.Lock()
+		grant, ok := m.codes[r.FormValue("code")]
+		delete(m.codes, r.FormValue("code"))
+		m.mu.Unlock()
+		sum := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+		if !ok || r.FormValue("grant_type") != "authorization_code" || r.FormValue("redirect_uri") != grant.redirectURI ||
+			base64.RawURLEncoding.EncodeToString(sum[:]) != grant.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			writeMockJSON(w, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		nonce := grant.nonce
+		if m.wrongNonce {
+			nonce = "replayed"
+		}
+		writeMockJSON(w, map[string]string{"access_token": "at", "token_type": "Bearer", "id_token": m.idToken(t, jwt.MapClaims{
+			"sub": grant.user.Subject, "email": grant.user.Email, "email_verified": grant.user.EmailVerified,
+			"name": grant.user.Name, "nonce": nonce,
+		})})
+	})
+
+	m.Server = httptest.NewServer(mux)
+	t.Cleanup(m.Close)
+	return m
+}
+
+func writeMockJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// rotateKey заменяет ключ подписи и kid, как при ротации ключей у провайдера.
+func (m *mockIdP) rotateKey(t *testing.T) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.key = key
+	m.kid = fmt.Sprintf("key-%d", time.Now().UnixNano())
+}
+
+// idToken подписывает ID token; стандартные claims (iss, aud, iat, exp) можно переопределить в extra.
+func (m *mockIdP) idToken(t *testing.T, extra jwt.MapClaims) string {
+	t.Helper()
+	now := time.Now()
+	claims := jwt.MapClaims{"iss": m.URL, "aud": mockClientID, "iat": now.Unix(), "exp": now.Add(5 * time.Minute).Unix()}
+	for k, v := range extra {
+		claims[k] = v
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tok := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	tok.Header["kid"] = m.kid
+	raw, err := tok.SignedString(m.key)
+	if err != nil {
+		t.Fatalf("sign id token: %v", err)
+	}
+	return raw
+}
+
+func (m *mockIdP) provider(name, redirectURL string) *oidc.Provider {
+	return oidc.NewProvider(oidc.ProviderConfig{
+		Name:         name,
+		DisplayName:  "Corp SSO",
+		Issuer:       m.URL,
+		ClientID:     mockClientID,
+		ClientSecret: mockClientSecret,
+		RedirectURL:  redirectURL,
+	}, m.Client())
+}
+
+// memIdentityRepo — user.IdentityRepository в памяти.
+type memIdentityRepo struct {
+	mu    sync.Mutex
+	links map[string]*user.Identity
+}
+
+func (m *memIdentityRepo) Find(ctx context.Context, provider, subject string) (*user.Identity, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if id, ok := m.links[provider+"/"+subject]; ok {
+		return id, nil
+	}
+	return nil, user.ErrIdentityNotFound
+}
+
+func (m *memIdentityRepo) Link(ctx context.Context, id *user.Identity) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.links == nil {
+		m.links = map[string]*user.Identity{}
+	}
+	id.CreatedAt = time.Now()
+	m.links[id.Provider+"/"+id.Subject] = id
+	return nil
+}
+
+func newSSOFixture(t *testing.T, opts ...func(*myhttp.Deps)) (*accountFixture, *mockIdP) {
+	t.Helper()
+	idp := newMockIdP(t)
+	f := newAccountFixture(t, service.EmailSettings{}, append([]func(*myhttp.Deps){func(d *myhttp.Deps) {
+		d.OIDCProviders = []*oidc.Provider{idp.provider("corp", ssoRedirectURL)}
+		d.IdentityRepo = &memIdentityRepo{}
+	}}, opts...)...)
+	return f, idp
+}
+
+// ssoLogin проходит вход через провайдера так, как это делает браузер, и возвращает
+// параметры из фрагмента адреса, на который сервис вернул пользователя.
+func ssoLogin(t *testing.T, router http.Handler, idp *mockIdP) url.Values {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/corp/login", nil))
+	if rec.Code != http.StatusFound {
+		t.Fatalf("login: expected redirect, got %d %s", rec.Code, rec.Body.String())
+	}
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || !cookies[0].HttpOnly || !cookies[0].Secure {
+		t.Fatalf("expected secure http-only flow cookie, got %+v", cookies)
+	}
+
+	client := idp.Client()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	resp, err := client.Get(rec.Header().Get("Location"))
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	_ = resp.Body.Close()
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil || resp.StatusCode != http.StatusFound || !strings.HasPrefix(callback.String(), ssoRedirectURL) {
+		t.Fatalf("authorize: unexpected response %d %q", resp.StatusCode, resp.Header.Get("Location"))
+	}
+
+	return ssoCallback(t, router, callback.RequestURI(), cookies[0])
+}
+
+func ssoCallback(t *testing.T, router http.Handler, uri string, cookie *http.Cookie) url.Values {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, uri, nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	loc, err := url.Parse(rec.Header().Get("Location"))
+	if err != nil || rec.Code != http.StatusFound || loc.Path != "/sso/callback" {
+		t.Fatalf("callback: unexpected response %d %q", rec.Code, rec.Header().Get("Location"))
+	}
+	result, err := url.ParseQuery(loc.Fragment)
+	if err != nil {
+		t.Fatalf("parse fragment: %v", err)
+	}
+	return result
+}
+
+func ssoUserID(t *testing.T, result url.Values) string {
+	t.Helper()
+	if result.Get("error") != "" {
+		t.Fatalf("sso login failed: %s", result.Get("error"))
+	}
+	userID, err := auth.ParseJWT(result.Get("token"), []byte(testSecret))
+	if err != nil {
+		t.Fatalf("parse session token: %v", err)
+	}
+	return userID
+}
+
+func TestOIDCLoginCreatesUserWithoutPassword(t *testing.T) {
+	f, idp := newSSOFixture(t)
+	idp.user.Name = "Sso User"
+
+	first := ssoUserID(t, ssoLogin(t, f.router, idp))
+	u := f.users["sso@example.com"]
+	if u == nil || u.ID != first || u.PasswordHash != "" || !u.EmailVerified() || u.DisplayName != "Sso User" {
+		t.Fatalf("unexpected sso user: %+v", u)
+	}
+
+	// Повторный вход находит пользователя по привязке, даже если email у провайдера сменился.
+	idp.user.Email = "renamed@example.com"
+	if again := ssoUserID(t, ssoLogin(t, f.router, idp)); again != first {
+		t.Fatalf("expected the same user, got %s and %s", first, again)
+	}
+	if _, ok := f.users["renamed@example.com"]; ok {
+		t.Fatal("second login must not create another user")
+	}
+
+	// Локального пароля нет, поэтому вход по паролю невозможен.
+	if code, _ := f.post("/api/v1/auth/login", map[string]string{"email": "sso@example.com", "password": ""}); code != http.StatusBadRequest {
+		t.Fatalf("expected password login to fail, got %d", code)
+	}
+	if code, _ := f.post("/api/v1/auth/login", map[string]string{"email": "sso@example.com", "password": "anything"}); code != http.StatusUnauthorized {
+		t.Fatalf("expected password login to fail, got %d", code)
+	}
+}
+
+func TestOIDCLinksExistingUserByVerifiedEmail(t *testing.T) {
+	f, idp := newSSOFixture(t)
+	f.register(t, "local@example.com", "correct horse")
+	f.post("/api/v1/auth/verify-email", map[string]string{"token": f.tokenFromMail(t, "local@example.com")})
+	local := f.users["local@example.com"]
+
+	idp.user = mockIdPUser{Subject: "sub-unverified", Email: "local@example.com"}
+	if res := ssoLogin(t, f.router, idp); res.Get("error") != service.CodeEmailNotVerified || res.Get("token") != "" {
+		t.Fatalf("unverified email must not be linked, got %v", res)
+	}
+
+	idp.user = mockIdPUser{Subject: "sub-local", Email: "local@example.com", EmailVerified: true}
+	if got := ssoUserID(t, ssoLogin(t, f.router, idp)); got != local.ID {
+		t.Fatalf("expected link to existing user %s, got %s", local.ID, got)
+	}
+	if code, _ := f.post("/api/v1/auth/login", map[string]string{"email": "local@example.com", "password": "correct horse"}); code != http.StatusOK {
+		t.Fatalf("password login must keep working after linking, got %d", code)
+	}
+}
+
+func TestOIDCTakesOverUnverifiedLocalUser(t *testing.T) {
+	f, idp := newSSOFixture(t, func(d *myhttp.Deps) { d.SessionRepo = &memSessionRepo{} })
+	// Адрес занял кто-то другой и не подтвердил его; владелец приходит через провайдера.
+	squatter := f.register(t, "victim@example.com", "correct horse")
+	local := f.users["victim@example.com"]
+
+	idp.user = mockIdPUser{Subject: "sub-victim", Email: "victim@example.com", EmailVerified: true}
+	if got := ssoUserID(t, ssoLogin(t, f.router, idp)); got != local.ID {
+		t.Fatalf("expected link to existing user %s, got %s", local.ID, got)
+	}
+	if local.PasswordHash != "" || !local.EmailVerified() {
+		t.Fatalf("linked user must lose the password and become verified: %+v", local)
+	}
+	if code, _ := f.post("/api/v1/auth/login", map[string]string{"email": "victim@example.com", "password": "correct horse"}); code != http.StatusUnauthorized {
+		t.Fatalf("password of the unverified registrant must stop working, got %d", code)
+	}
+	if code, _ := f.do(http.MethodGet, "/api/v1/me", nil, squatter); code != http.StatusUnauthorized {
+		t.Fatalf("sessions of the unverified registrant must be revoked, got %d", code)
+	}
+}
+
+func TestOIDCLoginRequiresSecondFactor(t *testing.T) {
+	f, idp := newSSOFixture(t, func(d *myhttp.Deps) { d.TwoFactorRepo = &memTwoFactorRepo{} })
+	result := ssoLogin(t, f.router, idp)
+	ssoUserID(t, result)
+	secret, _ := enableTwoFactor(t, f, result.Get("token"))
+
+	result = ssoLogin(t, f.router, idp)
+	ch := result.Get("challenge_token")
+	if result.Get("token") != "" || result.Get("two_factor_required") != "true" || ch == "" {
+		t.Fatalf("sso login must stop at the second factor, got %v", result)
+	}
+	code, body := f.post("/api/v1/auth/login/2fa", map[string]string{"challenge_token": ch, "code": totpCode(t, secret, 1)})
+	if code != http.StatusOK || body["token"] == nil {
+		t.Fatalf("second factor after sso: %d %v", code, body)
+	}
+}
+
+func TestOIDCCallbackRejectsForgedRequests(t *testing.T) {
+	f, idp := newSSOFixture(t)
+
+	rec := httptest.NewRecorder()
+	f.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/corp/login", nil))
+	cookie := rec.Result().Cookies()[0]
+	authURL, _ := url.Parse(rec.Header().Get("Location"))
+	state := authURL.Query().Get("state")
+	if authURL.Query().Get("code_challenge") == "" || authURL.Query().Get("nonce") == "" {
+		t.Fatalf("authorization url lacks PKCE or nonce: %s", authURL)
+	}
+
+	cases := []struct {
+		name   string
+		uri    string
+		cookie *http.Cookie
+		want   string
+	}{
+		{"no cookie", "/api/v1/auth/oidc/corp/callback?code=x&state=" + url.QueryEscape(state), nil, "invalid_state"},
+		{"wrong state", "/api/v1/auth/oidc/corp/callback?code=x&state=forged", cookie, "invalid_state"},
+		{"tampered cookie", "/api/v1/auth/oidc/corp/callback?code=x&state=" + url.QueryEscape(state),
+			&http.Cookie{Name: cookie.Name, Value: cookie.Value + "x"}, "invalid_state"},
+		{"provider error", "/api/v1/auth/oidc/corp/callback?error=access_denied&state=" + url.QueryEscape(state), cookie, "access_denied"},
+		{"unknown code", "/api/v1/auth/oidc/corp/callback?code=stolen&state=" + url.QueryEscape(state), cookie, "idp_error"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if res := ssoCallback(t, f.router, tc.uri, tc.cookie); res.Get("error") != tc.want || res.Get("token") != "" {
+				t.Fatalf("expected error %q, got %v", tc.want, res)
+			}
+		})
+	}
+
+	idp.wrongNonce = true
+	if res := ssoLogin(t, f.router, idp); res.Get("error") != "invalid_id_token" {
+		t.Fatalf("expected nonce mismatch to be rejected, got %v", res)
+	}
+
+	if rec := doJSONRequest(f.router, http.MethodGet, "/api/v1/auth/oidc/unknown/login", nil, nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("unknown provider: expected 404, got %d", rec.Code)
+	}
+	rec = doJSONRequest(f.router, http.MethodGet, "/api/v1/auth/oidc", nil, nil)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"login_url":"/api/v1/auth/oidc/corp/login"`) {
+		t.Fatalf("providers list: %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestOIDCProviderVerifiesIDToken(t *testing.T) {
+	idp := newMockIdP(t)
+	p := idp.provider("corp", ssoRedirectURL)
+	ctx := context.Background()
+
+	claims, err := p.VerifyIDToken(ctx, idp.idToken(t, jwt.MapClaims{"sub": "u1", "nonce": "n", "email_verified": "true"}), "n")
+	if err != nil || claims.Subject != "u1" || !claims.EmailVerified {
+		t.Fatalf("valid token: %+v %v", claims, err)
+	}
+
+	// Незнакомый kid после ротации ключей перечитывает JWKS.
+	idp.rotateKey(t)
+	before := idp.jwksFetches
+	if _, err := p.VerifyIDToken(ctx, idp.idToken(t, jwt.MapClaims{"sub": "u1", "nonce": "n"}), "n"); err == nil {
+		t.Fatal("JWKS must not be refetched more than once a minute")
+	}
+	if idp.jwksFetches != before {
+		t.Fatalf("unexpected JWKS refetch within the refresh interval")
+	}
+	fresh := idp.provider("corp", ssoRedirectURL)
+	if _, err := fresh.VerifyIDToken(ctx, idp.idToken(t, jwt.MapClaims{"sub": "u1", "nonce": "n"}), "n"); err != nil {
+		t.Fatalf("rotated key: %v", err)
+	}
+
+	invalid := map[string]jwt.MapClaims{
+		"expired":      {"sub": "u1", "nonce": "n", "exp": time.Now().Add(-time.Hour).Unix()},
+		"audience":     {"sub": "u1", "nonce": "n", "aud": "someone-else"},
+		"issuer":       {"sub": "u1", "nonce": "n", "iss": "https://evil.example.com"},
+		"nonce":        {"sub": "u1", "nonce": "other"},
+		"azp":          {"sub": "u1", "nonce": "n", "aud": []string{mockClientID, "other"}, "azp": "other"},
+		"empty sub":    {"nonce": "n"},
+		"future token": {"sub": "u1", "nonce": "n", "iat": time.Now().Add(time.Hour).Unix()},
+	}
+	for name, c := range invalid {
+		if _, err := fresh.VerifyIDToken(ctx, idp.idToken(t, c), "n"); !errors.Is(err, oidc.ErrInvalidIDToken) {
+			t.Errorf("%s: expected ErrInvalidIDToken, got %v", name, err)
+		}
+	}
+
+	// Симметричная подпись client secret'ом не принимается.
+	hs, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": idp.URL, "aud": mockClientID, "sub": "u1", "nonce": "n", "exp": time.Now().Add(time.Minute).Unix(),
+	}).SignedString([]byte(mockClientSecret))
+	if _, err := fresh.VerifyIDToken(ctx, hs, "n"); !errors.Is(err, oidc.ErrInvalidIDToken) {
+		t.Fatalf("HS256 token: expected ErrInvalidIDToken, got %v", err)
+	}
+
+	bad := oidc.NewProvider(oidc.ProviderConfig{Name: "bad", Issuer: idp.URL + "/other", ClientID: mockClientID}, idp.Client())
+	if _, err := bad.AuthCodeURL(ctx, "s", "n", "c"); !errors.Is(err, oidc.ErrProvider) {
+		t.Fatalf("discovery with mismatched issuer: expected ErrProvider, got %v", err)
+	}
+}
+
+func TestIntegration_IdentityRepository(t *testing.T) {
+	dsn, stop := startPostgres(t)
+	defer stop()
+
+	db, err := pg.New(dsn)
+	if err != nil {
+		t.Fatalf("connect db: %v", err)
+	}
+	defer db.Close()
+	applyMigrations(t, db.DB)
+
+	ctx := context.Background()
+	u := &user.User{Email: "sso@example.com"}
+	if err := pg.NewUserRepository(db).Create(ctx, u); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	repo := pg.NewIdentityRepository(db)
+	if _, err := repo.Find(ctx, "corp", "sub-1"); !errors.Is(err, user.ErrIdentityNotFound) {
+		t.Fatalf("expected ErrIdentityNotFound, got %v", err)
+	}
+	if err := repo.Link(ctx, &user.Identity{Provider: "corp", Subject: "sub-1", UserID: u.ID, Email: u.Email}); err != nil {
+		t.Fatalf("link: %v", err)
+	}
+	// Повторная привязка не перехватывает существующую.
+	other := &user.Identity{Provider: "corp", Subject: "sub-1", UserID: "00000000-0000-0000-0000-000000000000"}
+	if err := repo.Link(ctx, other); err != nil || other.UserID != u.ID {
+		t.Fatalf("relink: %+v %v", other, err)
+	}
+	id, err := repo.Find(ctx, "corp", "sub-1")
+	if err != nil || id.UserID != u.ID || id.Email != u.Email {
+		t.Fatalf("find: %+v %v", id, err)
+	}
+}
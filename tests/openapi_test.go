@@ -0,0 +1,408 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/accesstoken"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/activity"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/column"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/invitation"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/sharelink"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/trash"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/workspace"
+	"github.com/VladislavDraga398/kanban-backend/internal/health"
+	myhttp "github.com/VladislavDraga398/kanban-backend/internal/http"
+	"github.com/VladislavDraga398/kanban-backend/internal/http/openapi"
+	"github.com/VladislavDraga398/kanban-backend/internal/metrics"
+	"github.com/VladislavDraga398/kanban-backend/internal/oidc"
+)
+
+type openAPIDoc struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]map[string]any `json:"schemas"`
+	} `json:"components"`
+}
+
+type openAPIOperation struct {
+	RequestBody *struct {
+		Content map[string]struct {
+			Schema map[string]any `json:"schema"`
+		} `json:"content"`
+	} `json:"requestBody"`
+	Responses map[string]struct {
+		Content map[string]struct {
+			Schema map[string]any `json:"schema"`
+		} `json:"content"`
+	} `json:"responses"`
+}
+
+var httpMethods = map[string]bool{
+	"get": true, "post": true, "put": true, "patch": true, "delete": true,
+}
+
+func loadSpec(t *testing.T) openAPIDoc {
+	t.Helper()
+	var doc openAPIDoc
+	if err := json.Unmarshal(openapi.Spec(), &doc); err != nil {
+		t.Fatalf("parse openapi spec: %v", err)
+	}
+	return doc
+}
+
+// contractRouter собирает роутер на заглушках, которые возвращают полностью заполненные сущности.
+func contractRouter(t *testing.T) http.Handler {
+	t.Helper()
+	ts := time.Unix(1, 0).UTC()
+	hash, err := auth.HashPassword("value")
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+
+	fillBoard := func(b *board.Board) {
+		b.ID, b.OwnerID, b.CreatedAt, b.UpdatedAt = "b1", "owner-1", ts, ts
+	}
+	fillColumn := func(c *column.Column) {
+		c.ID, c.BoardID, c.Position, c.CreatedAt, c.UpdatedAt = "c1", "b1", 1, ts, ts
+	}
+	fillTask := func(tk *task.Task) {
+		tk.ID, tk.BoardID, tk.ColumnID, tk.Position, tk.CreatedAt, tk.UpdatedAt = "t1", "b1", "c1", 1, ts, ts
+	}
+
+	return myhttp.NewRouter(myhttp.Deps{
+		UserRepo: &stubUserRepo{
+			createFn: func(ctx context.Context, u *user.User) error {
+				u.ID, u.CreatedAt = "owner-1", ts
+				return nil
+			},
+			getByEmailF: func(ctx context.Context, email string) (*user.User, error) {
+				return &user.User{ID: "owner-1", Email: email, PasswordHash: hash, CreatedAt: ts}, nil
+			},
+			getByIDFn: func(ctx context.Context, id string) (*user.User, error) {
+				return &user.User{ID: id, Email: "owner@example.com", PasswordHash: hash, CreatedAt: ts}, nil
+			},
+		},
+		TokenRepo: &stubTokenRepo{
+			findFn: func(ctx context.Context, purpose user.TokenPurpose, hash string) (*user.Token, error) {
+				return &user.Token{ID: "tok-1", UserID: "owner-1", Purpose: purpose, Hash: hash}, nil
+			},
+			consumeFn: func(ctx context.Context, purpose user.TokenPurpose, hash string) (*user.Token, error) {
+				return &user.Token{ID: "tok-1", UserID: "owner-1", Purpose: purpose, Hash: hash}, nil
+			},
+		},
+		BoardRepo: &stubBoardRepo{
+			createFn: func(ctx context.Context, b *board.Board) error { fillBoard(b); return nil },
+			updateFn: func(ctx context.Context, b *board.Board, userID string) error { fillBoard(b); return nil },
+			getFn: func(ctx context.Context, id, ownerID string) (*board.Board, error) {
+				b := &board.Board{Name: "Board"}
+				fillBoard(b)
+				return b, nil
+			},
+			listFn: func(ctx context.Context, ownerID string) ([]*board.Board, error) {
+				b := &board.Board{Name: "Board"}
+				fillBoard(b)
+				return []*board.Board{b}, nil
+			},
+			listSharedFn: func(ctx context.Context, userID string) ([]*board.Board, error) {
+				b := &board.Board{Name: "Board"}
+				fillBoard(b)
+				return []*board.Board{b}, nil
+			},
+			canManageFn: func(ctx context.Context, id, userID string) (bool, error) { return true, nil },
+			listMembersFn: func(ctx context.Context, boardID string) ([]*board.Member, error) {
+				return []*board.Member{{BoardID: boardID, UserID: "user_id-1", Email: "member@example.com", Role: board.RoleMember, JoinedAt: ts}}, nil
+			},
+		},
+		ColumnRepo: &stubColumnRepo{
+			createInFn: func(ctx context.Context, c *column.Column, boardID, ownerID string) error { fillColumn(c); return nil },
+			updateFn:   func(ctx context.Context, c *column.Column, ownerID string) error { fillColumn(c); return nil },
+			listByOwnerFn: func(ctx context.Context, boardID, ownerID string) ([]*column.Column, error) {
+				c := &column.Column{Name: "Todo"}
+				fillColumn(c)
+				return []*column.Column{c}, nil
+			},
+		},
+		TaskRepo: &stubTaskRepo{
+			createInColumnFn: func(ctx context.Context, tk *task.Task, boardID, columnID, ownerID string) error {
+				fillTask(tk)
+				return nil
+			},
+			updateFn: func(ctx context.Context, tk *task.Task, ownerID string) error { fillTask(tk); return nil },
+			moveFn:   func(ctx context.Context, tk *task.Task, columnID, ownerID string) error { fillTask(tk); return nil },
+			listByColumnOwnerFn: func(ctx context.Context, boardID, columnID, ownerID string) ([]*task.Task, error) {
+				tk := &task.Task{Title: "Task"}
+				fillTask(tk)
+				return []*task.Task{tk}, nil
+			},
+		},
+		AccessTokenRepo: &memAccessTokenRepo{tokens: []*accesstoken.Token{{
+			ID: "pat-1", UserID: "owner-1", Name: "ci", Scopes: []auth.Scope{auth.ScopeRead},
+			Hint: "kbn_pat_abcd", ExpiresAt: &ts, LastUsedAt: &ts, CreatedAt: ts,
+		}}},
+		OIDCProviders: []*oidc.Provider{newMockIdP(t).provider("provider-1", "https://api.example.com/api/v1/auth/oidc/provider-1/callback")},
+		IdentityRepo:  &memIdentityRepo{},
+		TwoFactorRepo: &memTwoFactorRepo{},
+		SessionRepo:   &memSessionRepo{},
+		WorkspaceRepo: &memWorkspaceRepo{
+			workspaces: []*workspace.Workspace{{ID: "workspace_id-1", Name: "Team", CreatedAt: ts, UpdatedAt: ts}},
+			members: []*workspace.Member{
+				{WorkspaceID: "workspace_id-1", UserID: "owner-1", Email: "owner@example.com", Role: workspace.RoleOwner, JoinedAt: ts},
+				{WorkspaceID: "workspace_id-1", UserID: "user_id-1", Email: "member@example.com", Role: workspace.RoleMember, JoinedAt: ts},
+			},
+		},
+		InvitationRepo: &memInvitationRepo{invitations: []*invitation.Invitation{{
+			ID: "invitation_id-1", BoardID: "id-1", Email: "member@example.com", Role: board.RoleMember, InvitedBy: "owner-1",
+			Hash: auth.HashOpaqueToken("value"), Status: invitation.StatusPending, ExpiresAt: ts.Add(100 * 365 * 24 * time.Hour), CreatedAt: ts,
+		}}},
+		ShareLinkRepo: &memShareLinkRepo{links: []*sharelink.Link{{
+			ID: "link_id-1", BoardID: "id-1", CreatedBy: "owner-1", Hash: auth.HashOpaqueToken("token-1"), Hint: "abcd", CreatedAt: ts,
+		}}},
+		ActivityRepo: &memActivityRepo{entries: []*activity.Entry{
+			{ID: 1, BoardID: "id-1", ActorID: "owner-1", Action: activity.BoardUpdated, EntityType: activity.EntityBoard, EntityID: "id-1",
+				Before: activity.Fields{"name": "Old"}, After: activity.Fields{"name": "Board"}, CreatedAt: ts},
+			{ID: 2, BoardID: "board_id-1", ActorID: "owner-1", Action: activity.TaskCreated, EntityType: activity.EntityTask, EntityID: "task_id-1",
+				After: activity.Fields{"title": "Task"}, CreatedAt: ts},
+		}},
+		TaskVersionRepo: &memTaskVersionRepo{
+			tasks: []*task.Task{{ID: "task_id-1", BoardID: "board_id-1", ColumnID: "column_id-1", Title: "Task", CreatedAt: ts, UpdatedAt: ts}},
+			versions: []*task.Version{
+				{TaskID: "task_id-1", Version: 1, ColumnID: "column_id-1", Title: "Task", ActorID: "owner-1", CreatedAt: ts},
+			},
+		},
+		TrashRepo: &memTrashRepo{items: []*memTrashItem{
+			{Item: trash.Item{Kind: trash.KindBoard, ID: "id-1", BoardID: "id-1", Name: "Board", DeletedAt: ts, DeletedBy: "owner-1"}, userID: "owner-1"},
+			{Item: trash.Item{Kind: trash.KindColumn, ID: "id-1", BoardID: "b1", Name: "Todo", DeletedAt: ts}, userID: "owner-1"},
+			{Item: trash.Item{Kind: trash.KindTask, ID: "id-1", BoardID: "b1", ColumnID: "c1", Name: "Task", DeletedAt: ts}, userID: "owner-1"},
+		}},
+		ArchiveRepo: &memArchiveRepo{
+			boards: &memBoardRepo{boards: []*board.Board{
+				{ID: "id-1", OwnerID: "owner-1", Name: "Board", CreatedAt: ts, UpdatedAt: ts},
+				{ID: "board_id-1", OwnerID: "owner-1", Name: "Board", CreatedAt: ts, UpdatedAt: ts},
+			}},
+			tasks: []*task.Task{{ID: "task_id-1", BoardID: "board_id-1", ColumnID: "column_id-1", Title: "Task", Position: 1, CreatedAt: ts, UpdatedAt: ts}},
+		},
+		JWTSecret: testSecret,
+		JWTTTL:    time.Hour,
+	})
+}
+
+// adminOnlyPaths обслуживает только admin-листенер.
+var adminOnlyPaths = map[string]bool{"/health": true}
+
+func contractAdminRouter() http.Handler {
+	return myhttp.NewAdminRouter(metrics.New(), health.NewChecker(0))
+}
+
+func TestOpenAPICoversAllRoutes(t *testing.T) {
+	doc := loadSpec(t)
+
+	documented := map[string]bool{}
+	for path, item := range doc.Paths {
+		for method := range item {
+			if httpMethods[method] {
+				documented[strings.ToUpper(method)+" "+path] = true
+			}
+		}
+	}
+
+	registered := map[string]bool{}
+	for _, h := range []http.Handler{contractRouter(t), contractAdminRouter()} {
+		routes, ok := h.(chi.Routes)
+		if !ok {
+			t.Fatalf("router does not expose chi.Routes")
+		}
+		err := chi.Walk(routes, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+			if len(route) > 1 {
+				route = strings.TrimSuffix(route, "/")
+			}
+			registered[method+" "+route] = true
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("walk routes: %v", err)
+		}
+	}
+
+	var drift []string
+	for r := range registered {
+		if !documented[r] {
+			drift = append(drift, "undocumented route: "+r)
+		}
+	}
+	for r := range documented {
+		if !registered[r] {
+			drift = append(drift, "documented but not routed: "+r)
+		}
+	}
+	sort.Strings(drift)
+	if len(drift) > 0 {
+		t.Fatalf("openapi spec drifted from router:\n%s", strings.Join(drift, "\n"))
+	}
+}
+
+var pathParam = regexp.MustCompile(`\{[^}]+\}`)
+
+func TestOpenAPIResponsesMatchSchemas(t *testing.T) {
+	doc := loadSpec(t)
+	router, admin := contractRouter(t), contractAdminRouter()
+	token := mustToken(t, "owner-1")
+
+	for path, item := range doc.Paths {
+		for method, raw := range item {
+			if !httpMethods[method] {
+				continue
+			}
+			var op openAPIOperation
+			if err := json.Unmarshal(raw, &op); err != nil {
+				t.Fatalf("%s %s: parse operation: %v", method, path, err)
+			}
+
+			t.Run(strings.ToUpper(method)+" "+path, func(t *testing.T) {
+				var body any
+				if op.RequestBody != nil {
+					body = sampleFor(t, doc, op.RequestBody.Content["application/json"].Schema)
+				}
+				concrete := pathParam.ReplaceAllStringFunc(path, func(p string) string {
+					return strings.Trim(p, "{}") + "-1"
+				})
+				h := router
+				if adminOnlyPaths[path] {
+					h = admin
+				}
+				rec := doJSONRequest(h, strings.ToUpper(method), concrete, body, bearer(token))
+
+				resp, ok := op.Responses[fmt.Sprint(rec.Code)]
+				if !ok {
+					t.Fatalf("status %d is not documented (body %s)", rec.Code, rec.Body.String())
+				}
+				media, ok := resp.Content["application/json"]
+				if !ok {
+					return
+				}
+				var got any
+				if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+					t.Fatalf("decode body: %v", err)
+				}
+				if err := matchSchema(doc, media.Schema, got, "$"); err != nil {
+					t.Fatal(err)
+				}
+			})
+		}
+	}
+}
+
+func TestOpenAPIProblemMatchesSchema(t *testing.T) {
+	doc := loadSpec(t)
+	rec := doJSONRequest(contractRouter(t), http.MethodPost, "/api/v1/boards", map[string]string{"name": ""}, bearer(mustToken(t, "owner-1")))
+
+	var got any
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if err := matchSchema(doc, map[string]any{"$ref": "#/components/schemas/Problem"}, got, "$"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func resolveRef(doc openAPIDoc, schema map[string]any) map[string]any {
+	if ref, ok := schema["$ref"].(string); ok {
+		return doc.Components.Schemas[strings.TrimPrefix(ref, "#/components/schemas/")]
+	}
+	return schema
+}
+
+// matchSchema проверяет подмножество JSON Schema, которого достаточно для контракта:
+// типы, обязательные поля и отсутствие недокументированных полей.
+func matchSchema(doc openAPIDoc, schema map[string]any, v any, at string) error {
+	schema = resolveRef(doc, schema)
+	switch schema["type"] {
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: expected object, got %T", at, v)
+		}
+		props, _ := schema["properties"].(map[string]any)
+		for key, val := range obj {
+			ps, ok := props[key].(map[string]any)
+			if !ok {
+				if schema["additionalProperties"] == false {
+					return fmt.Errorf("%s: field %q is not in the spec", at, key)
+				}
+				continue
+			}
+			if err := matchSchema(doc, ps, val, at+"."+key); err != nil {
+				return err
+			}
+		}
+		required, _ := schema["required"].([]any)
+		for _, key := range required {
+			if _, ok := obj[key.(string)]; !ok {
+				return fmt.Errorf("%s: required field %q is missing", at, key)
+			}
+		}
+	case "array":
+		arr, ok := v.([]any)
+		if !ok {
+			return fmt.Errorf("%s: expected array, got %T", at, v)
+		}
+		items, _ := schema["items"].(map[string]any)
+		for i, item := range arr {
+			if err := matchSchema(doc, items, item, fmt.Sprintf("%s[%d]", at, i)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		if _, ok := v.(string); !ok {
+			return fmt.Errorf("%s: expected string, got %T", at, v)
+		}
+	case "integer", "number":
+		if _, ok := v.(float64); !ok {
+			return fmt.Errorf("%s: expected number, got %T", at, v)
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("%s: expected boolean, got %T", at, v)
+		}
+	}
+	return nil
+}
+
+// sampleFor строит валидное тело запроса по схеме.
+func sampleFor(t *testing.T, doc openAPIDoc, schema map[string]any) any {
+	t.Helper()
+	schema = resolveRef(doc, schema)
+	switch schema["type"] {
+	case "object":
+		out := map[string]any{}
+		props, _ := schema["properties"].(map[string]any)
+		for key, ps := range props {
+			out[key] = sampleFor(t, doc, ps.(map[string]any))
+		}
+		return out
+	case "array":
+		return []any{}
+	case "integer", "number":
+		return 1
+	case "boolean":
+		return true
+	default:
+		if enum, ok := schema["enum"].([]any); ok && len(enum) > 0 {
+			return enum[0]
+		}
+		return "value"
+	}
+}
@@ -0,0 +1,220 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	myhttp "github.com/VladislavDraga398/kanban-backend/internal/http"
+	"github.com/VladislavDraga398/kanban-backend/internal/http/httputil"
+	"github.com/VladislavDraga398/kanban-backend/internal/ratelimit"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
+	pg "github.com/VladislavDraga398/kanban-backend/internal/storage/postgres"
+)
+
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time          { return c.now }
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func TestParseLimit(t *testing.T) {
+	l, err := ratelimit.ParseLimit("20/1m")
+	if err != nil || l.Burst != 20 || l.Per != time.Minute {
+		t.Fatalf("unexpected limit %+v (%v)", l, err)
+	}
+	if l, err := ratelimit.ParseLimit("off"); err != nil || l.Enabled() {
+		t.Fatalf("off must disable the limit: %+v (%v)", l, err)
+	}
+	for _, bad := range []string{"20", "x/1m", "5/-1s", "0/1m"} {
+		if _, err := ratelimit.ParseLimit(bad); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestLimiterTokenBucket(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	l := ratelimit.NewLimiter(ratelimit.NewMemoryStore(time.Hour), ratelimit.Limit{Burst: 2, Per: time.Minute}).WithClock(clock.Now)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if res, _ := l.Allow(ctx, "k"); !res.Allowed {
+			t.Fatalf("request %d must be allowed", i+1)
+		}
+	}
+	res, _ := l.Allow(ctx, "k")
+	if res.Allowed || res.RetryAfter != 30*time.Second {
+		t.Fatalf("expected denial with 30s retry, got %+v", res)
+	}
+	if res, _ := l.Allow(ctx, "other"); !res.Allowed {
+		t.Fatalf("keys must have independent buckets")
+	}
+
+	clock.Advance(30 * time.Second)
+	if res, _ := l.Allow(ctx, "k"); !res.Allowed {
+		t.Fatalf("token must be refilled after 30s")
+	}
+}
+
+func TestLockoutIsProgressive(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	l := ratelimit.NewLockout(ratelimit.NewMemoryStore(time.Hour), ratelimit.LockoutPolicy{
+		Threshold: 3, Base: time.Minute, Max: 4 * time.Minute,
+	}).WithClock(clock.Now)
+	ctx := context.Background()
+
+	var got []time.Duration
+	for i := 0; i < 6; i++ {
+		d, err := l.Failed(ctx, "lockout:a@b.c")
+		if err != nil {
+			t.Fatalf("failed: %v", err)
+		}
+		got = append(got, d)
+	}
+	want := []time.Duration{0, 0, time.Minute, 2 * time.Minute, 4 * time.Minute, 4 * time.Minute}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("attempt %d: expected lock %s, got %s", i+1, want[i], got[i])
+		}
+	}
+	if left, _ := l.Check(ctx, "lockout:a@b.c"); left != 4*time.Minute {
+		t.Fatalf("expected 4m left, got %s", left)
+	}
+
+	if err := l.Succeeded(ctx, "lockout:a@b.c"); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	if left, _ := l.Check(ctx, "lockout:a@b.c"); left != 0 {
+		t.Fatalf("lock must be cleared, got %s", left)
+	}
+}
+
+func rateLimitedRouter(t *testing.T, deps myhttp.Deps) http.Handler {
+	t.Helper()
+	hash, err := auth.HashPassword("pass123")
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+	deps.UserRepo = &stubUserRepo{
+		getByEmailF: func(ctx context.Context, email string) (*user.User, error) {
+			return &user.User{ID: "owner-1", Email: email, PasswordHash: hash}, nil
+		},
+	}
+	deps.BoardRepo, deps.ColumnRepo, deps.TaskRepo = &stubBoardRepo{}, &stubColumnRepo{}, &stubTaskRepo{}
+	deps.JWTSecret, deps.JWTTTL = testSecret, time.Hour
+	return myhttp.NewRouter(deps)
+}
+
+func TestAuthRateLimitPerIPAndEmail(t *testing.T) {
+	store := ratelimit.NewMemoryStore(time.Hour)
+	router := rateLimitedRouter(t, myhttp.Deps{
+		AuthIPLimiter:    ratelimit.NewLimiter(store, ratelimit.Limit{Burst: 3, Per: time.Minute}),
+		AuthEmailLimiter: ratelimit.NewLimiter(store, ratelimit.Limit{Burst: 1, Per: time.Minute}),
+	})
+	login := func(email string) (int, http.Header, string) {
+		rec := doJSONRequest(router, http.MethodPost, "/api/v1/auth/login", map[string]string{"email": email, "password": "pass123"}, nil)
+		return rec.Code, rec.Header(), rec.Body.String()
+	}
+
+	// Тело запроса после middleware остаётся доступным обработчику.
+	if code, _, body := login("a@b.c"); code != http.StatusOK {
+		t.Fatalf("first login must pass, got %d: %s", code, body)
+	}
+	code, header, body := login("A@B.C ")
+	if code != http.StatusTooManyRequests || header.Get("Retry-After") != "60" {
+		t.Fatalf("expected per-email 429 with Retry-After 60, got %d %q", code, header.Get("Retry-After"))
+	}
+	if !strings.Contains(body, httputil.CodeRateLimited) {
+		t.Fatalf("expected rate_limited code, got %s", body)
+	}
+
+	if code, _, _ := login("other@b.c"); code != http.StatusOK {
+		t.Fatalf("other email must pass, got %d", code)
+	}
+	// Бюджет IP (3) исчерпан тремя запросами выше.
+	if code, _, _ := login("third@b.c"); code != http.StatusTooManyRequests {
+		t.Fatalf("expected per-IP 429, got %d", code)
+	}
+}
+
+func TestLoginLockoutAfterFailedAttempts(t *testing.T) {
+	router := rateLimitedRouter(t, myhttp.Deps{
+		LoginLockout: ratelimit.NewLockout(ratelimit.NewMemoryStore(time.Hour), ratelimit.LockoutPolicy{
+			Threshold: 2, Base: time.Minute, Max: time.Hour,
+		}),
+	})
+	login := func(password string) *http.Response {
+		rec := doJSONRequest(router, http.MethodPost, "/api/v1/auth/login", map[string]string{"email": "a@b.c", "password": password}, nil)
+		return rec.Result()
+	}
+
+	for i := 0; i < 2; i++ {
+		if resp := login("wrong"); resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("attempt %d: expected 401, got %d", i+1, resp.StatusCode)
+		}
+	}
+
+	resp := login("pass123")
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") == "" {
+		t.Fatalf("expected locked account, got %d", resp.StatusCode)
+	}
+	var problem httputil.ErrorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&problem); err != nil {
+		t.Fatalf("decode problem: %v", err)
+	}
+	if problem.Code != service.CodeAccountLocked {
+		t.Fatalf("expected account_locked, got %+v", problem)
+	}
+}
+
+// Integration: Postgres-хранилище сериализует конкурентные обращения разных реплик.
+func TestIntegration_PostgresRateLimitStore(t *testing.T) {
+	dsn, stop := startPostgres(t)
+	defer stop()
+
+	db, err := pg.New(dsn)
+	if err != nil {
+		t.Fatalf("connect db: %v", err)
+	}
+	defer db.Close()
+	applyMigrations(t, db.DB)
+
+	store := pg.NewRateLimitStore(db)
+	limiter := ratelimit.NewLimiter(store, ratelimit.Limit{Burst: 3, Per: time.Hour})
+
+	var allowed atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := limiter.Allow(context.Background(), "auth:ip:203.0.113.7")
+			if err != nil {
+				t.Errorf("allow: %v", err)
+				return
+			}
+			if res.Allowed {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if allowed.Load() != 3 {
+		t.Fatalf("expected exactly 3 allowed requests, got %d", allowed.Load())
+	}
+
+	lockout := ratelimit.NewLockout(store, ratelimit.LockoutPolicy{Threshold: 1, Base: time.Minute, Max: time.Hour})
+	if _, err := lockout.Failed(context.Background(), "lockout:pg@example.com"); err != nil {
+		t.Fatalf("record failure: %v", err)
+	}
+	if left, err := lockout.Check(context.Background(), "lockout:pg@example.com"); err != nil || left <= 0 {
+		t.Fatalf("expected active lock, got %s (%v)", left, err)
+	}
+}
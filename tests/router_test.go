@@ -1,15 +1,21 @@
 package tests
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
 	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
 	myhttp "github.com/VladislavDraga398/kanban-backend/internal/http"
+	"github.com/VladislavDraga398/kanban-backend/internal/logging"
 )
 
 func TestProtectedRouteRequiresJWT(t *testing.T) {
@@ -44,3 +50,55 @@ func TestProtectedRouteRequiresJWT(t *testing.T) {
 		t.Fatalf("expected 200 with token, got %d", rr.Code)
 	}
 }
+
+func TestAccessLogRecordsRequest(t *testing.T) {
+	var buf bytes.Buffer
+	router := myhttp.NewRouter(myhttp.Deps{
+		UserRepo: &stubUserRepo{},
+		BoardRepo: &stubBoardRepo{
+			getFn: func(ctx context.Context, id, ownerID string) (*board.Board, error) {
+				return nil, errors.New("connection reset")
+			},
+		},
+		ColumnRepo: &stubColumnRepo{},
+		TaskRepo:   &stubTaskRepo{},
+		JWTSecret:  testSecret,
+		JWTTTL:     time.Hour,
+		Logger:     logging.New(&buf, logging.FormatJSON, slog.LevelInfo),
+	})
+
+	rr := doJSONRequest(router, http.MethodGet, "/api/v1/boards/b1", nil, bearer(mustToken(t, "owner-1")))
+	if rr.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", rr.Code)
+	}
+
+	var access, failure map[string]any
+	for _, line := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+		var entry map[string]any
+		if err := json.Unmarshal(line, &entry); err != nil {
+			t.Fatalf("log line is not json: %q", line)
+		}
+		switch entry["msg"] {
+		case "http request":
+			access = entry
+		case "request failed":
+			failure = entry
+		}
+	}
+
+	if access == nil {
+		t.Fatalf("access log line missing in %s", buf.String())
+	}
+	if access["route"] != "/api/v1/boards/{id}" || access["method"] != http.MethodGet ||
+		access["status"] != float64(http.StatusInternalServerError) || access["user_id"] != "owner-1" {
+		t.Fatalf("unexpected access log: %v", access)
+	}
+	if access["request_id"] == "" || access["bytes"].(float64) <= 0 || access["level"] != "ERROR" {
+		t.Fatalf("access log misses request id, size or level: %v", access)
+	}
+
+	// Ошибка из обработчика пишется логгером запроса с теми же атрибутами.
+	if failure == nil || failure["request_id"] != access["request_id"] || failure["user_id"] != "owner-1" {
+		t.Fatalf("handler error must be logged with request context: %v", failure)
+	}
+}
@@ -0,0 +1,197 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/column"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
+)
+
+func TestBoardServiceCreateTrimsAndValidates(t *testing.T) {
+	var created *board.Board
+	svc := service.NewBoardService(&stubBoardRepo{
+		createFn: func(ctx context.Context, b *board.Board) error {
+			created = b
+			b.ID = "board-1"
+			return nil
+		},
+	})
+
+	if _, err := svc.Create(context.Background(), "owner-1", "   "); service.KindOf(err) != service.KindValidation {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+
+	b, err := svc.Create(context.Background(), "owner-1", "  Roadmap  ")
+	if err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+	if b.ID != "board-1" || created.Name != "Roadmap" || created.OwnerID != "owner-1" {
+		t.Fatalf("unexpected board: %+v", created)
+	}
+}
+
+func TestBoardServiceMapsNotFound(t *testing.T) {
+	svc := service.NewBoardService(&stubBoardRepo{})
+
+	_, err := svc.Get(context.Background(), "owner-1", "missing")
+	if service.KindOf(err) != service.KindNotFound {
+		t.Fatalf("expected not found kind, got %v", err)
+	}
+	if !errors.Is(err, board.ErrNotFound) {
+		t.Fatalf("expected wrapped board.ErrNotFound")
+	}
+	if service.MessageOf(err) != "board not found" {
+		t.Fatalf("unexpected message: %q", service.MessageOf(err))
+	}
+}
+
+func TestServicesCheckBoardAccess(t *testing.T) {
+	ctx := context.Background()
+	boards := &stubBoardRepo{
+		getFn: func(ctx context.Context, id, userID string) (*board.Board, error) {
+			if id == "shared" {
+				return &board.Board{ID: id, OwnerID: "owner-1"}, nil
+			}
+			return nil, board.ErrNotFound
+		},
+		canManageFn: func(ctx context.Context, id, userID string) (bool, error) {
+			if id == "shared" {
+				return false, nil
+			}
+			return false, board.ErrNotFound
+		},
+		updateFn: func(ctx context.Context, b *board.Board, userID string) error {
+			t.Fatalf("update must not reach the store")
+			return nil
+		},
+	}
+	columns := &stubColumnRepo{
+		createInFn: func(ctx context.Context, c *column.Column, boardID, ownerID string) error {
+			if boardID != "shared" {
+				t.Fatalf("create column must not reach the store for %s", boardID)
+			}
+			return nil
+		},
+	}
+	tasks := &stubTaskRepo{
+		moveFn: func(ctx context.Context, tk *task.Task, columnID, ownerID string) error {
+			t.Fatalf("move must not reach the store")
+			return nil
+		},
+	}
+
+	// Участник видит доску и работает с колонками, но управлять ею не может.
+	if _, err := service.NewColumnService(columns, boards).Create(ctx, "member-1", "shared", "Todo", false); err != nil {
+		t.Fatalf("create column on shared board: %v", err)
+	}
+	if _, err := service.NewBoardService(boards).Rename(ctx, "member-1", "shared", "New"); service.CodeOf(err) != service.CodeBoardForbidden {
+		t.Fatalf("expected %s, got %v", service.CodeBoardForbidden, err)
+	}
+
+	// Чужая доска неотличима от несуществующей.
+	if _, err := service.NewColumnService(columns, boards).Create(ctx, "member-1", "foreign", "Todo", false); service.CodeOf(err) != service.CodeBoardNotFound {
+		t.Fatalf("expected %s, got %v", service.CodeBoardNotFound, err)
+	}
+	if _, err := service.NewTaskService(tasks, boards).Move(ctx, "member-1", "foreign", "t1", "c2"); service.CodeOf(err) != service.CodeBoardNotFound {
+		t.Fatalf("expected %s, got %v", service.CodeBoardNotFound, err)
+	}
+	if _, err := service.NewBoardService(boards).Rename(ctx, "member-1", "foreign", "New"); service.CodeOf(err) != service.CodeBoardNotFound {
+		t.Fatalf("expected %s, got %v", service.CodeBoardNotFound, err)
+	}
+}
+
+func TestTaskServiceHidesInternalErrors(t *testing.T) {
+	svc := service.NewTaskService(&stubTaskRepo{
+		moveFn: func(ctx context.Context, t *task.Task, columnID, ownerID string) error {
+			return errors.New("connection reset")
+		},
+	}, visibleBoards())
+
+	_, err := svc.Move(context.Background(), "owner-1", "b1", "t1", "c2")
+	if service.KindOf(err) != service.KindInternal {
+		t.Fatalf("expected internal kind, got %v", err)
+	}
+	if service.MessageOf(err) != "internal server error" {
+		t.Fatalf("internal details must not leak: %q", service.MessageOf(err))
+	}
+}
+
+func TestAuthServiceRegisterConflict(t *testing.T) {
+	svc := service.NewAuthService(&stubUserRepo{
+		createFn: func(ctx context.Context, u *user.User) error {
+			return user.ErrEmailAlreadyUsed
+		},
+	}, testSecret, time.Hour)
+
+	_, err := svc.Register(context.Background(), "a@b.c", "pass123")
+	if service.KindOf(err) != service.KindConflict {
+		t.Fatalf("expected conflict kind, got %v", err)
+	}
+}
+
+func TestAuthServiceRegisterPasswordPolicy(t *testing.T) {
+	var created *user.User
+	svc := service.NewAuthService(&stubUserRepo{
+		createFn: func(ctx context.Context, u *user.User) error {
+			created = u
+			u.ID = "user-1"
+			return nil
+		},
+	}, testSecret, time.Hour).WithPasswordPolicy(&auth.PasswordPolicy{MinLength: 8, Breached: auth.DefaultBreachedList()})
+
+	_, err := svc.Register(context.Background(), "a@b.c", "qwerty")
+	fields := service.FieldsOf(err)
+	if service.KindOf(err) != service.KindValidation || len(fields) != 2 || fields[0].Field != "password" {
+		t.Fatalf("expected two password violations, got %v (%+v)", err, fields)
+	}
+
+	// Пароль сохраняется как введён, включая пробелы по краям.
+	if _, err := svc.Register(context.Background(), " a@b.c ", "  spaced out phrase  "); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if created.Email != "a@b.c" {
+		t.Fatalf("email must be trimmed: %q", created.Email)
+	}
+	if auth.ComparePasswords(created.PasswordHash, "  spaced out phrase  ") != nil || auth.ComparePasswords(created.PasswordHash, "spaced out phrase") == nil {
+		t.Fatalf("password must be hashed exactly as entered")
+	}
+}
+
+func TestAuthServiceLoginLegacyTrimmedPassword(t *testing.T) {
+	legacy, err := auth.HashPassword("spaced out phrase")
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+	var updates int
+	svc := service.NewAuthService(&stubUserRepo{
+		getByEmailF: func(ctx context.Context, email string) (*user.User, error) {
+			return &user.User{ID: "user-1", Email: "a@b.c", PasswordHash: legacy}, nil
+		},
+		passwordFn: func(ctx context.Context, id, passwordHash string) error {
+			updates++
+			return nil
+		},
+	}, testSecret, time.Hour)
+
+	// Хэш старого формата сохранён без пробелов по краям: вход с ними проходит.
+	if _, err := svc.Login(context.Background(), "a@b.c", "  spaced out phrase  "); err != nil {
+		t.Fatalf("login with legacy trimmed password: %v", err)
+	}
+	// Сохранённый пароль не меняется: исходный по-прежнему подходит.
+	if _, err := svc.Login(context.Background(), "a@b.c", "spaced out phrase"); err != nil {
+		t.Fatalf("original password must keep working after the fallback: %v", err)
+	}
+	if updates != 0 {
+		t.Fatalf("login must not rewrite the stored password, got %d updates", updates)
+	}
+	if _, err := svc.Login(context.Background(), "a@b.c", "  other phrase  "); service.KindOf(err) != service.KindUnauthorized {
+		t.Fatalf("wrong password must be rejected, got %v", err)
+	}
+}
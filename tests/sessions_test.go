@@ -0,0 +1,384 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/session"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	myhttp "github.com/VladislavDraga398/kanban-backend/internal/http"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
+	pg "github.com/VladislavDraga398/kanban-backend/internal/storage/postgres"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type memSessionRepo struct {
+	mu       sync.Mutex
+	sessions []*session.Session
+	gets     int
+}
+
+func (m *memSessionRepo) Create(ctx context.Context, s *session.Session) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	s.ID, s.CreatedAt, s.LastSeenAt = fmt.Sprintf("sess-%d", len(m.sessions)+1), now, now
+	cp := *s
+	m.sessions = append(m.sessions, &cp)
+	return nil
+}
+
+func (m *memSessionRepo) Get(ctx context.Context, id string) (*session.Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.gets++
+	for _, s := range m.sessions {
+		if s.ID == id {
+			cp := *s
+			return &cp, nil
+		}
+	}
+	return nil, session.ErrNotFound
+}
+
+func (m *memSessionRepo) ListActive(ctx context.Context, userID string, now time.Time) ([]*session.Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var res []*session.Session
+	for _, s := range m.sessions {
+		if s.UserID == userID && s.Active(now) {
+			cp := *s
+			res = append(res, &cp)
+		}
+	}
+	return res, nil
+}
+
+func (m *memSessionRepo) Revoke(ctx context.Context, id, userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	for _, s := range m.sessions {
+		if s.ID == id && s.UserID == userID && s.Active(now) {
+			s.RevokedAt = &now
+			return nil
+		}
+	}
+	return session.ErrNotFound
+}
+
+func (m *memSessionRepo) RevokeAll(ctx context.Context, userID, exceptID string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	var ids []string
+	for _, s := range m.sessions {
+		if s.UserID == userID && s.ID != exceptID && s.Active(now) {
+			s.RevokedAt = &now
+			ids = append(ids, s.ID)
+		}
+	}
+	return ids, nil
+}
+
+func (m *memSessionRepo) TouchLastSeen(ctx context.Context, id string, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, s := range m.sessions {
+		if s.ID == id && s.LastSeenAt.Before(at) {
+			s.LastSeenAt = at
+		}
+	}
+	return nil
+}
+
+func (m *memSessionRepo) getCalls() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.gets
+}
+
+func newSessionFixture(t *testing.T) *accountFixture {
+	t.Helper()
+	return newAccountFixture(t, service.EmailSettings{}, func(d *myhttp.Deps) { d.SessionRepo = &memSessionRepo{} })
+}
+
+// loginFrom входит от имени пользователя с заданным User-Agent и возвращает токен.
+func loginFrom(t *testing.T, f *accountFixture, email, password, userAgent string) string {
+	t.Helper()
+	rec := doJSONRequest(f.router, http.MethodPost, "/api/v1/auth/login",
+		map[string]string{"email": email, "password": password},
+		map[string]string{"Content-Type": "application/json", "User-Agent": userAgent})
+	var body map[string]any
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+	token, _ := body["token"].(string)
+	if rec.Code != http.StatusOK || token == "" {
+		t.Fatalf("login %s: %d %s", email, rec.Code, rec.Body.String())
+	}
+	return token
+}
+
+type sessionItem struct {
+	ID        string `json:"id"`
+	UserAgent string `json:"user_agent"`
+	IP        string `json:"ip"`
+	Current   bool   `json:"current"`
+}
+
+func listSessions(t *testing.T, f *accountFixture, token string) []sessionItem {
+	t.Helper()
+	rec := doJSONRequest(f.router, http.MethodGet, "/api/v1/me/sessions", nil, bearer(token))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("list sessions: %d %s", rec.Code, rec.Body.String())
+	}
+	var items []sessionItem
+	if err := json.Unmarshal(rec.Body.Bytes(), &items); err != nil {
+		t.Fatalf("decode sessions: %v", err)
+	}
+	return items
+}
+
+func sessionID(t *testing.T, token string) string {
+	t.Helper()
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		t.Fatalf("parse token: %v", err)
+	}
+	sid, _ := claims["sid"].(string)
+	if sid == "" {
+		t.Fatalf("token has no sid claim: %v", claims)
+	}
+	return sid
+}
+
+func TestSessionsListAndRevoke(t *testing.T) {
+	f := newSessionFixture(t)
+	laptop := f.register(t, "s@example.com", "correct horse")
+	phone := loginFrom(t, f, "s@example.com", "correct horse", "Phone/1.0")
+	other := f.register(t, "other@example.com", "correct horse")
+
+	items := listSessions(t, f, phone)
+	if len(items) != 2 {
+		t.Fatalf("expected 2 sessions, got %+v", items)
+	}
+	var current *sessionItem
+	for i := range items {
+		if items[i].Current {
+			current = &items[i]
+		}
+	}
+	if current == nil || current.ID != sessionID(t, phone) || current.UserAgent != "Phone/1.0" || current.IP != "192.0.2.1" {
+		t.Fatalf("current session must be marked and record the client: %+v", items)
+	}
+
+	// Чужую сессию отозвать нельзя.
+	if code, body := f.do(http.MethodDelete, "/api/v1/me/sessions/"+sessionID(t, laptop), nil, other); code != http.StatusNotFound || body["code"] != service.CodeSessionNotFound {
+		t.Fatalf("expected session_not_found for another user's session, got %d %v", code, body)
+	}
+	if code, _ := f.do(http.MethodDelete, "/api/v1/me/sessions/"+sessionID(t, laptop), nil, phone); code != http.StatusNoContent {
+		t.Fatalf("revoke session: %d", code)
+	}
+	if code, _ := f.do(http.MethodGet, "/api/v1/me", nil, laptop); code != http.StatusUnauthorized {
+		t.Fatalf("token of a revoked session must be rejected, got %d", code)
+	}
+	if code, body := f.do(http.MethodDelete, "/api/v1/me/sessions/"+sessionID(t, laptop), nil, phone); code != http.StatusNotFound || body["code"] != service.CodeSessionNotFound {
+		t.Fatalf("revoking twice must return session_not_found, got %d %v", code, body)
+	}
+	if code, _ := f.do(http.MethodGet, "/api/v1/me", nil, phone); code != http.StatusOK {
+		t.Fatalf("other sessions must keep working, got %d", code)
+	}
+	if items := listSessions(t, f, phone); len(items) != 1 {
+		t.Fatalf("revoked session must not be listed: %+v", items)
+	}
+
+	// Токены без сессии (выпущенные до её включения) принимаются до истечения срока.
+	if code, _ := f.do(http.MethodGet, "/api/v1/me", nil, mustToken(t, "user-s@example.com")); code != http.StatusOK {
+		t.Fatalf("token without a session must be accepted, got %d", code)
+	}
+}
+
+func TestSessionsLogoutEverywhere(t *testing.T) {
+	f := newSessionFixture(t)
+	first := f.register(t, "all@example.com", "correct horse")
+	second := loginFrom(t, f, "all@example.com", "correct horse", "Tablet")
+	current := loginFrom(t, f, "all@example.com", "correct horse", "Laptop")
+
+	if code, body := f.do(http.MethodDelete, "/api/v1/me/sessions?keep_current=maybe", nil, current); code != http.StatusBadRequest || body["code"] != service.CodeValidation {
+		t.Fatalf("expected validation error, got %d %v", code, body)
+	}
+	if code, body := f.do(http.MethodDelete, "/api/v1/me/sessions?keep_current=true", nil, current); code != http.StatusOK || body["revoked"] != float64(2) {
+		t.Fatalf("logout elsewhere: %d %v", code, body)
+	}
+	for _, token := range []string{first, second} {
+		if code, _ := f.do(http.MethodGet, "/api/v1/me", nil, token); code != http.StatusUnauthorized {
+			t.Fatalf("other sessions must be revoked, got %d", code)
+		}
+	}
+	if code, _ := f.do(http.MethodGet, "/api/v1/me", nil, current); code != http.StatusOK {
+		t.Fatalf("current session must be kept, got %d", code)
+	}
+
+	if code, body := f.do(http.MethodDelete, "/api/v1/me/sessions", nil, current); code != http.StatusOK || body["revoked"] != float64(1) {
+		t.Fatalf("logout everywhere: %d %v", code, body)
+	}
+	if code, _ := f.do(http.MethodGet, "/api/v1/me", nil, current); code != http.StatusUnauthorized {
+		t.Fatalf("current session must be revoked too, got %d", code)
+	}
+}
+
+func TestPasswordResetRevokesSessions(t *testing.T) {
+	f := newSessionFixture(t)
+	token := f.register(t, "reset@example.com", "correct horse")
+
+	if code, _ := f.post("/api/v1/auth/forgot-password", map[string]string{"email": "reset@example.com"}); code != http.StatusAccepted {
+		t.Fatalf("forgot password: %d", code)
+	}
+	reset := map[string]string{"token": f.tokenFromMail(t, "reset@example.com"), "password": "battery staple"}
+	if code, body := f.post("/api/v1/auth/reset-password", reset); code != http.StatusNoContent {
+		t.Fatalf("reset password: %d %v", code, body)
+	}
+	if code, _ := f.do(http.MethodGet, "/api/v1/me", nil, token); code != http.StatusUnauthorized {
+		t.Fatalf("sessions must be revoked after password reset, got %d", code)
+	}
+}
+
+func TestChangePasswordRevokesOtherSessions(t *testing.T) {
+	f := newSessionFixture(t)
+	laptop := f.register(t, "change@example.com", "correct horse")
+	phone := loginFrom(t, f, "change@example.com", "correct horse", "Phone/1.0")
+
+	body := map[string]string{"current_password": "correct horse", "new_password": "battery staple"}
+	if code, resp := f.do(http.MethodPost, "/api/v1/me/password", body, phone); code != http.StatusNoContent {
+		t.Fatalf("change password: %d %v", code, resp)
+	}
+	if code, _ := f.do(http.MethodGet, "/api/v1/me", nil, laptop); code != http.StatusUnauthorized {
+		t.Fatalf("other sessions must be revoked after password change, got %d", code)
+	}
+	if items := listSessions(t, f, phone); len(items) != 1 || !items[0].Current {
+		t.Fatalf("the session that changed the password must stay: %+v", items)
+	}
+}
+
+func TestSessionVerificationIsCached(t *testing.T) {
+	repo := &memSessionRepo{}
+	sessions := service.NewSessionService(repo)
+	ctx := service.WithClientInfo(context.Background(), service.ClientInfo{UserAgent: "cli", IP: "203.0.113.7"})
+	s, err := sessions.Start(ctx, "user-1", time.Hour)
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if s.UserAgent != "cli" || s.IP != "203.0.113.7" {
+		t.Fatalf("client info must be recorded: %+v", s)
+	}
+
+	for range 5 {
+		if err := sessions.VerifySession(context.Background(), "user-1", s.ID); err != nil {
+			t.Fatalf("verify: %v", err)
+		}
+	}
+	if n := repo.getCalls(); n != 0 {
+		t.Fatalf("fresh session must be served from cache, got %d lookups", n)
+	}
+	if err := sessions.VerifySession(context.Background(), "user-2", s.ID); err != auth.ErrSessionRevoked {
+		t.Fatalf("session of another user must be rejected, got %v", err)
+	}
+
+	if err := sessions.Revoke(context.Background(), "user-1", s.ID); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	if err := sessions.VerifySession(context.Background(), "user-1", s.ID); err != auth.ErrSessionRevoked {
+		t.Fatalf("revocation must bypass the cache, got %v", err)
+	}
+	if err := sessions.VerifySession(context.Background(), "user-1", s.ID); err != auth.ErrSessionRevoked {
+		t.Fatalf("revoked state must be cached, got %v", err)
+	}
+	if n := repo.getCalls(); n != 1 {
+		t.Fatalf("expected a single lookup after revoke, got %d", n)
+	}
+	if err := sessions.VerifySession(context.Background(), "user-1", "unknown"); err != auth.ErrSessionRevoked {
+		t.Fatalf("unknown session must be rejected, got %v", err)
+	}
+}
+
+func TestSessionsDisabledWithoutRepo(t *testing.T) {
+	f := newAccountFixture(t, service.EmailSettings{})
+	token := f.register(t, "nosess@example.com", "correct horse")
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil || claims["sid"] != nil {
+		t.Fatalf("token must not carry sid without sessions: %v %v", claims, err)
+	}
+	if code, _ := f.do(http.MethodGet, "/api/v1/me/sessions", nil, token); code != http.StatusNotFound {
+		t.Fatalf("expected 404 without session repo, got %d", code)
+	}
+}
+
+func TestIntegration_SessionRepository(t *testing.T) {
+	dsn, stop := startPostgres(t)
+	defer stop()
+
+	db, err := pg.New(dsn)
+	if err != nil {
+		t.Fatalf("connect db: %v", err)
+	}
+	defer db.Close()
+	applyMigrations(t, db.DB)
+
+	ctx := context.Background()
+	u := &user.User{Email: "sess@example.com", PasswordHash: "hash"}
+	if err := pg.NewUserRepository(db).Create(ctx, u); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	repo := pg.NewSessionRepository(db)
+	expires := time.Now().Add(time.Hour)
+	a := &session.Session{UserID: u.ID, UserAgent: "Laptop", IP: "192.0.2.1", ExpiresAt: expires}
+	b := &session.Session{UserID: u.ID, UserAgent: "Phone", IP: "192.0.2.2", ExpiresAt: expires}
+	expired := &session.Session{UserID: u.ID, ExpiresAt: time.Now().Add(-time.Minute)}
+	for _, s := range []*session.Session{a, b, expired} {
+		if err := repo.Create(ctx, s); err != nil || s.ID == "" {
+			t.Fatalf("create session: %v", err)
+		}
+	}
+
+	if err := repo.TouchLastSeen(ctx, a.ID, time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("touch: %v", err)
+	}
+	list, err := repo.ListActive(ctx, u.ID, time.Now())
+	if err != nil || len(list) != 2 || list[0].ID != a.ID || list[0].UserAgent != "Laptop" {
+		t.Fatalf("list active: %+v %v", list, err)
+	}
+
+	if err := repo.Revoke(ctx, a.ID, "00000000-0000-0000-0000-000000000000"); err != session.ErrNotFound {
+		t.Fatalf("revoke by another user: expected ErrNotFound, got %v", err)
+	}
+	if err := repo.Revoke(ctx, a.ID, u.ID); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	if err := repo.Revoke(ctx, a.ID, u.ID); err != session.ErrNotFound {
+		t.Fatalf("revoke twice: expected ErrNotFound, got %v", err)
+	}
+	if got, err := repo.Get(ctx, a.ID); err != nil || got.RevokedAt == nil {
+		t.Fatalf("get revoked: %+v %v", got, err)
+	}
+
+	c := &session.Session{UserID: u.ID, ExpiresAt: expires}
+	if err := repo.Create(ctx, c); err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	ids, err := repo.RevokeAll(ctx, u.ID, c.ID)
+	if err != nil || len(ids) != 1 || ids[0] != b.ID {
+		t.Fatalf("revoke all except current: %v %v", ids, err)
+	}
+	if ids, err := repo.RevokeAll(ctx, u.ID, ""); err != nil || len(ids) != 1 || ids[0] != c.ID {
+		t.Fatalf("revoke all: %v %v", ids, err)
+	}
+	if list, err := repo.ListActive(ctx, u.ID, time.Now()); err != nil || len(list) != 0 {
+		t.Fatalf("no active sessions expected: %+v %v", list, err)
+	}
+}
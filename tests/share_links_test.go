@@ -0,0 +1,289 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/column"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/sharelink"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	myhttp "github.com/VladislavDraga398/kanban-backend/internal/http"
+	"github.com/VladislavDraga398/kanban-backend/internal/ratelimit"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
+	pg "github.com/VladislavDraga398/kanban-backend/internal/storage/postgres"
+)
+
+// memShareLinkRepo хранит ссылки в памяти; снимок собирается из boards и заранее заданных колонок и задач.
+type memShareLinkRepo struct {
+	mu      sync.Mutex
+	boards  *memBoardRepo
+	links   []*sharelink.Link
+	columns []column.Column
+	tasks   []task.Task
+}
+
+func (m *memShareLinkRepo) Create(ctx context.Context, l *sharelink.Link) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	l.ID, l.CreatedAt = fmt.Sprintf("link-%d", len(m.links)+1), time.Now()
+	cp := *l
+	m.links = append(m.links, &cp)
+	return nil
+}
+
+func (m *memShareLinkRepo) ListByBoard(ctx context.Context, boardID string) ([]*sharelink.Link, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var res []*sharelink.Link
+	for _, l := range m.links {
+		if l.BoardID == boardID {
+			cp := *l
+			res = append(res, &cp)
+		}
+	}
+	return res, nil
+}
+
+func (m *memShareLinkRepo) Delete(ctx context.Context, id, boardID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, l := range m.links {
+		if l.ID == id && l.BoardID == boardID {
+			m.links = append(m.links[:i], m.links[i+1:]...)
+			return nil
+		}
+	}
+	return sharelink.ErrNotFound
+}
+
+func (m *memShareLinkRepo) Snapshot(ctx context.Context, hash string, now time.Time) (*sharelink.Snapshot, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, l := range m.links {
+		if l.Hash != hash || l.Expired(now) {
+			continue
+		}
+		var b *board.Board
+		if m.boards != nil {
+			m.boards.mu.Lock()
+			if found := m.boards.find(l.BoardID); found != nil {
+				cp := *found
+				b = &cp
+			}
+			m.boards.mu.Unlock()
+		} else {
+			b = &board.Board{ID: l.BoardID, Name: "Roadmap"}
+		}
+		if b == nil {
+			break
+		}
+		return &sharelink.Snapshot{Board: b, Columns: m.columns, Tasks: m.tasks}, nil
+	}
+	return nil, sharelink.ErrNotFound
+}
+
+// expire переносит срок действия всех ссылок в прошлое.
+func (m *memShareLinkRepo) expire() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	past := time.Now().Add(-time.Minute)
+	for _, l := range m.links {
+		l.ExpiresAt = &past
+	}
+}
+
+func TestBoardShareLinks(t *testing.T) {
+	ws := &memWorkspaceRepo{}
+	ws.boards = &memBoardRepo{ws: ws}
+	invitations := &memInvitationRepo{boards: ws.boards}
+	links := &memShareLinkRepo{boards: ws.boards}
+	f := newAccountFixture(t, service.EmailSettings{}, func(d *myhttp.Deps) {
+		d.WorkspaceRepo = ws
+		d.BoardRepo = ws.boards
+		d.InvitationRepo = invitations
+		d.ShareLinkRepo = links
+	})
+	alice := f.register(t, "alice@example.com", "correct horse")
+	bob := f.register(t, "bob@example.com", "correct horse")
+	carol := f.register(t, "carol@example.com", "correct horse")
+
+	_, b := f.do(http.MethodPost, "/api/v1/boards", map[string]string{"name": "Roadmap"}, alice)
+	boardID := b["id"].(string)
+	boardPath := "/api/v1/boards/" + boardID
+	links.columns = []column.Column{
+		{ID: "col-1", BoardID: boardID, Name: "Todo", Position: 0},
+		{ID: "col-2", BoardID: boardID, Name: "Done", Position: 1},
+	}
+	links.tasks = []task.Task{{ID: "task-1", BoardID: boardID, ColumnID: "col-1", Title: "Ship", Description: "v1", Position: 0}}
+	ws.boards.addMember(boardID, "user-bob@example.com", "bob@example.com", board.RoleMember)
+
+	past := time.Now().Add(-time.Hour)
+	if code, body := f.do(http.MethodPost, boardPath+"/share-links", map[string]any{"expires_at": past}, alice); code != http.StatusBadRequest {
+		t.Fatalf("past expiry: expected 400, got %d %v", code, body)
+	}
+	if code, body := f.do(http.MethodPost, boardPath+"/share-links", map[string]any{}, bob); code != http.StatusForbidden || body["code"] != service.CodeBoardForbidden {
+		t.Fatalf("member must not share the board: %d %v", code, body)
+	}
+	if code, _ := f.do(http.MethodPost, boardPath+"/share-links", map[string]any{}, carol); code != http.StatusNotFound {
+		t.Fatalf("outsider must not see the board, got %d", code)
+	}
+
+	code, created := f.do(http.MethodPost, boardPath+"/share-links", map[string]any{}, alice)
+	token, _ := created["token"].(string)
+	if code != http.StatusCreated || token == "" || created["token_hint"] != token[:4] || created["expires_at"] != nil {
+		t.Fatalf("create share link: %d %v", code, created)
+	}
+	items := listJSON(t, f, boardPath+"/share-links", alice)
+	if len(items) != 1 || items[0]["token"] != nil || items[0]["token_hint"] != token[:4] {
+		t.Fatalf("unexpected share links: %v", items)
+	}
+
+	// Доска по ссылке открывается без входа и без внутренних идентификаторов.
+	rec := doJSONRequest(f.router, http.MethodGet, "/api/v1/public/boards/"+token, nil, nil)
+	if rec.Code != http.StatusOK || rec.Header().Get("Cache-Control") != "no-store" {
+		t.Fatalf("public board: %d %q %s", rec.Code, rec.Header().Get("Cache-Control"), rec.Body.String())
+	}
+	body := rec.Body.String()
+	for _, secret := range []string{boardID, "user-alice", "owner_id", "workspace_id", "col-1", "task-1", `"id"`} {
+		if strings.Contains(body, secret) {
+			t.Fatalf("public board leaks %q: %s", secret, body)
+		}
+	}
+	for _, want := range []string{`"name":"Roadmap"`, `"name":"Done","position":1,"tasks":[]`, `"title":"Ship"`} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("public board must contain %s: %s", want, body)
+		}
+	}
+	if rec := doJSONRequest(f.router, http.MethodGet, "/api/v1/public/boards/unknown", nil, nil); rec.Code != http.StatusNotFound || !strings.Contains(rec.Body.String(), service.CodeShareLinkNotFound) {
+		t.Fatalf("unknown token: %d %s", rec.Code, rec.Body.String())
+	}
+
+	// Отозванная ссылка перестаёт работать сразу.
+	linkPath := boardPath + "/share-links/" + items[0]["id"].(string)
+	if code, _ := f.do(http.MethodDelete, linkPath, nil, bob); code != http.StatusForbidden {
+		t.Fatalf("member must not revoke, got %d", code)
+	}
+	if code, _ := f.do(http.MethodDelete, linkPath, nil, alice); code != http.StatusNoContent {
+		t.Fatalf("revoke, got %d", code)
+	}
+	if code, body := f.do(http.MethodDelete, linkPath, nil, alice); code != http.StatusNotFound || body["code"] != service.CodeShareLinkNotFound {
+		t.Fatalf("revoke twice: %d %v", code, body)
+	}
+	if rec := doJSONRequest(f.router, http.MethodGet, "/api/v1/public/boards/"+token, nil, nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("revoked link must not open the board, got %d", rec.Code)
+	}
+
+	// Истёкшая ссылка остаётся в списке, но доску не открывает.
+	future := time.Now().Add(time.Hour)
+	code, created = f.do(http.MethodPost, boardPath+"/share-links", map[string]any{"expires_at": future}, alice)
+	if code != http.StatusCreated || created["expires_at"] == nil {
+		t.Fatalf("create expiring link: %d %v", code, created)
+	}
+	token = created["token"].(string)
+	if rec := doJSONRequest(f.router, http.MethodGet, "/api/v1/public/boards/"+token, nil, nil); rec.Code != http.StatusOK {
+		t.Fatalf("expiring link must work before expiry, got %d", rec.Code)
+	}
+	links.expire()
+	if rec := doJSONRequest(f.router, http.MethodGet, "/api/v1/public/boards/"+token, nil, nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("expired link must not open the board, got %d", rec.Code)
+	}
+	if items := listJSON(t, f, boardPath+"/share-links", alice); len(items) != 1 {
+		t.Fatalf("expired link must stay listed: %v", items)
+	}
+}
+
+func TestPublicBoardRateLimit(t *testing.T) {
+	links := &memShareLinkRepo{}
+	raw, hash, err := auth.NewOpaqueToken()
+	if err != nil {
+		t.Fatalf("token: %v", err)
+	}
+	links.links = []*sharelink.Link{{ID: "link-1", BoardID: "board-1", Hash: hash}}
+	router := rateLimitedRouter(t, myhttp.Deps{
+		ShareLinkRepo: links,
+		PublicLimiter: ratelimit.NewLimiter(ratelimit.NewMemoryStore(time.Hour), ratelimit.Limit{Burst: 1, Per: time.Minute}),
+	})
+
+	if rec := doJSONRequest(router, http.MethodGet, "/api/v1/public/boards/"+raw, nil, nil); rec.Code != http.StatusOK {
+		t.Fatalf("first request must pass, got %d: %s", rec.Code, rec.Body.String())
+	}
+	// Перебор токенов упирается в тот же лимит по IP.
+	rec := doJSONRequest(router, http.MethodGet, "/api/v1/public/boards/guess", nil, nil)
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "60" {
+		t.Fatalf("expected 429 with Retry-After 60, got %d %q", rec.Code, rec.Header().Get("Retry-After"))
+	}
+}
+
+func TestIntegration_ShareLinkRepository(t *testing.T) {
+	dsn, stop := startPostgres(t)
+	defer stop()
+
+	db, err := pg.New(dsn)
+	if err != nil {
+		t.Fatalf("connect db: %v", err)
+	}
+	defer db.Close()
+	applyMigrations(t, db.DB)
+
+	ctx := context.Background()
+	owner := &user.User{Email: "owner@example.com", PasswordHash: "hash"}
+	if err := pg.NewUserRepository(db).Create(ctx, owner); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	b := &board.Board{OwnerID: owner.ID, Name: "Roadmap"}
+	if err := pg.NewBoardRepository(db).Create(ctx, b); err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+	c := &column.Column{Name: "Todo"}
+	if err := pg.NewColumnRepository(db).CreateInBoard(ctx, c, b.ID, owner.ID); err != nil {
+		t.Fatalf("create column: %v", err)
+	}
+	tk := &task.Task{Title: "Ship"}
+	if err := pg.NewTaskRepository(db).CreateInColumn(ctx, tk, b.ID, c.ID, owner.ID); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	repo := pg.NewShareLinkRepository(db)
+	now := time.Now()
+	expires := now.Add(time.Hour)
+	raw, hash, err := auth.NewOpaqueToken()
+	if err != nil {
+		t.Fatalf("token: %v", err)
+	}
+	l := &sharelink.Link{BoardID: b.ID, CreatedBy: owner.ID, Hash: hash, Hint: raw[:4], ExpiresAt: &expires}
+	if err := repo.Create(ctx, l); err != nil || l.ID == "" {
+		t.Fatalf("create share link: %+v %v", l, err)
+	}
+
+	snap, err := repo.Snapshot(ctx, auth.HashOpaqueToken(raw), now)
+	if err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	if snap.Board.Name != "Roadmap" || len(snap.Columns) != 1 || len(snap.Tasks) != 1 || snap.Tasks[0].ColumnID != c.ID {
+		t.Fatalf("unexpected snapshot: %+v", snap)
+	}
+	if _, err := repo.Snapshot(ctx, hash, now.Add(2*time.Hour)); err != sharelink.ErrNotFound {
+		t.Fatalf("expired link: expected ErrNotFound, got %v", err)
+	}
+
+	if list, err := repo.ListByBoard(ctx, b.ID); err != nil || len(list) != 1 || list[0].Hint != raw[:4] {
+		t.Fatalf("list share links: %v %v", list, err)
+	}
+	if err := repo.Delete(ctx, l.ID, b.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err := repo.Delete(ctx, l.ID, b.ID); err != sharelink.ErrNotFound {
+		t.Fatalf("delete twice: expected ErrNotFound, got %v", err)
+	}
+	if _, err := repo.Snapshot(ctx, hash, now); err != sharelink.ErrNotFound {
+		t.Fatalf("revoked link: expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,362 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/activity"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/column"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	myhttp "github.com/VladislavDraga398/kanban-backend/internal/http"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
+	pg "github.com/VladislavDraga398/kanban-backend/internal/storage/postgres"
+)
+
+// memTaskVersionRepo хранит задачи и их версии в памяти; доступ к доске проверяет boards, если он задан.
+type memTaskVersionRepo struct {
+	mu       sync.Mutex
+	boards   *memBoardRepo
+	tasks    []*task.Task
+	versions []*task.Version
+}
+
+// save кладёт задачу и записывает её состояние следующей версией, как это делает TaskRepository.
+func (m *memTaskVersionRepo) save(t task.Task, actorID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if cur := m.find(t.BoardID, t.ID); cur != nil {
+		*cur = t
+	} else {
+		m.tasks = append(m.tasks, &t)
+	}
+	m.record(&t, actorID)
+}
+
+func (m *memTaskVersionRepo) record(t *task.Task, actorID string) {
+	next := 1
+	for _, v := range m.versions {
+		if v.TaskID == t.ID && v.Version >= next {
+			next = v.Version + 1
+		}
+	}
+	m.versions = append(m.versions, &task.Version{
+		TaskID: t.ID, Version: next, ColumnID: t.ColumnID, Title: t.Title,
+		Description: t.Description, ActorID: actorID, CreatedAt: time.Now(),
+	})
+}
+
+func (m *memTaskVersionRepo) find(boardID, taskID string) *task.Task {
+	for _, t := range m.tasks {
+		if t.ID == taskID && t.BoardID == boardID {
+			return t
+		}
+	}
+	return nil
+}
+
+func (m *memTaskVersionRepo) accessibleTask(boardID, taskID, userID string) *task.Task {
+	t := m.find(boardID, taskID)
+	if t == nil || m.boards == nil {
+		return t
+	}
+	m.boards.mu.Lock()
+	defer m.boards.mu.Unlock()
+	if b := m.boards.find(boardID); b == nil || !m.boards.accessible(b, userID) {
+		return nil
+	}
+	return t
+}
+
+func (m *memTaskVersionRepo) ListVersions(ctx context.Context, boardID, taskID, ownerID string) ([]*task.Version, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.accessibleTask(boardID, taskID, ownerID) == nil {
+		return nil, task.ErrNotFound
+	}
+	var res []*task.Version
+	for i := len(m.versions) - 1; i >= 0; i-- {
+		if v := m.versions[i]; v.TaskID == taskID {
+			cp := *v
+			res = append(res, &cp)
+		}
+	}
+	return res, nil
+}
+
+func (m *memTaskVersionRepo) GetVersion(ctx context.Context, boardID, taskID string, version int, ownerID string) (*task.Version, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.accessibleTask(boardID, taskID, ownerID) == nil {
+		return nil, task.ErrNotFound
+	}
+	for _, v := range m.versions {
+		if v.TaskID == taskID && v.Version == version {
+			cp := *v
+			return &cp, nil
+		}
+	}
+	return nil, task.ErrVersionNotFound
+}
+
+func (m *memTaskVersionRepo) Restore(ctx context.Context, t *task.Task, version int, ownerID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cur := m.accessibleTask(t.BoardID, t.ID, ownerID)
+	if cur == nil {
+		return task.ErrNotFound
+	}
+	for _, v := range m.versions {
+		if v.TaskID == t.ID && v.Version == version {
+			cur.Title, cur.Description, cur.ColumnID = v.Title, v.Description, v.ColumnID
+			cur.UpdatedAt = time.Now()
+			m.record(cur, ownerID)
+			*t = *cur
+			return nil
+		}
+	}
+	return task.ErrVersionNotFound
+}
+
+func TestTaskVersionsDiffAndRestore(t *testing.T) {
+	ws := &memWorkspaceRepo{}
+	ws.boards = &memBoardRepo{ws: ws}
+	versions := &memTaskVersionRepo{boards: ws.boards}
+	f := newAccountFixture(t, service.EmailSettings{}, func(d *myhttp.Deps) {
+		d.WorkspaceRepo = ws
+		d.BoardRepo = ws.boards
+		d.TaskVersionRepo = versions
+	})
+	alice := f.register(t, "alice@example.com", "correct horse")
+	bob := f.register(t, "bob@example.com", "correct horse")
+
+	_, b := f.do(http.MethodPost, "/api/v1/boards", map[string]string{"name": "Roadmap"}, alice)
+	boardID := b["id"].(string)
+	tk := task.Task{ID: "task-1", BoardID: boardID, ColumnID: "col-todo", Title: "Ship", Description: "v1"}
+	versions.save(tk, "user-alice@example.com")
+	tk.Title = "Ship it"
+	versions.save(tk, "user-alice@example.com")
+	tk.ColumnID, tk.Description = "col-done", "done"
+	versions.save(tk, "")
+	taskPath := "/api/v1/boards/" + boardID + "/tasks/task-1"
+
+	list := listJSON(t, f, taskPath+"/versions", alice)
+	if len(list) != 3 || list[0]["version"] != float64(3) || list[0]["actor_id"] != nil || list[2]["title"] != "Ship" {
+		t.Fatalf("unexpected versions: %v", list)
+	}
+
+	code, diff := f.do(http.MethodGet, taskPath+"/versions/3/diff", nil, alice)
+	changes, _ := diff["changes"].(map[string]any)
+	if code != http.StatusOK || diff["from"] != float64(2) || len(changes) != 2 || changes["title"] != nil {
+		t.Fatalf("diff with previous version: %d %v", code, diff)
+	}
+	if col := changes["column_id"].(map[string]any); col["from"] != "col-todo" || col["to"] != "col-done" {
+		t.Fatalf("unexpected column change: %v", changes)
+	}
+	_, diff = f.do(http.MethodGet, taskPath+"/versions/3/diff?against=1", nil, alice)
+	if changes := diff["changes"].(map[string]any); len(changes) != 3 {
+		t.Fatalf("diff against version 1: %v", diff)
+	}
+	_, diff = f.do(http.MethodGet, taskPath+"/versions/1/diff", nil, alice)
+	if changes := diff["changes"].(map[string]any); diff["from"] != float64(0) || changes["title"].(map[string]any)["from"] != "" {
+		t.Fatalf("first version must be compared with an empty task: %v", diff)
+	}
+
+	code, restored := f.do(http.MethodPost, taskPath+"/restore/1", nil, alice)
+	if code != http.StatusOK || restored["title"] != "Ship" || restored["column_id"] != "col-todo" {
+		t.Fatalf("restore: %d %v", code, restored)
+	}
+	list = listJSON(t, f, taskPath+"/versions", alice)
+	if len(list) != 4 || list[0]["title"] != "Ship" || list[0]["actor_id"] != "user-alice@example.com" {
+		t.Fatalf("restore must add a new version: %v", list)
+	}
+
+	for path, want := range map[string]int{
+		taskPath + "/versions/9/diff":                          http.StatusNotFound,
+		taskPath + "/versions/x/diff":                          http.StatusBadRequest,
+		taskPath + "/versions/2/diff?against=y":                http.StatusBadRequest,
+		taskPath + "/versions/0/diff":                          http.StatusBadRequest,
+		"/api/v1/boards/" + boardID + "/tasks/task-2/versions": http.StatusNotFound,
+	} {
+		if code, body := f.do(http.MethodGet, path, nil, alice); code != want {
+			t.Fatalf("%s: expected %d, got %d %v", path, want, code, body)
+		}
+	}
+	if code, body := f.do(http.MethodPost, taskPath+"/restore/9", nil, alice); code != http.StatusNotFound || body["code"] != "task_version_not_found" {
+		t.Fatalf("restore of a missing version: %d %v", code, body)
+	}
+	if code, _ := f.do(http.MethodPost, taskPath+"/restore/2", nil, bob); code != http.StatusNotFound {
+		t.Fatalf("outsider must not restore the task, got %d", code)
+	}
+	ws.boards.addMember(boardID, "user-bob@example.com", "bob@example.com", board.RoleMember)
+	if code, _ := f.do(http.MethodPost, taskPath+"/restore/2", nil, bob); code != http.StatusOK {
+		t.Fatalf("board member must restore the task, got %d", code)
+	}
+}
+
+func TestIntegration_TaskVersions(t *testing.T) {
+	dsn, stop := startPostgres(t)
+	defer stop()
+
+	db, err := pg.New(dsn)
+	if err != nil {
+		t.Fatalf("connect db: %v", err)
+	}
+	defer db.Close()
+	applyMigrations(t, db.DB)
+
+	ctx := context.Background()
+	owner := &user.User{Email: "owner@example.com", PasswordHash: "hash"}
+	if err := pg.NewUserRepository(db).Create(ctx, owner); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	boards, columns, tasks := pg.NewBoardRepository(db), pg.NewColumnRepository(db), pg.NewTaskRepository(db)
+
+	b := &board.Board{OwnerID: owner.ID, Name: "Roadmap"}
+	if err := boards.Create(ctx, b); err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+	todo, doing, done := &column.Column{Name: "Todo"}, &column.Column{Name: "Doing"}, &column.Column{Name: "Done"}
+	for _, c := range []*column.Column{todo, doing, done} {
+		if err := columns.CreateInBoard(ctx, c, b.ID, owner.ID); err != nil {
+			t.Fatalf("create column: %v", err)
+		}
+	}
+	tk := &task.Task{Title: "Ship", Description: "first"}
+	if err := tasks.CreateInColumn(ctx, tk, b.ID, todo.ID, owner.ID); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	other := &task.Task{Title: "Other"}
+	if err := tasks.CreateInColumn(ctx, other, b.ID, done.ID, owner.ID); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	// Сохранение без изменений новой версии не создаёт.
+	if err := tasks.Update(ctx, tk, owner.ID); err != nil {
+		t.Fatalf("update task: %v", err)
+	}
+	tk.Title = "Ship v1"
+	if err := tasks.Update(ctx, tk, owner.ID); err != nil {
+		t.Fatalf("update task: %v", err)
+	}
+	if err := tasks.MoveToColumn(ctx, tk, doing.ID, owner.ID); err != nil {
+		t.Fatalf("move task: %v", err)
+	}
+
+	versions, err := tasks.ListVersions(ctx, b.ID, tk.ID, owner.ID)
+	if err != nil || len(versions) != 3 {
+		t.Fatalf("expected 3 versions, got %+v %v", versions, err)
+	}
+	if v := versions[0]; v.Version != 3 || v.ColumnID != doing.ID || v.ActorID != owner.ID {
+		t.Fatalf("unexpected latest version: %+v", v)
+	}
+	if v, err := tasks.GetVersion(ctx, b.ID, tk.ID, 1, owner.ID); err != nil || v.Title != "Ship" || v.ColumnID != todo.ID {
+		t.Fatalf("unexpected first version: %+v %v", v, err)
+	}
+	if _, err := tasks.GetVersion(ctx, b.ID, tk.ID, 9, owner.ID); err != task.ErrVersionNotFound {
+		t.Fatalf("expected ErrVersionNotFound, got %v", err)
+	}
+	if _, err := tasks.ListVersions(ctx, b.ID, tk.ID, "00000000-0000-0000-0000-000000000000"); err != task.ErrNotFound {
+		t.Fatalf("outsider: expected ErrNotFound, got %v", err)
+	}
+
+	// Версия 2 лежала в Todo: задача возвращается туда в конец колонки.
+	restored := &task.Task{ID: tk.ID, BoardID: b.ID}
+	if err := tasks.Restore(ctx, restored, 2, owner.ID); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if restored.Title != "Ship v1" || restored.ColumnID != todo.ID || restored.Position != 1 {
+		t.Fatalf("unexpected restored task: %+v", restored)
+	}
+
+	// Колонку версии удалили — восстанавливается только содержимое.
+	if err := tasks.MoveToColumn(ctx, restored, doing.ID, owner.ID); err != nil {
+		t.Fatalf("move task: %v", err)
+	}
+	if err := columns.Delete(ctx, todo.ID, b.ID, owner.ID); err != nil {
+		t.Fatalf("delete column: %v", err)
+	}
+	if err := tasks.Restore(ctx, restored, 1, owner.ID); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if restored.Title != "Ship" || restored.Description != "first" || restored.ColumnID != doing.ID {
+		t.Fatalf("content must be restored in place: %+v", restored)
+	}
+	if err := tasks.Restore(ctx, restored, 42, owner.ID); err != task.ErrVersionNotFound {
+		t.Fatalf("expected ErrVersionNotFound, got %v", err)
+	}
+
+	versions, err = tasks.ListVersions(ctx, b.ID, tk.ID, owner.ID)
+	if err != nil || len(versions) != 6 || versions[0].Title != "Ship" {
+		t.Fatalf("expected 6 versions, got %+v %v", versions, err)
+	}
+	entries, err := pg.NewActivityRepository(db).List(ctx, activity.Filter{
+		BoardID: b.ID, TaskID: tk.ID, Actions: []activity.Action{activity.TaskRestored}, Limit: 10,
+	})
+	if err != nil || len(entries) != 2 || entries[0].Before["title"] != "Ship v1" {
+		t.Fatalf("restores must be logged: %+v %v", entries, err)
+	}
+}
+
+// Integration: восстановление версии из другой колонки задачи, которая стоит не последней.
+func TestIntegration_TaskVersionRestoreFromMiddleOfColumn(t *testing.T) {
+	dsn, stop := startPostgres(t)
+	defer stop()
+
+	db, err := pg.New(dsn)
+	if err != nil {
+		t.Fatalf("connect db: %v", err)
+	}
+	defer db.Close()
+	applyMigrations(t, db.DB)
+
+	ctx := context.Background()
+	owner := &user.User{Email: "owner@example.com", PasswordHash: "hash"}
+	if err := pg.NewUserRepository(db).Create(ctx, owner); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	boards, columns, tasks := pg.NewBoardRepository(db), pg.NewColumnRepository(db), pg.NewTaskRepository(db)
+
+	b := &board.Board{OwnerID: owner.ID, Name: "Roadmap"}
+	if err := boards.Create(ctx, b); err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+	todo, doing := &column.Column{Name: "Todo"}, &column.Column{Name: "Doing"}
+	for _, c := range []*column.Column{todo, doing} {
+		if err := columns.CreateInBoard(ctx, c, b.ID, owner.ID); err != nil {
+			t.Fatalf("create column: %v", err)
+		}
+	}
+	first, mid, last := &task.Task{Title: "First"}, &task.Task{Title: "Mid"}, &task.Task{Title: "Last"}
+	if err := tasks.CreateInColumn(ctx, first, b.ID, todo.ID, owner.ID); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	// Версия 1 задачи mid лежит в Doing, затем задача переезжает в середину Todo.
+	if err := tasks.CreateInColumn(ctx, mid, b.ID, doing.ID, owner.ID); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	if err := tasks.MoveToColumn(ctx, mid, todo.ID, owner.ID); err != nil {
+		t.Fatalf("move task: %v", err)
+	}
+	if err := tasks.CreateInColumn(ctx, last, b.ID, todo.ID, owner.ID); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	if mid.Position != 2 || last.Position != 3 {
+		t.Fatalf("mid must not be the last task: mid=%d last=%d", mid.Position, last.Position)
+	}
+
+	restored := &task.Task{ID: mid.ID, BoardID: b.ID}
+	if err := tasks.Restore(ctx, restored, 1, owner.ID); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if restored.ColumnID != doing.ID || restored.Position != 1 {
+		t.Fatalf("task must go back to Doing: %+v", restored)
+	}
+	list, err := tasks.ListByColumnOwner(ctx, b.ID, todo.ID, owner.ID)
+	if err != nil || len(list) != 2 || list[0].ID != first.ID || list[1].ID != last.ID || list[1].Position != 2 {
+		t.Fatalf("source column must be compacted: %+v %v", list, err)
+	}
+}
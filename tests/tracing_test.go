@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	myhttp "github.com/VladislavDraga398/kanban-backend/internal/http"
+	pg "github.com/VladislavDraga398/kanban-backend/internal/storage/postgres"
+	"github.com/VladislavDraga398/kanban-backend/internal/tracing"
+)
+
+const incomingTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+func spanAttr(s tracetest.SpanStub, key string) attribute.Value {
+	for _, kv := range s.Attributes {
+		if string(kv.Key) == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestTracingSpanPerRequest(t *testing.T) {
+	tp, exporter := tracing.NewInMemory()
+	router := myhttp.NewRouter(myhttp.Deps{
+		UserRepo: &stubUserRepo{},
+		BoardRepo: &stubBoardRepo{
+			getFn: func(ctx context.Context, id, ownerID string) (*board.Board, error) {
+				return &board.Board{ID: id, OwnerID: ownerID, Name: "Board"}, nil
+			},
+		},
+		ColumnRepo:     &stubColumnRepo{},
+		TaskRepo:       &stubTaskRepo{},
+		JWTSecret:      testSecret,
+		JWTTTL:         time.Hour,
+		TracerProvider: tp,
+	})
+
+	headers := bearer(mustToken(t, "owner-1"))
+	headers["traceparent"] = "00-" + incomingTraceID + "-00f067aa0ba902b7-01"
+	if rec := doJSONRequest(router, http.MethodGet, "/api/v1/boards/b1", nil, headers); rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected one span, got %d", len(spans))
+	}
+	s := spans[0]
+	if s.Name != "GET /api/v1/boards/{id}" {
+		t.Fatalf("unexpected span name %q", s.Name)
+	}
+	if s.SpanContext.TraceID().String() != incomingTraceID || !s.Parent.IsRemote() {
+		t.Fatalf("span must continue incoming W3C trace, got trace %s", s.SpanContext.TraceID())
+	}
+	if spanAttr(s, "http.route").AsString() != "/api/v1/boards/{id}" ||
+		spanAttr(s, "enduser.id").AsString() != "owner-1" ||
+		spanAttr(s, "http.response.status_code").AsInt64() != http.StatusOK {
+		t.Fatalf("unexpected span attributes: %v", s.Attributes)
+	}
+}
+
+// Integration: дочерние span репозиториев и отдельные span запросов внутри MoveToColumn.
+func TestIntegration_RepositorySpans(t *testing.T) {
+	dsn, stop := startPostgres(t)
+	defer stop()
+
+	db, err := pg.New(dsn)
+	if err != nil {
+		t.Fatalf("connect db: %v", err)
+	}
+	defer db.Close()
+	applyMigrations(t, db.DB)
+
+	tp, exporter := tracing.NewInMemory()
+	router := myhttp.NewRouter(myhttp.Deps{
+		UserRepo:       pg.NewUserRepository(db),
+		BoardRepo:      pg.NewBoardRepository(db),
+		ColumnRepo:     pg.NewColumnRepository(db),
+		TaskRepo:       pg.NewTaskRepository(db),
+		JWTSecret:      "integration-secret",
+		JWTTTL:         time.Hour,
+		TracerProvider: tp,
+	})
+
+	create := func(path string, body any, headers map[string]string) string {
+		t.Helper()
+		rec := doJSONRequest(router, http.MethodPost, path, body, headers)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("POST %s: expected 201, got %d: %s", path, rec.Code, rec.Body.String())
+		}
+		var resp struct {
+			ID    string `json:"id"`
+			Token string `json:"token"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if resp.Token != "" {
+			return resp.Token
+		}
+		return resp.ID
+	}
+
+	token := create("/api/v1/auth/register", map[string]string{"email": "trace@example.com", "password": "password123"}, nil)
+	headers := bearer(token)
+	boardID := create("/api/v1/boards", map[string]string{"name": "Traced"}, headers)
+	todo := create("/api/v1/boards/"+boardID+"/columns", map[string]string{"name": "Todo"}, headers)
+	done := create("/api/v1/boards/"+boardID+"/columns", map[string]string{"name": "Done"}, headers)
+	taskID := create("/api/v1/boards/"+boardID+"/columns/"+todo+"/tasks", map[string]string{"title": "Ship"}, headers)
+
+	exporter.Reset()
+	rec := doJSONRequest(router, http.MethodPatch, "/api/v1/boards/"+boardID+"/tasks/"+taskID+"/move", map[string]string{"column_id": done}, headers)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("move: expected 200, got %d", rec.Code)
+	}
+
+	byName := map[string]sdktrace.ReadOnlySpan{}
+	for _, s := range exporter.GetSpans().Snapshots() {
+		byName[s.Name()] = s
+	}
+	root, ok := byName["PATCH /api/v1/boards/{board_id}/tasks/{task_id}/move"]
+	if !ok {
+		t.Fatalf("request span missing: %v", byName)
+	}
+	repo, ok := byName["TaskRepository.MoveToColumn"]
+	if !ok || repo.Parent().SpanID() != root.SpanContext().SpanID() {
+		t.Fatalf("repository span must be a child of the request span")
+	}
+	for _, step := range []string{"lock_board", "lock_task", "lock_column", "compact_source", "next_position", "update_task", "commit"} {
+		s, ok := byName["MoveToColumn."+step]
+		if !ok || s.Parent().SpanID() != repo.SpanContext().SpanID() {
+			t.Fatalf("query span MoveToColumn.%s missing or detached", step)
+		}
+	}
+}
@@ -0,0 +1,333 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/activity"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/column"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/task"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/trash"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	myhttp "github.com/VladislavDraga398/kanban-backend/internal/http"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
+	pg "github.com/VladislavDraga398/kanban-backend/internal/storage/postgres"
+)
+
+// memTrashItem — элемент корзины и пользователь, который его видит.
+type memTrashItem struct {
+	trash.Item
+	userID string
+	// inTrashColumn — задача из колонки, которая сама в корзине.
+	inTrashColumn bool
+}
+
+// memTrashRepo хранит корзину в памяти; восстановленный элемент просто исчезает из неё.
+type memTrashRepo struct {
+	mu    sync.Mutex
+	items []*memTrashItem
+}
+
+func (m *memTrashRepo) add(it trash.Item, userID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.items = append(m.items, &memTrashItem{Item: it, userID: userID})
+}
+
+func (m *memTrashRepo) List(ctx context.Context, userID string) ([]*trash.Item, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var res []*trash.Item
+	for i := len(m.items) - 1; i >= 0; i-- {
+		if it := m.items[i]; it.userID == userID && !it.inTrashColumn {
+			cp := it.Item
+			res = append(res, &cp)
+		}
+	}
+	return res, nil
+}
+
+func (m *memTrashRepo) take(kind trash.Kind, id, userID string) (*trash.Item, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, it := range m.items {
+		if it.Kind != kind || it.ID != id || it.userID != userID {
+			continue
+		}
+		if it.inTrashColumn {
+			return nil, trash.ErrParentDeleted
+		}
+		m.items = append(m.items[:i], m.items[i+1:]...)
+		if kind == trash.KindColumn {
+			for _, other := range m.items {
+				if other.Kind == trash.KindTask && other.ColumnID == id {
+					other.inTrashColumn = false
+				}
+			}
+		}
+		return &it.Item, nil
+	}
+	return nil, trash.ErrNotFound
+}
+
+func (m *memTrashRepo) RestoreBoard(ctx context.Context, b *board.Board, userID string) error {
+	it, err := m.take(trash.KindBoard, b.ID, userID)
+	if err != nil {
+		return err
+	}
+	*b = board.Board{ID: it.ID, OwnerID: userID, Name: it.Name, CreatedAt: it.DeletedAt, UpdatedAt: time.Now()}
+	return nil
+}
+
+func (m *memTrashRepo) RestoreColumn(ctx context.Context, c *column.Column, userID string) error {
+	it, err := m.take(trash.KindColumn, c.ID, userID)
+	if err != nil {
+		return err
+	}
+	*c = column.Column{ID: it.ID, BoardID: it.BoardID, Name: it.Name, Position: 1, CreatedAt: it.DeletedAt, UpdatedAt: time.Now()}
+	return nil
+}
+
+func (m *memTrashRepo) RestoreTask(ctx context.Context, t *task.Task, userID string) error {
+	it, err := m.take(trash.KindTask, t.ID, userID)
+	if err != nil {
+		return err
+	}
+	*t = task.Task{ID: it.ID, BoardID: it.BoardID, ColumnID: it.ColumnID, Title: it.Name, Position: 1, CreatedAt: it.DeletedAt, UpdatedAt: time.Now()}
+	return nil
+}
+
+func (m *memTrashRepo) Purge(ctx context.Context, before time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var kept []*memTrashItem
+	for _, it := range m.items {
+		if !it.DeletedAt.Before(before) {
+			kept = append(kept, it)
+		}
+	}
+	n := int64(len(m.items) - len(kept))
+	m.items = kept
+	return n, nil
+}
+
+func TestTrashListAndRestore(t *testing.T) {
+	items := &memTrashRepo{}
+	f := newAccountFixture(t, service.EmailSettings{}, func(d *myhttp.Deps) {
+		d.TrashRepo = items
+		d.TrashRetention = 48 * time.Hour
+	})
+	alice := f.register(t, "alice@example.com", "correct horse")
+	bob := f.register(t, "bob@example.com", "correct horse")
+
+	deletedAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	aliceID := "user-alice@example.com"
+	items.add(trash.Item{Kind: trash.KindBoard, ID: "board-1", BoardID: "board-1", Name: "Old roadmap", DeletedAt: deletedAt, DeletedBy: aliceID}, aliceID)
+	items.add(trash.Item{Kind: trash.KindColumn, ID: "col-1", BoardID: "board-2", Name: "Done", DeletedAt: deletedAt}, aliceID)
+	items.add(trash.Item{Kind: trash.KindTask, ID: "task-1", BoardID: "board-2", ColumnID: "col-2", Name: "Ship", DeletedAt: deletedAt, DeletedBy: aliceID}, aliceID)
+	items.items = append(items.items, &memTrashItem{
+		Item:   trash.Item{Kind: trash.KindTask, ID: "task-2", BoardID: "board-2", ColumnID: "col-1", Name: "Test", DeletedAt: deletedAt},
+		userID: aliceID, inTrashColumn: true,
+	})
+
+	list := listJSON(t, f, "/api/v1/me/trash", alice)
+	if len(list) != 3 || list[0]["kind"] != "task" || list[0]["column_id"] != "col-2" || list[1]["deleted_by"] != nil {
+		t.Fatalf("unexpected trash: %v", list)
+	}
+	if purge := list[2]["purge_at"].(string); purge != deletedAt.Add(48*time.Hour).Format(time.RFC3339) {
+		t.Fatalf("purge_at must be deleted_at + retention, got %s", purge)
+	}
+	if list := listJSON(t, f, "/api/v1/me/trash", bob); len(list) != 0 {
+		t.Fatalf("trash of another user must be empty: %v", list)
+	}
+
+	code, body := f.do(http.MethodPost, "/api/v1/me/trash/tasks/task-2/restore", nil, alice)
+	if code != http.StatusConflict || body["code"] != "column_in_trash" {
+		t.Fatalf("task of a deleted column: %d %v", code, body)
+	}
+	code, body = f.do(http.MethodPost, "/api/v1/me/trash/columns/col-1/restore", nil, alice)
+	if code != http.StatusOK || body["name"] != "Done" {
+		t.Fatalf("restore column: %d %v", code, body)
+	}
+	code, body = f.do(http.MethodPost, "/api/v1/me/trash/tasks/task-2/restore", nil, alice)
+	if code != http.StatusOK || body["column_id"] != "col-1" {
+		t.Fatalf("restore task after its column: %d %v", code, body)
+	}
+	if code, body := f.do(http.MethodPost, "/api/v1/me/trash/boards/board-1/restore", nil, bob); code != http.StatusNotFound || body["code"] != "trash_item_not_found" {
+		t.Fatalf("outsider must not restore the board: %d %v", code, body)
+	}
+	code, body = f.do(http.MethodPost, "/api/v1/me/trash/boards/board-1/restore", nil, alice)
+	if code != http.StatusOK || body["name"] != "Old roadmap" {
+		t.Fatalf("restore board: %d %v", code, body)
+	}
+	if code, _ := f.do(http.MethodPost, "/api/v1/me/trash/boards/board-1/restore", nil, alice); code != http.StatusNotFound {
+		t.Fatalf("restored board must leave the trash, got %d", code)
+	}
+	if list := listJSON(t, f, "/api/v1/me/trash", alice); len(list) != 1 || list[0]["id"] != "task-1" {
+		t.Fatalf("unexpected trash after restore: %v", list)
+	}
+}
+
+func TestTrashServicePurgeUsesRetention(t *testing.T) {
+	now := time.Now()
+	items := &memTrashRepo{}
+	items.add(trash.Item{Kind: trash.KindTask, ID: "old", DeletedAt: now.Add(-72 * time.Hour)}, "user-1")
+	items.add(trash.Item{Kind: trash.KindTask, ID: "fresh", DeletedAt: now.Add(-time.Hour)}, "user-1")
+
+	n, err := service.NewTrashService(items, 48*time.Hour).Purge(context.Background(), now)
+	if err != nil || n != 1 {
+		t.Fatalf("expected 1 purged item, got %d %v", n, err)
+	}
+	if list, _ := items.List(context.Background(), "user-1"); len(list) != 1 || list[0].ID != "fresh" {
+		t.Fatalf("only the expired item must be purged: %+v", list)
+	}
+}
+
+func TestIntegration_Trash(t *testing.T) {
+	dsn, stop := startPostgres(t)
+	defer stop()
+
+	db, err := pg.New(dsn)
+	if err != nil {
+		t.Fatalf("connect db: %v", err)
+	}
+	defer db.Close()
+	applyMigrations(t, db.DB)
+
+	ctx := context.Background()
+	users := pg.NewUserRepository(db)
+	owner := &user.User{Email: "owner@example.com", PasswordHash: "hash"}
+	outsider := &user.User{Email: "outsider@example.com", PasswordHash: "hash"}
+	for _, u := range []*user.User{owner, outsider} {
+		if err := users.Create(ctx, u); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+	boards, columns, tasks := pg.NewBoardRepository(db), pg.NewColumnRepository(db), pg.NewTaskRepository(db)
+	bin := pg.NewTrashRepository(db)
+
+	b := &board.Board{OwnerID: owner.ID, Name: "Roadmap"}
+	if err := boards.Create(ctx, b); err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+	todo, done := &column.Column{Name: "Todo"}, &column.Column{Name: "Done"}
+	for _, c := range []*column.Column{todo, done} {
+		if err := columns.CreateInBoard(ctx, c, b.ID, owner.ID); err != nil {
+			t.Fatalf("create column: %v", err)
+		}
+	}
+	var todoTasks []*task.Task
+	for _, title := range []string{"One", "Two", "Three"} {
+		tk := &task.Task{Title: title}
+		if err := tasks.CreateInColumn(ctx, tk, b.ID, todo.ID, owner.ID); err != nil {
+			t.Fatalf("create task: %v", err)
+		}
+		todoTasks = append(todoTasks, tk)
+	}
+	doneTask := &task.Task{Title: "Shipped"}
+	if err := tasks.CreateInColumn(ctx, doneTask, b.ID, done.ID, owner.ID); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	// Удалённая задача пропадает из колонки и возвращается на прежнее место.
+	two := todoTasks[1]
+	if err := tasks.Delete(ctx, two.ID, b.ID, todo.ID, owner.ID); err != nil {
+		t.Fatalf("delete task: %v", err)
+	}
+	if list, err := tasks.ListByColumnOwner(ctx, b.ID, todo.ID, owner.ID); err != nil || len(list) != 2 {
+		t.Fatalf("deleted task must be hidden: %+v %v", list, err)
+	}
+	two.Title = "Two v2"
+	if err := tasks.Update(ctx, two, owner.ID); err != task.ErrNotFound {
+		t.Fatalf("deleted task must not be editable, got %v", err)
+	}
+	if err := tasks.Delete(ctx, two.ID, b.ID, todo.ID, owner.ID); err != task.ErrNotFound {
+		t.Fatalf("delete twice: expected ErrNotFound, got %v", err)
+	}
+	if err := bin.RestoreTask(ctx, &task.Task{ID: two.ID}, outsider.ID); err != trash.ErrNotFound {
+		t.Fatalf("outsider restore: expected ErrNotFound, got %v", err)
+	}
+	restored := &task.Task{ID: two.ID}
+	if err := bin.RestoreTask(ctx, restored, owner.ID); err != nil {
+		t.Fatalf("restore task: %v", err)
+	}
+	if restored.Position != two.Position || restored.Title != "Two" {
+		t.Fatalf("task must return to its slot: %+v", restored)
+	}
+	if err := bin.RestoreTask(ctx, restored, owner.ID); err != trash.ErrNotFound {
+		t.Fatalf("restore twice: expected ErrNotFound, got %v", err)
+	}
+
+	// Колонка уходит в корзину с задачами и возвращается с ними же; задача, удалённая раньше, остаётся в корзине.
+	if err := tasks.Delete(ctx, todoTasks[0].ID, b.ID, todo.ID, owner.ID); err != nil {
+		t.Fatalf("delete task: %v", err)
+	}
+	if err := columns.Delete(ctx, todo.ID, b.ID, owner.ID); err != nil {
+		t.Fatalf("delete column: %v", err)
+	}
+	if list, err := columns.ListByBoardOwner(ctx, b.ID, owner.ID); err != nil || len(list) != 1 || list[0].ID != done.ID {
+		t.Fatalf("deleted column must be hidden: %+v %v", list, err)
+	}
+	if err := tasks.MoveToColumn(ctx, &task.Task{ID: doneTask.ID, BoardID: b.ID}, todo.ID, owner.ID); err != task.ErrNotFound {
+		t.Fatalf("move into a deleted column: expected ErrNotFound, got %v", err)
+	}
+	items, err := bin.List(ctx, owner.ID)
+	if err != nil || len(items) != 1 || items[0].Kind != trash.KindColumn || items[0].DeletedBy != owner.ID {
+		t.Fatalf("trash must show only the column: %+v %v", items, err)
+	}
+	if err := bin.RestoreTask(ctx, &task.Task{ID: todoTasks[0].ID}, owner.ID); err != trash.ErrParentDeleted {
+		t.Fatalf("restore task of a deleted column: expected ErrParentDeleted, got %v", err)
+	}
+	col := &column.Column{ID: todo.ID}
+	if err := bin.RestoreColumn(ctx, col, owner.ID); err != nil || col.Position != todo.Position {
+		t.Fatalf("restore column: %+v %v", col, err)
+	}
+	if list, err := tasks.ListByColumnOwner(ctx, b.ID, todo.ID, owner.ID); err != nil || len(list) != 2 {
+		t.Fatalf("column must come back with its tasks: %+v %v", list, err)
+	}
+	if items, err := bin.List(ctx, owner.ID); err != nil || len(items) != 1 || items[0].ID != todoTasks[0].ID {
+		t.Fatalf("earlier deleted task must stay in the trash: %+v %v", items, err)
+	}
+
+	// Доска в корзине недоступна вместе со всем содержимым, восстановить её может только управляющий.
+	if err := boards.Delete(ctx, b.ID, owner.ID); err != nil {
+		t.Fatalf("delete board: %v", err)
+	}
+	if _, err := boards.GetByID(ctx, b.ID, owner.ID); err != board.ErrNotFound {
+		t.Fatalf("deleted board must be hidden, got %v", err)
+	}
+	if list, err := boards.ListByOwnerID(ctx, owner.ID); err != nil || len(list) != 0 {
+		t.Fatalf("deleted board must not be listed: %+v %v", list, err)
+	}
+	if items, err := bin.List(ctx, owner.ID); err != nil || len(items) != 1 || items[0].Kind != trash.KindBoard {
+		t.Fatalf("trash must show only the board: %+v %v", items, err)
+	}
+	if err := bin.RestoreBoard(ctx, &board.Board{ID: b.ID}, outsider.ID); err != trash.ErrNotFound {
+		t.Fatalf("outsider restore: expected ErrNotFound, got %v", err)
+	}
+	rb := &board.Board{ID: b.ID}
+	if err := bin.RestoreBoard(ctx, rb, owner.ID); err != nil || rb.Name != "Roadmap" {
+		t.Fatalf("restore board: %+v %v", rb, err)
+	}
+	entries, err := pg.NewActivityRepository(db).List(ctx, activity.Filter{
+		BoardID: b.ID, Actions: []activity.Action{activity.BoardDeleted, activity.BoardRestored, activity.ColumnRestored}, Limit: 10,
+	})
+	if err != nil || len(entries) != 3 || entries[0].Action != activity.BoardRestored {
+		t.Fatalf("trash actions must be logged: %+v %v", entries, err)
+	}
+
+	// Очистка удаляет только то, что пролежало в корзине дольше срока.
+	if n, err := bin.Purge(ctx, time.Now().Add(-time.Hour)); err != nil || n != 0 {
+		t.Fatalf("fresh items must survive the purge: %d %v", n, err)
+	}
+	if n, err := bin.Purge(ctx, time.Now().Add(time.Minute)); err != nil || n != 1 {
+		t.Fatalf("expected 1 purged item, got %d %v", n, err)
+	}
+	if items, err := bin.List(ctx, owner.ID); err != nil || len(items) != 0 {
+		t.Fatalf("trash must be empty after purge: %+v %v", items, err)
+	}
+}
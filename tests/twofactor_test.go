@@ -0,0 +1,402 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/auth"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	myhttp "github.com/VladislavDraga398/kanban-backend/internal/http"
+	"github.com/VladislavDraga398/kanban-backend/internal/ratelimit"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
+	pg "github.com/VladislavDraga398/kanban-backend/internal/storage/postgres"
+)
+
+type memTwoFactorRepo struct {
+	mu       sync.Mutex
+	settings map[string]*user.TwoFactor
+	// codes: userID -> hash -> использован ли код.
+	codes map[string]map[string]bool
+}
+
+func (m *memTwoFactorRepo) Get(ctx context.Context, userID string) (*user.TwoFactor, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tf, ok := m.settings[userID]
+	if !ok {
+		return nil, user.ErrTwoFactorNotFound
+	}
+	cp := *tf
+	cp.RecoveryCodesLeft = 0
+	for _, used := range m.codes[userID] {
+		if !used {
+			cp.RecoveryCodesLeft++
+		}
+	}
+	return &cp, nil
+}
+
+func (m *memTwoFactorRepo) Begin(ctx context.Context, userID, secret string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.settings == nil {
+		m.settings = map[string]*user.TwoFactor{}
+	}
+	if tf, ok := m.settings[userID]; ok && tf.Enabled() {
+		return nil
+	}
+	m.settings[userID] = &user.TwoFactor{UserID: userID, Secret: secret, CreatedAt: time.Now()}
+	return nil
+}
+
+func (m *memTwoFactorRepo) Confirm(ctx context.Context, userID string, step int64, recoveryHashes []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tf, ok := m.settings[userID]
+	if !ok || tf.Enabled() {
+		return user.ErrTwoFactorNotFound
+	}
+	now := time.Now()
+	tf.ConfirmedAt, tf.LastUsedStep = &now, step
+	m.replace(userID, recoveryHashes)
+	return nil
+}
+
+func (m *memTwoFactorRepo) UseStep(ctx context.Context, userID string, step int64) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tf, ok := m.settings[userID]
+	if !ok || !tf.Enabled() || tf.LastUsedStep >= step {
+		return false, nil
+	}
+	tf.LastUsedStep = step
+	return true, nil
+}
+
+func (m *memTwoFactorRepo) UseRecoveryCode(ctx context.Context, userID, hash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	used, ok := m.codes[userID][hash]
+	if !ok || used {
+		return user.ErrRecoveryCodeInvalid
+	}
+	m.codes[userID][hash] = true
+	return nil
+}
+
+func (m *memTwoFactorRepo) ReplaceRecoveryCodes(ctx context.Context, userID string, hashes []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.replace(userID, hashes)
+	return nil
+}
+
+func (m *memTwoFactorRepo) Delete(ctx context.Context, userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.settings[userID]; !ok {
+		return user.ErrTwoFactorNotFound
+	}
+	delete(m.settings, userID)
+	delete(m.codes, userID)
+	return nil
+}
+
+func (m *memTwoFactorRepo) replace(userID string, hashes []string) {
+	if m.codes == nil {
+		m.codes = map[string]map[string]bool{}
+	}
+	m.codes[userID] = map[string]bool{}
+	for _, h := range hashes {
+		m.codes[userID][h] = false
+	}
+}
+
+func newTwoFactorFixture(t *testing.T, opts ...func(*myhttp.Deps)) *accountFixture {
+	t.Helper()
+	return newAccountFixture(t, service.EmailSettings{}, append([]func(*myhttp.Deps){func(d *myhttp.Deps) {
+		d.TwoFactorRepo = &memTwoFactorRepo{}
+	}}, opts...)...)
+}
+
+// enableTwoFactor подключает 2FA и возвращает секрет и коды восстановления.
+func enableTwoFactor(t *testing.T, f *accountFixture, token string) (string, []string) {
+	t.Helper()
+	code, body := f.do(http.MethodPost, "/api/v1/me/2fa/enroll", nil, token)
+	secret, _ := body["secret"].(string)
+	if code != http.StatusOK || secret == "" {
+		t.Fatalf("enroll: %d %v", code, body)
+	}
+	uri, err := url.Parse(body["otpauth_uri"].(string))
+	if err != nil || uri.Scheme != "otpauth" || uri.Host != "totp" || uri.Query().Get("secret") != secret || uri.Query().Get("issuer") != service.TOTPIssuer {
+		t.Fatalf("unexpected otpauth uri %q", body["otpauth_uri"])
+	}
+
+	code, body = f.do(http.MethodPost, "/api/v1/me/2fa/confirm", map[string]string{"code": "000000"}, token)
+	if code != http.StatusBadRequest {
+		t.Fatalf("confirm with wrong code: %d %v", code, body)
+	}
+	code, body = f.do(http.MethodPost, "/api/v1/me/2fa/confirm", map[string]string{"code": totpCode(t, secret, 0)}, token)
+	if code != http.StatusOK {
+		t.Fatalf("confirm: %d %v", code, body)
+	}
+	var recovery []string
+	for _, c := range body["recovery_codes"].([]any) {
+		recovery = append(recovery, c.(string))
+	}
+	if len(recovery) != auth.RecoveryCodeCount {
+		t.Fatalf("expected %d recovery codes, got %v", auth.RecoveryCodeCount, recovery)
+	}
+	return secret, recovery
+}
+
+// totpCode вычисляет код для текущего шага со сдвигом offset (в пределах допуска сервера).
+func totpCode(t *testing.T, secret string, offset int64) string {
+	t.Helper()
+	code, err := auth.TOTPCode(secret, auth.TOTPStep(time.Now())+offset)
+	if err != nil {
+		t.Fatalf("totp code: %v", err)
+	}
+	return code
+}
+
+// challenge выполняет первый шаг входа и возвращает промежуточный токен.
+func challenge(t *testing.T, f *accountFixture, email, password string) string {
+	t.Helper()
+	code, body := f.post("/api/v1/auth/login", map[string]string{"email": email, "password": password})
+	ch, _ := body["challenge_token"].(string)
+	if code != http.StatusOK || body["token"] != nil || body["two_factor_required"] != true || ch == "" {
+		t.Fatalf("expected two-factor challenge, got %d %v", code, body)
+	}
+	return ch
+}
+
+func TestTwoFactorLoginFlow(t *testing.T) {
+	f := newTwoFactorFixture(t)
+	token := f.register(t, "mfa@example.com", "correct horse")
+
+	if code, body := f.do(http.MethodGet, "/api/v1/me/2fa", nil, token); code != http.StatusOK || body["enabled"] != false {
+		t.Fatalf("status before enrollment: %d %v", code, body)
+	}
+	// Начатое, но не подтверждённое подключение на вход не влияет.
+	if code, _ := f.do(http.MethodPost, "/api/v1/me/2fa/enroll", nil, token); code != http.StatusOK {
+		t.Fatalf("enroll: %d", code)
+	}
+	if code, body := f.post("/api/v1/auth/login", map[string]string{"email": "mfa@example.com", "password": "correct horse"}); code != http.StatusOK || body["token"] == nil {
+		t.Fatalf("pending enrollment must not require a code: %d %v", code, body)
+	}
+
+	secret, recovery := enableTwoFactor(t, f, token)
+	if code, body := f.do(http.MethodGet, "/api/v1/me/2fa", nil, token); code != http.StatusOK || body["enabled"] != true || body["recovery_codes_left"] != float64(auth.RecoveryCodeCount) {
+		t.Fatalf("status after enrollment: %d %v", code, body)
+	}
+	if code, body := f.do(http.MethodPost, "/api/v1/me/2fa/enroll", nil, token); code != http.StatusConflict || body["code"] != service.CodeTwoFactorEnabled {
+		t.Fatalf("repeated enroll: %d %v", code, body)
+	}
+
+	ch := challenge(t, f, "mfa@example.com", "correct horse")
+	// Промежуточный токен не заменяет токен доступа.
+	if code, _ := f.do(http.MethodGet, "/api/v1/me", nil, ch); code != http.StatusUnauthorized {
+		t.Fatalf("challenge token must not authenticate API calls, got %d", code)
+	}
+	next := totpCode(t, secret, 1)
+	code, body := f.post("/api/v1/auth/login/2fa", map[string]string{"challenge_token": ch, "code": next})
+	if code != http.StatusOK || body["token"] == nil {
+		t.Fatalf("second factor: %d %v", code, body)
+	}
+	if code, body := f.post("/api/v1/auth/login/2fa", map[string]string{"challenge_token": ch, "code": next}); code != http.StatusUnauthorized || body["code"] != service.CodeInvalidTwoFactorCode {
+		t.Fatalf("code must be single-use, got %d %v", code, body)
+	}
+
+	// Код восстановления принимается в любом регистре и гасится после использования.
+	ch = challenge(t, f, "mfa@example.com", "correct horse")
+	if code, body := f.post("/api/v1/auth/login/2fa", map[string]string{"challenge_token": ch, "recovery_code": strings.ToUpper(recovery[0])}); code != http.StatusOK || body["token"] == nil {
+		t.Fatalf("recovery code: %d %v", code, body)
+	}
+	if code, body := f.post("/api/v1/auth/login/2fa", map[string]string{"challenge_token": ch, "recovery_code": recovery[0]}); code != http.StatusUnauthorized || body["code"] != service.CodeInvalidTwoFactorCode {
+		t.Fatalf("used recovery code: %d %v", code, body)
+	}
+
+	if code, body := f.post("/api/v1/auth/login/2fa", map[string]string{"challenge_token": "forged", "code": next}); code != http.StatusUnauthorized || body["code"] != service.CodeInvalidChallenge {
+		t.Fatalf("forged challenge: %d %v", code, body)
+	}
+	if code, _ := f.post("/api/v1/auth/login/2fa", map[string]string{"challenge_token": ch, "code": next, "recovery_code": recovery[1]}); code != http.StatusBadRequest {
+		t.Fatalf("both code and recovery_code: expected 400, got %d", code)
+	}
+}
+
+func TestTwoFactorDisableAndRegenerateRequirePassword(t *testing.T) {
+	f := newTwoFactorFixture(t)
+	token := f.register(t, "mfa@example.com", "correct horse")
+	if code, body := f.do(http.MethodPost, "/api/v1/me/2fa/disable", map[string]string{"password": "correct horse"}, token); code != http.StatusConflict || body["code"] != service.CodeTwoFactorNotEnabled {
+		t.Fatalf("disable before enrollment: %d %v", code, body)
+	}
+	_, recovery := enableTwoFactor(t, f, token)
+
+	for _, path := range []string{"/api/v1/me/2fa/recovery-codes", "/api/v1/me/2fa/disable"} {
+		if code, body := f.do(http.MethodPost, path, map[string]string{"password": "wrong"}, token); code != http.StatusBadRequest || body["code"] != service.CodeValidation {
+			t.Fatalf("%s with wrong password: %d %v", path, code, body)
+		}
+	}
+
+	code, body := f.do(http.MethodPost, "/api/v1/me/2fa/recovery-codes", map[string]string{"password": "correct horse"}, token)
+	fresh, _ := body["recovery_codes"].([]any)
+	if code != http.StatusOK || len(fresh) != auth.RecoveryCodeCount {
+		t.Fatalf("regenerate: %d %v", code, body)
+	}
+	ch := challenge(t, f, "mfa@example.com", "correct horse")
+	if code, _ := f.post("/api/v1/auth/login/2fa", map[string]string{"challenge_token": ch, "recovery_code": recovery[0]}); code != http.StatusUnauthorized {
+		t.Fatalf("old recovery codes must stop working, got %d", code)
+	}
+
+	if code, _ := f.do(http.MethodPost, "/api/v1/me/2fa/disable", map[string]string{"password": "correct horse"}, token); code != http.StatusNoContent {
+		t.Fatalf("disable: %d", code)
+	}
+	// Выданный до отключения промежуточный токен больше не действует, а вход снова по одному паролю.
+	if code, body := f.post("/api/v1/auth/login/2fa", map[string]string{"challenge_token": ch, "recovery_code": fresh[0].(string)}); code != http.StatusUnauthorized || body["code"] != service.CodeInvalidChallenge {
+		t.Fatalf("challenge after disable: %d %v", code, body)
+	}
+	if code, body := f.post("/api/v1/auth/login", map[string]string{"email": "mfa@example.com", "password": "correct horse"}); code != http.StatusOK || body["token"] == nil {
+		t.Fatalf("login after disable: %d %v", code, body)
+	}
+}
+
+func TestTwoFactorWithoutPasswordConfirmsWithCodes(t *testing.T) {
+	f := newTwoFactorFixture(t)
+	token := f.register(t, "sso@example.com", "correct horse")
+	// Учётная запись, созданная через внешнего провайдера, пароля не имеет.
+	f.mu.Lock()
+	f.users["sso@example.com"].PasswordHash = ""
+	f.mu.Unlock()
+	secret, recovery := enableTwoFactor(t, f, token)
+
+	for _, req := range []map[string]string{
+		{"password": "correct horse"},
+		{"code": "000000"},
+		{"recovery_code": "wrong"},
+		{"code": totpCode(t, secret, 1), "recovery_code": recovery[0]},
+	} {
+		if code, body := f.do(http.MethodPost, "/api/v1/me/2fa/disable", req, token); code != http.StatusBadRequest || body["code"] != service.CodeValidation {
+			t.Fatalf("disable with %v: %d %v", req, code, body)
+		}
+	}
+
+	code, body := f.do(http.MethodPost, "/api/v1/me/2fa/recovery-codes", map[string]string{"recovery_code": recovery[0]}, token)
+	fresh, _ := body["recovery_codes"].([]any)
+	if code != http.StatusOK || len(fresh) != auth.RecoveryCodeCount {
+		t.Fatalf("regenerate with a recovery code: %d %v", code, body)
+	}
+	// Код из приложения подходит один раз: шаг, использованный при подключении, повторно не принимается.
+	if code, _ := f.do(http.MethodPost, "/api/v1/me/2fa/disable", map[string]string{"code": totpCode(t, secret, 0)}, token); code != http.StatusBadRequest {
+		t.Fatalf("reused totp step must be rejected, got %d", code)
+	}
+	if code, body := f.do(http.MethodPost, "/api/v1/me/2fa/disable", map[string]string{"code": totpCode(t, secret, 1)}, token); code != http.StatusNoContent {
+		t.Fatalf("disable with a totp code: %d %v", code, body)
+	}
+	if _, body := f.do(http.MethodGet, "/api/v1/me/2fa", nil, token); body["enabled"] != false {
+		t.Fatalf("two-factor must be disabled: %v", body)
+	}
+}
+
+func TestTwoFactorLockoutAfterFailedCodes(t *testing.T) {
+	f := newTwoFactorFixture(t, func(d *myhttp.Deps) {
+		d.LoginLockout = ratelimit.NewLockout(ratelimit.NewMemoryStore(time.Hour), ratelimit.LockoutPolicy{
+			Threshold: 3, Base: time.Minute, Max: time.Hour,
+		})
+	})
+	token := f.register(t, "mfa@example.com", "correct horse")
+	secret, _ := enableTwoFactor(t, f, token)
+	ch := challenge(t, f, "mfa@example.com", "correct horse")
+
+	for range 3 {
+		if code, _ := f.post("/api/v1/auth/login/2fa", map[string]string{"challenge_token": ch, "code": "000000"}); code != http.StatusUnauthorized {
+			t.Fatalf("wrong code: expected 401, got %d", code)
+		}
+	}
+	code, body := f.post("/api/v1/auth/login/2fa", map[string]string{"challenge_token": ch, "code": totpCode(t, secret, 1)})
+	if code != http.StatusTooManyRequests || body["code"] != service.CodeAccountLocked {
+		t.Fatalf("expected lockout after failed codes, got %d %v", code, body)
+	}
+}
+
+func TestTwoFactorDisabledWithoutRepo(t *testing.T) {
+	f := newAccountFixture(t, service.EmailSettings{})
+	token := f.register(t, "plain@example.com", "correct horse")
+	if code, _ := f.do(http.MethodGet, "/api/v1/me/2fa", nil, token); code != http.StatusNotFound {
+		t.Fatalf("expected 404 without two-factor repo, got %d", code)
+	}
+	if code, _ := f.post("/api/v1/auth/login/2fa", map[string]string{"challenge_token": "x", "code": "123456"}); code != http.StatusNotFound {
+		t.Fatalf("expected 404 without two-factor repo, got %d", code)
+	}
+}
+
+func TestIntegration_TwoFactorRepository(t *testing.T) {
+	dsn, stop := startPostgres(t)
+	defer stop()
+
+	db, err := pg.New(dsn)
+	if err != nil {
+		t.Fatalf("connect db: %v", err)
+	}
+	defer db.Close()
+	applyMigrations(t, db.DB)
+
+	ctx := context.Background()
+	u := &user.User{Email: "mfa@example.com", PasswordHash: "hash"}
+	if err := pg.NewUserRepository(db).Create(ctx, u); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	repo := pg.NewTwoFactorRepository(db)
+	if _, err := repo.Get(ctx, u.ID); err != user.ErrTwoFactorNotFound {
+		t.Fatalf("expected ErrTwoFactorNotFound, got %v", err)
+	}
+	if err := repo.Begin(ctx, u.ID, "SECRET1"); err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	if err := repo.Begin(ctx, u.ID, "SECRET2"); err != nil {
+		t.Fatalf("begin again: %v", err)
+	}
+	if err := repo.Confirm(ctx, u.ID, 100, []string{"h1", "h2"}); err != nil {
+		t.Fatalf("confirm: %v", err)
+	}
+	// Включённую 2FA повторное подключение не затирает.
+	if err := repo.Begin(ctx, u.ID, "SECRET3"); err != nil {
+		t.Fatalf("begin after confirm: %v", err)
+	}
+	tf, err := repo.Get(ctx, u.ID)
+	if err != nil || tf.Secret != "SECRET2" || !tf.Enabled() || tf.LastUsedStep != 100 || tf.RecoveryCodesLeft != 2 {
+		t.Fatalf("get: %+v %v", tf, err)
+	}
+
+	if ok, err := repo.UseStep(ctx, u.ID, 100); err != nil || ok {
+		t.Fatalf("replayed step must be rejected: %v %v", ok, err)
+	}
+	if ok, err := repo.UseStep(ctx, u.ID, 101); err != nil || !ok {
+		t.Fatalf("new step: %v %v", ok, err)
+	}
+	if err := repo.UseRecoveryCode(ctx, u.ID, "h1"); err != nil {
+		t.Fatalf("use recovery code: %v", err)
+	}
+	if err := repo.UseRecoveryCode(ctx, u.ID, "h1"); err != user.ErrRecoveryCodeInvalid {
+		t.Fatalf("expected ErrRecoveryCodeInvalid, got %v", err)
+	}
+	if err := repo.ReplaceRecoveryCodes(ctx, u.ID, []string{"h3", "h4", "h5"}); err != nil {
+		t.Fatalf("replace: %v", err)
+	}
+	if tf, err := repo.Get(ctx, u.ID); err != nil || tf.RecoveryCodesLeft != 3 {
+		t.Fatalf("get after replace: %+v %v", tf, err)
+	}
+
+	if err := repo.Delete(ctx, u.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err := repo.Delete(ctx, u.ID); err != user.ErrTwoFactorNotFound {
+		t.Fatalf("expected ErrTwoFactorNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,668 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/board"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/column"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/user"
+	"github.com/VladislavDraga398/kanban-backend/internal/domain/workspace"
+	myhttp "github.com/VladislavDraga398/kanban-backend/internal/http"
+	"github.com/VladislavDraga398/kanban-backend/internal/service"
+	pg "github.com/VladislavDraga398/kanban-backend/internal/storage/postgres"
+)
+
+type memWorkspaceRepo struct {
+	mu         sync.Mutex
+	workspaces []*workspace.Workspace
+	members    []*workspace.Member
+	// boards — доски, которые проверяются при удалении пространства.
+	boards *memBoardRepo
+}
+
+func (m *memWorkspaceRepo) Create(ctx context.Context, w *workspace.Workspace, ownerID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	w.ID, w.Role, w.CreatedAt, w.UpdatedAt = fmt.Sprintf("ws-%d", len(m.workspaces)+1), workspace.RoleOwner, now, now
+	cp := *w
+	m.workspaces = append(m.workspaces, &cp)
+	m.members = append(m.members, &workspace.Member{WorkspaceID: w.ID, UserID: ownerID, Role: workspace.RoleOwner, JoinedAt: now})
+	return nil
+}
+
+func (m *memWorkspaceRepo) role(workspaceID, userID string) (workspace.Role, bool) {
+	for _, mb := range m.members {
+		if mb.WorkspaceID == workspaceID && mb.UserID == userID {
+			return mb.Role, true
+		}
+	}
+	return "", false
+}
+
+func (m *memWorkspaceRepo) ListByMember(ctx context.Context, userID string) ([]*workspace.Workspace, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var res []*workspace.Workspace
+	for _, w := range m.workspaces {
+		if role, ok := m.role(w.ID, userID); ok {
+			cp := *w
+			cp.Role = role
+			res = append(res, &cp)
+		}
+	}
+	return res, nil
+}
+
+func (m *memWorkspaceRepo) GetForMember(ctx context.Context, id, userID string) (*workspace.Workspace, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, w := range m.workspaces {
+		if role, ok := m.role(w.ID, userID); ok && w.ID == id {
+			cp := *w
+			cp.Role = role
+			return &cp, nil
+		}
+	}
+	return nil, workspace.ErrNotFound
+}
+
+func (m *memWorkspaceRepo) Rename(ctx context.Context, w *workspace.Workspace) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, s := range m.workspaces {
+		if s.ID == w.ID {
+			s.Name, s.UpdatedAt = w.Name, time.Now()
+			w.UpdatedAt = s.UpdatedAt
+			return nil
+		}
+	}
+	return workspace.ErrNotFound
+}
+
+func (m *memWorkspaceRepo) Delete(ctx context.Context, id string) error {
+	if m.boards != nil {
+		if list, _ := m.boards.ListByWorkspace(ctx, id); len(list) > 0 {
+			return workspace.ErrNotEmpty
+		}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, w := range m.workspaces {
+		if w.ID == id {
+			m.workspaces = append(m.workspaces[:i], m.workspaces[i+1:]...)
+			return nil
+		}
+	}
+	return workspace.ErrNotFound
+}
+
+func (m *memWorkspaceRepo) ListMembers(ctx context.Context, workspaceID string) ([]*workspace.Member, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var res []*workspace.Member
+	for _, mb := range m.members {
+		if mb.WorkspaceID == workspaceID {
+			cp := *mb
+			res = append(res, &cp)
+		}
+	}
+	return res, nil
+}
+
+func (m *memWorkspaceRepo) GetMember(ctx context.Context, workspaceID, userID string) (*workspace.Member, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, mb := range m.members {
+		if mb.WorkspaceID == workspaceID && mb.UserID == userID {
+			cp := *mb
+			return &cp, nil
+		}
+	}
+	return nil, workspace.ErrMemberNotFound
+}
+
+func (m *memWorkspaceRepo) AddMember(ctx context.Context, mb *workspace.Member) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.role(mb.WorkspaceID, mb.UserID); ok {
+		return workspace.ErrMemberExists
+	}
+	mb.JoinedAt = time.Now()
+	cp := *mb
+	m.members = append(m.members, &cp)
+	return nil
+}
+
+// lastOwner сообщает, что userID — единственный владелец пространства.
+func (m *memWorkspaceRepo) lastOwner(workspaceID, userID string) bool {
+	for _, mb := range m.members {
+		if mb.WorkspaceID == workspaceID && mb.UserID != userID && mb.Role == workspace.RoleOwner {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *memWorkspaceRepo) SetMemberRole(ctx context.Context, workspaceID, userID string, role workspace.Role) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, mb := range m.members {
+		if mb.WorkspaceID == workspaceID && mb.UserID == userID {
+			if mb.Role == workspace.RoleOwner && role != workspace.RoleOwner && m.lastOwner(workspaceID, userID) {
+				return workspace.ErrLastOwner
+			}
+			mb.Role = role
+			return nil
+		}
+	}
+	return workspace.ErrMemberNotFound
+}
+
+func (m *memWorkspaceRepo) RemoveMember(ctx context.Context, workspaceID, userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, mb := range m.members {
+		if mb.WorkspaceID == workspaceID && mb.UserID == userID {
+			if mb.Role == workspace.RoleOwner && m.lastOwner(workspaceID, userID) {
+				return workspace.ErrLastOwner
+			}
+			m.members = append(m.members[:i], m.members[i+1:]...)
+			return nil
+		}
+	}
+	return workspace.ErrMemberNotFound
+}
+
+// memBoardRepo повторяет правила доступа BoardRepository: участники пространства видят его доски,
+// управляют ими администраторы и создатель доски; приглашённые участники видят доску,
+// а с ролью admin и управляют ею.
+type memBoardRepo struct {
+	mu      sync.Mutex
+	ws      *memWorkspaceRepo
+	boards  []*board.Board
+	members []*board.Member
+}
+
+func (m *memBoardRepo) member(boardID, userID string) *board.Member {
+	for _, mb := range m.members {
+		if mb.BoardID == boardID && mb.UserID == userID {
+			return mb
+		}
+	}
+	return nil
+}
+
+func (m *memBoardRepo) accessible(b *board.Board, userID string) bool {
+	if m.member(b.ID, userID) != nil {
+		return true
+	}
+	if b.WorkspaceID == nil {
+		return b.OwnerID == userID
+	}
+	_, ok := m.ws.GetMember(context.Background(), *b.WorkspaceID, userID)
+	return ok == nil
+}
+
+func (m *memBoardRepo) manageable(b *board.Board, userID string) bool {
+	if mb := m.member(b.ID, userID); mb != nil && mb.Role == board.RoleAdmin {
+		return true
+	}
+	if b.WorkspaceID == nil {
+		return b.OwnerID == userID
+	}
+	mb, err := m.ws.GetMember(context.Background(), *b.WorkspaceID, userID)
+	return err == nil && (mb.Role.AtLeast(workspace.RoleAdmin) || b.OwnerID == userID)
+}
+
+// addMember повторяет вступление по приглашению: роль можно повысить, но не понизить.
+func (m *memBoardRepo) addMember(boardID, userID, email string, role board.Role) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if mb := m.member(boardID, userID); mb != nil {
+		if role == board.RoleAdmin {
+			mb.Role = role
+		}
+		return
+	}
+	m.members = append(m.members, &board.Member{BoardID: boardID, UserID: userID, Email: email, Role: role, JoinedAt: time.Now()})
+}
+
+func (m *memBoardRepo) ListShared(ctx context.Context, userID string) ([]*board.Board, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var res []*board.Board
+	for _, b := range m.boards {
+		if b.ArchivedAt == nil && m.member(b.ID, userID) != nil {
+			cp := *b
+			res = append(res, &cp)
+		}
+	}
+	return res, nil
+}
+
+func (m *memBoardRepo) CanManage(ctx context.Context, id, userID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b := m.find(id)
+	if b == nil || !m.accessible(b, userID) {
+		return false, board.ErrNotFound
+	}
+	return m.manageable(b, userID), nil
+}
+
+func (m *memBoardRepo) ListMembers(ctx context.Context, boardID string) ([]*board.Member, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var res []*board.Member
+	for _, mb := range m.members {
+		if mb.BoardID == boardID {
+			cp := *mb
+			res = append(res, &cp)
+		}
+	}
+	return res, nil
+}
+
+func (m *memBoardRepo) RemoveMember(ctx context.Context, boardID, userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, mb := range m.members {
+		if mb.BoardID == boardID && mb.UserID == userID {
+			m.members = append(m.members[:i], m.members[i+1:]...)
+			return nil
+		}
+	}
+	return board.ErrMemberNotFound
+}
+
+func (m *memBoardRepo) Create(ctx context.Context, b *board.Board) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	b.ID, b.CreatedAt, b.UpdatedAt = fmt.Sprintf("board-%d", len(m.boards)+1), now, now
+	cp := *b
+	m.boards = append(m.boards, &cp)
+	return nil
+}
+
+func (m *memBoardRepo) find(id string) *board.Board {
+	for _, b := range m.boards {
+		if b.ID == id {
+			return b
+		}
+	}
+	return nil
+}
+
+func (m *memBoardRepo) Update(ctx context.Context, b *board.Board, userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.find(b.ID)
+	if s == nil || !m.manageable(s, userID) {
+		return board.ErrNotFound
+	}
+	s.Name, s.UpdatedAt = b.Name, time.Now()
+	*b = *s
+	return nil
+}
+
+func (m *memBoardRepo) GetByID(ctx context.Context, id, userID string) (*board.Board, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b := m.find(id)
+	if b == nil || !m.accessible(b, userID) {
+		return nil, board.ErrNotFound
+	}
+	cp := *b
+	return &cp, nil
+}
+
+func (m *memBoardRepo) ListByOwnerID(ctx context.Context, ownerID string) ([]*board.Board, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var res []*board.Board
+	for _, b := range m.boards {
+		if b.ArchivedAt == nil && b.WorkspaceID == nil && b.OwnerID == ownerID {
+			cp := *b
+			res = append(res, &cp)
+		}
+	}
+	return res, nil
+}
+
+func (m *memBoardRepo) ListByWorkspace(ctx context.Context, workspaceID string) ([]*board.Board, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var res []*board.Board
+	for _, b := range m.boards {
+		if b.ArchivedAt == nil && b.WorkspaceID != nil && *b.WorkspaceID == workspaceID {
+			cp := *b
+			res = append(res, &cp)
+		}
+	}
+	return res, nil
+}
+
+func (m *memBoardRepo) Transfer(ctx context.Context, b *board.Board) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.find(b.ID)
+	if s == nil {
+		return board.ErrNotFound
+	}
+	s.OwnerID, s.WorkspaceID, s.UpdatedAt = b.OwnerID, b.WorkspaceID, time.Now()
+	*b = *s
+	return nil
+}
+
+func (m *memBoardRepo) Delete(ctx context.Context, id, userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, b := range m.boards {
+		if b.ID == id && m.manageable(b, userID) {
+			m.boards = append(m.boards[:i], m.boards[i+1:]...)
+			return nil
+		}
+	}
+	return board.ErrNotFound
+}
+
+func newWorkspaceFixture(t *testing.T) *accountFixture {
+	t.Helper()
+	ws := &memWorkspaceRepo{}
+	ws.boards = &memBoardRepo{ws: ws}
+	return newAccountFixture(t, service.EmailSettings{}, func(d *myhttp.Deps) {
+		d.WorkspaceRepo = ws
+		d.BoardRepo = ws.boards
+	})
+}
+
+func listJSON(t *testing.T, f *accountFixture, path, token string) []map[string]any {
+	t.Helper()
+	rec := doJSONRequest(f.router, http.MethodGet, path, nil, bearer(token))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET %s: %d %s", path, rec.Code, rec.Body.String())
+	}
+	var items []map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &items); err != nil {
+		t.Fatalf("decode %s: %v", path, err)
+	}
+	return items
+}
+
+func TestWorkspaceMembersAndRoles(t *testing.T) {
+	f := newWorkspaceFixture(t)
+	alice := f.register(t, "alice@example.com", "correct horse")
+	bob := f.register(t, "bob@example.com", "correct horse")
+	carol := f.register(t, "carol@example.com", "correct horse")
+	f.register(t, "dave@example.com", "correct horse")
+
+	code, ws := f.do(http.MethodPost, "/api/v1/workspaces", map[string]string{"name": " Team "}, alice)
+	if code != http.StatusCreated || ws["name"] != "Team" || ws["role"] != "owner" {
+		t.Fatalf("create workspace: %d %v", code, ws)
+	}
+	base := "/api/v1/workspaces/" + ws["id"].(string)
+
+	if code, m := f.do(http.MethodPost, base+"/members", map[string]string{"email": "bob@example.com"}, alice); code != http.StatusCreated || m["role"] != "member" {
+		t.Fatalf("add member: %d %v", code, m)
+	}
+	if code, _ := f.do(http.MethodPost, base+"/members", map[string]string{"email": "bob@example.com"}, alice); code != http.StatusConflict {
+		t.Fatalf("duplicate member: expected 409, got %d", code)
+	}
+	if code, body := f.do(http.MethodPost, base+"/members", map[string]string{"email": "nobody@example.com"}, alice); code != http.StatusNotFound || body["code"] != service.CodeUserNotFound {
+		t.Fatalf("unknown email: %d %v", code, body)
+	}
+	if items := listJSON(t, f, "/api/v1/workspaces", bob); len(items) != 1 || items[0]["role"] != "member" {
+		t.Fatalf("bob must see the workspace as member: %v", items)
+	}
+	if code, body := f.do(http.MethodPost, base+"/members", map[string]string{"email": "carol@example.com"}, bob); code != http.StatusForbidden || body["code"] != service.CodeWorkspaceForbidden {
+		t.Fatalf("member must not add members: %d %v", code, body)
+	}
+	if code, _ := f.do(http.MethodPatch, base, map[string]string{"name": "Renamed"}, bob); code != http.StatusForbidden {
+		t.Fatalf("member must not rename the workspace, got %d", code)
+	}
+
+	// Администратор управляет только участниками с ролью member.
+	if code, _ := f.do(http.MethodPost, base+"/members", map[string]string{"email": "carol@example.com", "role": "admin"}, alice); code != http.StatusCreated {
+		t.Fatalf("add admin: %d", code)
+	}
+	if code, _ := f.do(http.MethodPost, base+"/members", map[string]string{"email": "dave@example.com", "role": "admin"}, carol); code != http.StatusForbidden {
+		t.Fatalf("admin must not grant admin, got %d", code)
+	}
+	if code, _ := f.do(http.MethodPost, base+"/members", map[string]string{"email": "dave@example.com"}, carol); code != http.StatusCreated {
+		t.Fatalf("admin adds member: %d", code)
+	}
+	if code, _ := f.do(http.MethodDelete, base+"/members/user-alice@example.com", nil, carol); code != http.StatusForbidden {
+		t.Fatalf("admin must not remove the owner, got %d", code)
+	}
+	if code, _ := f.do(http.MethodDelete, base+"/members/user-dave@example.com", nil, carol); code != http.StatusNoContent {
+		t.Fatalf("admin removes member: %d", code)
+	}
+	if code, _ := f.do(http.MethodPatch, base, map[string]string{"name": "Renamed"}, carol); code != http.StatusOK {
+		t.Fatalf("admin renames workspace: %d", code)
+	}
+
+	// Последний владелец не может уйти или сложить полномочия, пока не назначит преемника.
+	if code, body := f.do(http.MethodDelete, base+"/members/user-alice@example.com", nil, alice); code != http.StatusConflict || body["code"] != service.CodeLastOwner {
+		t.Fatalf("last owner leaving: %d %v", code, body)
+	}
+	if code, _ := f.do(http.MethodPatch, base+"/members/user-bob@example.com", map[string]string{"role": "owner"}, alice); code != http.StatusOK {
+		t.Fatalf("promote bob: %d", code)
+	}
+	if code, _ := f.do(http.MethodDelete, base+"/members/user-alice@example.com", nil, alice); code != http.StatusNoContent {
+		t.Fatalf("alice leaves: %d", code)
+	}
+	if code, _ := f.do(http.MethodGet, base, nil, alice); code != http.StatusNotFound {
+		t.Fatalf("former member must not see the workspace, got %d", code)
+	}
+	if items := listJSON(t, f, base+"/members", bob); len(items) != 2 {
+		t.Fatalf("expected bob and carol, got %v", items)
+	}
+}
+
+func TestWorkspaceBoardsAccessAndTransfer(t *testing.T) {
+	f := newWorkspaceFixture(t)
+	alice := f.register(t, "alice@example.com", "correct horse")
+	bob := f.register(t, "bob@example.com", "correct horse")
+	eve := f.register(t, "eve@example.com", "correct horse")
+
+	_, ws := f.do(http.MethodPost, "/api/v1/workspaces", map[string]string{"name": "Team"}, alice)
+	wsID := ws["id"].(string)
+	f.do(http.MethodPost, "/api/v1/workspaces/"+wsID+"/members", map[string]string{"email": "bob@example.com"}, alice)
+
+	_, b := f.do(http.MethodPost, "/api/v1/boards", map[string]string{"name": "Roadmap"}, alice)
+	boardPath := "/api/v1/boards/" + b["id"].(string)
+	if code, _ := f.do(http.MethodPost, boardPath+"/transfer", map[string]any{"workspace_id": wsID}, bob); code != http.StatusNotFound {
+		t.Fatalf("someone else's personal board must stay hidden, got %d", code)
+	}
+	code, moved := f.do(http.MethodPost, boardPath+"/transfer", map[string]any{"workspace_id": wsID}, alice)
+	if code != http.StatusOK || moved["workspace_id"] != wsID {
+		t.Fatalf("transfer to workspace: %d %v", code, moved)
+	}
+	if items := listJSON(t, f, "/api/v1/boards", alice); len(items) != 0 {
+		t.Fatalf("workspace boards must not be listed as personal: %v", items)
+	}
+
+	// Участник видит доски пространства, но управлять чужой доской не может.
+	if items := listJSON(t, f, "/api/v1/workspaces/"+wsID+"/boards", bob); len(items) != 1 {
+		t.Fatalf("bob must see the workspace board: %v", items)
+	}
+	if code, _ := f.do(http.MethodGet, boardPath, nil, bob); code != http.StatusOK {
+		t.Fatalf("member reads workspace board: %d", code)
+	}
+	if code, body := f.do(http.MethodPut, boardPath, map[string]string{"name": "Mine"}, bob); code != http.StatusForbidden || body["code"] != service.CodeBoardForbidden {
+		t.Fatalf("member renames someone else's board: %d %v", code, body)
+	}
+	if code, _ := f.do(http.MethodDelete, boardPath, nil, bob); code != http.StatusForbidden {
+		t.Fatalf("member deletes someone else's board: %d", code)
+	}
+	if code, _ := f.do(http.MethodPost, boardPath+"/transfer", map[string]any{"workspace_id": nil}, bob); code != http.StatusForbidden {
+		t.Fatalf("member takes a board out of the workspace: %d", code)
+	}
+	code, own := f.do(http.MethodPost, "/api/v1/workspaces/"+wsID+"/boards", map[string]string{"name": "Bob's"}, bob)
+	if code != http.StatusCreated || own["workspace_id"] != wsID || own["owner_id"] != "user-bob@example.com" {
+		t.Fatalf("member creates workspace board: %d %v", code, own)
+	}
+	if code, _ := f.do(http.MethodPut, "/api/v1/boards/"+own["id"].(string), map[string]string{"name": "Renamed"}, bob); code != http.StatusOK {
+		t.Fatalf("creator renames own board: %d", code)
+	}
+	// Владелец пространства управляет всеми досками.
+	if code, _ := f.do(http.MethodPut, "/api/v1/boards/"+own["id"].(string), map[string]string{"name": "By owner"}, alice); code != http.StatusOK {
+		t.Fatalf("owner renames member's board: %d", code)
+	}
+
+	// Посторонний не видит ни пространство, ни его доски.
+	if code, _ := f.do(http.MethodGet, "/api/v1/workspaces/"+wsID+"/boards", nil, eve); code != http.StatusNotFound {
+		t.Fatalf("outsider lists workspace boards: %d", code)
+	}
+	if code, _ := f.do(http.MethodGet, boardPath, nil, eve); code != http.StatusNotFound {
+		t.Fatalf("outsider reads workspace board: %d", code)
+	}
+	if code, _ := f.do(http.MethodPost, boardPath+"/transfer", map[string]any{"workspace_id": nil}, eve); code != http.StatusNotFound {
+		t.Fatalf("outsider transfers workspace board: %d", code)
+	}
+
+	// Пространство с досками не удаляется.
+	if code, body := f.do(http.MethodDelete, "/api/v1/workspaces/"+wsID, nil, alice); code != http.StatusConflict || body["code"] != service.CodeWorkspaceNotEmpty {
+		t.Fatalf("delete non-empty workspace: %d %v", code, body)
+	}
+	code, back := f.do(http.MethodPost, boardPath+"/transfer", map[string]any{"workspace_id": nil}, alice)
+	if code != http.StatusOK || back["workspace_id"] != nil || back["owner_id"] != "user-alice@example.com" {
+		t.Fatalf("transfer back to personal: %d %v", code, back)
+	}
+	if code, _ := f.do(http.MethodGet, boardPath, nil, bob); code != http.StatusNotFound {
+		t.Fatalf("personal board must be hidden from former workspace members, got %d", code)
+	}
+	if code, _ := f.do(http.MethodDelete, "/api/v1/boards/"+own["id"].(string), nil, alice); code != http.StatusNoContent {
+		t.Fatalf("owner deletes workspace board: %d", code)
+	}
+	if code, _ := f.do(http.MethodDelete, "/api/v1/workspaces/"+wsID, nil, bob); code != http.StatusForbidden {
+		t.Fatalf("member deletes workspace: %d", code)
+	}
+	if code, _ := f.do(http.MethodDelete, "/api/v1/workspaces/"+wsID, nil, alice); code != http.StatusNoContent {
+		t.Fatalf("delete empty workspace: %d", code)
+	}
+}
+
+func TestIntegration_WorkspaceRepository(t *testing.T) {
+	dsn, stop := startPostgres(t)
+	defer stop()
+
+	db, err := pg.New(dsn)
+	if err != nil {
+		t.Fatalf("connect db: %v", err)
+	}
+	defer db.Close()
+	applyMigrations(t, db.DB)
+
+	ctx := context.Background()
+	users := pg.NewUserRepository(db)
+	newUser := func(email string) *user.User {
+		u := &user.User{Email: email, PasswordHash: "hash"}
+		if err := users.Create(ctx, u); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+		return u
+	}
+	owner, member, outsider := newUser("owner@example.com"), newUser("member@example.com"), newUser("outsider@example.com")
+
+	repo := pg.NewWorkspaceRepository(db)
+	w := &workspace.Workspace{Name: "Team"}
+	if err := repo.Create(ctx, w, owner.ID); err != nil || w.ID == "" {
+		t.Fatalf("create workspace: %v", err)
+	}
+	if err := repo.AddMember(ctx, &workspace.Member{WorkspaceID: w.ID, UserID: member.ID, Role: workspace.RoleMember}); err != nil {
+		t.Fatalf("add member: %v", err)
+	}
+	if err := repo.AddMember(ctx, &workspace.Member{WorkspaceID: w.ID, UserID: member.ID, Role: workspace.RoleMember}); err != workspace.ErrMemberExists {
+		t.Fatalf("duplicate member: expected ErrMemberExists, got %v", err)
+	}
+	if got, err := repo.GetForMember(ctx, w.ID, member.ID); err != nil || got.Role != workspace.RoleMember {
+		t.Fatalf("get for member: %+v %v", got, err)
+	}
+	if _, err := repo.GetForMember(ctx, w.ID, outsider.ID); err != workspace.ErrNotFound {
+		t.Fatalf("outsider: expected ErrNotFound, got %v", err)
+	}
+	if err := repo.RemoveMember(ctx, w.ID, owner.ID); err != workspace.ErrLastOwner {
+		t.Fatalf("remove last owner: expected ErrLastOwner, got %v", err)
+	}
+
+	// Доступ к доске и её колонкам получают участники пространства, управление — владелец и создатель.
+	boards := pg.NewBoardRepository(db)
+	b := &board.Board{OwnerID: owner.ID, WorkspaceID: &w.ID, Name: "Roadmap"}
+	if err := boards.Create(ctx, b); err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+	if _, err := boards.GetByID(ctx, b.ID, member.ID); err != nil {
+		t.Fatalf("member reads workspace board: %v", err)
+	}
+	if _, err := boards.GetByID(ctx, b.ID, outsider.ID); err != board.ErrNotFound {
+		t.Fatalf("outsider: expected ErrNotFound, got %v", err)
+	}
+	if err := boards.Update(ctx, &board.Board{ID: b.ID, Name: "x"}, member.ID); err != board.ErrNotFound {
+		t.Fatalf("member renames owner's board: expected ErrNotFound, got %v", err)
+	}
+	columns := pg.NewColumnRepository(db)
+	c := &column.Column{Name: "Todo"}
+	if err := columns.CreateInBoard(ctx, c, b.ID, member.ID); err != nil {
+		t.Fatalf("member creates column: %v", err)
+	}
+	if list, err := columns.ListByBoardOwner(ctx, b.ID, outsider.ID); err != nil || len(list) != 0 {
+		t.Fatalf("outsider lists columns: %v %v", list, err)
+	}
+	if list, err := boards.ListByOwnerID(ctx, owner.ID); err != nil || len(list) != 0 {
+		t.Fatalf("workspace boards must not be personal: %v %v", list, err)
+	}
+	if err := repo.Delete(ctx, w.ID); err != workspace.ErrNotEmpty {
+		t.Fatalf("delete non-empty workspace: expected ErrNotEmpty, got %v", err)
+	}
+
+	// При удалении аккаунта единственного владельца пространство и его доски переходят оставшемуся участнику.
+	if err := users.Delete(ctx, owner.ID); err != nil {
+		t.Fatalf("delete owner: %v", err)
+	}
+	if got, err := repo.GetForMember(ctx, w.ID, member.ID); err != nil || got.Role != workspace.RoleOwner {
+		t.Fatalf("member must become owner: %+v %v", got, err)
+	}
+	if got, err := boards.GetByID(ctx, b.ID, member.ID); err != nil || got.OwnerID != member.ID {
+		t.Fatalf("board must be handed over: %+v %v", got, err)
+	}
+
+	// Пространство, где больше никого нет, удаляется вместе с аккаунтом.
+	solo := &workspace.Workspace{Name: "Solo"}
+	if err := repo.Create(ctx, solo, outsider.ID); err != nil {
+		t.Fatalf("create workspace: %v", err)
+	}
+	if err := users.Delete(ctx, outsider.ID); err != nil {
+		t.Fatalf("delete outsider: %v", err)
+	}
+	if list, err := repo.ListByMember(ctx, member.ID); err != nil || len(list) != 1 || list[0].ID != w.ID {
+		t.Fatalf("list by member: %+v %v", list, err)
+	}
+	if _, err := repo.GetMember(ctx, solo.ID, outsider.ID); err != workspace.ErrMemberNotFound {
+		t.Fatalf("solo workspace must be gone: %v", err)
+	}
+
+	// Доска из корзины не мешает удалить пространство и остаётся в корзине удалившего её.
+	if err := boards.Delete(ctx, b.ID, member.ID); err != nil {
+		t.Fatalf("trash board: %v", err)
+	}
+	if err := repo.Delete(ctx, w.ID); err != nil {
+		t.Fatalf("delete workspace with a trashed board: %v", err)
+	}
+	items, err := pg.NewTrashRepository(db).List(ctx, member.ID)
+	if err != nil || len(items) != 1 || items[0].ID != b.ID {
+		t.Fatalf("trashed board must survive workspace deletion: %+v %v", items, err)
+	}
+	restored := &board.Board{ID: b.ID}
+	if err := pg.NewTrashRepository(db).RestoreBoard(ctx, restored, member.ID); err != nil || restored.WorkspaceID != nil || restored.OwnerID != member.ID {
+		t.Fatalf("restored board must become personal: %+v %v", restored, err)
+	}
+}